@@ -4,8 +4,13 @@ import "time"
 
 // CreateAPIKeyRequest represents the request to create a new API key
 type CreateAPIKeyRequest struct {
-	Role        string  `json:"role" binding:"required,oneof=read write admin"`
-	Description *string `json:"description,omitempty"`
+	Role              string     `json:"role" binding:"required,oneof=read write admin"`
+	Description       *string    `json:"description,omitempty"`
+	SessionIDs        []string   `json:"session_ids,omitempty" binding:"omitempty,max=100,dive,required,max=128"` // Sessions the key may access, omit for all sessions
+	Scopes            []string   `json:"scopes,omitempty" binding:"omitempty,dive,required"`                      // e.g. messages:send, groups:manage; omit for everything the role allows
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`                                                    // Omit for a key that never expires
+	DailyRequestQuota int        `json:"daily_request_quota,omitempty" binding:"omitempty,min=0"`                 // Requests per UTC day, omit for unlimited
+	DailyMessageQuota int        `json:"daily_message_quota,omitempty" binding:"omitempty,min=0"`                 // Messages sent per UTC day, omit for unlimited
 }
 
 // CreateAPIKeyResponse represents the response after creating an API key
@@ -14,6 +19,19 @@ type CreateAPIKeyResponse struct {
 	PlainKey string         `json:"plain_key"` // Only returned once during creation
 }
 
+// RotateAPIKeyRequest represents the request to rotate an API key
+type RotateAPIKeyRequest struct {
+	GracePeriodSeconds *int       `json:"grace_period_seconds,omitempty" binding:"omitempty,min=0,max=2592000"` // How long the previous key keeps working, default 24 hours
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`                                                 // Expiry of the successor key, omit for no expiry
+}
+
+// RotateAPIKeyResponse represents the response after rotating an API key
+type RotateAPIKeyResponse struct {
+	APIKey      APIKeyResponse `json:"api_key"`      // The successor key
+	PlainKey    string         `json:"plain_key"`    // Only returned once during rotation
+	PreviousKey APIKeyResponse `json:"previous_key"` // The rotated key, working until its expires_at
+}
+
 // ListAPIKeysRequest represents the request to list API keys with filters
 type ListAPIKeysRequest struct {
 	Page   int     `form:"page" binding:"omitempty,min=1"`
@@ -56,20 +74,28 @@ type APIKeyDetailsResponse struct {
 
 // UsageStats contains usage statistics for an API key
 type UsageStats struct {
-	TotalRequests int `json:"total_requests"`
-	Last7Days     int `json:"last_7_days"`
+	TotalRequests int   `json:"total_requests"`
+	Last7Days     int   `json:"last_7_days"`
+	TodayRequests int64 `json:"today_requests"` // Counted against the daily request quota
+	TodayMessages int64 `json:"today_messages"` // Counted against the daily message quota
 }
 
 // APIKeyResponse represents an API key in responses
 type APIKeyResponse struct {
-	ID               string     `json:"id"`
-	MaskedKey        string     `json:"masked_key"`
-	Role             string     `json:"role"`
-	Description      *string    `json:"description,omitempty"`
-	CreatedAt        time.Time  `json:"created_at"`
-	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
-	IsActive         bool       `json:"is_active"`
-	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
-	RevokedBy        *string    `json:"revoked_by,omitempty"`
-	RevocationReason *string    `json:"revocation_reason,omitempty"`
+	ID                string     `json:"id"`
+	MaskedKey         string     `json:"masked_key"`
+	Role              string     `json:"role"`
+	AllowedSessions   []string   `json:"allowed_sessions,omitempty"`
+	Scopes            []string   `json:"scopes,omitempty"`
+	Description       *string    `json:"description,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	LastUsedAt        *time.Time `json:"last_used_at,omitempty"`
+	IsActive          bool       `json:"is_active"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy         *string    `json:"revoked_by,omitempty"`
+	RevocationReason  *string    `json:"revocation_reason,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	ReplacedBy        *string    `json:"replaced_by,omitempty"`
+	DailyRequestQuota int        `json:"daily_request_quota,omitempty"`
+	DailyMessageQuota int        `json:"daily_message_quota,omitempty"`
 }
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Audit log export formats
+const (
+	AuditLogExportFormatCSV    = "csv"
+	AuditLogExportFormatNDJSON = "ndjson"
+)
+
+// AuditLogDTO represents a recorded audit event in API responses
+type AuditLogDTO struct {
+	ID        string          `json:"id"`
+	EventType string          `json:"event_type"`
+	APIKeyID  string          `json:"api_key_id,omitempty"`
+	SessionID string          `json:"session_id,omitempty"`
+	Endpoint  string          `json:"endpoint,omitempty"`
+	Action    string          `json:"action,omitempty"`
+	IPAddress string          `json:"ip_address,omitempty"`
+	Details   json.RawMessage `json:"details,omitempty"`
+	CreatedAt string          `json:"created_at"`
+}
+
+// AuditLogFilterRequest holds the filters shared by audit log listing and export
+type AuditLogFilterRequest struct {
+	EventType string `json:"event_type,omitempty" form:"event_type"` // e.g. api_key_usage, auth_failure
+	APIKeyID  string `json:"api_key_id,omitempty" form:"api_key_id"`
+	SessionID string `json:"session_id,omitempty" form:"session_id"`
+	IPAddress string `json:"ip_address,omitempty" form:"ip_address"`
+	Since     string `json:"since,omitempty" form:"since"` // RFC3339 timestamp
+	Until     string `json:"until,omitempty" form:"until"` // RFC3339 timestamp
+}
+
+// ListAuditLogsRequest represents a request to list audit logs
+type ListAuditLogsRequest struct {
+	AuditLogFilterRequest
+	Limit  int    `json:"limit,omitempty" form:"limit"`
+	Cursor string `json:"cursor,omitempty" form:"cursor"` // Opaque cursor from a previous response
+}
+
+// Validate validates the list audit logs request
+func (r *ListAuditLogsRequest) Validate() error {
+	// Set default limit if not specified
+	if r.Limit == 0 {
+		r.Limit = 100
+	}
+
+	// Validate limit
+	if r.Limit < 0 || r.Limit > 1000 {
+		return fmt.Errorf("limit must be between 1 and 1000")
+	}
+
+	return nil
+}
+
+// ListAuditLogsResponse represents a page of audit logs, newest first
+type ListAuditLogsResponse struct {
+	AuditLogs  []AuditLogDTO `json:"audit_logs"`
+	NextCursor string        `json:"next_cursor,omitempty"` // Empty when there are no older entries
+	Limit      int           `json:"limit"`
+}
+
+// ExportAuditLogsRequest represents a request to export audit logs
+type ExportAuditLogsRequest struct {
+	AuditLogFilterRequest
+	Format string `json:"format,omitempty" form:"format"` // csv (default) or ndjson
+}
+
+// Validate validates the export audit logs request
+func (r *ExportAuditLogsRequest) Validate() error {
+	if r.Format == "" {
+		r.Format = AuditLogExportFormatCSV
+	}
+
+	if r.Format != AuditLogExportFormatCSV && r.Format != AuditLogExportFormatNDJSON {
+		return fmt.Errorf("format must be one of: csv, ndjson")
+	}
+
+	return nil
+}
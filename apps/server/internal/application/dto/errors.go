@@ -7,8 +7,19 @@ import (
 // DTO validation errors
 var (
 	ErrTextRequired     = errors.New("text content is required for text messages")
-	ErrImageURLRequired = errors.New("image_url is required for image messages")
-	ErrDocURLRequired   = errors.New("doc_url is required for document messages")
-	ErrAudioURLRequired = errors.New("audio_url is required for audio messages")
-	ErrVideoURLRequired = errors.New("video_url is required for video messages")
+	ErrImageURLRequired = errors.New("image_url or a file is required for image messages")
+	ErrDocURLRequired   = errors.New("doc_url or a file is required for document messages")
+	ErrAudioURLRequired = errors.New("audio_url or a file is required for audio messages")
+	ErrVideoURLRequired = errors.New("video_url or a file is required for video messages")
+
+	ErrStickerURLRequired   = errors.New("sticker_url or a file is required for sticker messages")
+	ErrMediaSourceConflict  = errors.New("send media either by URL, as a file or as media_data, not several")
+	ErrMediaNotAllowed      = errors.New("files can only be sent with image, document, audio, video or sticker messages")
+	ErrInlineMediaTooLarge  = errors.New("media_data exceeds 5MB, send the file as multipart/form-data instead")
+	ErrLocationRequired     = errors.New("location is required for location messages")
+	ErrContactsRequired     = errors.New("at least one contact is required for contact messages")
+	ErrInvalidVCard         = errors.New("contact vcard must start with BEGIN:VCARD")
+	ErrPollRequired         = errors.New("poll is required for poll messages")
+	ErrPollOptionsNotUnique = errors.New("poll options must be unique")
+	ErrPollSelectableCount  = errors.New("poll selectable_count cannot exceed the number of options")
 )
@@ -2,6 +2,9 @@ package dto
 
 import (
 	"fmt"
+	"strings"
+
+	"whatspire/internal/domain/entity"
 )
 
 // EventDTO represents an event in API responses
@@ -9,6 +12,7 @@ type EventDTO struct {
 	ID        string `json:"id"`
 	Type      string `json:"type"`
 	SessionID string `json:"session_id"`
+	Sequence  int64  `json:"sequence,omitempty"`
 	Data      []byte `json:"data,omitempty"`
 	Timestamp string `json:"timestamp"`
 }
@@ -43,6 +47,45 @@ func (r *QueryEventsRequest) Validate() error {
 	return nil
 }
 
+// StreamEventsRequest represents a request to stream live events
+type StreamEventsRequest struct {
+	SessionID   string `form:"session_id"`    // Comma-separated session IDs, empty for all accessible sessions
+	EventType   string `form:"event_type"`    // Comma-separated event types or wildcards such as "message.*"
+	LastEventID string `form:"last_event_id"` // Fallback for clients that cannot send the Last-Event-ID header
+}
+
+// SessionIDs returns the requested session IDs
+func (r *StreamEventsRequest) SessionIDs() []string {
+	return splitList(r.SessionID)
+}
+
+// EventTypes returns the requested event type patterns
+func (r *StreamEventsRequest) EventTypes() []string {
+	return splitList(r.EventType)
+}
+
+// Validate validates the stream events request
+func (r *StreamEventsRequest) Validate() error {
+	for _, pattern := range r.EventTypes() {
+		if !entity.IsValidEventTypePattern(pattern) {
+			return fmt.Errorf("unknown event type: %s", pattern)
+		}
+	}
+
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping blank entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // QueryEventsResponse represents a response to query events
 type QueryEventsResponse struct {
 	Events []EventDTO `json:"events"`
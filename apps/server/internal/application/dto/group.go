@@ -0,0 +1,59 @@
+package dto
+
+import "whatspire/internal/domain/entity"
+
+// CreateGroupRequest represents a request to create a new group
+type CreateGroupRequest struct {
+	SessionID    string   `json:"-"`
+	Name         string   `json:"name" validate:"required,max=25"`                                      // WhatsApp limits group names to 25 characters
+	Participants []string `json:"participants" validate:"required,min=1,max=256,dive,required,max=128"` // Phone numbers or JIDs
+}
+
+// UpdateGroupRequest represents a request to change group settings; omitted fields are left untouched
+type UpdateGroupRequest struct {
+	SessionID        string  `json:"-"`
+	GroupJID         string  `json:"-"`
+	Name             *string `json:"name,omitempty" validate:"omitempty,min=1,max=25"`
+	Description      *string `json:"description,omitempty" validate:"omitempty,max=2048"` // Empty string clears the description
+	IsAnnounce       *bool   `json:"is_announce,omitempty"`                               // Only admins can send messages
+	IsLocked         *bool   `json:"is_locked,omitempty"`                                 // Only admins can edit group info
+	EphemeralSeconds *int    `json:"ephemeral_seconds,omitempty" validate:"omitempty,oneof=0 86400 604800 7776000"`
+}
+
+// SetGroupPictureRequest represents a request to replace the group picture
+type SetGroupPictureRequest struct {
+	SessionID string `json:"-"`
+	GroupJID  string `json:"-"`
+	ImageURL  string `json:"image_url" validate:"required,url"` // Must point to a JPEG image
+}
+
+// UpdateGroupParticipantsRequest represents a request to add, remove, promote or demote participants
+type UpdateGroupParticipantsRequest struct {
+	SessionID    string   `json:"-"`
+	GroupJID     string   `json:"-"`
+	Action       string   `json:"action" validate:"required,oneof=add remove promote demote"`
+	Participants []string `json:"participants" validate:"required,min=1,max=256,dive,required,max=128"` // Phone numbers or JIDs
+}
+
+// JoinGroupRequest represents a request to join a group through an invite link
+type JoinGroupRequest struct {
+	SessionID  string `json:"-"`
+	InviteLink string `json:"invite_link" validate:"required,max=256"` // Full link or bare invite code
+}
+
+// ListGroupsRequest represents a request to list the locally stored groups of a session
+type ListGroupsRequest struct {
+	SessionID string  `json:"-" form:"-"`
+	Name      *string `form:"name" validate:"omitempty,max=100"` // Case-insensitive substring of the group name
+	Archived  *bool   `form:"archived"`
+	Muted     *bool   `form:"muted"`
+	AdminOnly bool    `form:"admin_only"` // Only groups the session is an admin of
+	Page      int     `form:"page" validate:"omitempty,min=1"`
+	Limit     int     `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ListGroupsResponse represents the response for listing groups
+type ListGroupsResponse struct {
+	Groups     []*entity.Group `json:"groups"`
+	Pagination PaginationInfo  `json:"pagination"`
+}
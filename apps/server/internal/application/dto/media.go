@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"whatspire/internal/domain/entity"
+)
+
+// MediaCleanupCandidateDTO represents a media file selected for deletion in API responses
+type MediaCleanupCandidateDTO struct {
+	Path           string `json:"path"`
+	SessionID      string `json:"session_id,omitempty"` // Empty for files outside a session directory
+	Size           int64  `json:"size"`
+	StoredAt       string `json:"stored_at"`
+	LastAccessedAt string `json:"last_accessed_at"`
+	Reason         string `json:"reason"` // retention, orphan or disk_budget
+}
+
+// MediaCleanupTotals counts the media selected for deletion for one reason
+type MediaCleanupTotals struct {
+	Files int   `json:"files"`
+	Bytes int64 `json:"bytes"`
+}
+
+// MediaCleanupReportResponse represents what the media cleanup job would delete
+type MediaCleanupReportResponse struct {
+	DryRun         bool                          `json:"dry_run"`
+	GeneratedAt    string                        `json:"generated_at"`
+	ScannedFiles   int                           `json:"scanned_files"`
+	ScannedBytes   int64                         `json:"scanned_bytes"`
+	DeleteFiles    int                           `json:"delete_files"`
+	DeleteBytes    int64                         `json:"delete_bytes"`
+	RemainingBytes int64                         `json:"remaining_bytes"`
+	OrphanSweep    bool                          `json:"orphan_sweep"` // False when the sweep is disabled or events are not stored
+	ByReason       map[string]MediaCleanupTotals `json:"by_reason"`
+	Candidates     []MediaCleanupCandidateDTO    `json:"candidates"`
+}
+
+// NewMediaCleanupReportResponse creates a MediaCleanupReportResponse from a domain MediaCleanupReport
+func NewMediaCleanupReportResponse(report *entity.MediaCleanupReport) *MediaCleanupReportResponse {
+	response := &MediaCleanupReportResponse{
+		DryRun:         report.DryRun,
+		GeneratedAt:    report.StartedAt.Format(time.RFC3339),
+		ScannedFiles:   report.ScannedFiles,
+		ScannedBytes:   report.ScannedBytes,
+		DeleteFiles:    len(report.Candidates),
+		DeleteBytes:    report.CandidateBytes(),
+		RemainingBytes: report.RemainingBytes,
+		OrphanSweep:    report.OrphanSweep,
+		ByReason:       make(map[string]MediaCleanupTotals),
+		Candidates:     make([]MediaCleanupCandidateDTO, 0, len(report.Candidates)),
+	}
+
+	for _, candidate := range report.Candidates {
+		totals := response.ByReason[string(candidate.Reason)]
+		totals.Files++
+		totals.Bytes += candidate.Size
+		response.ByReason[string(candidate.Reason)] = totals
+
+		response.Candidates = append(response.Candidates, MediaCleanupCandidateDTO{
+			Path:           candidate.Path,
+			SessionID:      candidate.SessionID,
+			Size:           candidate.Size,
+			StoredAt:       candidate.StoredAt.Format(time.RFC3339),
+			LastAccessedAt: candidate.LastAccessed.Format(time.RFC3339),
+			Reason:         string(candidate.Reason),
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"fmt"
+
+	"whatspire/internal/domain/entity"
+)
+
+// MessageDTO represents a stored message in API responses
+type MessageDTO struct {
+	ID         string                `json:"id"`
+	SessionID  string                `json:"session_id"`
+	ChatJID    string                `json:"chat_jid"`
+	From       string                `json:"from,omitempty"`
+	To         string                `json:"to,omitempty"`
+	WhatsAppID string                `json:"whatsapp_id,omitempty"`
+	Direction  string                `json:"direction"`
+	Type       string                `json:"type"`
+	Status     string                `json:"status"`
+	Content    entity.MessageContent `json:"content"`
+	Timestamp  string                `json:"timestamp"`
+}
+
+// ListMessagesRequest represents a request to list the message history of a chat
+type ListMessagesRequest struct {
+	SessionID string `json:"-"`
+	ChatJID   string `json:"-"`
+	Limit     int    `json:"limit,omitempty" form:"limit"`
+	Cursor    string `json:"cursor,omitempty" form:"cursor"` // Opaque cursor from a previous response
+}
+
+// Validate validates the list messages request
+func (r *ListMessagesRequest) Validate() error {
+	if r.SessionID == "" {
+		return fmt.Errorf("session_id is required")
+	}
+
+	if r.ChatJID == "" {
+		return fmt.Errorf("chat_jid is required")
+	}
+
+	// Set default limit if not specified
+	if r.Limit == 0 {
+		r.Limit = 50
+	}
+
+	// Validate limit
+	if r.Limit < 0 || r.Limit > 200 {
+		return fmt.Errorf("limit must be between 1 and 200")
+	}
+
+	return nil
+}
+
+// ListMessagesResponse represents a page of chat message history, newest first
+type ListMessagesResponse struct {
+	Messages   []MessageDTO `json:"messages"`
+	NextCursor string       `json:"next_cursor,omitempty"` // Empty when there are no older messages
+	Limit      int          `json:"limit"`
+}
+
+// MessageStatusChangeDTO represents a single entry of a message status timeline
+type MessageStatusChangeDTO struct {
+	Status    string `json:"status"`
+	Timestamp string `json:"timestamp"`
+}
+
+// MessageStatusResponse represents the current status of a message and how it got there
+type MessageStatusResponse struct {
+	ID         string                   `json:"id"`
+	SessionID  string                   `json:"session_id"`
+	ChatJID    string                   `json:"chat_jid"`
+	WhatsAppID string                   `json:"whatsapp_id,omitempty"` // ID assigned on the WhatsApp network
+	Direction  string                   `json:"direction"`
+	Status     string                   `json:"status"`
+	Timeline   []MessageStatusChangeDTO `json:"timeline"`
+}
+
+// EditMessageRequest represents a request to edit the text or caption of a sent message
+type EditMessageRequest struct {
+	MessageID string `json:"-"`
+	Text      string `json:"text" validate:"required,max=4096"`
+}
+
+// RevokeMessageRequest represents a request to delete a sent message for everyone
+type RevokeMessageRequest struct {
+	MessageID string `json:"-"`
+}
@@ -1,5 +1,17 @@
 package dto
 
+import (
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"whatspire/internal/domain/entity"
+)
+
+// MaxInlineMediaSize is the largest media accepted base64 encoded in media_data (5MB decoded)
+// Larger files are sent as multipart/form-data
+const MaxInlineMediaSize = 5 * 1024 * 1024
+
 // SessionConfig represents session configuration options
 type SessionConfig struct {
 	AccountProtection *bool `json:"account_protection,omitempty"`
@@ -10,6 +22,43 @@ type SessionConfig struct {
 	IgnoreGroups      *bool `json:"ignore_groups,omitempty"`
 	IgnoreBroadcasts  *bool `json:"ignore_broadcasts,omitempty"`
 	IgnoreChannels    *bool `json:"ignore_channels,omitempty"`
+
+	MediaRetentionDays *int `json:"media_retention_days,omitempty" validate:"omitempty,min=0"` // 0 = server default
+}
+
+// ApplyTo overwrites the settings that are explicitly set in the config
+func (c *SessionConfig) ApplyTo(settings *entity.SessionSettings) {
+	if c == nil || settings == nil {
+		return
+	}
+
+	if c.AccountProtection != nil {
+		settings.AccountProtection = *c.AccountProtection
+	}
+	if c.MessageLogging != nil {
+		settings.MessageLogging = *c.MessageLogging
+	}
+	if c.ReadMessages != nil {
+		settings.ReadMessages = *c.ReadMessages
+	}
+	if c.AutoRejectCalls != nil {
+		settings.AutoRejectCalls = *c.AutoRejectCalls
+	}
+	if c.AlwaysOnline != nil {
+		settings.AlwaysOnline = *c.AlwaysOnline
+	}
+	if c.IgnoreGroups != nil {
+		settings.IgnoreGroups = *c.IgnoreGroups
+	}
+	if c.IgnoreBroadcasts != nil {
+		settings.IgnoreBroadcasts = *c.IgnoreBroadcasts
+	}
+	if c.IgnoreChannels != nil {
+		settings.IgnoreChannels = *c.IgnoreChannels
+	}
+	if c.MediaRetentionDays != nil {
+		settings.MediaRetentionDays = *c.MediaRetentionDays
+	}
 }
 
 // CreateSessionRequest represents a request to create a new WhatsApp session
@@ -21,9 +70,19 @@ type CreateSessionRequest struct {
 // SendMessageRequest represents a request to send a WhatsApp message
 type SendMessageRequest struct {
 	SessionID string                  `json:"session_id" validate:"required,uuid"`
-	To        string                  `json:"to" validate:"required,e164"`
-	Type      string                  `json:"type" validate:"required,oneof=text image document audio video"`
+	To        string                  `json:"to" validate:"required,recipient"` // E.164 phone number or group JID
+	Type      string                  `json:"type" validate:"required,oneof=text image document audio video sticker location contact poll"`
 	Content   SendMessageContentInput `json:"content" validate:"required"`
+
+	// File is media uploaded as multipart/form-data, sent instead of fetching a URL
+	File *MediaFileInput `json:"-"`
+}
+
+// MediaFileInput is media sent with a request rather than by URL
+type MediaFileInput struct {
+	Content  io.Reader
+	Filename string
+	MimeType string // Declared MIME type; inferred from the filename or content when empty
 }
 
 // SendMessageContentInput represents the content of a message to send
@@ -35,6 +94,46 @@ type SendMessageContentInput struct {
 	VideoURL *string `json:"video_url,omitempty" validate:"required_if=Type video,omitempty,url"`
 	Caption  *string `json:"caption,omitempty" validate:"omitempty,max=1024"`
 	Filename *string `json:"filename,omitempty" validate:"omitempty,max=255"`
+
+	// MediaData is small media sent inline, base64 encoded, instead of by URL
+	MediaData *string `json:"media_data,omitempty" validate:"omitempty,base64"`
+	MimeType  *string `json:"mime_type,omitempty" validate:"omitempty,max=255"`
+
+	StickerURL *string        `json:"sticker_url,omitempty" validate:"omitempty,url"`
+	Location   *LocationInput `json:"location,omitempty"`
+	Contacts   []ContactInput `json:"contacts,omitempty" validate:"omitempty,max=20,dive"`
+	Poll       *PollInput     `json:"poll,omitempty"`
+
+	ReplyTo  *ReplyToInput `json:"reply_to,omitempty"`
+	Mentions []string      `json:"mentions,omitempty" validate:"omitempty,max=256,dive,required,max=128"`
+}
+
+// ReplyToInput identifies the message being replied to
+type ReplyToInput struct {
+	MessageID string `json:"message_id" validate:"required,max=128"`
+	ChatJID   string `json:"chat_jid,omitempty" validate:"omitempty,max=128"`
+	Sender    string `json:"sender,omitempty" validate:"omitempty,max=128"` // Author of the quoted message; looked up if omitted
+}
+
+// LocationInput represents the location of a location message
+type LocationInput struct {
+	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
+	Name      string  `json:"name,omitempty" validate:"omitempty,max=256"`
+	Address   string  `json:"address,omitempty" validate:"omitempty,max=512"`
+}
+
+// ContactInput represents a single contact card of a contact message
+type ContactInput struct {
+	DisplayName string `json:"display_name" validate:"required,max=256"`
+	VCard       string `json:"vcard" validate:"required,max=65536"`
+}
+
+// PollInput represents the question and options of a poll message
+type PollInput struct {
+	Question        string   `json:"question" validate:"required,max=255"`
+	Options         []string `json:"options" validate:"required,min=2,max=12,dive,required,max=100"`
+	SelectableCount int      `json:"selectable_count" validate:"min=0"` // 0 allows selecting any number of options
 }
 
 // GetSessionRequest represents a request to get a session by ID
@@ -71,27 +170,103 @@ type StartQRAuthRequest struct {
 // Validate validates the SendMessageRequest based on message type
 func (r *SendMessageRequest) Validate() error {
 	// Additional validation logic beyond struct tags
+	if r.Content.MediaData != nil && r.File != nil {
+		return ErrMediaSourceConflict
+	}
+	if r.Content.MediaData != nil && len(*r.Content.MediaData) > base64.StdEncoding.EncodedLen(MaxInlineMediaSize) {
+		return ErrInlineMediaTooLarge
+	}
+
 	switch r.Type {
 	case "text":
 		if r.Content.Text == nil || *r.Content.Text == "" {
 			return ErrTextRequired
 		}
 	case "image":
-		if r.Content.ImageURL == nil || *r.Content.ImageURL == "" {
-			return ErrImageURLRequired
-		}
+		return r.validateMediaSource(r.Content.ImageURL, ErrImageURLRequired)
 	case "document":
-		if r.Content.DocURL == nil || *r.Content.DocURL == "" {
-			return ErrDocURLRequired
-		}
+		return r.validateMediaSource(r.Content.DocURL, ErrDocURLRequired)
 	case "audio":
-		if r.Content.AudioURL == nil || *r.Content.AudioURL == "" {
-			return ErrAudioURLRequired
-		}
+		return r.validateMediaSource(r.Content.AudioURL, ErrAudioURLRequired)
 	case "video":
-		if r.Content.VideoURL == nil || *r.Content.VideoURL == "" {
-			return ErrVideoURLRequired
+		return r.validateMediaSource(r.Content.VideoURL, ErrVideoURLRequired)
+	case "sticker":
+		return r.validateMediaSource(r.Content.StickerURL, ErrStickerURLRequired)
+	case "location":
+		if r.Content.Location == nil {
+			return ErrLocationRequired
+		}
+	case "contact":
+		if len(r.Content.Contacts) == 0 {
+			return ErrContactsRequired
+		}
+		for _, contact := range r.Content.Contacts {
+			if !strings.HasPrefix(strings.TrimSpace(strings.ToUpper(contact.VCard)), "BEGIN:VCARD") {
+				return ErrInvalidVCard
+			}
+		}
+	case "poll":
+		if r.Content.Poll == nil {
+			return ErrPollRequired
+		}
+		return r.Content.Poll.validate()
+	}
+
+	if r.MediaFile() != nil {
+		return ErrMediaNotAllowed
+	}
+	return nil
+}
+
+// validateMediaSource checks that a media message has exactly one of a URL or a file
+func (r *SendMessageRequest) validateMediaSource(url *string, errRequired error) error {
+	hasURL := url != nil && *url != ""
+	hasFile := r.MediaFile() != nil
+
+	if hasURL && hasFile {
+		return ErrMediaSourceConflict
+	}
+	if !hasURL && !hasFile {
+		return errRequired
+	}
+	return nil
+}
+
+// MediaFile returns the media sent with the request itself: an uploaded file or the decoded media_data
+// Returns nil for media sent by URL
+func (r *SendMessageRequest) MediaFile() *MediaFileInput {
+	if r.File != nil {
+		return r.File
+	}
+	if r.Content.MediaData == nil {
+		return nil
+	}
+
+	file := &MediaFileInput{
+		Content: base64.NewDecoder(base64.StdEncoding, strings.NewReader(*r.Content.MediaData)),
+	}
+	if r.Content.Filename != nil {
+		file.Filename = *r.Content.Filename
+	}
+	if r.Content.MimeType != nil {
+		file.MimeType = *r.Content.MimeType
+	}
+	return file
+}
+
+// validate checks the poll rules that struct tags cannot express
+func (p *PollInput) validate() error {
+	seen := make(map[string]bool, len(p.Options))
+	for _, option := range p.Options {
+		if seen[option] {
+			return ErrPollOptionsNotUnique
 		}
+		seen[option] = true
+	}
+
+	if p.SelectableCount > len(p.Options) {
+		return ErrPollSelectableCount
 	}
+
 	return nil
 }
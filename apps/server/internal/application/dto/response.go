@@ -56,6 +56,8 @@ type SessionResponse struct {
 	Status    string `json:"status"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+
+	Config entity.SessionSettings `json:"config"`
 }
 
 // NewSessionResponse creates a SessionResponse from a domain Session entity
@@ -67,6 +69,7 @@ func NewSessionResponse(session *entity.Session) SessionResponse {
 		Status:    session.Status.String(),
 		CreatedAt: session.CreatedAt.Format(time.RFC3339),
 		UpdatedAt: session.UpdatedAt.Format(time.RFC3339),
+		Config:    session.Settings,
 	}
 }
 
@@ -0,0 +1,215 @@
+package dto
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"whatspire/internal/domain/entity"
+)
+
+// WebhookDeliveryDTO represents a queued webhook delivery in API responses
+type WebhookDeliveryDTO struct {
+	ID             string `json:"id"`
+	SessionID      string `json:"session_id"`
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	EndpointID     string `json:"endpoint_id,omitempty"` // Empty for the session's default webhook
+	URL            string `json:"url"`
+	Status         string `json:"status"` // pending, in_flight, delivered or dead_letter
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"` // Only set while the delivery is pending
+	LastStatusCode int    `json:"last_status_code,omitempty"`
+	LastResponse   string `json:"last_response,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+// NewWebhookDeliveryDTO creates a WebhookDeliveryDTO from a domain WebhookDelivery entity
+func NewWebhookDeliveryDTO(delivery *entity.WebhookDelivery) WebhookDeliveryDTO {
+	result := WebhookDeliveryDTO{
+		ID:             delivery.ID,
+		SessionID:      delivery.SessionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType.String(),
+		EndpointID:     delivery.EndpointID,
+		URL:            delivery.URL,
+		Status:         delivery.Status.String(),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastResponse:   delivery.LastResponse,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      delivery.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if delivery.Status == entity.WebhookDeliveryStatusPending {
+		result.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	if delivery.DeliveredAt != nil {
+		result.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+
+	return result
+}
+
+// ListWebhookDeliveriesRequest represents a request to list a session's webhook deliveries
+type ListWebhookDeliveriesRequest struct {
+	Status     string `json:"status,omitempty" form:"status"`           // pending, in_flight, delivered or dead_letter
+	EndpointID string `json:"endpoint_id,omitempty" form:"endpoint_id"` // Only deliveries to this webhook endpoint
+	Limit      int    `json:"limit,omitempty" form:"limit"`
+	Offset     int    `json:"offset,omitempty" form:"offset"`
+}
+
+// Validate validates the list webhook deliveries request
+func (r *ListWebhookDeliveriesRequest) Validate() error {
+	// Set default limit if not specified
+	if r.Limit == 0 {
+		r.Limit = 100
+	}
+
+	// Validate limit
+	if r.Limit < 0 || r.Limit > 1000 {
+		return fmt.Errorf("limit must be between 1 and 1000")
+	}
+
+	// Validate offset
+	if r.Offset < 0 {
+		return fmt.Errorf("offset must be non-negative")
+	}
+
+	if r.Status != "" && !entity.WebhookDeliveryStatus(r.Status).IsValid() {
+		return fmt.Errorf("status must be one of: pending, in_flight, delivered, dead_letter")
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveriesResponse represents a page of webhook deliveries, newest first
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryDTO `json:"deliveries"`
+	Total      int64                `json:"total"`
+	Limit      int                  `json:"limit"`
+	Offset     int                  `json:"offset"`
+}
+
+// maxWebhookEndpointHeaders limits the number of custom headers on a webhook endpoint
+const maxWebhookEndpointHeaders = 20
+
+// webhookHeaderName matches valid HTTP header names
+var webhookHeaderName = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`)
+
+// WebhookEndpointRequest represents a request to create or replace one of a session's webhook endpoints
+type WebhookEndpointRequest struct {
+	Name             string            `json:"name"`
+	Enabled          *bool             `json:"enabled"` // Defaults to true
+	URL              string            `json:"url"`
+	Events           []string          `json:"events"`  // Event types or wildcards such as "message.*"; empty delivers all
+	Headers          map[string]string `json:"headers"` // Extra headers sent with every delivery
+	IgnoreGroups     bool              `json:"ignore_groups"`
+	IgnoreBroadcasts bool              `json:"ignore_broadcasts"`
+	IgnoreChannels   bool              `json:"ignore_channels"`
+}
+
+// Validate validates the webhook endpoint request
+func (r *WebhookEndpointRequest) Validate() error {
+	if len(r.Name) > 100 {
+		return fmt.Errorf("name must be at most 100 characters")
+	}
+
+	parsed, err := url.Parse(r.URL)
+	if r.URL == "" || err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+
+	for _, pattern := range r.Events {
+		if !entity.IsValidEventTypePattern(pattern) {
+			return fmt.Errorf("unknown event type: %s", pattern)
+		}
+	}
+
+	if len(r.Headers) > maxWebhookEndpointHeaders {
+		return fmt.Errorf("at most %d headers are allowed", maxWebhookEndpointHeaders)
+	}
+	for name, value := range r.Headers {
+		if !webhookHeaderName.MatchString(name) {
+			return fmt.Errorf("invalid header name: %s", name)
+		}
+		// Headers set by the delivery itself cannot be overridden
+		canonical := http.CanonicalHeaderKey(name)
+		if canonical == "Content-Type" || canonical == "Content-Length" || canonical == "Host" ||
+			strings.HasPrefix(canonical, "X-Webhook-") {
+			return fmt.Errorf("header %s is reserved", canonical)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("header %s has an invalid value", canonical)
+		}
+	}
+
+	return nil
+}
+
+// Settings returns the endpoint settings described by the request
+func (r *WebhookEndpointRequest) Settings() entity.WebhookEndpointSettings {
+	enabled := true
+	if r.Enabled != nil {
+		enabled = *r.Enabled
+	}
+
+	return entity.WebhookEndpointSettings{
+		Name:             r.Name,
+		Enabled:          enabled,
+		URL:              r.URL,
+		Events:           r.Events,
+		Headers:          r.Headers,
+		IgnoreGroups:     r.IgnoreGroups,
+		IgnoreBroadcasts: r.IgnoreBroadcasts,
+		IgnoreChannels:   r.IgnoreChannels,
+	}
+}
+
+// WebhookEndpointResponse represents a webhook endpoint in API responses
+type WebhookEndpointResponse struct {
+	ID               string            `json:"id"`
+	SessionID        string            `json:"session_id"`
+	Name             string            `json:"name,omitempty"`
+	Enabled          bool              `json:"enabled"`
+	URL              string            `json:"url"`
+	Secret           string            `json:"secret"`
+	Events           []string          `json:"events"`
+	Headers          map[string]string `json:"headers,omitempty"`
+	IgnoreGroups     bool              `json:"ignore_groups"`
+	IgnoreBroadcasts bool              `json:"ignore_broadcasts"`
+	IgnoreChannels   bool              `json:"ignore_channels"`
+	CreatedAt        string            `json:"created_at"`
+	UpdatedAt        string            `json:"updated_at"`
+}
+
+// NewWebhookEndpointResponse creates a WebhookEndpointResponse from a domain WebhookEndpoint entity
+func NewWebhookEndpointResponse(endpoint *entity.WebhookEndpoint) WebhookEndpointResponse {
+	return WebhookEndpointResponse{
+		ID:               endpoint.ID,
+		SessionID:        endpoint.SessionID,
+		Name:             endpoint.Name,
+		Enabled:          endpoint.Enabled,
+		URL:              endpoint.URL,
+		Secret:           endpoint.Secret,
+		Events:           endpoint.Events,
+		Headers:          endpoint.Headers,
+		IgnoreGroups:     endpoint.IgnoreGroups,
+		IgnoreBroadcasts: endpoint.IgnoreBroadcasts,
+		IgnoreChannels:   endpoint.IgnoreChannels,
+		CreatedAt:        endpoint.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        endpoint.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ListWebhookEndpointsResponse represents a session's webhook endpoints, oldest first
+type ListWebhookEndpointsResponse struct {
+	Endpoints []WebhookEndpointResponse `json:"endpoints"`
+}
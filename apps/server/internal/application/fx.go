@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"whatspire/internal/application/usecase"
 	"whatspire/internal/domain/repository"
@@ -27,6 +28,8 @@ var Module = fx.Module("application",
 		NewEventUseCase,
 		NewAPIKeyUseCase,
 		NewWebhookUseCase,
+		NewAuditLogUseCase,
+		NewMediaUseCase,
 	),
 )
 
@@ -47,6 +50,8 @@ func NewMessageUseCase(
 	publisher repository.EventPublisher,
 	mediaUploader repository.MediaUploader,
 	auditLogger repository.AuditLogger,
+	messageRepo repository.MessageRepository,
+	outboxRepo repository.OutboxRepository,
 	cfg *config.Config,
 	log *logger.Logger,
 ) *usecase.MessageUseCase {
@@ -57,6 +62,9 @@ func NewMessageUseCase(
 		MaxRetries:         3,
 		RateLimitPerSecond: rateLimitPerSecond,
 		QueueSize:          1000,
+		OutboxPollInterval: time.Second,
+		OutboxBatchSize:    50,
+		OutboxClaimTimeout: 5 * time.Minute,
 	}
 
 	uc := usecase.NewMessageUseCaseBuilder(msgConfig, log).
@@ -64,6 +72,8 @@ func NewMessageUseCase(
 		WithEventPublisher(publisher).
 		WithMediaUploader(mediaUploader).
 		WithAuditLogger(auditLogger).
+		WithMessageRepository(messageRepo).
+		WithOutboxRepository(outboxRepo).
 		Build()
 
 	lc.Append(fx.Hook{
@@ -85,8 +95,8 @@ func NewHealthUseCase(checkers *infrastructure.HealthCheckers) *usecase.HealthUs
 }
 
 // NewGroupsUseCase creates a new groups use case
-func NewGroupsUseCase(groupFetcher repository.GroupFetcher) *usecase.GroupsUseCase {
-	return usecase.NewGroupsUseCase(groupFetcher)
+func NewGroupsUseCase(groupFetcher repository.GroupFetcher, groupManager repository.GroupManager, groupRepo repository.GroupRepository) *usecase.GroupsUseCase {
+	return usecase.NewGroupsUseCase(groupFetcher, groupManager, groupRepo)
 }
 
 // NewReactionUseCase creates a new reaction use case
@@ -143,8 +153,20 @@ func NewAPIKeyUseCase(
 // NewWebhookUseCase creates a new webhook use case
 func NewWebhookUseCase(
 	repo repository.WebhookConfigRepository,
+	endpointRepo repository.WebhookEndpointRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
 	sessionRepo repository.SessionRepository,
 	auditLogger repository.AuditLogger,
 ) *usecase.WebhookUseCase {
-	return usecase.NewWebhookUseCase(repo, sessionRepo, auditLogger)
+	return usecase.NewWebhookUseCase(repo, endpointRepo, deliveryRepo, sessionRepo, auditLogger)
+}
+
+// NewAuditLogUseCase creates a new audit log use case
+func NewAuditLogUseCase(auditLogRepo repository.AuditLogRepository) *usecase.AuditLogUseCase {
+	return usecase.NewAuditLogUseCase(auditLogRepo)
+}
+
+// NewMediaUseCase creates a new media use case
+func NewMediaUseCase(storage repository.MediaStorage, signer repository.MediaURLSigner, cleaner repository.MediaCleaner) *usecase.MediaUseCase {
+	return usecase.NewMediaUseCase(storage, signer, cleaner)
 }
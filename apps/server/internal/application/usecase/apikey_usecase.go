@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
 	"whatspire/internal/domain/entity"
@@ -58,14 +59,26 @@ func (uc *APIKeyUseCase) generateAPIKey() (string, error) {
 // hashAPIKey hashes an API key using SHA-256
 // Returns a hex-encoded hash string (64 characters)
 func (uc *APIKeyUseCase) hashAPIKey(key string) string {
-	hash := sha256.Sum256([]byte(key))
-	return fmt.Sprintf("%x", hash)
+	return HashAPIKey(key)
 }
 
 // maskAPIKey masks an API key for display purposes
 // Shows first 8 and last 4 characters: "abcd1234...xyz9"
 // This is exported so handlers can use it for masking keys in responses
 func (uc *APIKeyUseCase) MaskAPIKey(key string) string {
+	return MaskAPIKey(key)
+}
+
+// HashAPIKey hashes an API key using SHA-256, the form API keys are stored and looked up in
+// Returns a hex-encoded hash string (64 characters)
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("%x", hash)
+}
+
+// MaskAPIKey masks an API key so it can be displayed or recorded without revealing it
+// Shows first 8 and last 4 characters: "abcd1234...xyz9"
+func MaskAPIKey(key string) string {
 	if len(key) <= 12 {
 		// Key too short to mask meaningfully
 		return "****"
@@ -76,14 +89,57 @@ func (uc *APIKeyUseCase) MaskAPIKey(key string) string {
 	return fmt.Sprintf("%s...%s", prefix, suffix)
 }
 
+// DefaultAPIKeyRotationGracePeriod is how long a rotated key keeps working when no grace period is given
+const DefaultAPIKeyRotationGracePeriod = 24 * time.Hour
+
+// MaxAPIKeyRotationGracePeriod is the longest grace period a rotated key may keep working for
+const MaxAPIKeyRotationGracePeriod = 30 * 24 * time.Hour
+
+// APIKeyOptions holds the optional restrictions of a new API key
+type APIKeyOptions struct {
+	SessionIDs        []string   // Sessions the key may access, empty for all sessions
+	Scopes            []string   // Scopes the key is limited to, empty for everything the role allows
+	ExpiresAt         *time.Time // Time the key stops working, nil for no expiry
+	DailyRequestQuota int        // Requests allowed per UTC day, 0 for unlimited
+	DailyMessageQuota int        // Messages allowed per UTC day, 0 for unlimited
+}
+
 // CreateAPIKey generates a new API key with the specified role and optional description
 // Returns the plain-text key (shown only once) and the created entity
 func (uc *APIKeyUseCase) CreateAPIKey(ctx context.Context, role string, description *string, createdBy string) (plainKey string, apiKey *entity.APIKey, err error) {
+	return uc.CreateAPIKeyWithOptions(ctx, role, description, APIKeyOptions{}, createdBy)
+}
+
+// CreateAPIKeyWithOptions generates a new API key restricted by the given options
+// Empty options create a key that may do everything its role allows, forever
+func (uc *APIKeyUseCase) CreateAPIKeyWithOptions(ctx context.Context, role string, description *string, opts APIKeyOptions, createdBy string) (plainKey string, apiKey *entity.APIKey, err error) {
 	// Validate role
 	if role != "read" && role != "write" && role != "admin" {
 		return "", nil, errors.ErrValidationFailed.WithMessage("invalid role: must be read, write, or admin")
 	}
 
+	allowedSessions, err := normalizeList(opts.SessionIDs)
+	if err != nil {
+		return "", nil, errors.ErrValidationFailed.WithMessage("session IDs must not be empty")
+	}
+
+	scopes, err := normalizeList(opts.Scopes)
+	if err != nil {
+		return "", nil, errors.ErrValidationFailed.WithMessage("scopes must not be empty")
+	}
+	for _, scope := range scopes {
+		if !entity.IsValidAPIKeyScope(scope) {
+			return "", nil, errors.ErrValidationFailed.WithMessage(fmt.Sprintf("invalid scope: %s", scope))
+		}
+	}
+
+	if opts.ExpiresAt != nil && !opts.ExpiresAt.After(time.Now()) {
+		return "", nil, errors.ErrValidationFailed.WithMessage("expiry time must be in the future")
+	}
+	if opts.DailyRequestQuota < 0 || opts.DailyMessageQuota < 0 {
+		return "", nil, errors.ErrValidationFailed.WithMessage("quotas must not be negative")
+	}
+
 	// Generate plain-text API key
 	plainKey, err = uc.generateAPIKey()
 	if err != nil {
@@ -98,6 +154,11 @@ func (uc *APIKeyUseCase) CreateAPIKey(ctx context.Context, role string, descript
 
 	// Create entity
 	apiKey = entity.NewAPIKey(id, keyHash, role, description)
+	apiKey.AllowedSessions = allowedSessions
+	apiKey.Scopes = scopes
+	apiKey.ExpiresAt = opts.ExpiresAt
+	apiKey.DailyRequestQuota = opts.DailyRequestQuota
+	apiKey.DailyMessageQuota = opts.DailyMessageQuota
 
 	// Save to repository
 	if err := uc.repo.Save(ctx, apiKey); err != nil {
@@ -153,6 +214,61 @@ func (uc *APIKeyUseCase) RevokeAPIKey(ctx context.Context, id string, revokedBy
 	return apiKey, nil
 }
 
+// RotateAPIKey issues a successor for an API key with the same role, description and restrictions
+// The previous key keeps working for the grace period so integrations can switch over without downtime
+// Returns the plain-text successor key (shown only once), the successor and the updated previous key
+func (uc *APIKeyUseCase) RotateAPIKey(ctx context.Context, id string, gracePeriod time.Duration, expiresAt *time.Time, rotatedBy string) (plainKey string, successor, previous *entity.APIKey, err error) {
+	if gracePeriod < 0 || gracePeriod > MaxAPIKeyRotationGracePeriod {
+		return "", nil, nil, errors.ErrValidationFailed.WithMessage("grace period must be between 0 and 30 days")
+	}
+
+	// Find the API key by ID
+	previous, err = uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if previous.IsRevoked() {
+		return "", nil, nil, errors.ErrAlreadyRevoked
+	}
+	if previous.IsRotated() {
+		return "", nil, nil, errors.ErrAlreadyRotated
+	}
+	if previous.IsExpired() {
+		return "", nil, nil, errors.ErrAPIKeyExpired
+	}
+
+	plainKey, successor, err = uc.CreateAPIKeyWithOptions(ctx, previous.Role, previous.Description, APIKeyOptions{
+		SessionIDs:        previous.AllowedSessions,
+		Scopes:            previous.Scopes,
+		ExpiresAt:         expiresAt,
+		DailyRequestQuota: previous.DailyRequestQuota,
+		DailyMessageQuota: previous.DailyMessageQuota,
+	}, rotatedBy)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	// Shorten the previous key's lifetime to the grace period
+	previous.RotateTo(successor.ID, gracePeriod)
+	if err := uc.repo.Update(ctx, previous); err != nil {
+		return "", nil, nil, err
+	}
+
+	return plainKey, successor, previous, nil
+}
+
+// GetAPIKey retrieves an API key by its ID
+func (uc *APIKeyUseCase) GetAPIKey(ctx context.Context, id string) (*entity.APIKey, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+// GetDailyUsage retrieves today's usage counters of an API key
+// Usage is only counted for keys with a daily quota
+func (uc *APIKeyUseCase) GetDailyUsage(ctx context.Context, id string) (*entity.APIKeyUsage, error) {
+	return uc.repo.GetUsage(ctx, id, time.Now())
+}
+
 // ListAPIKeys retrieves a paginated list of API keys with optional filters
 // Supports filtering by role and status, with pagination and sorting
 func (uc *APIKeyUseCase) ListAPIKeys(ctx context.Context, page, limit int, role, status *string) ([]*entity.APIKey, int64, error) {
@@ -241,3 +357,26 @@ func (uc *APIKeyUseCase) GetAPIKeyDetails(ctx context.Context, id string) (*enti
 
 	return apiKey, totalRequests, last7DaysRequests, nil
 }
+
+// normalizeList trims and de-duplicates list values, preserving order
+// Returns an error if a value is blank
+func normalizeList(values []string) ([]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, errors.ErrValidationFailed
+		}
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		normalized = append(normalized, value)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+)
+
+// auditLogExportBatchSize is the number of audit logs read from the database per export batch
+const auditLogExportBatchSize = 500
+
+// AuditLogUseCase handles audit log queries and exports
+type AuditLogUseCase struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+// NewAuditLogUseCase creates a new audit log use case
+func NewAuditLogUseCase(auditLogRepo repository.AuditLogRepository) *AuditLogUseCase {
+	return &AuditLogUseCase{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// ListAuditLogs returns a page of audit logs matching the filters, newest first
+func (uc *AuditLogUseCase) ListAuditLogs(ctx context.Context, req dto.ListAuditLogsRequest) (*dto.ListAuditLogsResponse, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, errors.ErrInvalidInput.WithMessage(err.Error())
+	}
+
+	filter, err := buildAuditLogFilter(req.AuditLogFilterRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra entry to know whether another page exists
+	filter.Limit = req.Limit + 1
+
+	if req.Cursor != "" {
+		cursor, err := decodeAuditLogCursor(req.Cursor)
+		if err != nil {
+			return nil, errors.ErrInvalidInput.WithMessage("invalid cursor")
+		}
+		filter.Before = &cursor.CreatedAt
+		filter.BeforeID = cursor.ID
+	}
+
+	auditLogs, err := uc.auditLogRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor string
+	if len(auditLogs) > req.Limit {
+		auditLogs = auditLogs[:req.Limit]
+		last := auditLogs[len(auditLogs)-1]
+		nextCursor = auditLogCursor{CreatedAt: last.CreatedAt.UTC(), ID: last.ID}.encode()
+	}
+
+	return &dto.ListAuditLogsResponse{
+		AuditLogs:  toAuditLogDTOs(auditLogs),
+		NextCursor: nextCursor,
+		Limit:      req.Limit,
+	}, nil
+}
+
+// ExportAuditLogs streams every audit log matching the filters to emit in batches, newest first
+// Validation errors are returned before emit is called for the first time
+func (uc *AuditLogUseCase) ExportAuditLogs(ctx context.Context, req dto.ExportAuditLogsRequest, emit func([]dto.AuditLogDTO) error) error {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return errors.ErrInvalidInput.WithMessage(err.Error())
+	}
+
+	filter, err := buildAuditLogFilter(req.AuditLogFilterRequest)
+	if err != nil {
+		return err
+	}
+	filter.Limit = auditLogExportBatchSize
+
+	for {
+		auditLogs, err := uc.auditLogRepo.List(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if len(auditLogs) == 0 {
+			return nil
+		}
+
+		if err := emit(toAuditLogDTOs(auditLogs)); err != nil {
+			return err
+		}
+
+		if len(auditLogs) < auditLogExportBatchSize {
+			return nil
+		}
+
+		// Continue after the last entry of this batch
+		last := auditLogs[len(auditLogs)-1]
+		before := last.CreatedAt
+		filter.Before = &before
+		filter.BeforeID = last.ID
+	}
+}
+
+// auditLogCursor is the keyset position of the last audit log of a page
+type auditLogCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+}
+
+// encode returns the cursor as an opaque pagination token
+func (c auditLogCursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeAuditLogCursor parses a token produced by auditLogCursor.encode
+func decodeAuditLogCursor(token string) (auditLogCursor, error) {
+	var cursor auditLogCursor
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, err
+	}
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return cursor, err
+	}
+	if cursor.ID == "" || cursor.CreatedAt.IsZero() {
+		return cursor, errors.ErrInvalidInput
+	}
+
+	return cursor, nil
+}
+
+// buildAuditLogFilter converts request filters to a repository filter
+func buildAuditLogFilter(req dto.AuditLogFilterRequest) (repository.AuditLogFilter, error) {
+	var filter repository.AuditLogFilter
+
+	if req.EventType != "" {
+		eventType := entity.AuditEventType(req.EventType)
+		if !eventType.IsValid() {
+			return filter, errors.ErrInvalidInput.WithMessage("invalid event type")
+		}
+		filter.EventType = &eventType
+	}
+
+	if req.APIKeyID != "" {
+		filter.APIKeyID = &req.APIKeyID
+	}
+
+	if req.SessionID != "" {
+		filter.SessionID = &req.SessionID
+	}
+
+	if req.IPAddress != "" {
+		filter.IPAddress = &req.IPAddress
+	}
+
+	if req.Since != "" {
+		since, err := time.Parse(time.RFC3339, req.Since)
+		if err != nil {
+			return filter, errors.ErrInvalidInput.WithMessage("since must be an RFC3339 timestamp")
+		}
+		filter.Since = &since
+	}
+
+	if req.Until != "" {
+		until, err := time.Parse(time.RFC3339, req.Until)
+		if err != nil {
+			return filter, errors.ErrInvalidInput.WithMessage("until must be an RFC3339 timestamp")
+		}
+		filter.Until = &until
+	}
+
+	if filter.Since != nil && filter.Until != nil && filter.Until.Before(*filter.Since) {
+		return filter, errors.ErrInvalidInput.WithMessage("until must not be before since")
+	}
+
+	return filter, nil
+}
+
+// toAuditLogDTOs converts audit log entities to DTOs
+func toAuditLogDTOs(auditLogs []*entity.AuditLog) []dto.AuditLogDTO {
+	auditLogDTOs := make([]dto.AuditLogDTO, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		auditLogDTOs[i] = dto.AuditLogDTO{
+			ID:        auditLog.ID,
+			EventType: auditLog.EventType.String(),
+			APIKeyID:  stringValue(auditLog.APIKeyID),
+			SessionID: stringValue(auditLog.SessionID),
+			Endpoint:  stringValue(auditLog.Endpoint),
+			Action:    stringValue(auditLog.Action),
+			IPAddress: stringValue(auditLog.IPAddress),
+			Details:   auditLog.Details,
+			CreatedAt: auditLog.CreatedAt.UTC().Format(time.RFC3339Nano),
+		}
+	}
+	return auditLogDTOs
+}
+
+// stringValue returns the value of an optional string, or an empty string when unset
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
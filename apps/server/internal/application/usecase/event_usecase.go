@@ -83,6 +83,7 @@ func (uc *EventUseCase) QueryEvents(ctx context.Context, req dto.QueryEventsRequ
 			ID:        event.ID,
 			Type:      event.Type.String(),
 			SessionID: event.SessionID,
+			Sequence:  event.Sequence,
 			Data:      event.Data,
 			Timestamp: event.Timestamp.Format(time.RFC3339),
 		}
@@ -191,6 +192,7 @@ func (uc *EventUseCase) GetEventByID(ctx context.Context, id string) (*dto.Event
 		ID:        event.ID,
 		Type:      event.Type.String(),
 		SessionID: event.SessionID,
+		Sequence:  event.Sequence,
 		Data:      event.Data,
 		Timestamp: event.Timestamp.Format(time.RFC3339),
 	}, nil
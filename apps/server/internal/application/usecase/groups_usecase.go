@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"whatspire/internal/application/dto"
 	"whatspire/internal/domain/entity"
 	"whatspire/internal/domain/errors"
 	"whatspire/internal/domain/repository"
@@ -11,12 +14,17 @@ import (
 // GroupsUseCase handles WhatsApp group operations
 type GroupsUseCase struct {
 	groupFetcher repository.GroupFetcher
+	groupManager repository.GroupManager
+	groupRepo    repository.GroupRepository
 }
 
 // NewGroupsUseCase creates a new GroupsUseCase
-func NewGroupsUseCase(groupFetcher repository.GroupFetcher) *GroupsUseCase {
+// groupRepo is optional; without it groups are not stored locally and cannot be listed
+func NewGroupsUseCase(groupFetcher repository.GroupFetcher, groupManager repository.GroupManager, groupRepo repository.GroupRepository) *GroupsUseCase {
 	return &GroupsUseCase{
 		groupFetcher: groupFetcher,
+		groupManager: groupManager,
+		groupRepo:    groupRepo,
 	}
 }
 
@@ -28,7 +36,7 @@ type SyncGroupsResult struct {
 }
 
 // SyncGroups fetches all groups from WhatsApp for the given session
-// Returns the groups data for the API server to persist
+// and replaces the local copy with them; groups the session is no longer part of are removed
 func (uc *GroupsUseCase) SyncGroups(ctx context.Context, sessionID string) (*SyncGroupsResult, error) {
 	if uc.groupFetcher == nil {
 		return nil, errors.ErrInternal.WithMessage("group fetcher not available")
@@ -50,5 +58,210 @@ func (uc *GroupsUseCase) SyncGroups(ctx context.Context, sessionID string) (*Syn
 		Errors: []string{},
 	}
 
+	if uc.groupRepo == nil {
+		return result, nil
+	}
+
+	now := time.Now()
+	keepJIDs := make([]string, 0, len(groups))
+	for _, group := range groups {
+		group.SetLastSyncAt(now)
+		keepJIDs = append(keepJIDs, group.JID)
+		if err := uc.groupRepo.Upsert(ctx, group); err != nil {
+			result.Synced--
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", group.JID, err))
+		}
+	}
+
+	if _, err := uc.groupRepo.DeleteExcept(ctx, sessionID, keepJIDs); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to remove stale groups: %v", err))
+	}
+
 	return result, nil
 }
+
+// ListGroups lists the locally stored groups of a session
+// Groups are stored by SyncGroups and kept up to date by group events
+func (uc *GroupsUseCase) ListGroups(ctx context.Context, req dto.ListGroupsRequest) (*dto.ListGroupsResponse, error) {
+	if uc.groupRepo == nil {
+		return nil, errors.ErrInternal.WithMessage("group repository not available")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 || limit > 100 {
+		limit = 50 // Default page size
+	}
+
+	filter := repository.GroupFilter{
+		SessionID:  req.SessionID,
+		Name:       req.Name,
+		IsArchived: req.Archived,
+		IsMuted:    req.Muted,
+		AdminOnly:  req.AdminOnly,
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	}
+
+	groups, err := uc.groupRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.groupRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &dto.ListGroupsResponse{
+		Groups: groups,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// storeGroup refreshes the local copy of a group after a change made through the API
+// Failures are not reported; group events and the next sync correct the local copy
+func (uc *GroupsUseCase) storeGroup(ctx context.Context, group *entity.Group) {
+	if uc.groupRepo == nil || group == nil {
+		return
+	}
+	_ = uc.groupRepo.Upsert(ctx, group)
+}
+
+// CreateGroup creates a new group owned by the session
+func (uc *GroupsUseCase) CreateGroup(ctx context.Context, req dto.CreateGroupRequest) (*entity.Group, error) {
+	if uc.groupManager == nil {
+		return nil, errors.ErrInternal.WithMessage("group manager not available")
+	}
+
+	group, err := uc.groupManager.CreateGroup(ctx, req.SessionID, req.Name, req.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.storeGroup(ctx, group)
+	return group, nil
+}
+
+// GetGroup fetches the current metadata and participants of a group from WhatsApp
+func (uc *GroupsUseCase) GetGroup(ctx context.Context, sessionID, groupJID string) (*entity.Group, error) {
+	if uc.groupManager == nil {
+		return nil, errors.ErrInternal.WithMessage("group manager not available")
+	}
+
+	return uc.groupManager.GetGroupInfo(ctx, sessionID, groupJID)
+}
+
+// UpdateGroup changes group settings and returns the updated group
+func (uc *GroupsUseCase) UpdateGroup(ctx context.Context, req dto.UpdateGroupRequest) (*entity.Group, error) {
+	if uc.groupManager == nil {
+		return nil, errors.ErrInternal.WithMessage("group manager not available")
+	}
+
+	update := entity.GroupSettingsUpdate{
+		Name:        req.Name,
+		Description: req.Description,
+		IsAnnounce:  req.IsAnnounce,
+		IsLocked:    req.IsLocked,
+	}
+	if req.EphemeralSeconds != nil {
+		timer := time.Duration(*req.EphemeralSeconds) * time.Second
+		update.Ephemeral = &timer
+	}
+
+	if update.IsEmpty() {
+		return nil, errors.ErrValidationFailed.WithMessage("at least one setting must be provided")
+	}
+
+	if err := uc.groupManager.UpdateGroupSettings(ctx, req.SessionID, req.GroupJID, update); err != nil {
+		return nil, err
+	}
+
+	group, err := uc.groupManager.GetGroupInfo(ctx, req.SessionID, req.GroupJID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.storeGroup(ctx, group)
+	return group, nil
+}
+
+// SetGroupPicture replaces the group picture and returns the new picture ID
+func (uc *GroupsUseCase) SetGroupPicture(ctx context.Context, req dto.SetGroupPictureRequest) (string, error) {
+	if uc.groupManager == nil {
+		return "", errors.ErrInternal.WithMessage("group manager not available")
+	}
+
+	return uc.groupManager.SetGroupPhoto(ctx, req.SessionID, req.GroupJID, req.ImageURL)
+}
+
+// UpdateParticipants adds, removes, promotes or demotes group participants
+// Per-participant failures (e.g. privacy settings) are reported in the results, not as an error
+func (uc *GroupsUseCase) UpdateParticipants(ctx context.Context, req dto.UpdateGroupParticipantsRequest) ([]entity.ParticipantChangeResult, error) {
+	if uc.groupManager == nil {
+		return nil, errors.ErrInternal.WithMessage("group manager not available")
+	}
+
+	action := entity.ParticipantAction(req.Action)
+	if !action.IsValid() {
+		return nil, errors.ErrValidationFailed.WithMessage("invalid participant action")
+	}
+
+	return uc.groupManager.UpdateGroupParticipants(ctx, req.SessionID, req.GroupJID, req.Participants, action)
+}
+
+// GetInviteLink returns the current invite link of a group
+func (uc *GroupsUseCase) GetInviteLink(ctx context.Context, sessionID, groupJID string) (string, error) {
+	if uc.groupManager == nil {
+		return "", errors.ErrInternal.WithMessage("group manager not available")
+	}
+
+	return uc.groupManager.GetGroupInviteLink(ctx, sessionID, groupJID, false)
+}
+
+// RevokeInviteLink invalidates the current invite link of a group and returns its replacement
+func (uc *GroupsUseCase) RevokeInviteLink(ctx context.Context, sessionID, groupJID string) (string, error) {
+	if uc.groupManager == nil {
+		return "", errors.ErrInternal.WithMessage("group manager not available")
+	}
+
+	return uc.groupManager.GetGroupInviteLink(ctx, sessionID, groupJID, true)
+}
+
+// JoinGroup joins a group through an invite link and returns the group JID
+func (uc *GroupsUseCase) JoinGroup(ctx context.Context, req dto.JoinGroupRequest) (string, error) {
+	if uc.groupManager == nil {
+		return "", errors.ErrInternal.WithMessage("group manager not available")
+	}
+
+	return uc.groupManager.JoinGroupWithLink(ctx, req.SessionID, req.InviteLink)
+}
+
+// LeaveGroup leaves a group
+func (uc *GroupsUseCase) LeaveGroup(ctx context.Context, sessionID, groupJID string) error {
+	if uc.groupManager == nil {
+		return errors.ErrInternal.WithMessage("group manager not available")
+	}
+
+	if err := uc.groupManager.LeaveGroup(ctx, sessionID, groupJID); err != nil {
+		return err
+	}
+
+	if uc.groupRepo != nil {
+		_ = uc.groupRepo.Delete(ctx, sessionID, groupJID)
+	}
+	return nil
+}
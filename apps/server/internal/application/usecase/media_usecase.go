@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+)
+
+// MediaUseCase handles access to media downloaded from WhatsApp
+type MediaUseCase struct {
+	storage repository.MediaStorage
+	signer  repository.MediaURLSigner
+	cleaner repository.MediaCleaner
+}
+
+// NewMediaUseCase creates a new media use case
+// signer may be nil, in which case no signed media links are accepted; cleaner may be nil
+func NewMediaUseCase(storage repository.MediaStorage, signer repository.MediaURLSigner, cleaner repository.MediaCleaner) *MediaUseCase {
+	return &MediaUseCase{
+		storage: storage,
+		signer:  signer,
+		cleaner: cleaner,
+	}
+}
+
+// OpenMedia opens a stored media file for reading; the caller must close its content
+func (uc *MediaUseCase) OpenMedia(ctx context.Context, filePath string) (*repository.MediaFile, error) {
+	if strings.Trim(filePath, "/") == "" {
+		return nil, errors.ErrNotFound.WithMessage("media not found")
+	}
+
+	file, err := uc.storage.OpenMedia(ctx, filePath)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.ErrNotFound.WithMessage("media not found")
+		}
+		return nil, errors.ErrInternal.WithCause(err)
+	}
+
+	return file, nil
+}
+
+// PreviewCleanup reports what the media cleanup job would delete if it ran now
+func (uc *MediaUseCase) PreviewCleanup(ctx context.Context) (*dto.MediaCleanupReportResponse, error) {
+	if uc.cleaner == nil {
+		return nil, errors.ErrInternal.WithMessage("media cleanup is not configured")
+	}
+
+	report, err := uc.cleaner.PlanCleanup(ctx)
+	if err != nil {
+		if errors.GetDomainError(err) != nil {
+			return nil, err
+		}
+		return nil, errors.ErrInternal.WithCause(err)
+	}
+
+	return dto.NewMediaCleanupReportResponse(report), nil
+}
+
+// VerifyMediaToken checks that a signed media link grants access to a media file
+func (uc *MediaUseCase) VerifyMediaToken(filePath, expires, signature string) error {
+	if uc.signer == nil {
+		return errors.ErrInvalidMediaToken
+	}
+	return uc.signer.Verify(filePath, expires, signature)
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,14 +17,23 @@ import (
 	"github.com/google/uuid"
 )
 
+var errMessageQueueFull = errors.ErrMessageSendFailed.WithMessage("message queue is full")
+
 // MessageUseCaseConfig holds configuration for the MessageUseCase
 type MessageUseCaseConfig struct {
 	// MaxRetries is the maximum number of retry attempts for failed messages
 	MaxRetries int
 	// RateLimitPerSecond is the maximum number of messages per second
 	RateLimitPerSecond int
-	// QueueSize is the maximum size of the message queue
+	// QueueSize is the maximum size of the in-memory message queue (used when no outbox is configured)
 	QueueSize int
+	// OutboxPollInterval is how often the durable outbox is polled for due messages
+	OutboxPollInterval time.Duration
+	// OutboxBatchSize is the maximum number of outbox messages claimed at once
+	OutboxBatchSize int
+	// OutboxClaimTimeout is how long a claimed outbox message may stay in flight before it is
+	// considered abandoned and claimed again; it must be longer than sending a message takes
+	OutboxClaimTimeout time.Duration
 }
 
 // DefaultMessageUseCaseConfig returns the default configuration
@@ -31,6 +42,9 @@ func DefaultMessageUseCaseConfig() MessageUseCaseConfig {
 		MaxRetries:         3,
 		RateLimitPerSecond: 10,
 		QueueSize:          1000,
+		OutboxPollInterval: time.Second,
+		OutboxBatchSize:    50,
+		OutboxClaimTimeout: 5 * time.Minute,
 	}
 }
 
@@ -40,6 +54,8 @@ type MessageUseCase struct {
 	publisher     repository.EventPublisher
 	mediaUploader repository.MediaUploader
 	auditLogger   repository.AuditLogger
+	messageRepo   repository.MessageRepository
+	outboxRepo    repository.OutboxRepository
 	config        MessageUseCaseConfig
 	logger        *logger.Logger
 
@@ -51,6 +67,7 @@ type MessageUseCase struct {
 
 	// Message queue for rate limiting
 	queue chan *entity.Message
+	wake  chan struct{} // Signals the outbox processor that new messages were enqueued
 	done  chan struct{}
 }
 
@@ -67,6 +84,7 @@ func NewMessageUseCaseBuilder(config MessageUseCaseConfig, log *logger.Logger) *
 			logger:        log.Sub("message_usecase"),
 			rateLimitChan: make(chan struct{}, config.RateLimitPerSecond),
 			queue:         make(chan *entity.Message, config.QueueSize),
+			wake:          make(chan struct{}, 1),
 			done:          make(chan struct{}),
 		},
 	}
@@ -96,6 +114,18 @@ func (b *MessageUseCaseBuilder) WithAuditLogger(auditLogger repository.AuditLogg
 	return b
 }
 
+// WithMessageRepository sets the repository used to persist message history
+func (b *MessageUseCaseBuilder) WithMessageRepository(repo repository.MessageRepository) *MessageUseCaseBuilder {
+	b.usecase.messageRepo = repo
+	return b
+}
+
+// WithOutboxRepository sets the durable outbox used to queue outbound messages
+func (b *MessageUseCaseBuilder) WithOutboxRepository(repo repository.OutboxRepository) *MessageUseCaseBuilder {
+	b.usecase.outboxRepo = repo
+	return b
+}
+
 // Build returns the constructed MessageUseCase and starts the message processor
 func (b *MessageUseCaseBuilder) Build() *MessageUseCase {
 	// Start the message processor
@@ -106,35 +136,26 @@ func (b *MessageUseCaseBuilder) Build() *MessageUseCase {
 
 // SendMessage sends a WhatsApp message
 func (uc *MessageUseCase) SendMessage(ctx context.Context, req dto.SendMessageRequest) (*entity.Message, error) {
-	// Validate phone number
-	_, err := valueobject.NewPhoneNumber(req.To)
+	msg, err := uc.prepareOutboundMessage(ctx, req)
 	if err != nil {
-		return nil, errors.ErrInvalidPhoneNumber
+		return nil, err
 	}
 
-	// Create message entity
-	msgID := uuid.New().String()
-	content := uc.buildMessageContent(req)
-	msgType := uc.getMessageType(req.Type)
-
-	msg := entity.NewMessageBuilder(msgID, req.SessionID).
-		From(""). // From will be set by the WhatsApp client
-		To(req.To).
-		WithContent(content).
-		WithType(msgType).
-		Build()
-
-	// Validate media if it's a media message
-	if err := uc.validateMediaMessage(msg); err != nil {
+	// Refuse before uploading when the message could not be queued anyway
+	if uc.queueFull() {
+		return nil, errMessageQueueFull
+	}
+	if err := uc.attachMediaFile(ctx, req, &msg.Content); err != nil {
 		return nil, err
 	}
 
+	// Record the message in history before it leaves the process
+	uc.saveMessage(ctx, msg)
+
 	// Enqueue the message for rate-limited sending
-	select {
-	case uc.queue <- msg:
-		// Message queued successfully
-	default:
-		return nil, errors.ErrMessageSendFailed.WithMessage("message queue is full")
+	if err := uc.enqueue(ctx, msg); err != nil {
+		uc.updateMessageStatus(ctx, msg, entity.MessageStatusFailed)
+		return nil, err
 	}
 
 	// Emit pending status event
@@ -145,31 +166,26 @@ func (uc *MessageUseCase) SendMessage(ctx context.Context, req dto.SendMessageRe
 
 // SendMessageSync sends a message synchronously (bypassing the queue)
 func (uc *MessageUseCase) SendMessageSync(ctx context.Context, req dto.SendMessageRequest) (*entity.Message, error) {
-	// Validate phone number
-	_, err := valueobject.NewPhoneNumber(req.To)
+	msg, err := uc.prepareOutboundMessage(ctx, req)
 	if err != nil {
-		return nil, errors.ErrInvalidPhoneNumber
+		return nil, err
+	}
+	if err := uc.attachMediaFile(ctx, req, &msg.Content); err != nil {
+		return nil, err
 	}
 
-	// Create message entity
-	msgID := uuid.New().String()
-	content := uc.buildMessageContent(req)
-	msgType := uc.getMessageType(req.Type)
-
-	msg := entity.NewMessageBuilder(msgID, req.SessionID).
-		From("").
-		To(req.To).
-		WithContent(content).
-		WithType(msgType).
-		Build()
+	// Record the message in history
+	uc.saveMessage(ctx, msg)
 
 	// Apply rate limiting
 	if err := uc.waitForRateLimit(ctx); err != nil {
+		uc.updateMessageStatus(ctx, msg, entity.MessageStatusFailed)
 		return nil, err
 	}
 
 	// Send the message
 	if err := uc.sendWithRetry(ctx, msg); err != nil {
+		uc.updateMessageStatus(ctx, msg, entity.MessageStatusFailed)
 		return nil, err
 	}
 
@@ -237,6 +253,177 @@ func (uc *MessageUseCase) HandleMessageStatusUpdate(ctx context.Context, msgID,
 	return nil
 }
 
+// ListChatMessages returns the stored message history of a chat, newest first
+func (uc *MessageUseCase) ListChatMessages(ctx context.Context, req dto.ListMessagesRequest) (*dto.ListMessagesResponse, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, errors.ErrInvalidInput.WithMessage(err.Error())
+	}
+
+	if uc.messageRepo == nil {
+		return nil, errors.ErrInternal.WithMessage("message history is not available")
+	}
+
+	// Fetch one extra message to know whether another page exists
+	filter := repository.MessageFilter{
+		SessionID: req.SessionID,
+		ChatJID:   req.ChatJID,
+		Limit:     req.Limit + 1,
+	}
+
+	if req.Cursor != "" {
+		before, beforeID, err := decodeMessageCursor(req.Cursor)
+		if err != nil {
+			return nil, errors.ErrInvalidInput.WithMessage("invalid cursor")
+		}
+		filter.Before = &before
+		filter.BeforeID = beforeID
+	}
+
+	messages, err := uc.messageRepo.ListByChat(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor string
+	if len(messages) > req.Limit {
+		messages = messages[:req.Limit]
+		last := messages[len(messages)-1]
+		nextCursor = encodeMessageCursor(last.Timestamp, last.ID)
+	}
+
+	// Convert to DTOs
+	messageDTOs := make([]dto.MessageDTO, len(messages))
+	for i, msg := range messages {
+		messageDTOs[i] = dto.MessageDTO{
+			ID:         msg.ID,
+			SessionID:  msg.SessionID,
+			ChatJID:    msg.ChatJID,
+			From:       msg.From,
+			To:         msg.To,
+			WhatsAppID: msg.WhatsAppID,
+			Direction:  msg.Direction.String(),
+			Type:       msg.Type.String(),
+			Status:     msg.GetStatus().String(),
+			Content:    msg.Content.WithoutUploadedMedia(),
+			Timestamp:  msg.Timestamp.Format(time.RFC3339Nano),
+		}
+	}
+
+	return &dto.ListMessagesResponse{
+		Messages:   messageDTOs,
+		NextCursor: nextCursor,
+		Limit:      req.Limit,
+	}, nil
+}
+
+// GetMessageStatus returns the current status of a message and its status timeline
+func (uc *MessageUseCase) GetMessageStatus(ctx context.Context, id string) (*dto.MessageStatusResponse, error) {
+	if id == "" {
+		return nil, errors.ErrInvalidInput.WithMessage("message ID is required")
+	}
+
+	if uc.messageRepo == nil {
+		return nil, errors.ErrInternal.WithMessage("message history is not available")
+	}
+
+	msg, err := uc.messageRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.messageRepo.GetStatusHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := make([]dto.MessageStatusChangeDTO, len(history))
+	for i, change := range history {
+		timeline[i] = dto.MessageStatusChangeDTO{
+			Status:    change.Status.String(),
+			Timestamp: change.Timestamp.Format(time.RFC3339Nano),
+		}
+	}
+
+	return &dto.MessageStatusResponse{
+		ID:         msg.ID,
+		SessionID:  msg.SessionID,
+		ChatJID:    msg.ChatJID,
+		WhatsAppID: msg.WhatsAppID,
+		Direction:  msg.Direction.String(),
+		Status:     msg.GetStatus().String(),
+		Timeline:   timeline,
+	}, nil
+}
+
+// FindMessage loads a message from the message history
+func (uc *MessageUseCase) FindMessage(ctx context.Context, id string) (*entity.Message, error) {
+	if id == "" {
+		return nil, errors.ErrInvalidInput.WithMessage("message ID is required")
+	}
+
+	if uc.messageRepo == nil {
+		return nil, errors.ErrInternal.WithMessage("message history is not available")
+	}
+
+	return uc.messageRepo.FindByID(ctx, id)
+}
+
+// EditMessage replaces the text, or the caption of a media message, previously sent by the session
+func (uc *MessageUseCase) EditMessage(ctx context.Context, req dto.EditMessageRequest) (*entity.Message, error) {
+	msg, err := uc.findChangeableMessage(ctx, req.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !msg.CanEdit(now) {
+		return nil, errors.ErrMessageNotEditable.WithMessage("only text messages and media captions sent by this session can be edited, within 15 minutes of sending")
+	}
+
+	if err := uc.waClient.EditMessage(ctx, msg.SessionID, msg.ChatJID, msg.WhatsAppID, msg.Type, req.Text); err != nil {
+		return nil, err
+	}
+
+	msg.Edit(req.Text, now)
+	uc.updateMessageContent(ctx, msg)
+	uc.emitMessageUpdateEvent(ctx, entity.EventTypeMessageEdited, msg, &req.Text, now)
+
+	return msg, nil
+}
+
+// RevokeMessage deletes a message previously sent by the session for everyone in the chat
+func (uc *MessageUseCase) RevokeMessage(ctx context.Context, req dto.RevokeMessageRequest) (*entity.Message, error) {
+	msg, err := uc.findChangeableMessage(ctx, req.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !msg.CanRevoke() {
+		return nil, errors.ErrMessageNotRevocable.WithMessage("only sent messages of this session that are not already revoked can be revoked")
+	}
+
+	if err := uc.waClient.RevokeMessage(ctx, msg.SessionID, msg.ChatJID, msg.WhatsAppID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	msg.Revoke(now)
+	uc.updateMessageContent(ctx, msg)
+	uc.emitMessageUpdateEvent(ctx, entity.EventTypeMessageRevoked, msg, nil, now)
+
+	return msg, nil
+}
+
+// findChangeableMessage loads a stored message that is about to be edited or revoked
+func (uc *MessageUseCase) findChangeableMessage(ctx context.Context, id string) (*entity.Message, error) {
+	if uc.waClient == nil {
+		return nil, errors.ErrConnectionFailed.WithMessage("WhatsApp client not available")
+	}
+
+	return uc.FindMessage(ctx, id)
+}
+
 // Close stops the message processor
 func (uc *MessageUseCase) Close() {
 	close(uc.done)
@@ -244,11 +431,53 @@ func (uc *MessageUseCase) Close() {
 
 // QueueSize returns the current number of messages in the queue
 func (uc *MessageUseCase) QueueSize() int {
+	if uc.outboxRepo != nil {
+		count, err := uc.outboxRepo.CountPending(context.Background())
+		if err != nil {
+			uc.logger.WithError(err).Warn("Failed to count pending outbox messages")
+			return 0
+		}
+		return int(count)
+	}
 	return len(uc.queue)
 }
 
+// enqueue hands a message to the durable outbox, or to the in-memory queue if no outbox is configured
+func (uc *MessageUseCase) enqueue(ctx context.Context, msg *entity.Message) error {
+	if uc.outboxRepo != nil {
+		if err := uc.outboxRepo.Enqueue(ctx, entity.NewOutboxEntry(msg)); err != nil {
+			return errors.ErrMessageSendFailed.WithCause(err)
+		}
+
+		// Wake the processor without blocking if it is already signalled
+		select {
+		case uc.wake <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+
+	select {
+	case uc.queue <- msg:
+		// Message queued successfully
+		return nil
+	default:
+		return errMessageQueueFull
+	}
+}
+
+// queueFull reports whether the in-memory queue has no room for another message
+func (uc *MessageUseCase) queueFull() bool {
+	return uc.outboxRepo == nil && len(uc.queue) == cap(uc.queue)
+}
+
 // processQueue processes messages from the queue with rate limiting
 func (uc *MessageUseCase) processQueue() {
+	if uc.outboxRepo != nil {
+		uc.processOutbox()
+		return
+	}
+
 	uc.logger.WithInt("queue_size", uc.config.QueueSize).
 		Debug("Message queue processor started")
 
@@ -282,7 +511,7 @@ func (uc *MessageUseCase) processQueue() {
 					Error("Message send failed after all retry attempts")
 
 				// Message failed after all retries
-				msg.SetStatus(entity.MessageStatusFailed)
+				uc.updateMessageStatus(ctx, msg, entity.MessageStatusFailed)
 				uc.emitMessageStatusEvent(ctx, msg, entity.MessageStatusFailed)
 			} else {
 				uc.logger.WithFields(map[string]interface{}{
@@ -295,6 +524,197 @@ func (uc *MessageUseCase) processQueue() {
 	}
 }
 
+// processOutbox delivers messages from the durable outbox until the use case is closed
+func (uc *MessageUseCase) processOutbox() {
+	ctx := context.Background()
+
+	pollInterval := uc.config.OutboxPollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	uc.logger.WithStr("poll_interval", pollInterval.String()).
+		Debug("Outbox processor started")
+
+	for {
+		uc.resetAbandonedOutboxClaims(ctx)
+		uc.drainOutbox(ctx)
+
+		select {
+		case <-uc.done:
+			uc.logger.Info("Outbox processor stopped gracefully")
+			return
+		case <-ticker.C:
+		case <-uc.wake:
+		}
+	}
+}
+
+// drainOutbox claims and delivers due outbox messages until none are left
+func (uc *MessageUseCase) drainOutbox(ctx context.Context) {
+	batchSize := uc.config.OutboxBatchSize
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+
+	for {
+		entries, err := uc.outboxRepo.ClaimDue(ctx, time.Now(), batchSize)
+		if err != nil {
+			uc.logger.WithError(err).Error("Failed to claim outbox messages")
+			return
+		}
+		if len(entries) == 0 {
+			return
+		}
+
+		for i, entry := range entries {
+			select {
+			case <-uc.done:
+				uc.releaseOutboxEntries(ctx, entries[i:])
+				return
+			default:
+			}
+
+			_ = uc.waitForRateLimit(ctx)
+
+			// Entries claimed too long ago may be claimed again by another processor, so they are handed back
+			if time.Since(entry.UpdatedAt) > uc.outboxClaimTimeout()/2 {
+				uc.releaseOutboxEntries(ctx, entries[i:])
+				break
+			}
+
+			uc.deliverOutboxEntry(ctx, entry)
+		}
+	}
+}
+
+// outboxClaimTimeout returns how long a claimed outbox message may stay in flight
+func (uc *MessageUseCase) outboxClaimTimeout() time.Duration {
+	if uc.config.OutboxClaimTimeout <= 0 {
+		return 5 * time.Minute
+	}
+	return uc.config.OutboxClaimTimeout
+}
+
+// resetAbandonedOutboxClaims returns messages claimed by a processor that stopped or crashed to pending
+func (uc *MessageUseCase) resetAbandonedOutboxClaims(ctx context.Context) {
+	reset, err := uc.outboxRepo.ResetInFlight(ctx, time.Now().Add(-uc.outboxClaimTimeout()))
+	if err != nil {
+		uc.logger.WithError(err).Error("Failed to reset abandoned outbox messages")
+	} else if reset > 0 {
+		uc.logger.WithInt("count", int(reset)).Info("Resuming abandoned outbox messages")
+	}
+}
+
+// releaseOutboxEntries returns claimed entries that were not attempted to pending, unchanged
+func (uc *MessageUseCase) releaseOutboxEntries(ctx context.Context, entries []*entity.OutboxEntry) {
+	for _, entry := range entries {
+		if err := uc.outboxRepo.Reschedule(ctx, entry.Message.ID, entry.Attempts, time.Now(), entry.LastError); err != nil {
+			uc.logger.WithError(err).
+				WithStr("message_id", entry.Message.ID).
+				Error("Failed to release outbox message")
+		}
+	}
+}
+
+// deliverOutboxEntry makes a single delivery attempt and records the outcome in the outbox
+func (uc *MessageUseCase) deliverOutboxEntry(ctx context.Context, entry *entity.OutboxEntry) {
+	msg := entry.Message
+	attempts := entry.Attempts + 1
+
+	err := uc.sendOnce(ctx, msg)
+	if err == nil {
+		if markErr := uc.outboxRepo.MarkSent(ctx, msg.ID); markErr != nil {
+			uc.logger.WithError(markErr).
+				WithStr("message_id", msg.ID).
+				Error("Failed to mark outbox message as sent")
+		}
+		return
+	}
+
+	if attempts >= uc.config.MaxRetries {
+		uc.logger.WithError(err).
+			WithFields(map[string]interface{}{
+				"message_id":  msg.ID,
+				"session_id":  msg.SessionID,
+				"recipient":   msg.To,
+				"retry_count": attempts,
+			}).
+			Error("Message send failed after all retry attempts")
+
+		if markErr := uc.outboxRepo.MarkFailed(ctx, msg.ID, attempts, err.Error()); markErr != nil {
+			uc.logger.WithError(markErr).
+				WithStr("message_id", msg.ID).
+				Error("Failed to mark outbox message as failed")
+		}
+
+		uc.updateMessageStatus(ctx, msg, entity.MessageStatusFailed)
+		uc.emitMessageStatusEvent(ctx, msg, entity.MessageStatusFailed)
+		return
+	}
+
+	// Exponential backoff: 1s, 2s, 4s
+	backoff := time.Duration(1<<(attempts-1)) * time.Second
+	uc.logger.WithError(err).
+		WithFields(map[string]interface{}{
+			"attempt":    attempts,
+			"message_id": msg.ID,
+			"session_id": msg.SessionID,
+		}).
+		Warn("WhatsApp client failed to send message, rescheduling")
+
+	if rescheduleErr := uc.outboxRepo.Reschedule(ctx, msg.ID, attempts, time.Now().Add(backoff), err.Error()); rescheduleErr != nil {
+		uc.logger.WithError(rescheduleErr).
+			WithStr("message_id", msg.ID).
+			Error("Failed to reschedule outbox message")
+	}
+}
+
+// sendOnce makes a single send attempt and records the sent status on success
+func (uc *MessageUseCase) sendOnce(ctx context.Context, msg *entity.Message) error {
+	if uc.waClient == nil {
+		uc.logger.Warn("WhatsApp client is nil, cannot send message")
+		return errors.ErrConnectionFailed.WithMessage("WhatsApp client not available")
+	}
+
+	if err := uc.waClient.SendMessage(ctx, msg); err != nil {
+		return err
+	}
+
+	uc.logger.WithFields(map[string]interface{}{
+		"message_id":  msg.ID,
+		"whatsapp_id": msg.WhatsAppID,
+		"session_id":  msg.SessionID,
+		"recipient":   msg.To,
+	}).Debug("WhatsApp client successfully sent message")
+
+	// Link our message ID to the WhatsApp ID so receipts can be matched
+	if uc.messageRepo != nil && msg.WhatsAppID != "" {
+		if err := uc.messageRepo.SetWhatsAppID(ctx, msg.ID, msg.WhatsAppID); err != nil {
+			uc.logger.WithError(err).
+				WithStr("message_id", msg.ID).
+				Warn("Failed to persist WhatsApp message ID")
+		}
+	}
+
+	uc.updateMessageStatus(ctx, msg, entity.MessageStatusSent)
+	uc.emitMessageStatusEvent(ctx, msg, entity.MessageStatusSent)
+
+	// Log message sent
+	if uc.auditLogger != nil {
+		uc.auditLogger.LogMessageSent(ctx, repository.MessageSentEvent{
+			SessionID:   msg.SessionID,
+			Recipient:   msg.To,
+			MessageType: msg.Type.String(),
+			Timestamp:   time.Now(),
+		})
+	}
+
+	return nil
+}
+
 // sendWithRetry sends a message with exponential backoff retry
 func (uc *MessageUseCase) sendWithRetry(ctx context.Context, msg *entity.Message) error {
 	var lastErr error
@@ -313,28 +733,8 @@ func (uc *MessageUseCase) sendWithRetry(ctx context.Context, msg *entity.Message
 			continue
 		}
 
-		err := uc.waClient.SendMessage(ctx, msg)
+		err := uc.sendOnce(ctx, msg)
 		if err == nil {
-			// Success
-			uc.logger.WithFields(map[string]interface{}{
-				"message_id": msg.ID,
-				"session_id": msg.SessionID,
-				"recipient":  msg.To,
-			}).Debug("WhatsApp client successfully sent message")
-
-			msg.SetStatus(entity.MessageStatusSent)
-			uc.emitMessageStatusEvent(ctx, msg, entity.MessageStatusSent)
-
-			// Log message sent
-			if uc.auditLogger != nil {
-				uc.auditLogger.LogMessageSent(ctx, repository.MessageSentEvent{
-					SessionID:   msg.SessionID,
-					Recipient:   msg.To,
-					MessageType: msg.Type.String(),
-					Timestamp:   time.Now(),
-				})
-			}
-
 			return nil
 		}
 
@@ -390,6 +790,94 @@ func (uc *MessageUseCase) waitForRateLimit(ctx context.Context) error {
 	return nil
 }
 
+// saveMessage records a message in history if a message repository is configured
+func (uc *MessageUseCase) saveMessage(ctx context.Context, msg *entity.Message) {
+	if uc.messageRepo == nil {
+		return
+	}
+
+	content := msg.Content.WithoutUploadedMedia()
+
+	// Keep only delivery metadata when message logging is disabled for the session
+	if uc.waClient != nil && !uc.waClient.GetSessionSettings(msg.SessionID).MessageLogging {
+		content = entity.MessageContent{}
+	}
+
+	record := entity.NewMessageBuilder(msg.ID, msg.SessionID).
+		InChat(msg.ChatJID).
+		From(msg.From).
+		To(msg.To).
+		WithWhatsAppID(msg.WhatsAppID).
+		WithDirection(msg.Direction).
+		WithContent(content).
+		WithType(msg.Type).
+		WithStatus(msg.GetStatus()).
+		WithTimestamp(msg.Timestamp).
+		Build()
+
+	if err := uc.messageRepo.Save(ctx, record); err != nil {
+		uc.logger.WithError(err).
+			WithStr("message_id", msg.ID).
+			Warn("Failed to persist message")
+	}
+}
+
+// updateMessageStatus sets the message status and persists the transition
+func (uc *MessageUseCase) updateMessageStatus(ctx context.Context, msg *entity.Message, status entity.MessageStatus) {
+	msg.SetStatus(status)
+
+	if uc.messageRepo == nil {
+		return
+	}
+
+	if err := uc.messageRepo.UpdateStatus(ctx, msg.ID, status, time.Now()); err != nil {
+		uc.logger.WithError(err).
+			WithStr("message_id", msg.ID).
+			WithStr("status", status.String()).
+			Warn("Failed to persist message status")
+	}
+}
+
+// updateMessageContent persists the content of an edited or revoked message
+func (uc *MessageUseCase) updateMessageContent(ctx context.Context, msg *entity.Message) {
+	content := msg.Content.WithoutUploadedMedia()
+	if !uc.waClient.GetSessionSettings(msg.SessionID).MessageLogging {
+		content = content.WithoutPayload()
+	}
+
+	if err := uc.messageRepo.UpdateContent(ctx, msg.ID, content); err != nil {
+		uc.logger.WithError(err).
+			WithStr("message_id", msg.ID).
+			Warn("Failed to persist message content")
+	}
+}
+
+// encodeMessageCursor builds an opaque pagination cursor from a message position
+func encodeMessageCursor(timestamp time.Time, id string) string {
+	raw := timestamp.UTC().Format(time.RFC3339Nano) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeMessageCursor parses a cursor produced by encodeMessageCursor
+func decodeMessageCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return time.Time{}, "", errors.ErrInvalidInput
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	return timestamp, parts[1], nil
+}
+
 // emitMessageStatusEvent emits a message status event
 func (uc *MessageUseCase) emitMessageStatusEvent(ctx context.Context, msg *entity.Message, status entity.MessageStatus) {
 	if uc.publisher == nil || !uc.publisher.IsConnected() {
@@ -429,6 +917,61 @@ func (uc *MessageUseCase) emitMessageStatusEvent(ctx context.Context, msg *entit
 	}
 }
 
+// emitMessageUpdateEvent emits an edit or revoke event for a message
+func (uc *MessageUseCase) emitMessageUpdateEvent(ctx context.Context, eventType entity.EventType, msg *entity.Message, text *string, at time.Time) {
+	if uc.publisher == nil || !uc.publisher.IsConnected() {
+		return
+	}
+
+	event, err := entity.NewEventWithPayload(
+		uuid.New().String(),
+		eventType,
+		msg.SessionID,
+		entity.MessageUpdate{
+			MessageID:  msg.ID,
+			WhatsAppID: msg.WhatsAppID,
+			ChatJID:    msg.ChatJID,
+			Text:       text,
+			Timestamp:  at,
+		},
+	)
+	if err == nil {
+		_ = uc.publisher.Publish(ctx, event)
+	}
+}
+
+// prepareOutboundMessage validates a send request and builds the message for it
+// Both send paths go through here; media sent with the request is attached afterwards, once the message is valid
+func (uc *MessageUseCase) prepareOutboundMessage(ctx context.Context, req dto.SendMessageRequest) (*entity.Message, error) {
+	// Validate the recipient: a phone number or a group
+	if !valueobject.ValidateGroupJID(req.To) {
+		if _, err := valueobject.NewPhoneNumber(req.To); err != nil {
+			return nil, errors.ErrInvalidPhoneNumber
+		}
+	}
+
+	// Create message entity
+	msgID := uuid.New().String()
+	content := uc.buildMessageContent(req)
+	uc.resolveReplyContext(ctx, req.SessionID, content.ReplyTo)
+	msgType := uc.getMessageType(req.Type)
+
+	msg := entity.NewMessageBuilder(msgID, req.SessionID).
+		From(""). // From will be set by the WhatsApp client
+		To(req.To).
+		InChat(valueobject.RecipientChatJID(req.To)).
+		WithContent(content).
+		WithType(msgType).
+		Build()
+
+	// Validate media if it's a media message
+	if err := uc.validateMediaMessage(msg, req.MediaFile() != nil); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
 // buildMessageContent builds MessageContent from the request
 func (uc *MessageUseCase) buildMessageContent(req dto.SendMessageRequest) entity.MessageContent {
 	content := entity.MessageContent{}
@@ -454,10 +997,107 @@ func (uc *MessageUseCase) buildMessageContent(req dto.SendMessageRequest) entity
 	if req.Content.Filename != nil {
 		content.Filename = req.Content.Filename
 	}
+	if req.Content.StickerURL != nil {
+		content.StickerURL = req.Content.StickerURL
+	}
+	if req.Content.Location != nil {
+		content.Location = &entity.LocationContent{
+			Latitude:  req.Content.Location.Latitude,
+			Longitude: req.Content.Location.Longitude,
+			Name:      req.Content.Location.Name,
+			Address:   req.Content.Location.Address,
+		}
+	}
+	for _, contact := range req.Content.Contacts {
+		content.Contacts = append(content.Contacts, entity.ContactCard{
+			DisplayName: contact.DisplayName,
+			VCard:       contact.VCard,
+		})
+	}
+	if req.Content.Poll != nil {
+		content.Poll = &entity.PollContent{
+			Question:        req.Content.Poll.Question,
+			Options:         req.Content.Poll.Options,
+			SelectableCount: req.Content.Poll.SelectableCount,
+		}
+	}
+	if req.Content.ReplyTo != nil {
+		content.ReplyTo = &entity.ReplyContext{
+			MessageID: req.Content.ReplyTo.MessageID,
+			ChatJID:   req.Content.ReplyTo.ChatJID,
+			Sender:    req.Content.ReplyTo.Sender,
+		}
+	}
+	if len(req.Content.Mentions) > 0 {
+		content.Mentions = req.Content.Mentions
+	}
 
 	return content
 }
 
+// attachMediaFile uploads media sent with the request to WhatsApp and attaches the result to the content
+// The file only exists while the request is handled, so it is uploaded before the message is queued
+func (uc *MessageUseCase) attachMediaFile(ctx context.Context, req dto.SendMessageRequest, content *entity.MessageContent) error {
+	file := req.MediaFile()
+	if file == nil {
+		return nil
+	}
+
+	if uc.mediaUploader == nil {
+		return errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
+	}
+
+	result, err := uc.mediaUploader.UploadContent(ctx, req.SessionID, &entity.MediaUploadInfo{
+		Content:   file.Content,
+		MediaType: valueobject.MediaType(req.Type),
+		Filename:  file.Filename,
+		MimeType:  file.MimeType,
+	})
+	if err != nil {
+		return err
+	}
+
+	content.Media = result
+	if content.Filename == nil && file.Filename != "" {
+		content.Filename = &file.Filename
+	}
+	return nil
+}
+
+// resolveReplyContext completes a reply from the message history
+// The quoted message may be referenced by our ID or its WhatsApp ID; the WhatsApp ID is what goes on the wire
+func (uc *MessageUseCase) resolveReplyContext(ctx context.Context, sessionID string, reply *entity.ReplyContext) {
+	if reply == nil || uc.messageRepo == nil {
+		return
+	}
+
+	quoted, err := uc.messageRepo.FindByID(ctx, reply.MessageID)
+	if err != nil || quoted.SessionID != sessionID {
+		quoted, err = uc.messageRepo.FindByWhatsAppID(ctx, sessionID, reply.MessageID)
+		if err != nil {
+			return // Unknown message; send the reply with what the caller gave us
+		}
+	}
+
+	if quoted.WhatsAppID != "" {
+		reply.MessageID = quoted.WhatsAppID
+	}
+	reply.FromMe = quoted.Direction == entity.MessageDirectionOutbound
+	if reply.Sender == "" {
+		reply.Sender = quoted.From
+	}
+	if reply.ChatJID == "" {
+		reply.ChatJID = quoted.ChatJID
+	}
+	if reply.QuotedText == "" {
+		if quoted.Content.Text != nil {
+			reply.QuotedText = *quoted.Content.Text
+		} else if quoted.Content.Caption != nil {
+			reply.QuotedText = *quoted.Content.Caption
+		}
+	}
+}
+
 // getMessageType converts string type to MessageType
 func (uc *MessageUseCase) getMessageType(typeStr string) entity.MessageType {
 	switch typeStr {
@@ -471,42 +1111,71 @@ func (uc *MessageUseCase) getMessageType(typeStr string) entity.MessageType {
 		return entity.MessageTypeAudio
 	case "video":
 		return entity.MessageTypeVideo
+	case "sticker":
+		return entity.MessageTypeSticker
+	case "location":
+		return entity.MessageTypeLocation
+	case "contact":
+		return entity.MessageTypeContact
+	case "poll":
+		return entity.MessageTypePoll
 	default:
 		return entity.MessageTypeText
 	}
 }
 
 // validateMediaMessage validates media messages before sending
-func (uc *MessageUseCase) validateMediaMessage(msg *entity.Message) error {
+// withFile reports that the media comes with the request and has not been uploaded yet
+func (uc *MessageUseCase) validateMediaMessage(msg *entity.Message, withFile bool) error {
+	hasMedia := msg.Content.Media != nil || withFile
 	switch msg.Type {
 	case entity.MessageTypeImage:
-		if msg.Content.ImageURL == nil || *msg.Content.ImageURL == "" {
+		if !hasMedia && (msg.Content.ImageURL == nil || *msg.Content.ImageURL == "") {
 			return errors.ErrEmptyContent.WithMessage("image URL is required for image messages")
 		}
 		if uc.mediaUploader == nil {
 			return errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
 		}
 	case entity.MessageTypeDocument:
-		if msg.Content.DocURL == nil || *msg.Content.DocURL == "" {
+		if !hasMedia && (msg.Content.DocURL == nil || *msg.Content.DocURL == "") {
 			return errors.ErrEmptyContent.WithMessage("document URL is required for document messages")
 		}
 		if uc.mediaUploader == nil {
 			return errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
 		}
 	case entity.MessageTypeAudio:
-		if msg.Content.AudioURL == nil || *msg.Content.AudioURL == "" {
+		if !hasMedia && (msg.Content.AudioURL == nil || *msg.Content.AudioURL == "") {
 			return errors.ErrEmptyContent.WithMessage("audio URL is required for audio messages")
 		}
 		if uc.mediaUploader == nil {
 			return errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
 		}
 	case entity.MessageTypeVideo:
-		if msg.Content.VideoURL == nil || *msg.Content.VideoURL == "" {
+		if !hasMedia && (msg.Content.VideoURL == nil || *msg.Content.VideoURL == "") {
 			return errors.ErrEmptyContent.WithMessage("video URL is required for video messages")
 		}
 		if uc.mediaUploader == nil {
 			return errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
 		}
+	case entity.MessageTypeSticker:
+		if !hasMedia && (msg.Content.StickerURL == nil || *msg.Content.StickerURL == "") {
+			return errors.ErrEmptyContent.WithMessage("sticker URL is required for sticker messages")
+		}
+		if uc.mediaUploader == nil {
+			return errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
+		}
+	case entity.MessageTypeLocation:
+		if msg.Content.Location == nil {
+			return errors.ErrEmptyContent.WithMessage("location is required for location messages")
+		}
+	case entity.MessageTypeContact:
+		if len(msg.Content.Contacts) == 0 {
+			return errors.ErrEmptyContent.WithMessage("at least one contact is required for contact messages")
+		}
+	case entity.MessageTypePoll:
+		if msg.Content.Poll == nil || len(msg.Content.Poll.Options) < 2 {
+			return errors.ErrEmptyContent.WithMessage("a question and at least two options are required for poll messages")
+		}
 	case entity.MessageTypeText:
 		if msg.Content.Text == nil || *msg.Content.Text == "" {
 			return errors.ErrEmptyContent.WithMessage("text content is required for text messages")
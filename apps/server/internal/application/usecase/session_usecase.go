@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"whatspire/internal/application/dto"
 	"whatspire/internal/domain/entity"
 	"whatspire/internal/domain/errors"
 	"whatspire/internal/domain/repository"
@@ -39,12 +40,21 @@ func NewSessionUseCase(
 // CreateSessionWithID creates a session record for WhatsApp client tracking
 // Called by Node.js API when a new session is created
 func (uc *SessionUseCase) CreateSessionWithID(ctx context.Context, id, name string) (*entity.Session, error) {
+	return uc.CreateSessionWithConfig(ctx, id, name, nil)
+}
+
+// CreateSessionWithConfig creates a session record with the given behaviour settings
+// Settings not present in config keep their defaults
+func (uc *SessionUseCase) CreateSessionWithConfig(ctx context.Context, id, name string, config *dto.SessionConfig) (*entity.Session, error) {
 	session := entity.NewSession(id, name)
+	config.ApplyTo(&session.Settings)
 
 	if err := uc.repo.Create(ctx, session); err != nil {
 		return nil, errors.ErrDatabase.WithCause(err)
 	}
 
+	uc.applySessionSettings(session)
+
 	// Log session creation
 	if uc.auditLogger != nil {
 		uc.auditLogger.LogSessionAction(ctx, repository.SessionActionEvent{
@@ -87,8 +97,8 @@ func (uc *SessionUseCase) DeleteSession(ctx context.Context, id string) error {
 	return nil
 }
 
-// UpdateSession updates session settings (name, etc.)
-func (uc *SessionUseCase) UpdateSession(ctx context.Context, id string, name *string) (*entity.Session, error) {
+// UpdateSession updates session settings (name and behaviour config)
+func (uc *SessionUseCase) UpdateSession(ctx context.Context, id string, name *string, config *dto.SessionConfig) (*entity.Session, error) {
 	// Get existing session
 	session, err := uc.repo.GetByID(ctx, id)
 	if err != nil {
@@ -104,11 +114,19 @@ func (uc *SessionUseCase) UpdateSession(ctx context.Context, id string, name *st
 		session.UpdatedAt = time.Now()
 	}
 
+	if config != nil {
+		settings := session.Settings
+		config.ApplyTo(&settings)
+		session.SetSettings(settings)
+	}
+
 	// Save to repository
 	if err := uc.repo.Update(ctx, session); err != nil {
 		return nil, errors.ErrDatabase.WithCause(err)
 	}
 
+	uc.applySessionSettings(session)
+
 	// Log session update
 	if uc.auditLogger != nil {
 		uc.auditLogger.LogSessionAction(ctx, repository.SessionActionEvent{
@@ -204,6 +222,11 @@ func (uc *SessionUseCase) ReconnectSessionWithJID(ctx context.Context, sessionID
 		uc.waClient.SetSessionJIDMapping(sessionID, jid)
 	}
 
+	// Load stored behaviour settings so they apply from the first event
+	if session, err := uc.repo.GetByID(ctx, sessionID); err == nil {
+		uc.applySessionSettings(session)
+	}
+
 	if err := uc.waClient.Connect(ctx, sessionID); err != nil {
 		_ = uc.UpdateSessionStatus(ctx, sessionID, entity.StatusDisconnected)
 		uc.publishConnectionFailedEvent(ctx, sessionID, "CONNECTION_ERROR", err.Error())
@@ -224,6 +247,14 @@ func (uc *SessionUseCase) ReconnectSessionWithJID(ctx context.Context, sessionID
 	return nil
 }
 
+// applySessionSettings pushes the behaviour settings of a session to the WhatsApp client
+func (uc *SessionUseCase) applySessionSettings(session *entity.Session) {
+	if uc.waClient == nil {
+		return
+	}
+	uc.waClient.SetSessionSettings(session.ID, session.Settings)
+}
+
 // publishConnectionEvent publishes a connection event via WebSocket
 func (uc *SessionUseCase) publishConnectionEvent(ctx context.Context, sessionID string, eventType entity.EventType) {
 	if uc.publisher == nil || !uc.publisher.IsConnected() {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"whatspire/internal/application/dto"
 	"whatspire/internal/domain/entity"
 	"whatspire/internal/domain/errors"
 	"whatspire/internal/domain/repository"
@@ -13,21 +14,27 @@ import (
 
 // WebhookUseCase handles webhook configuration operations
 type WebhookUseCase struct {
-	repo        repository.WebhookConfigRepository
-	sessionRepo repository.SessionRepository
-	auditLogger repository.AuditLogger
+	repo         repository.WebhookConfigRepository
+	endpointRepo repository.WebhookEndpointRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+	sessionRepo  repository.SessionRepository
+	auditLogger  repository.AuditLogger
 }
 
 // NewWebhookUseCase creates a new WebhookUseCase
 func NewWebhookUseCase(
 	repo repository.WebhookConfigRepository,
+	endpointRepo repository.WebhookEndpointRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
 	sessionRepo repository.SessionRepository,
 	auditLogger repository.AuditLogger,
 ) *WebhookUseCase {
 	return &WebhookUseCase{
-		repo:        repo,
-		sessionRepo: sessionRepo,
-		auditLogger: auditLogger,
+		repo:         repo,
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+		sessionRepo:  sessionRepo,
+		auditLogger:  auditLogger,
 	}
 }
 
@@ -187,3 +194,210 @@ func (uc *WebhookUseCase) DeleteWebhookConfig(ctx context.Context, sessionID str
 
 	return nil
 }
+
+// ListDeliveries lists the webhook deliveries of a session, newest first
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, sessionID string, req dto.ListWebhookDeliveriesRequest) (*dto.ListWebhookDeliveriesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.ErrInvalidInput.WithMessage(err.Error())
+	}
+
+	// Verify session exists
+	if _, err := uc.sessionRepo.GetByID(ctx, sessionID); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.ErrNotFound.WithMessage("session not found")
+		}
+		return nil, errors.ErrDatabase.WithCause(err)
+	}
+
+	filter := repository.WebhookDeliveryFilter{
+		SessionID: &sessionID,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	if req.Status != "" {
+		status := entity.WebhookDeliveryStatus(req.Status)
+		filter.Status = &status
+	}
+	if req.EndpointID != "" {
+		filter.EndpointID = &req.EndpointID
+	}
+
+	deliveries, err := uc.deliveryRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get total count (without pagination)
+	countFilter := filter
+	countFilter.Limit = 0
+	countFilter.Offset = 0
+	total, err := uc.deliveryRepo.Count(ctx, countFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ListWebhookDeliveriesResponse{
+		Deliveries: make([]dto.WebhookDeliveryDTO, len(deliveries)),
+		Total:      total,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	}
+	for i, delivery := range deliveries {
+		response.Deliveries[i] = dto.NewWebhookDeliveryDTO(delivery)
+	}
+
+	return response, nil
+}
+
+// RedeliverDelivery queues a webhook delivery of a session for an immediate attempt with a fresh retry schedule
+func (uc *WebhookUseCase) RedeliverDelivery(ctx context.Context, sessionID, deliveryID string) (*entity.WebhookDelivery, error) {
+	delivery, err := uc.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deliveries of other sessions are reported as missing rather than leaking their existence
+	if delivery.SessionID != sessionID {
+		return nil, errors.ErrNotFound.WithMessage("webhook delivery not found")
+	}
+
+	if delivery.Status == entity.WebhookDeliveryStatusInFlight {
+		return nil, errors.ErrWebhookDeliveryInFlight
+	}
+
+	delivery.Requeue()
+	if err := uc.deliveryRepo.Requeue(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	// Log manual redelivery
+	if uc.auditLogger != nil {
+		uc.auditLogger.LogSessionAction(ctx, repository.SessionActionEvent{
+			SessionID: sessionID,
+			Action:    "webhook_redelivered",
+			APIKeyID:  "", // API key ID would be extracted from context in production
+			Timestamp: time.Now(),
+		})
+	}
+
+	return delivery, nil
+}
+
+// ListWebhookEndpoints lists a session's webhook endpoints, oldest first
+func (uc *WebhookUseCase) ListWebhookEndpoints(ctx context.Context, sessionID string) ([]*entity.WebhookEndpoint, error) {
+	if err := uc.verifySession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	return uc.endpointRepo.ListBySessionID(ctx, sessionID)
+}
+
+// GetWebhookEndpoint retrieves one of a session's webhook endpoints
+func (uc *WebhookUseCase) GetWebhookEndpoint(ctx context.Context, sessionID, endpointID string) (*entity.WebhookEndpoint, error) {
+	if err := uc.verifySession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	return uc.endpointRepo.GetByID(ctx, sessionID, endpointID)
+}
+
+// CreateWebhookEndpoint adds a webhook endpoint with a newly generated secret to a session
+func (uc *WebhookUseCase) CreateWebhookEndpoint(ctx context.Context, sessionID string, req dto.WebhookEndpointRequest) (*entity.WebhookEndpoint, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.ErrInvalidInput.WithMessage(err.Error())
+	}
+
+	if err := uc.verifySession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	endpoint := entity.NewWebhookEndpoint(uuid.New().String(), sessionID, req.Settings())
+	if err := endpoint.GenerateSecret(); err != nil {
+		return nil, errors.ErrInternal.WithCause(err)
+	}
+
+	if err := uc.endpointRepo.Create(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	uc.logEndpointAction(ctx, sessionID, "webhook_endpoint_created")
+	return endpoint, nil
+}
+
+// UpdateWebhookEndpoint replaces the settings of one of a session's webhook endpoints, keeping its secret
+func (uc *WebhookUseCase) UpdateWebhookEndpoint(ctx context.Context, sessionID, endpointID string, req dto.WebhookEndpointRequest) (*entity.WebhookEndpoint, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.ErrInvalidInput.WithMessage(err.Error())
+	}
+
+	endpoint, err := uc.GetWebhookEndpoint(ctx, sessionID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint.Update(req.Settings())
+	if err := uc.endpointRepo.Update(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	uc.logEndpointAction(ctx, sessionID, "webhook_endpoint_updated")
+	return endpoint, nil
+}
+
+// RotateWebhookEndpointSecret generates a new secret for one of a session's webhook endpoints
+func (uc *WebhookUseCase) RotateWebhookEndpointSecret(ctx context.Context, sessionID, endpointID string) (*entity.WebhookEndpoint, error) {
+	endpoint, err := uc.GetWebhookEndpoint(ctx, sessionID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := endpoint.GenerateSecret(); err != nil {
+		return nil, errors.ErrInternal.WithCause(err)
+	}
+	if err := uc.endpointRepo.Update(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	uc.logEndpointAction(ctx, sessionID, "webhook_endpoint_secret_rotated")
+	return endpoint, nil
+}
+
+// DeleteWebhookEndpoint removes one of a session's webhook endpoints
+// Its queued deliveries are dead-lettered when they come up for an attempt
+func (uc *WebhookUseCase) DeleteWebhookEndpoint(ctx context.Context, sessionID, endpointID string) error {
+	if err := uc.verifySession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	if err := uc.endpointRepo.Delete(ctx, sessionID, endpointID); err != nil {
+		return err
+	}
+
+	uc.logEndpointAction(ctx, sessionID, "webhook_endpoint_deleted")
+	return nil
+}
+
+// verifySession checks that a session exists
+func (uc *WebhookUseCase) verifySession(ctx context.Context, sessionID string) error {
+	if _, err := uc.sessionRepo.GetByID(ctx, sessionID); err != nil {
+		if errors.IsNotFound(err) {
+			return errors.ErrNotFound.WithMessage("session not found")
+		}
+		return errors.ErrDatabase.WithCause(err)
+	}
+	return nil
+}
+
+// logEndpointAction records a change to a session's webhook endpoints in the audit log
+func (uc *WebhookUseCase) logEndpointAction(ctx context.Context, sessionID, action string) {
+	if uc.auditLogger == nil {
+		return
+	}
+
+	uc.auditLogger.LogSessionAction(ctx, repository.SessionActionEvent{
+		SessionID: sessionID,
+		Action:    action,
+		APIKeyID:  "", // API key ID would be extracted from context in production
+		Timestamp: time.Now(),
+	})
+}
@@ -5,18 +5,78 @@ import (
 	"time"
 )
 
+// API key scopes narrow what a key may do beyond its role
+const (
+	ScopeSessionsRead  = "sessions:read"
+	ScopeSessionsWrite = "sessions:write"
+	ScopeMessagesRead  = "messages:read"
+	ScopeMessagesSend  = "messages:send"
+	ScopePresenceWrite = "presence:write"
+	ScopeContactsRead  = "contacts:read"
+	ScopeGroupsRead    = "groups:read"
+	ScopeGroupsManage  = "groups:manage"
+	ScopeWebhooksRead  = "webhooks:read"
+	ScopeWebhooksWrite = "webhooks:write"
+	ScopeEventsRead    = "events:read"
+)
+
+// APIKeyScopes lists all valid API key scopes
+var APIKeyScopes = []string{
+	ScopeSessionsRead,
+	ScopeSessionsWrite,
+	ScopeMessagesRead,
+	ScopeMessagesSend,
+	ScopePresenceWrite,
+	ScopeContactsRead,
+	ScopeGroupsRead,
+	ScopeGroupsManage,
+	ScopeWebhooksRead,
+	ScopeWebhooksWrite,
+	ScopeEventsRead,
+}
+
+// IsValidAPIKeyScope checks if a scope is a known API key scope
+func IsValidAPIKeyScope(scope string) bool {
+	for _, s := range APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // APIKey represents an API key with role-based access control
+// A key can additionally be bound to a set of sessions and limited to a set of scopes
 type APIKey struct {
-	ID               string     `json:"id"`
-	KeyHash          string     `json:"key_hash"` // SHA-256 hash of the API key
-	Role             string     `json:"role"`     // read, write, admin
-	Description      *string    `json:"description,omitempty"`
-	CreatedAt        time.Time  `json:"created_at"`
-	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
-	IsActive         bool       `json:"is_active"`
-	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
-	RevokedBy        *string    `json:"revoked_by,omitempty"`
-	RevocationReason *string    `json:"revocation_reason,omitempty"`
+	ID                string     `json:"id"`
+	KeyHash           string     `json:"key_hash"`                   // SHA-256 hash of the API key
+	Role              string     `json:"role"`                       // read, write, admin
+	AllowedSessions   []string   `json:"allowed_sessions,omitempty"` // Sessions the key may access, empty for all sessions
+	Scopes            []string   `json:"scopes,omitempty"`           // Operations the key may perform, empty for everything its role allows
+	Description       *string    `json:"description,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	LastUsedAt        *time.Time `json:"last_used_at,omitempty"`
+	IsActive          bool       `json:"is_active"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy         *string    `json:"revoked_by,omitempty"`
+	RevocationReason  *string    `json:"revocation_reason,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`          // Key stops authenticating after this time, nil for no expiry
+	ReplacedBy        *string    `json:"replaced_by,omitempty"`         // ID of the successor key issued by rotation
+	DailyRequestQuota int        `json:"daily_request_quota,omitempty"` // Requests allowed per UTC day, 0 for unlimited
+	DailyMessageQuota int        `json:"daily_message_quota,omitempty"` // Messages allowed per UTC day, 0 for unlimited
+}
+
+// APIKeyUsage holds the request and message counters of an API key for one UTC day
+type APIKeyUsage struct {
+	APIKeyID string `json:"api_key_id"`
+	Day      string `json:"day"` // UTC day in YYYY-MM-DD format
+	Requests int64  `json:"requests"`
+	Messages int64  `json:"messages"`
+}
+
+// APIKeyUsageDay returns the UTC day that usage at the given time is counted against
+func APIKeyUsageDay(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
 }
 
 // NewAPIKey creates a new APIKey with the given ID, key hash, role, and optional description
@@ -32,6 +92,92 @@ func NewAPIKey(id, keyHash, role string, description *string) *APIKey {
 	}
 }
 
+// CanAccessSession reports whether the key may access the given session
+// Keys without allowed sessions may access every session
+func (k *APIKey) CanAccessSession(sessionID string) bool {
+	if len(k.AllowedSessions) == 0 {
+		return true
+	}
+	return containsString(k.AllowedSessions, sessionID)
+}
+
+// HasScope reports whether the key may perform operations requiring the given scope
+// Keys without scopes may perform everything their role allows
+func (k *APIKey) HasScope(scope string) bool {
+	if len(k.Scopes) == 0 {
+		return true
+	}
+	return containsString(k.Scopes, scope)
+}
+
+// IsSessionScoped reports whether the key is bound to specific sessions
+func (k *APIKey) IsSessionScoped() bool {
+	return len(k.AllowedSessions) > 0
+}
+
+// CanGrant reports whether the key may create another key with the given sessions and scopes
+// A restricted key can only hand out access that is at most as broad as its own
+func (k *APIKey) CanGrant(sessionIDs, scopes []string) bool {
+	if k.IsSessionScoped() {
+		if len(sessionIDs) == 0 {
+			return false
+		}
+		for _, sessionID := range sessionIDs {
+			if !k.CanAccessSession(sessionID) {
+				return false
+			}
+		}
+	}
+
+	if len(k.Scopes) > 0 {
+		if len(scopes) == 0 {
+			return false
+		}
+		for _, scope := range scopes {
+			if !k.HasScope(scope) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// IsExpired returns true if the API key has an expiry time that has passed
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && !time.Now().Before(*k.ExpiresAt)
+}
+
+// IsRotated returns true if a successor key has been issued for the API key
+func (k *APIKey) IsRotated() bool {
+	return k.ReplacedBy != nil
+}
+
+// HasQuota returns true if the API key has a daily request or message quota
+func (k *APIKey) HasQuota() bool {
+	return k.DailyRequestQuota > 0 || k.DailyMessageQuota > 0
+}
+
+// ExceedsRequestQuota returns true if the usage is over the daily request quota
+func (k *APIKey) ExceedsRequestQuota(usage *APIKeyUsage) bool {
+	return k.DailyRequestQuota > 0 && usage.Requests > int64(k.DailyRequestQuota)
+}
+
+// ExceedsMessageQuota returns true if the usage is over the daily message quota
+func (k *APIKey) ExceedsMessageQuota(usage *APIKeyUsage) bool {
+	return k.DailyMessageQuota > 0 && usage.Messages > int64(k.DailyMessageQuota)
+}
+
+// RotateTo marks the API key as replaced by its successor
+// The key keeps working until the end of the grace period, or its own expiry if that is earlier
+func (k *APIKey) RotateTo(successorID string, gracePeriod time.Duration) {
+	expiresAt := time.Now().Add(gracePeriod)
+	if k.ExpiresAt == nil || expiresAt.Before(*k.ExpiresAt) {
+		k.ExpiresAt = &expiresAt
+	}
+	k.ReplacedBy = &successorID
+}
+
 // UpdateLastUsed updates the last used timestamp
 func (k *APIKey) UpdateLastUsed() {
 	now := time.Now()
@@ -70,6 +216,7 @@ func (k *APIKey) MarshalJSON() ([]byte, error) {
 		CreatedAt  string  `json:"created_at"`
 		LastUsedAt *string `json:"last_used_at,omitempty"`
 		RevokedAt  *string `json:"revoked_at,omitempty"`
+		ExpiresAt  *string `json:"expires_at,omitempty"`
 	}{
 		Alias:     (*Alias)(k),
 		CreatedAt: k.CreatedAt.Format(time.RFC3339),
@@ -85,5 +232,10 @@ func (k *APIKey) MarshalJSON() ([]byte, error) {
 		aux.RevokedAt = &revokedAt
 	}
 
+	if k.ExpiresAt != nil {
+		expiresAt := k.ExpiresAt.Format(time.RFC3339)
+		aux.ExpiresAt = &expiresAt
+	}
+
 	return json.Marshal(aux)
 }
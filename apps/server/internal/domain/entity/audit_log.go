@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEventType represents the kind of a recorded audit log entry
+type AuditEventType string
+
+const (
+	AuditEventAPIKeyUsage     AuditEventType = "api_key_usage"
+	AuditEventAPIKeyCreated   AuditEventType = "api_key_created"
+	AuditEventAPIKeyRevoked   AuditEventType = "api_key_revoked"
+	AuditEventSessionAction   AuditEventType = "session_action"
+	AuditEventMessageSent     AuditEventType = "message_sent"
+	AuditEventAuthFailure     AuditEventType = "auth_failure"
+	AuditEventWebhookDelivery AuditEventType = "webhook_delivery"
+)
+
+// IsValid checks if the audit event type is valid
+func (t AuditEventType) IsValid() bool {
+	switch t {
+	case AuditEventAPIKeyUsage, AuditEventAPIKeyCreated, AuditEventAPIKeyRevoked,
+		AuditEventSessionAction, AuditEventMessageSent, AuditEventAuthFailure, AuditEventWebhookDelivery:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the audit event type
+func (t AuditEventType) String() string {
+	return string(t)
+}
+
+// AuditLog represents a recorded audit event
+type AuditLog struct {
+	ID        string          `json:"id"`
+	EventType AuditEventType  `json:"event_type"`
+	APIKeyID  *string         `json:"api_key_id,omitempty"`
+	SessionID *string         `json:"session_id,omitempty"`
+	Endpoint  *string         `json:"endpoint,omitempty"`
+	Action    *string         `json:"action,omitempty"`
+	Details   json.RawMessage `json:"details,omitempty"`
+	IPAddress *string         `json:"ip_address,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -16,6 +17,8 @@ const (
 	EventTypeMessageRead      EventType = "message.read"
 	EventTypeMessageFailed    EventType = "message.failed"
 	EventTypeMessageReaction  EventType = "message.reaction"
+	EventTypeMessageEdited    EventType = "message.edited"
+	EventTypeMessageRevoked   EventType = "message.revoked"
 )
 
 // Presence events
@@ -23,6 +26,17 @@ const (
 	EventTypePresenceUpdate EventType = "presence.update"
 )
 
+// Group events
+const (
+	EventTypeGroupJoined               EventType = "group.joined"
+	EventTypeGroupLeft                 EventType = "group.left"
+	EventTypeGroupUpdated              EventType = "group.updated"
+	EventTypeGroupParticipantsAdded    EventType = "group.participants.added"
+	EventTypeGroupParticipantsRemoved  EventType = "group.participants.removed"
+	EventTypeGroupParticipantsPromoted EventType = "group.participants.promoted"
+	EventTypeGroupParticipantsDemoted  EventType = "group.participants.demoted"
+)
+
 // Connection events
 const (
 	EventTypeConnectionConnecting EventType = "connection.connecting"
@@ -49,16 +63,40 @@ const (
 	EventTypeSyncProgress EventType = "sync.progress"
 )
 
+// Security events
+const (
+	EventTypeSecurityAuthLockout EventType = "security.auth_lockout"
+)
+
+// AllEventTypes lists every known event type
+var AllEventTypes = []EventType{
+	EventTypeMessageReceived, EventTypeMessageSent, EventTypeMessageDelivered,
+	EventTypeMessageRead, EventTypeMessageFailed, EventTypeMessageReaction,
+	EventTypeMessageEdited, EventTypeMessageRevoked,
+	EventTypePresenceUpdate,
+	EventTypeGroupJoined, EventTypeGroupLeft, EventTypeGroupUpdated,
+	EventTypeGroupParticipantsAdded, EventTypeGroupParticipantsRemoved,
+	EventTypeGroupParticipantsPromoted, EventTypeGroupParticipantsDemoted,
+	EventTypeConnectionConnecting, EventTypeConnected, EventTypeDisconnected,
+	EventTypeLoggedOut, EventTypeConnectionFailed, EventTypeQRScanned,
+	EventTypeAuthenticated, EventTypeSessionExpired, EventTypeQRCode,
+	EventTypeSyncProgress, EventTypeSecurityAuthLockout,
+}
+
 // IsValid checks if the event type is valid
 func (et EventType) IsValid() bool {
 	switch et {
 	case EventTypeMessageReceived, EventTypeMessageSent, EventTypeMessageDelivered,
 		EventTypeMessageRead, EventTypeMessageFailed, EventTypeMessageReaction,
+		EventTypeMessageEdited, EventTypeMessageRevoked,
 		EventTypePresenceUpdate,
+		EventTypeGroupJoined, EventTypeGroupLeft, EventTypeGroupUpdated,
+		EventTypeGroupParticipantsAdded, EventTypeGroupParticipantsRemoved,
+		EventTypeGroupParticipantsPromoted, EventTypeGroupParticipantsDemoted,
 		EventTypeConnectionConnecting, EventTypeConnected, EventTypeDisconnected,
 		EventTypeLoggedOut, EventTypeConnectionFailed, EventTypeQRScanned,
 		EventTypeAuthenticated, EventTypeSessionExpired, EventTypeQRCode,
-		EventTypeSyncProgress:
+		EventTypeSyncProgress, EventTypeSecurityAuthLockout:
 		return true
 	}
 	return false
@@ -69,11 +107,45 @@ func (et EventType) String() string {
 	return string(et)
 }
 
+// Matches reports whether the event type matches a subscription pattern
+// A pattern is an exact event type, "*" for every event, or a prefix wildcard such as "message.*"
+func (et EventType) Matches(pattern string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasSuffix(prefix, ".") && strings.HasPrefix(string(et), prefix)
+	}
+	return string(et) == pattern
+}
+
+// IsValidEventTypePattern checks if a subscription pattern matches at least one known event type
+func IsValidEventTypePattern(pattern string) bool {
+	for _, et := range AllEventTypes {
+		if et.Matches(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsMessageEvent returns true if this is a message-related event
 func (et EventType) IsMessageEvent() bool {
 	switch et {
 	case EventTypeMessageReceived, EventTypeMessageSent, EventTypeMessageDelivered,
-		EventTypeMessageRead, EventTypeMessageFailed, EventTypeMessageReaction:
+		EventTypeMessageRead, EventTypeMessageFailed, EventTypeMessageReaction,
+		EventTypeMessageEdited, EventTypeMessageRevoked:
+		return true
+	}
+	return false
+}
+
+// IsGroupEvent returns true if this is a group lifecycle or participant event
+func (et EventType) IsGroupEvent() bool {
+	switch et {
+	case EventTypeGroupJoined, EventTypeGroupLeft, EventTypeGroupUpdated,
+		EventTypeGroupParticipantsAdded, EventTypeGroupParticipantsRemoved,
+		EventTypeGroupParticipantsPromoted, EventTypeGroupParticipantsDemoted:
 		return true
 	}
 	return false
@@ -103,6 +175,7 @@ type Event struct {
 	ID        string          `json:"id,omitempty"`
 	Type      EventType       `json:"type"`
 	SessionID string          `json:"session_id"`
+	Sequence  int64           `json:"sequence,omitempty"` // Monotonically increasing per session, 0 if unassigned
 	Data      json.RawMessage `json:"data,omitempty"`
 	Timestamp time.Time       `json:"timestamp,omitempty"`
 }
@@ -164,3 +237,17 @@ func NewConnectionFailedEvent(id, sessionID string, errorCode, errorMessage stri
 	}
 	return NewEventWithPayload(id, EventTypeConnectionFailed, sessionID, data)
 }
+
+// AuthLockoutData represents the data payload for security.auth_lockout events
+type AuthLockoutData struct {
+	IPAddress   string    `json:"ip_address"`
+	Failures    int       `json:"failures"`
+	Endpoint    string    `json:"endpoint"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// NewAuthLockoutEvent creates a new security.auth_lockout event
+// The event is not tied to a WhatsApp session, so its session ID is empty
+func NewAuthLockoutEvent(id string, data AuthLockoutData) (*Event, error) {
+	return NewEventWithPayload(id, EventTypeSecurityAuthLockout, "", data)
+}
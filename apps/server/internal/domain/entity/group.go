@@ -28,6 +28,75 @@ func (r ParticipantRole) String() string {
 	return string(r)
 }
 
+// ParticipantAction represents a change applied to the participants of a group
+type ParticipantAction string
+
+const (
+	ParticipantActionAdd     ParticipantAction = "add"
+	ParticipantActionRemove  ParticipantAction = "remove"
+	ParticipantActionPromote ParticipantAction = "promote"
+	ParticipantActionDemote  ParticipantAction = "demote"
+)
+
+// IsValid checks if the action is a valid ParticipantAction value
+func (a ParticipantAction) IsValid() bool {
+	switch a {
+	case ParticipantActionAdd, ParticipantActionRemove, ParticipantActionPromote, ParticipantActionDemote:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the action
+func (a ParticipantAction) String() string {
+	return string(a)
+}
+
+// ParticipantChangeResult is the outcome of a participant change for a single participant
+type ParticipantChangeResult struct {
+	JID       string `json:"jid"`
+	Success   bool   `json:"success"`
+	ErrorCode int    `json:"error_code,omitempty"` // WhatsApp status code, e.g. 403 (privacy settings) or 409 (already a member)
+}
+
+// GroupSettingsUpdate holds the group settings to change; nil fields are left untouched
+type GroupSettingsUpdate struct {
+	Name        *string
+	Description *string
+	IsAnnounce  *bool
+	IsLocked    *bool
+	Ephemeral   *time.Duration // Disappearing messages timer, 0 turns them off
+}
+
+// IsEmpty reports whether the update changes nothing
+func (u GroupSettingsUpdate) IsEmpty() bool {
+	return u.Name == nil && u.Description == nil && u.IsAnnounce == nil && u.IsLocked == nil && u.Ephemeral == nil
+}
+
+// GroupEvent is the payload of group lifecycle and participant change events
+type GroupEvent struct {
+	GroupJID     string    `json:"group_jid"`
+	ActorJID     string    `json:"actor_jid,omitempty"`    // Who made the change; empty when WhatsApp doesn't report it
+	Participants []string  `json:"participants,omitempty"` // Participants affected by the change
+	Reason       string    `json:"reason,omitempty"`       // e.g. "invite" for group.joined, "removed" for group.left
+	Timestamp    time.Time `json:"timestamp"`
+
+	// Changed settings, only set for group.updated
+	Name          *string `json:"name,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	IsAnnounce    *bool   `json:"is_announce,omitempty"`
+	IsLocked      *bool   `json:"is_locked,omitempty"`
+	EphemeralTime *int    `json:"ephemeral_time,omitempty"` // Seconds, 0 when disappearing messages were turned off
+
+	// Group is the full group info, only set for group.joined
+	Group *Group `json:"group,omitempty"`
+}
+
+// HasSettingsChange reports whether the event carries at least one changed group setting
+func (e *GroupEvent) HasSettingsChange() bool {
+	return e.Name != nil || e.Description != nil || e.IsAnnounce != nil || e.IsLocked != nil || e.EphemeralTime != nil
+}
+
 // Group represents a WhatsApp group
 type Group struct {
 	ID            string  `json:"id"`
@@ -41,6 +110,7 @@ type Group struct {
 	EphemeralTime *int    `json:"ephemeral_time,omitempty"`
 	OwnerJID      *string `json:"owner_jid,omitempty"`
 	SessionID     string  `json:"session_id"`
+	IsAdmin       bool    `json:"is_admin"` // Whether the session itself is an admin of the group
 	// Filter support fields
 	IsArchived bool       `json:"is_archived"`
 	IsMuted    bool       `json:"is_muted"`
@@ -98,6 +168,99 @@ func (g *Group) SetLastSyncAt(t time.Time) {
 	g.UpdatedAt = time.Now()
 }
 
+// ApplyEvent updates the group with the changes carried by a group event
+func (g *Group) ApplyEvent(eventType EventType, change *GroupEvent) {
+	switch eventType {
+	case EventTypeGroupUpdated:
+		if change.Name != nil {
+			g.Name = *change.Name
+		}
+		if change.Description != nil {
+			g.Description = change.Description
+			if *change.Description == "" {
+				g.Description = nil
+			}
+		}
+		if change.IsAnnounce != nil {
+			g.IsAnnounce = *change.IsAnnounce
+		}
+		if change.IsLocked != nil {
+			g.IsLocked = *change.IsLocked
+		}
+		if change.EphemeralTime != nil {
+			g.IsEphemeral = *change.EphemeralTime > 0
+			g.EphemeralTime = change.EphemeralTime
+		}
+	case EventTypeGroupParticipantsAdded:
+		for _, jid := range change.Participants {
+			if g.FindParticipant(jid) != nil {
+				continue
+			}
+			participant := NewParticipant("", jid, g.ID, ParticipantRoleMember)
+			if change.ActorJID != "" && change.ActorJID != jid {
+				participant.SetAddedBy(change.ActorJID)
+			}
+			g.Participants = append(g.Participants, *participant)
+		}
+	case EventTypeGroupParticipantsRemoved:
+		remaining := g.Participants[:0]
+		for _, participant := range g.Participants {
+			if !containsString(change.Participants, participant.JID) {
+				remaining = append(remaining, participant)
+			}
+		}
+		g.Participants = remaining
+	case EventTypeGroupParticipantsPromoted, EventTypeGroupParticipantsDemoted:
+		role := ParticipantRoleAdmin
+		if eventType == EventTypeGroupParticipantsDemoted {
+			role = ParticipantRoleMember
+		}
+		for _, jid := range change.Participants {
+			if participant := g.FindParticipant(jid); participant != nil {
+				participant.Role = role
+				participant.UpdatedAt = time.Now()
+			}
+		}
+	default:
+		return
+	}
+
+	if eventType.IsGroupEvent() && eventType != EventTypeGroupUpdated {
+		g.MemberCount = len(g.Participants)
+	}
+	g.UpdatedAt = time.Now()
+}
+
+// FindParticipant returns the participant with the given JID, or nil if they are not in the group
+func (g *Group) FindParticipant(jid string) *Participant {
+	for i := range g.Participants {
+		if g.Participants[i].JID == jid {
+			return &g.Participants[i]
+		}
+	}
+	return nil
+}
+
+// HasAdmin reports whether any of the given JIDs is an admin of the group
+func (g *Group) HasAdmin(jids ...string) bool {
+	for _, jid := range jids {
+		if participant := g.FindParticipant(jid); participant != nil && participant.IsAdmin() {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString reports whether a slice contains a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // MarshalJSON implements json.Marshaler
 func (g *Group) MarshalJSON() ([]byte, error) {
 	type Alias Group
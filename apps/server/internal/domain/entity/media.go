@@ -2,6 +2,7 @@ package entity
 
 import (
 	"encoding/json"
+	"io"
 	"time"
 
 	"whatspire/internal/domain/valueobject"
@@ -135,3 +136,23 @@ func (m *MediaDownloadInfo) WithMaxSize(maxSize int64) *MediaDownloadInfo {
 func (m *MediaDownloadInfo) IsValid() bool {
 	return m.URL != ""
 }
+
+// MediaUploadInfo contains media supplied directly instead of downloaded from a URL
+type MediaUploadInfo struct {
+	// Content is the media itself; it is read once
+	Content io.Reader
+
+	// MediaType is the type the media is sent as, which selects the constraints it is validated against
+	MediaType valueobject.MediaType
+
+	// Filename is the optional original filename, also used to infer the MIME type
+	Filename string
+
+	// MimeType is the optional declared MIME type; inferred from the filename or content when empty
+	MimeType string
+}
+
+// IsValid checks if the upload info contains all required fields
+func (m *MediaUploadInfo) IsValid() bool {
+	return m.Content != nil && m.MediaType.IsValid()
+}
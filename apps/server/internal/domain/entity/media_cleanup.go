@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// MediaCleanupReason explains why the media cleanup job removes a file
+type MediaCleanupReason string
+
+const (
+	// MediaCleanupReasonRetention marks media older than its session's retention period
+	MediaCleanupReasonRetention MediaCleanupReason = "retention"
+
+	// MediaCleanupReasonOrphan marks media no stored event refers to
+	MediaCleanupReasonOrphan MediaCleanupReason = "orphan"
+
+	// MediaCleanupReasonDiskBudget marks least recently used media evicted to stay within the disk budget
+	MediaCleanupReasonDiskBudget MediaCleanupReason = "disk_budget"
+)
+
+// MediaCleanupCandidate is a media file selected for deletion by the media cleanup job
+type MediaCleanupCandidate struct {
+	Path         string
+	SessionID    string
+	Size         int64
+	StoredAt     time.Time
+	LastAccessed time.Time
+	Reason       MediaCleanupReason
+}
+
+// MediaCleanupReport describes a run of the media cleanup job, or what a run would do when DryRun is set
+type MediaCleanupReport struct {
+	DryRun         bool
+	StartedAt      time.Time
+	Duration       time.Duration
+	ScannedFiles   int
+	ScannedBytes   int64
+	Candidates     []MediaCleanupCandidate
+	DeletedFiles   int
+	DeletedBytes   int64
+	RemainingBytes int64
+	FailedFiles    int
+	OrphanSweep    bool // Whether orphaned media was looked for
+}
+
+// CandidateBytes returns the total size of the media selected for deletion
+func (r *MediaCleanupReport) CandidateBytes() int64 {
+	var total int64
+	for _, candidate := range r.Candidates {
+		total += candidate.Size
+	}
+	return total
+}
@@ -16,12 +16,25 @@ const (
 	MessageTypeAudio    MessageType = "audio"
 	MessageTypeVideo    MessageType = "video"
 	MessageTypeSticker  MessageType = "sticker"
+	MessageTypeLocation MessageType = "location"
+	MessageTypeContact  MessageType = "contact"
+	MessageTypePoll     MessageType = "poll"
 )
 
 // IsValid checks if the message type is valid
 func (mt MessageType) IsValid() bool {
 	switch mt {
-	case MessageTypeText, MessageTypeImage, MessageTypeDocument, MessageTypeAudio, MessageTypeVideo, MessageTypeSticker:
+	case MessageTypeText, MessageTypeImage, MessageTypeDocument, MessageTypeAudio, MessageTypeVideo, MessageTypeSticker,
+		MessageTypeLocation, MessageTypeContact, MessageTypePoll:
+		return true
+	}
+	return false
+}
+
+// HasCaption reports whether messages of this type carry a caption alongside their media
+func (mt MessageType) HasCaption() bool {
+	switch mt {
+	case MessageTypeImage, MessageTypeVideo, MessageTypeDocument:
 		return true
 	}
 	return false
@@ -57,15 +70,96 @@ func (ms MessageStatus) String() string {
 	return string(ms)
 }
 
+// CanTransitionTo reports whether the status may advance to next
+// Statuses only move forward (pending -> sent -> delivered -> read); failed is terminal
+func (ms MessageStatus) CanTransitionTo(next MessageStatus) bool {
+	switch ms {
+	case MessageStatusPending:
+		return next != MessageStatusPending && next.IsValid()
+	case MessageStatusSent:
+		return next == MessageStatusDelivered || next == MessageStatusRead || next == MessageStatusFailed
+	case MessageStatusDelivered:
+		return next == MessageStatusRead
+	}
+	return false
+}
+
+// MessageStatusChange records when a message reached a given status
+type MessageStatusChange struct {
+	Status    MessageStatus `json:"status"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// MessageDirection indicates whether a message was sent or received by the session
+type MessageDirection string
+
+const (
+	MessageDirectionOutbound MessageDirection = "outbound"
+	MessageDirectionInbound  MessageDirection = "inbound"
+)
+
+// IsValid checks if the message direction is valid
+func (md MessageDirection) IsValid() bool {
+	switch md {
+	case MessageDirectionOutbound, MessageDirectionInbound:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the message direction
+func (md MessageDirection) String() string {
+	return string(md)
+}
+
+// LocationContent holds the payload of a location message
+type LocationContent struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+}
+
+// ContactCard holds a single shared contact as a vCard
+type ContactCard struct {
+	DisplayName string `json:"display_name"`
+	VCard       string `json:"vcard"`
+}
+
+// PollContent holds the payload of a poll message
+type PollContent struct {
+	Question        string   `json:"question"`
+	Options         []string `json:"options"`
+	SelectableCount int      `json:"selectable_count"` // 0 allows selecting any number of options
+}
+
+// ReplyContext identifies the message an outbound message replies to
+type ReplyContext struct {
+	MessageID  string `json:"message_id"`            // WhatsApp ID of the quoted message
+	ChatJID    string `json:"chat_jid,omitempty"`    // Chat of the quoted message, if different from the reply's chat
+	Sender     string `json:"sender,omitempty"`      // JID of the quoted message's author
+	FromMe     bool   `json:"from_me,omitempty"`     // Quoted message was sent by this session
+	QuotedText string `json:"quoted_text,omitempty"` // Text shown in the reply preview
+}
+
 // MessageContent holds message content with type safety
 type MessageContent struct {
-	Text     *string `json:"text,omitempty"`
-	ImageURL *string `json:"image_url,omitempty"`
-	DocURL   *string `json:"doc_url,omitempty"`
-	AudioURL *string `json:"audio_url,omitempty"`
-	VideoURL *string `json:"video_url,omitempty"`
-	Caption  *string `json:"caption,omitempty"`
-	Filename *string `json:"filename,omitempty"`
+	Text       *string            `json:"text,omitempty"`
+	ImageURL   *string            `json:"image_url,omitempty"`
+	DocURL     *string            `json:"doc_url,omitempty"`
+	AudioURL   *string            `json:"audio_url,omitempty"`
+	VideoURL   *string            `json:"video_url,omitempty"`
+	StickerURL *string            `json:"sticker_url,omitempty"`
+	Media      *MediaUploadResult `json:"media,omitempty"` // Media uploaded with the send request, sent instead of fetching a URL
+	Caption    *string            `json:"caption,omitempty"`
+	Filename   *string            `json:"filename,omitempty"`
+	Location   *LocationContent   `json:"location,omitempty"`
+	Contacts   []ContactCard      `json:"contacts,omitempty"`
+	Poll       *PollContent       `json:"poll,omitempty"`
+	ReplyTo    *ReplyContext      `json:"reply_to,omitempty"`
+	Mentions   []string           `json:"mentions,omitempty"` // JIDs mentioned in the message
+	EditedAt   *time.Time         `json:"edited_at,omitempty"`
+	RevokedAt  *time.Time         `json:"revoked_at,omitempty"` // Set when the message was deleted for everyone
 }
 
 // NewTextContent creates a MessageContent with text
@@ -83,9 +177,23 @@ func NewDocumentContent(docURL string, caption *string) MessageContent {
 	return MessageContent{DocURL: &docURL, Caption: caption}
 }
 
+// WithoutPayload returns the content with only the edit and revoke markers kept
+// Used for sessions that don't log message content
+func (mc MessageContent) WithoutPayload() MessageContent {
+	return MessageContent{EditedAt: mc.EditedAt, RevokedAt: mc.RevokedAt}
+}
+
+// WithoutUploadedMedia returns the content without the upload result of media sent with the request
+// The result holds the keys that decrypt the media, so it is only kept for sending
+func (mc MessageContent) WithoutUploadedMedia() MessageContent {
+	mc.Media = nil
+	return mc
+}
+
 // IsEmpty checks if the content is empty
 func (mc MessageContent) IsEmpty() bool {
-	return mc.Text == nil && mc.ImageURL == nil && mc.DocURL == nil && mc.AudioURL == nil && mc.VideoURL == nil
+	return mc.Text == nil && mc.ImageURL == nil && mc.DocURL == nil && mc.AudioURL == nil && mc.VideoURL == nil &&
+		mc.StickerURL == nil && mc.Media == nil && mc.Location == nil && len(mc.Contacts) == 0 && mc.Poll == nil
 }
 
 // GetContentType returns the type of content based on what's populated
@@ -105,19 +213,50 @@ func (mc MessageContent) GetContentType() MessageType {
 	if mc.VideoURL != nil {
 		return MessageTypeVideo
 	}
+	if mc.StickerURL != nil {
+		return MessageTypeSticker
+	}
+	if mc.Media != nil {
+		return MessageType(mc.Media.MediaType)
+	}
+	if mc.Location != nil {
+		return MessageTypeLocation
+	}
+	if len(mc.Contacts) > 0 {
+		return MessageTypeContact
+	}
+	if mc.Poll != nil {
+		return MessageTypePoll
+	}
 	return MessageTypeText // default
 }
 
+// MessageEditWindow is how long after sending WhatsApp accepts edits to a message
+const MessageEditWindow = 15 * time.Minute
+
+// MessageUpdate describes an edit or revocation of an existing message
+type MessageUpdate struct {
+	MessageID  string    `json:"message_id"`            // ID of the original message
+	WhatsAppID string    `json:"whatsapp_id,omitempty"` // WhatsApp ID of the original message
+	ChatJID    string    `json:"chat_jid"`
+	Sender     string    `json:"sender,omitempty"` // JID of whoever changed the message
+	Text       *string   `json:"text,omitempty"`   // New text, for edits
+	Timestamp  time.Time `json:"timestamp"`
+}
+
 // Message represents a WhatsApp message
 type Message struct {
-	ID        string         `json:"id"`
-	SessionID string         `json:"session_id"`
-	From      string         `json:"from"`
-	To        string         `json:"to"`
-	Content   MessageContent `json:"content"`
-	Type      MessageType    `json:"type"`
-	Status    MessageStatus  `json:"status"`
-	Timestamp time.Time      `json:"timestamp"`
+	ID         string           `json:"id"`
+	SessionID  string           `json:"session_id"`
+	ChatJID    string           `json:"chat_jid"`
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	Direction  MessageDirection `json:"direction"`
+	WhatsAppID string           `json:"whatsapp_id"` // ID assigned on the WhatsApp network (may differ from ID)
+	Content    MessageContent   `json:"content"`
+	Type       MessageType      `json:"type"`
+	Status     MessageStatus    `json:"status"`
+	Timestamp  time.Time        `json:"timestamp"`
 
 	mu sync.RWMutex `json:"-"` // Protects Status field for concurrent access
 }
@@ -133,6 +272,7 @@ func NewMessageBuilder(id, sessionID string) *MessageBuilder {
 		message: &Message{
 			ID:        id,
 			SessionID: sessionID,
+			Direction: MessageDirectionOutbound,
 			Status:    MessageStatusPending,
 			Timestamp: time.Now(),
 		},
@@ -151,6 +291,24 @@ func (b *MessageBuilder) To(to string) *MessageBuilder {
 	return b
 }
 
+// InChat sets the chat JID the message belongs to
+func (b *MessageBuilder) InChat(chatJID string) *MessageBuilder {
+	b.message.ChatJID = chatJID
+	return b
+}
+
+// WithDirection sets the message direction (optional, defaults to outbound)
+func (b *MessageBuilder) WithDirection(direction MessageDirection) *MessageBuilder {
+	b.message.Direction = direction
+	return b
+}
+
+// WithWhatsAppID sets the WhatsApp network message ID
+func (b *MessageBuilder) WithWhatsAppID(whatsappID string) *MessageBuilder {
+	b.message.WhatsAppID = whatsappID
+	return b
+}
+
 // WithContent sets the message content
 func (b *MessageBuilder) WithContent(content MessageContent) *MessageBuilder {
 	b.message.Content = content
@@ -200,6 +358,43 @@ func (m *Message) IsSent() bool {
 	return status == MessageStatusSent || status == MessageStatusDelivered || status == MessageStatusRead
 }
 
+// IsRevoked returns true if the message was deleted for everyone
+func (m *Message) IsRevoked() bool {
+	return m.Content.RevokedAt != nil
+}
+
+// CanEdit reports whether this session may still edit the message at the given time
+// Only sent outbound text messages and media captions can be edited, within MessageEditWindow
+func (m *Message) CanEdit(now time.Time) bool {
+	if m.Direction != MessageDirectionOutbound || m.WhatsAppID == "" || m.IsRevoked() {
+		return false
+	}
+	if m.Type != MessageTypeText && !m.Type.HasCaption() {
+		return false
+	}
+	return now.Sub(m.Timestamp) <= MessageEditWindow
+}
+
+// CanRevoke reports whether this session may delete the message for everyone
+func (m *Message) CanRevoke() bool {
+	return m.Direction == MessageDirectionOutbound && m.WhatsAppID != "" && !m.IsRevoked()
+}
+
+// Edit replaces the text of a text message or the caption of a media message
+func (m *Message) Edit(text string, at time.Time) {
+	if m.Type.HasCaption() {
+		m.Content.Caption = &text
+	} else {
+		m.Content.Text = &text
+	}
+	m.Content.EditedAt = &at
+}
+
+// Revoke drops the content of a message deleted for everyone
+func (m *Message) Revoke(at time.Time) {
+	m.Content = MessageContent{RevokedAt: &at}
+}
+
 // MarshalJSON implements json.Marshaler
 func (m *Message) MarshalJSON() ([]byte, error) {
 	m.mu.RLock()
@@ -207,22 +402,28 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 	m.mu.RUnlock()
 
 	return json.Marshal(&struct {
-		ID        string         `json:"id"`
-		SessionID string         `json:"session_id"`
-		From      string         `json:"from"`
-		To        string         `json:"to"`
-		Content   MessageContent `json:"content"`
-		Type      MessageType    `json:"type"`
-		Status    MessageStatus  `json:"status"`
-		Timestamp string         `json:"timestamp"`
+		ID         string           `json:"id"`
+		SessionID  string           `json:"session_id"`
+		ChatJID    string           `json:"chat_jid,omitempty"`
+		From       string           `json:"from"`
+		To         string           `json:"to"`
+		Direction  MessageDirection `json:"direction,omitempty"`
+		WhatsAppID string           `json:"whatsapp_id,omitempty"`
+		Content    MessageContent   `json:"content"`
+		Type       MessageType      `json:"type"`
+		Status     MessageStatus    `json:"status"`
+		Timestamp  string           `json:"timestamp"`
 	}{
-		ID:        m.ID,
-		SessionID: m.SessionID,
-		From:      m.From,
-		To:        m.To,
-		Content:   m.Content,
-		Type:      m.Type,
-		Status:    status,
-		Timestamp: m.Timestamp.Format(time.RFC3339),
+		ID:         m.ID,
+		SessionID:  m.SessionID,
+		ChatJID:    m.ChatJID,
+		From:       m.From,
+		To:         m.To,
+		Direction:  m.Direction,
+		WhatsAppID: m.WhatsAppID,
+		Content:    m.Content,
+		Type:       m.Type,
+		Status:     status,
+		Timestamp:  m.Timestamp.Format(time.RFC3339),
 	})
 }
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// OutboxStatus represents the processing state of an outbound message in the outbox
+type OutboxStatus string
+
+const (
+	OutboxStatusPending  OutboxStatus = "pending"
+	OutboxStatusInFlight OutboxStatus = "in_flight"
+	OutboxStatusSent     OutboxStatus = "sent"
+	OutboxStatusFailed   OutboxStatus = "failed"
+)
+
+// IsValid checks if the outbox status is valid
+func (os OutboxStatus) IsValid() bool {
+	switch os {
+	case OutboxStatusPending, OutboxStatusInFlight, OutboxStatusSent, OutboxStatusFailed:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the outbox status
+func (os OutboxStatus) String() string {
+	return string(os)
+}
+
+// OutboxEntry represents an outbound message waiting to be delivered to WhatsApp
+type OutboxEntry struct {
+	Message       *Message     `json:"message"`
+	Status        OutboxStatus `json:"status"`
+	Attempts      int          `json:"attempts"`
+	NextAttemptAt time.Time    `json:"next_attempt_at"`
+	LastError     string       `json:"last_error,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// NewOutboxEntry creates a pending outbox entry that is due immediately
+func NewOutboxEntry(msg *Message) *OutboxEntry {
+	now := time.Now()
+	return &OutboxEntry{
+		Message:       msg,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
@@ -31,6 +31,27 @@ func (s Status) String() string {
 	return string(s)
 }
 
+// SessionSettings holds the per-session behaviours enforced by the WhatsApp client
+type SessionSettings struct {
+	AccountProtection bool `json:"account_protection"` // Throttle outgoing messages to reduce ban risk
+	MessageLogging    bool `json:"message_logging"`    // Store full message content (false = status only)
+	ReadMessages      bool `json:"read_messages"`      // Automatically mark incoming messages as read
+	AutoRejectCalls   bool `json:"auto_reject_calls"`  // Automatically reject incoming calls
+	AlwaysOnline      bool `json:"always_online"`      // Keep presence "available" while connected
+	IgnoreGroups      bool `json:"ignore_groups"`      // Drop group traffic
+	IgnoreBroadcasts  bool `json:"ignore_broadcasts"`  // Drop broadcast list and status traffic
+	IgnoreChannels    bool `json:"ignore_channels"`    // Drop channel (newsletter) traffic
+
+	MediaRetentionDays int `json:"media_retention_days"` // Days to keep downloaded media (0 = server default)
+}
+
+// DefaultSessionSettings returns the settings applied to new sessions
+func DefaultSessionSettings() SessionSettings {
+	return SessionSettings{
+		MessageLogging: true,
+	}
+}
+
 // Session represents a WhatsApp session
 type Session struct {
 	ID        string    `json:"id"`
@@ -44,6 +65,9 @@ type Session struct {
 	HistorySyncEnabled bool   `json:"history_sync_enabled"` // Whether to sync history on first connection
 	FullSync           bool   `json:"full_sync"`            // Whether to perform full history sync
 	SyncSince          string `json:"sync_since"`           // ISO 8601 timestamp for incremental sync
+
+	// Behaviour settings
+	Settings SessionSettings `json:"settings"`
 }
 
 // NewSession creates a new Session with the given ID and name
@@ -55,6 +79,7 @@ func NewSession(id, name string) *Session {
 		Status:    StatusPending,
 		CreatedAt: now,
 		UpdatedAt: now,
+		Settings:  DefaultSessionSettings(),
 	}
 }
 
@@ -79,6 +104,12 @@ func (s *Session) SetHistorySyncConfig(enabled, fullSync bool, since string) {
 	s.UpdatedAt = time.Now()
 }
 
+// SetSettings replaces the behaviour settings for the session
+func (s *Session) SetSettings(settings SessionSettings) {
+	s.Settings = settings
+	s.UpdatedAt = time.Now()
+}
+
 // IsConnected returns true if the session is connected
 func (s *Session) IsConnected() bool {
 	return s.Status == StatusConnected
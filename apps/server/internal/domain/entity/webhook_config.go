@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"time"
+
+	"whatspire/internal/domain/valueobject"
 )
 
 // WebhookConfig represents a per-session webhook configuration
@@ -85,6 +87,28 @@ func (w *WebhookConfig) ShouldDeliverEvent(eventType EventType) bool {
 	return false
 }
 
+// ShouldDeliverChat checks the message filtering options against the chat an event belongs to
+// Events that don't belong to a chat (empty JID) are always delivered
+func (w *WebhookConfig) ShouldDeliverChat(chatJID string) bool {
+	return shouldDeliverChat(chatJID, w.IgnoreGroups, w.IgnoreBroadcasts, w.IgnoreChannels)
+}
+
+// shouldDeliverChat applies group, broadcast and channel ignore flags to a chat JID
+func shouldDeliverChat(chatJID string, ignoreGroups, ignoreBroadcasts, ignoreChannels bool) bool {
+	switch {
+	case chatJID == "":
+		return true
+	case valueobject.IsGroupJID(chatJID):
+		return !ignoreGroups
+	case valueobject.IsBroadcastJID(chatJID):
+		return !ignoreBroadcasts
+	case valueobject.IsNewsletterJID(chatJID):
+		return !ignoreChannels
+	default:
+		return true
+	}
+}
+
 // MarshalJSON implements json.Marshaler
 func (w *WebhookConfig) MarshalJSON() ([]byte, error) {
 	type Alias WebhookConfig
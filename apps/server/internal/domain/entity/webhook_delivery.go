@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookDeliveryStatus represents the processing state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending    WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusInFlight   WebhookDeliveryStatus = "in_flight"
+	WebhookDeliveryStatusDelivered  WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusDeadLetter WebhookDeliveryStatus = "dead_letter"
+)
+
+// IsValid checks if the webhook delivery status is valid
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusInFlight, WebhookDeliveryStatusDelivered, WebhookDeliveryStatusDeadLetter:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the webhook delivery status
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// maxWebhookResponseLength caps how much of a receiver's response body is kept on a delivery
+const maxWebhookResponseLength = 1024
+
+// WebhookDelivery represents an event queued for delivery to a webhook endpoint
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	SessionID      string                `json:"session_id"`
+	EventID        string                `json:"event_id"`
+	EventType      EventType             `json:"event_type"`
+	EndpointID     string                `json:"endpoint_id,omitempty"` // Empty for the session's default webhook
+	URL            string                `json:"url"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	LastStatusCode int                   `json:"last_status_code,omitempty"`
+	LastResponse   string                `json:"last_response,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// NewWebhookDelivery creates a pending delivery of the event payload that is due immediately
+// endpointID identifies the session webhook endpoint it goes to, or is empty for the session's default webhook
+func NewWebhookDelivery(id string, event *Event, endpointID, url string, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            id,
+		SessionID:     event.SessionID,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		EndpointID:    endpointID,
+		URL:           url,
+		Payload:       payload,
+		Status:        WebhookDeliveryStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// RecordSuccess records an attempt the receiver accepted
+func (d *WebhookDelivery) RecordSuccess(statusCode int, response string) {
+	now := time.Now()
+	d.Attempts++
+	d.Status = WebhookDeliveryStatusDelivered
+	d.LastStatusCode = statusCode
+	d.LastResponse = truncateWebhookResponse(response)
+	d.LastError = ""
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// RecordFailure records a failed attempt and schedules the next one from the retry schedule.
+// The delivery is dead-lettered when the failure is not retryable or the schedule is used up.
+func (d *WebhookDelivery) RecordFailure(statusCode int, response, lastErr string, retryable bool, schedule []time.Duration) {
+	now := time.Now()
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastResponse = truncateWebhookResponse(response)
+	d.LastError = lastErr
+	d.UpdatedAt = now
+
+	// The first attempt is not part of the schedule, so attempt n waits schedule[n-1] before the next
+	if !retryable || d.Attempts > len(schedule) {
+		d.Status = WebhookDeliveryStatusDeadLetter
+		return
+	}
+
+	d.Status = WebhookDeliveryStatusPending
+	d.NextAttemptAt = now.Add(schedule[d.Attempts-1])
+}
+
+// Requeue makes the delivery due immediately with a fresh retry schedule
+func (d *WebhookDelivery) Requeue() {
+	now := time.Now()
+	d.Status = WebhookDeliveryStatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.UpdatedAt = now
+}
+
+// truncateWebhookResponse keeps the start of a response body so large error pages do not bloat the queue
+func truncateWebhookResponse(response string) string {
+	if len(response) <= maxWebhookResponseLength {
+		return response
+	}
+	return response[:maxWebhookResponseLength]
+}
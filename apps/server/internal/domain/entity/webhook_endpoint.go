@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// WebhookEndpoint represents one of a session's webhook endpoints
+// Each endpoint filters and receives events independently of the others
+type WebhookEndpoint struct {
+	ID        string            `json:"id"`
+	SessionID string            `json:"session_id"`
+	Name      string            `json:"name,omitempty"` // Optional label, e.g. "crm"
+	Enabled   bool              `json:"enabled"`
+	URL       string            `json:"url"`
+	Secret    string            `json:"secret"`
+	Events    []string          `json:"events"`            // Event types or wildcards such as "message.*"; empty delivers all
+	Headers   map[string]string `json:"headers,omitempty"` // Extra headers sent with every delivery
+
+	// Message filtering options
+	IgnoreGroups     bool `json:"ignore_groups"`
+	IgnoreBroadcasts bool `json:"ignore_broadcasts"`
+	IgnoreChannels   bool `json:"ignore_channels"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookEndpointSettings holds the user-editable settings of a webhook endpoint
+type WebhookEndpointSettings struct {
+	Name             string
+	Enabled          bool
+	URL              string
+	Events           []string
+	Headers          map[string]string
+	IgnoreGroups     bool
+	IgnoreBroadcasts bool
+	IgnoreChannels   bool
+}
+
+// NewWebhookEndpoint creates a new webhook endpoint for a session
+func NewWebhookEndpoint(id, sessionID string, settings WebhookEndpointSettings) *WebhookEndpoint {
+	now := time.Now()
+	endpoint := &WebhookEndpoint{
+		ID:        id,
+		SessionID: sessionID,
+		CreatedAt: now,
+	}
+	endpoint.Update(settings)
+	return endpoint
+}
+
+// Update replaces the endpoint's settings
+func (e *WebhookEndpoint) Update(settings WebhookEndpointSettings) {
+	e.Name = settings.Name
+	e.Enabled = settings.Enabled
+	e.URL = settings.URL
+	e.Events = settings.Events
+	if e.Events == nil {
+		e.Events = []string{}
+	}
+	e.Headers = settings.Headers
+	e.IgnoreGroups = settings.IgnoreGroups
+	e.IgnoreBroadcasts = settings.IgnoreBroadcasts
+	e.IgnoreChannels = settings.IgnoreChannels
+	e.UpdatedAt = time.Now()
+}
+
+// GenerateSecret generates a cryptographically secure random secret
+func (e *WebhookEndpoint) GenerateSecret() error {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return err
+	}
+
+	e.Secret = hex.EncodeToString(bytes)
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+// ShouldDeliverEvent checks if the endpoint is enabled and subscribes to the event type
+func (e *WebhookEndpoint) ShouldDeliverEvent(eventType EventType) bool {
+	if !e.Enabled {
+		return false
+	}
+
+	if len(e.Events) == 0 {
+		return true
+	}
+
+	for _, pattern := range e.Events {
+		if eventType.Matches(pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ShouldDeliverChat checks the message filtering options against the chat an event belongs to
+// Events that don't belong to a chat (empty JID) are always delivered
+func (e *WebhookEndpoint) ShouldDeliverChat(chatJID string) bool {
+	return shouldDeliverChat(chatJID, e.IgnoreGroups, e.IgnoreBroadcasts, e.IgnoreChannels)
+}
@@ -114,10 +114,12 @@ var (
 	ErrInvalidPhoneNumber = NewDomainError("INVALID_PHONE", "invalid E.164 phone number")
 
 	// Message errors
-	ErrMessageSendFailed  = NewDomainError("MESSAGE_SEND_FAILED", "failed to send message")
-	ErrMessageNotFound    = NewDomainError("MESSAGE_NOT_FOUND", "message not found")
-	ErrEmptyContent       = NewDomainError("EMPTY_CONTENT", "message content cannot be empty")
-	ErrInvalidMessageType = NewDomainError("INVALID_MESSAGE_TYPE", "invalid message type")
+	ErrMessageSendFailed   = NewDomainError("MESSAGE_SEND_FAILED", "failed to send message")
+	ErrMessageNotFound     = NewDomainError("MESSAGE_NOT_FOUND", "message not found")
+	ErrEmptyContent        = NewDomainError("EMPTY_CONTENT", "message content cannot be empty")
+	ErrInvalidMessageType  = NewDomainError("INVALID_MESSAGE_TYPE", "invalid message type")
+	ErrMessageNotEditable  = NewDomainError("MESSAGE_NOT_EDITABLE", "message cannot be edited")
+	ErrMessageNotRevocable = NewDomainError("MESSAGE_NOT_REVOCABLE", "message cannot be revoked")
 
 	// QR/Authentication errors
 	ErrQRTimeout          = NewDomainError("QR_TIMEOUT", "QR authentication timed out")
@@ -153,6 +155,7 @@ var (
 	ErrUnsupportedMimeType  = NewDomainError("UNSUPPORTED_MIME_TYPE", "MIME type not supported for this media type")
 	ErrMediaDownloadFailed  = NewDomainError("MEDIA_DOWNLOAD_FAILED", "failed to download media from URL")
 	ErrMediaUploadFailed    = NewDomainError("MEDIA_UPLOAD_FAILED", "failed to upload media to WhatsApp")
+	ErrInvalidMediaToken    = NewDomainError("INVALID_MEDIA_TOKEN", "media link is invalid or has expired")
 
 	// Circuit breaker errors
 	ErrCircuitOpen = NewDomainError("CIRCUIT_OPEN", "circuit breaker is open, service temporarily unavailable")
@@ -175,8 +178,18 @@ var (
 	ErrChatNotFound    = NewDomainError("CHAT_NOT_FOUND", "chat not found")
 	ErrInvalidJID      = NewDomainError("INVALID_JID", "invalid JID format")
 
+	// Group errors
+	ErrGroupNotFound     = NewDomainError("GROUP_NOT_FOUND", "group not found")
+	ErrGroupForbidden    = NewDomainError("GROUP_FORBIDDEN", "session is not allowed to manage the group")
+	ErrInvalidInviteLink = NewDomainError("INVALID_INVITE_LINK", "invalid or revoked group invite link")
+
 	// API Key errors
 	ErrAlreadyRevoked = NewDomainError("ALREADY_REVOKED", "API key is already revoked")
+	ErrAlreadyRotated = NewDomainError("ALREADY_ROTATED", "API key has already been rotated")
+	ErrAPIKeyExpired  = NewDomainError("API_KEY_EXPIRED", "API key has expired")
+
+	// Webhook errors
+	ErrWebhookDeliveryInFlight = NewDomainError("DELIVERY_IN_FLIGHT", "webhook delivery is currently being attempted")
 
 	// WhatsApp errors
 	ErrWhatsAppUnavailable = NewDomainError("WHATSAPP_UNAVAILABLE", "WhatsApp service is unavailable")
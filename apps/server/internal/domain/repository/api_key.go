@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"whatspire/internal/domain/entity"
 )
@@ -35,4 +36,12 @@ type APIKeyRepository interface {
 	// role: filter by role (read, write, admin) - nil means no filter
 	// isActive: filter by active status - nil means no filter
 	Count(ctx context.Context, role *string, isActive *bool) (int64, error)
+
+	// IncrementUsage adds to the usage counters of an API key for the day of at
+	// and returns the counters after the increment
+	IncrementUsage(ctx context.Context, id string, at time.Time, requests, messages int) (*entity.APIKeyUsage, error)
+
+	// GetUsage retrieves the usage counters of an API key for the day of at
+	// Returns zero counters if the key has no usage that day
+	GetUsage(ctx context.Context, id string, at time.Time) (*entity.APIKeyUsage, error)
 }
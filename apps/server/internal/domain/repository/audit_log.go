@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"whatspire/internal/domain/entity"
+)
+
+// AuditLogRepository defines read and retention operations for recorded audit events
+type AuditLogRepository interface {
+	// List retrieves audit logs matching the filter, newest first
+	List(ctx context.Context, filter AuditLogFilter) ([]*entity.AuditLog, error)
+
+	// DeleteOlderThan removes audit logs created before the cutoff and returns how many were removed
+	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// AuditLogFilter defines filtering and keyset pagination options for audit log queries
+type AuditLogFilter struct {
+	EventType *entity.AuditEventType // Only return events of this kind
+	APIKeyID  *string                // Only return events recorded for this API key
+	SessionID *string                // Only return events recorded for this session
+	IPAddress *string                // Only return events recorded from this client IP
+	Since     *time.Time             // Only return events created at or after this time
+	Until     *time.Time             // Only return events created at or before this time
+	Before    *time.Time             // Only return events older than this position (cursor)
+	BeforeID  string                 // Tie-breaker ID for events sharing the Before timestamp
+	Limit     int                    // Maximum number of results (0 = no limit)
+}
@@ -59,8 +59,10 @@ type MessageSentEvent struct {
 }
 
 // AuthFailureEvent represents an authentication failure event
+// The presented key is never recorded, only its masked form and hash
 type AuthFailureEvent struct {
-	APIKey    string
+	MaskedKey string // Masked form of the presented key, empty when no key was given
+	KeyHash   string // SHA-256 hash of the presented key, empty when no key was given
 	Endpoint  string
 	Reason    string
 	Timestamp time.Time
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"whatspire/internal/domain/entity"
+)
+
+// GroupRepository defines persistence operations for the local copy of a session's groups
+type GroupRepository interface {
+	// Upsert stores a group, replacing its stored settings and participants
+	Upsert(ctx context.Context, group *entity.Group) error
+
+	// FindByJID retrieves a group of a session, including its participants
+	FindByJID(ctx context.Context, sessionID, jid string) (*entity.Group, error)
+
+	// List retrieves groups matching the filter ordered by name, without participants
+	List(ctx context.Context, filter GroupFilter) ([]*entity.Group, error)
+
+	// Count returns the number of groups matching the filter, ignoring Limit and Offset
+	Count(ctx context.Context, filter GroupFilter) (int64, error)
+
+	// Delete removes a group of a session and its participants
+	Delete(ctx context.Context, sessionID, jid string) error
+
+	// DeleteExcept removes all groups of a session whose JID is not in keepJIDs and returns how many were removed
+	DeleteExcept(ctx context.Context, sessionID string, keepJIDs []string) (int64, error)
+}
+
+// GroupFilter defines filtering and pagination options for group queries
+type GroupFilter struct {
+	SessionID  string  // Session the groups belong to (required)
+	Name       *string // Case-insensitive substring of the group name
+	IsArchived *bool   // Only return archived (true) or unarchived (false) groups
+	IsMuted    *bool   // Only return muted (true) or unmuted (false) groups
+	AdminOnly  bool    // Only return groups the session is an admin of
+	Limit      int     // Maximum number of results (0 = no limit)
+	Offset     int     // Number of results to skip
+}
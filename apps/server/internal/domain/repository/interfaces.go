@@ -83,6 +83,12 @@ type WhatsAppClient interface {
 	// SendReaction sends a reaction to a message
 	SendReaction(ctx context.Context, sessionID, chatJID, messageID, emoji string) error
 
+	// EditMessage replaces the text, or the caption of a media message, previously sent by the session
+	EditMessage(ctx context.Context, sessionID, chatJID, messageID string, messageType entity.MessageType, text string) error
+
+	// RevokeMessage deletes a message previously sent by the session for everyone in the chat
+	RevokeMessage(ctx context.Context, sessionID, chatJID, messageID string) error
+
 	// SendReadReceipt sends read receipts for multiple messages atomically
 	SendReadReceipt(ctx context.Context, sessionID, chatJID string, messageIDs []string) error
 
@@ -110,6 +116,12 @@ type WhatsAppClient interface {
 	// GetHistorySyncConfig gets the history sync configuration for a session
 	GetHistorySyncConfig(sessionID string) (enabled, fullSync bool, since string)
 
+	// SetSessionSettings sets the behaviour settings enforced for a session
+	SetSessionSettings(sessionID string, settings entity.SessionSettings)
+
+	// GetSessionSettings gets the behaviour settings enforced for a session
+	GetSessionSettings(sessionID string) entity.SessionSettings
+
 	// CheckPhoneNumber checks if a phone number is registered on WhatsApp
 	CheckPhoneNumber(ctx context.Context, sessionID, phone string) (*entity.Contact, error)
 
@@ -162,6 +174,33 @@ type GroupFetcher interface {
 	GetJoinedGroups(ctx context.Context, sessionID string) ([]*entity.Group, error)
 }
 
+// GroupManager defines group administration operations on WhatsApp
+type GroupManager interface {
+	// CreateGroup creates a new group with the given name and initial participants
+	CreateGroup(ctx context.Context, sessionID, name string, participants []string) (*entity.Group, error)
+
+	// GetGroupInfo fetches the current metadata and participants of a group
+	GetGroupInfo(ctx context.Context, sessionID, groupJID string) (*entity.Group, error)
+
+	// UpdateGroupSettings applies the non-nil fields of the update to a group
+	UpdateGroupSettings(ctx context.Context, sessionID, groupJID string, update entity.GroupSettingsUpdate) error
+
+	// SetGroupPhoto replaces the group picture with the image at the given URL and returns the new picture ID
+	SetGroupPhoto(ctx context.Context, sessionID, groupJID, imageURL string) (string, error)
+
+	// UpdateGroupParticipants adds, removes, promotes or demotes group participants
+	UpdateGroupParticipants(ctx context.Context, sessionID, groupJID string, participants []string, action entity.ParticipantAction) ([]entity.ParticipantChangeResult, error)
+
+	// GetGroupInviteLink returns the group invite link, generating a new one (and revoking the old one) if reset is true
+	GetGroupInviteLink(ctx context.Context, sessionID, groupJID string, reset bool) (string, error)
+
+	// JoinGroupWithLink joins a group using an invite link or code and returns the group JID
+	JoinGroupWithLink(ctx context.Context, sessionID, link string) (string, error)
+
+	// LeaveGroup leaves a group
+	LeaveGroup(ctx context.Context, sessionID, groupJID string) error
+}
+
 // EventRepository defines event persistence operations for debugging and audit
 type EventRepository interface {
 	// Create stores a new event in the repository
@@ -178,6 +217,12 @@ type EventRepository interface {
 
 	// Count returns the total number of events matching the filter
 	Count(ctx context.Context, filter EventFilter) (int64, error)
+
+	// LatestSequence returns the highest sequence number stored for a session, 0 if none
+	LatestSequence(ctx context.Context, sessionID string) (int64, error)
+
+	// ListAfterSequence retrieves events of a session with a sequence number above the given one, oldest first
+	ListAfterSequence(ctx context.Context, sessionID string, afterSequence int64, limit int) ([]*entity.Event, error)
 }
 
 // EventFilter defines filtering options for event queries
@@ -23,9 +23,16 @@ type MediaUploader interface {
 	// UploadVideo uploads a video from a URL to WhatsApp servers
 	UploadVideo(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error)
 
+	// UploadSticker uploads a WebP sticker from a URL to WhatsApp servers
+	UploadSticker(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error)
+
 	// Upload is a generic upload method that determines the media type from the MIME type
 	Upload(ctx context.Context, sessionID string, info *entity.MediaDownloadInfo) (*entity.MediaUploadResult, error)
 
+	// UploadContent uploads media supplied by the caller to WhatsApp servers
+	// The media is validated against the constraints of info.MediaType, as media downloaded from a URL is
+	UploadContent(ctx context.Context, sessionID string, info *entity.MediaUploadInfo) (*entity.MediaUploadResult, error)
+
 	// GetConstraints returns the media constraints used for validation
 	GetConstraints() *valueobject.MediaConstraints
 }
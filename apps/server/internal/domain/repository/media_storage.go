@@ -3,6 +3,9 @@ package repository
 import (
 	"context"
 	"io"
+	"time"
+
+	"whatspire/internal/domain/entity"
 )
 
 // MediaStorage defines operations for storing and retrieving media files
@@ -22,6 +25,49 @@ type MediaStorage interface {
 
 	// ValidateSize checks if the media size is within allowed limits
 	ValidateSize(size int64) error
+
+	// OpenMedia opens a stored media file for reading
+	// Returns ErrNotFound if there is no media at the path
+	OpenMedia(ctx context.Context, filePath string) (*MediaFile, error)
+
+	// ListMedia returns every media file held in storage
+	ListMedia(ctx context.Context) ([]StoredMedia, error)
+}
+
+// MediaFile is a stored media file opened for reading
+// The caller must close Content
+type MediaFile struct {
+	Content     io.ReadSeekCloser
+	ContentType string    // Empty if unknown
+	Size        int64     // Size in bytes
+	ModTime     time.Time // Last modification time
+	ETag        string    // Quoted entity tag identifying this version of the file
+}
+
+// StoredMedia describes a media file held in storage
+type StoredMedia struct {
+	Path         string    // Path relative to the storage root, as returned by DownloadAndStore
+	SessionID    string    // Session the media was received on; empty if the path is not in a session directory
+	MessageID    string    // Message the media belongs to
+	Size         int64     // Size in bytes
+	ModTime      time.Time // When the media was stored
+	LastAccessed time.Time // When the media was last served; ModTime if the backend does not track access
+}
+
+// MediaURLSigner mints and verifies expiring tokens that grant access to a single media file
+type MediaURLSigner interface {
+	// Sign returns the query string granting access to a media file until it expires
+	Sign(filePath string) string
+
+	// Verify checks the expiry and signature of a token for a media file
+	// Returns ErrInvalidMediaToken if the token is invalid or has expired
+	Verify(filePath, expires, signature string) error
+}
+
+// MediaCleaner applies the retention policy for stored media
+type MediaCleaner interface {
+	// PlanCleanup reports what a cleanup run would delete now, without deleting anything
+	PlanCleanup(ctx context.Context) (*entity.MediaCleanupReport, error)
 }
 
 // MediaStorageConfig holds configuration for media storage
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"whatspire/internal/domain/entity"
+)
+
+// MessageRepository defines message history persistence operations
+type MessageRepository interface {
+	// Save stores a message, replacing any existing message with the same ID
+	Save(ctx context.Context, msg *entity.Message) error
+
+	// FindByID retrieves a message by its ID
+	FindByID(ctx context.Context, id string) (*entity.Message, error)
+
+	// FindByWhatsAppID retrieves a message of a session by the ID assigned on the WhatsApp network
+	FindByWhatsAppID(ctx context.Context, sessionID, whatsappID string) (*entity.Message, error)
+
+	// SetWhatsAppID records the WhatsApp network ID of a message
+	SetWhatsAppID(ctx context.Context, id, whatsappID string) error
+
+	// UpdateContent replaces the content of a message (e.g. after an edit or revocation)
+	UpdateContent(ctx context.Context, id string, content entity.MessageContent) error
+
+	// UpdateStatus updates the delivery status of a message and records it in the status history
+	UpdateStatus(ctx context.Context, id string, status entity.MessageStatus, at time.Time) error
+
+	// AddStatusHistory records a status in the history without changing the current status
+	AddStatusHistory(ctx context.Context, id string, status entity.MessageStatus, at time.Time) error
+
+	// GetStatusHistory retrieves the status history of a message, oldest first
+	GetStatusHistory(ctx context.Context, id string) ([]entity.MessageStatusChange, error)
+
+	// ListByChat retrieves messages for a chat, newest first, using keyset pagination
+	ListByChat(ctx context.Context, filter MessageFilter) ([]*entity.Message, error)
+}
+
+// MessageFilter defines filtering and cursor options for message history queries
+type MessageFilter struct {
+	SessionID string     // Session the chat belongs to (required)
+	ChatJID   string     // Chat JID to list messages for (required)
+	Before    *time.Time // Only return messages older than this timestamp (cursor)
+	BeforeID  string     // Tie-breaker for messages sharing the Before timestamp
+	Limit     int        // Maximum number of results (0 = no limit)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"whatspire/internal/domain/entity"
+)
+
+// OutboxRepository defines persistence operations for the durable outbound message queue
+type OutboxRepository interface {
+	// Enqueue stores a new pending outbox entry
+	Enqueue(ctx context.Context, entry *entity.OutboxEntry) error
+
+	// ClaimDue atomically marks up to limit pending entries due at or before now as in-flight
+	// and returns them, oldest first
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*entity.OutboxEntry, error)
+
+	// MarkSent marks an entry as sent
+	MarkSent(ctx context.Context, id string) error
+
+	// MarkFailed marks an entry as permanently failed
+	MarkFailed(ctx context.Context, id string, attempts int, lastErr string) error
+
+	// Reschedule returns an entry to pending with an updated attempt counter and next attempt time
+	Reschedule(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, lastErr string) error
+
+	// ResetInFlight returns entries claimed before claimedBefore that are still in-flight to pending
+	// A claim that old was abandoned by a process that stopped or crashed while sending
+	ResetInFlight(ctx context.Context, claimedBefore time.Time) (int64, error)
+
+	// CountPending returns the number of entries still waiting to be sent (pending or in-flight)
+	CountPending(ctx context.Context) (int64, error)
+}
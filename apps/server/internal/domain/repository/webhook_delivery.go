@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"whatspire/internal/domain/entity"
+)
+
+// WebhookDeliveryFilter represents filters for listing webhook deliveries
+type WebhookDeliveryFilter struct {
+	SessionID  *string                       // Filter by session ID
+	Status     *entity.WebhookDeliveryStatus // Filter by delivery status
+	EndpointID *string                       // Filter by webhook endpoint ID (empty for the default webhook)
+	Limit      int                           // Maximum number of results (0 = no limit)
+	Offset     int                           // Number of results to skip
+}
+
+// WebhookDeliveryRepository defines persistence operations for the durable webhook delivery queue
+type WebhookDeliveryRepository interface {
+	// Enqueue stores a new pending delivery
+	Enqueue(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// ClaimDue atomically marks up to limit pending deliveries due at or before now as in-flight
+	// and returns them, oldest first
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+
+	// RecordAttempt stores the outcome of a delivery attempt
+	RecordAttempt(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// Release returns claimed deliveries that were not attempted to pending
+	Release(ctx context.Context, ids []string) error
+
+	// Requeue returns a delivery that is not in flight to pending with a fresh retry schedule
+	Requeue(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// ResetInFlight returns deliveries claimed before claimedBefore that are still in-flight to pending
+	// A claim that old was abandoned by a process that stopped or crashed while delivering
+	ResetInFlight(ctx context.Context, claimedBefore time.Time) (int64, error)
+
+	// GetByID retrieves a delivery by its ID
+	GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+
+	// List retrieves deliveries matching the filter, newest first
+	List(ctx context.Context, filter WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error)
+
+	// Count returns the number of deliveries matching the filter, ignoring pagination
+	Count(ctx context.Context, filter WebhookDeliveryFilter) (int64, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"whatspire/internal/domain/entity"
+)
+
+// WebhookEndpointRepository defines persistence operations for a session's webhook endpoints
+type WebhookEndpointRepository interface {
+	// Create creates a new webhook endpoint
+	Create(ctx context.Context, endpoint *entity.WebhookEndpoint) error
+
+	// GetByID retrieves one of a session's webhook endpoints
+	GetByID(ctx context.Context, sessionID, id string) (*entity.WebhookEndpoint, error)
+
+	// ListBySessionID retrieves a session's webhook endpoints, oldest first
+	ListBySessionID(ctx context.Context, sessionID string) ([]*entity.WebhookEndpoint, error)
+
+	// Update updates an existing webhook endpoint
+	Update(ctx context.Context, endpoint *entity.WebhookEndpoint) error
+
+	// Delete removes one of a session's webhook endpoints
+	Delete(ctx context.Context, sessionID, id string) error
+}
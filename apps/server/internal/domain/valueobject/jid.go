@@ -1,6 +1,12 @@
 package valueobject
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
+
+// groupJIDRegex matches group JIDs: a numeric group ID, or creator-timestamp for older groups
+var groupJIDRegex = regexp.MustCompile(`^\d{5,32}(-\d{5,16})?@g\.us$`)
 
 // JID represents a WhatsApp Jabber ID
 type JID string
@@ -52,11 +58,31 @@ func IsGroupJID(jid string) bool {
 	return strings.Contains(jid, "@g.us")
 }
 
+// ValidateGroupJID validates if a string is a well-formed group JID
+func ValidateGroupJID(jid string) bool {
+	return groupJIDRegex.MatchString(strings.TrimSpace(jid))
+}
+
+// RecipientChatJID returns the chat an outbound message goes to
+// Recipients are E.164 phone numbers, which are sent to the user's chat, or group JIDs
+func RecipientChatJID(to string) string {
+	to = strings.TrimSpace(to)
+	if IsGroupJID(to) {
+		return to
+	}
+	return strings.TrimPrefix(to, "+") + "@s.whatsapp.net"
+}
+
 // IsBroadcastJID checks if the JID is a broadcast list JID
 func IsBroadcastJID(jid string) bool {
 	return strings.Contains(jid, "@broadcast")
 }
 
+// IsNewsletterJID checks if the JID is a channel (newsletter) JID
+func IsNewsletterJID(jid string) bool {
+	return strings.Contains(jid, "@newsletter")
+}
+
 // IsValidJID validates if the JID has a valid format
 func IsValidJID(jid string) bool {
 	if jid == "" {
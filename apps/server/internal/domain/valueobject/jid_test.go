@@ -158,3 +158,74 @@ func TestIsValidJID(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateGroupJID(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{
+			name:     "Group JID",
+			input:    "120363123456789012@g.us",
+			expected: true,
+		},
+		{
+			name:     "Older group JID",
+			input:    "201021347532-1588152400@g.us",
+			expected: true,
+		},
+		{
+			name:     "User JID",
+			input:    "201021347532@s.whatsapp.net",
+			expected: false,
+		},
+		{
+			name:     "Non-numeric group ID",
+			input:    "team@g.us",
+			expected: false,
+		},
+		{
+			name:     "Phone number",
+			input:    "+201021347532",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateGroupJID(tt.input)
+			if result != tt.expected {
+				t.Errorf("ValidateGroupJID(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRecipientChatJID(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Phone number",
+			input:    "+201021347532",
+			expected: "201021347532@s.whatsapp.net",
+		},
+		{
+			name:     "Group JID",
+			input:    "120363123456789012@g.us",
+			expected: "120363123456789012@g.us",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RecipientChatJID(tt.input)
+			if result != tt.expected {
+				t.Errorf("RecipientChatJID(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -14,12 +14,13 @@ const (
 	MediaTypeDocument MediaType = "document"
 	MediaTypeAudio    MediaType = "audio"
 	MediaTypeVideo    MediaType = "video"
+	MediaTypeSticker  MediaType = "sticker"
 )
 
 // IsValid checks if the media type is valid
 func (mt MediaType) IsValid() bool {
 	switch mt {
-	case MediaTypeImage, MediaTypeDocument, MediaTypeAudio, MediaTypeVideo:
+	case MediaTypeImage, MediaTypeDocument, MediaTypeAudio, MediaTypeVideo, MediaTypeSticker:
 		return true
 	}
 	return false
@@ -36,6 +37,7 @@ const (
 	MaxDocumentSize int64 = 100 * 1024 * 1024 // 100MB
 	MaxAudioSize    int64 = 16 * 1024 * 1024  // 16MB
 	MaxVideoSize    int64 = 16 * 1024 * 1024  // 16MB
+	MaxStickerSize  int64 = 500 * 1024        // 500KB (animated sticker limit)
 )
 
 // Allowed MIME types for each media type
@@ -65,6 +67,10 @@ var (
 		"video/mp4",
 		"video/3gpp",
 	}
+
+	AllowedStickerTypes = []string{
+		"image/webp",
+	}
 )
 
 // MediaConstraints holds validation constraints for media uploads
@@ -73,10 +79,12 @@ type MediaConstraints struct {
 	MaxDocumentSize      int64
 	MaxAudioSize         int64
 	MaxVideoSize         int64
+	MaxStickerSize       int64
 	AllowedImageTypes    []string
 	AllowedDocumentTypes []string
 	AllowedAudioTypes    []string
 	AllowedVideoTypes    []string
+	AllowedStickerTypes  []string
 }
 
 // DefaultMediaConstraints returns the default media constraints based on WhatsApp limits
@@ -86,14 +94,17 @@ func DefaultMediaConstraints() *MediaConstraints {
 		MaxDocumentSize:      MaxDocumentSize,
 		MaxAudioSize:         MaxAudioSize,
 		MaxVideoSize:         MaxVideoSize,
+		MaxStickerSize:       MaxStickerSize,
 		AllowedImageTypes:    AllowedImageTypes,
 		AllowedDocumentTypes: AllowedDocumentTypes,
 		AllowedAudioTypes:    AllowedAudioTypes,
 		AllowedVideoTypes:    AllowedVideoTypes,
+		AllowedStickerTypes:  AllowedStickerTypes,
 	}
 }
 
 // NewMediaConstraints creates a new MediaConstraints with custom values
+// Sticker constraints use the defaults since WhatsApp fixes their format
 func NewMediaConstraints(
 	maxImageSize, maxDocumentSize, maxAudioSize, maxVideoSize int64,
 	allowedImageTypes, allowedDocumentTypes, allowedAudioTypes, allowedVideoTypes []string,
@@ -103,10 +114,12 @@ func NewMediaConstraints(
 		MaxDocumentSize:      maxDocumentSize,
 		MaxAudioSize:         maxAudioSize,
 		MaxVideoSize:         maxVideoSize,
+		MaxStickerSize:       MaxStickerSize,
 		AllowedImageTypes:    allowedImageTypes,
 		AllowedDocumentTypes: allowedDocumentTypes,
 		AllowedAudioTypes:    allowedAudioTypes,
 		AllowedVideoTypes:    allowedVideoTypes,
+		AllowedStickerTypes:  AllowedStickerTypes,
 	}
 }
 
@@ -121,6 +134,8 @@ func (mc *MediaConstraints) GetMaxSize(mediaType MediaType) int64 {
 		return mc.MaxAudioSize
 	case MediaTypeVideo:
 		return mc.MaxVideoSize
+	case MediaTypeSticker:
+		return mc.MaxStickerSize
 	default:
 		return 0
 	}
@@ -137,6 +152,8 @@ func (mc *MediaConstraints) GetAllowedTypes(mediaType MediaType) []string {
 		return mc.AllowedAudioTypes
 	case MediaTypeVideo:
 		return mc.AllowedVideoTypes
+	case MediaTypeSticker:
+		return mc.AllowedStickerTypes
 	default:
 		return nil
 	}
@@ -45,6 +45,12 @@ type Config struct {
 
 	// Event storage configuration
 	Events EventsConfig `mapstructure:"events"`
+
+	// Audit log retention configuration
+	Audit AuditConfig `mapstructure:"audit"`
+
+	// Webhook delivery configuration
+	Webhook WebhookConfig `mapstructure:"webhook"`
 }
 
 // CircuitBreakerConfig holds circuit breaker configuration
@@ -57,11 +63,45 @@ type CircuitBreakerConfig struct {
 	SuccessThreshold uint32        `mapstructure:"success_threshold"` // Consecutive successes to close circuit
 }
 
+// Media storage backends
+const (
+	MediaBackendLocal = "local"
+	MediaBackendS3    = "s3"
+)
+
 // MediaConfig holds media storage configuration
 type MediaConfig struct {
-	BasePath    string `mapstructure:"base_path"`     // Local directory for storing media files
-	BaseURL     string `mapstructure:"base_url"`      // Public URL prefix for accessing media
-	MaxFileSize int64  `mapstructure:"max_file_size"` // Maximum file size in bytes (default: 16MB)
+	Backend     string        `mapstructure:"backend"`       // Storage backend: "local" or "s3"
+	BasePath    string        `mapstructure:"base_path"`     // Local directory for storing media files
+	BaseURL     string        `mapstructure:"base_url"`      // Public URL prefix for accessing media (the /api/media route)
+	MaxFileSize int64         `mapstructure:"max_file_size"` // Maximum file size in bytes (default: 16MB)
+	URLExpiry   time.Duration `mapstructure:"url_expiry"`    // Lifetime of signed and presigned media URLs
+	SigningKey  string        `mapstructure:"signing_key"`   // HMAC key for signed media URLs (random per process if empty)
+	TempDir     string        `mapstructure:"temp_dir"`      // Directory outgoing media is spooled to before upload (system temp directory if empty)
+	S3          S3MediaConfig `mapstructure:"s3"`            // Object store settings (s3 backend)
+
+	Cleanup MediaCleanupConfig `mapstructure:"cleanup"` // Retention and garbage collection of stored media
+}
+
+// MediaCleanupConfig holds media retention and garbage collection configuration
+type MediaCleanupConfig struct {
+	Enabled           bool          `mapstructure:"enabled"`             // Run the media cleanup job
+	RetentionDays     int           `mapstructure:"retention_days"`      // Days to keep media (0 = forever); sessions may override it
+	MaxTotalSize      int64         `mapstructure:"max_total_size"`      // Disk budget in bytes, enforced by evicting least recently used media (0 = unlimited)
+	OrphanSweep       bool          `mapstructure:"orphan_sweep"`        // Delete media no stored event refers to (requires events.enabled)
+	OrphanGracePeriod time.Duration `mapstructure:"orphan_grace_period"` // Minimum age before media without an event counts as orphaned
+	CleanupInterval   time.Duration `mapstructure:"cleanup_interval"`    // How often the job runs (default: 1 hour)
+}
+
+// S3MediaConfig holds the settings of the S3-compatible object store media is kept in
+type S3MediaConfig struct {
+	Endpoint        string `mapstructure:"endpoint"`          // Service URL (default: AWS endpoint of the region)
+	Region          string `mapstructure:"region"`            // Region requests are signed for
+	Bucket          string `mapstructure:"bucket"`            // Bucket media objects are stored in
+	AccessKeyID     string `mapstructure:"access_key_id"`     // Access key ID
+	SecretAccessKey string `mapstructure:"secret_access_key"` // Secret access key
+	Prefix          string `mapstructure:"prefix"`            // Key prefix media objects are stored under
+	PathStyle       bool   `mapstructure:"path_style"`        // Address the bucket in the URL path (MinIO)
 }
 
 // DatabaseConfig holds database configuration
@@ -82,6 +122,20 @@ type EventsConfig struct {
 	CleanupInterval time.Duration `mapstructure:"cleanup_interval"` // Cleanup check interval (default: 1 hour)
 }
 
+// AuditConfig holds audit log retention configuration
+type AuditConfig struct {
+	RetentionDays   int           `mapstructure:"retention_days"`   // Days to retain audit logs (0 = forever)
+	CleanupTime     string        `mapstructure:"cleanup_time"`     // Daily cleanup time in UTC (HH:MM format, e.g., "03:00")
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"` // Cleanup check interval (default: 1 hour)
+}
+
+// WebhookConfig holds webhook delivery configuration
+type WebhookConfig struct {
+	Enabled       bool            `mapstructure:"enabled"`        // Enable webhook delivery to the endpoints configured per session
+	RetrySchedule []time.Duration `mapstructure:"retry_schedule"` // Delay before each retry; deliveries are dead-lettered once it is used up
+	PollInterval  time.Duration   `mapstructure:"poll_interval"`  // How often the delivery queue is polled for due retries
+}
+
 // MetricsConfig holds Prometheus metrics configuration
 type MetricsConfig struct {
 	Enabled   bool   `mapstructure:"enabled"`
@@ -102,8 +156,11 @@ const (
 // Note: API keys are now managed in the database. This config only controls
 // whether API key authentication is enabled and which header to use.
 type APIKeyConfig struct {
-	Enabled bool   `mapstructure:"enabled"` // Enable API key authentication
-	Header  string `mapstructure:"header"`  // Header name for API key (default: X-API-Key)
+	Enabled          bool          `mapstructure:"enabled"`           // Enable API key authentication
+	Header           string        `mapstructure:"header"`            // Header name for API key (default: X-API-Key)
+	LockoutThreshold int           `mapstructure:"lockout_threshold"` // Failed attempts from one IP before it is locked out (0 = disabled)
+	LockoutWindow    time.Duration `mapstructure:"lockout_window"`    // Period failed attempts are counted over
+	LockoutDuration  time.Duration `mapstructure:"lockout_duration"`  // How long a locked out IP is rejected
 }
 
 // CORSConfig holds CORS configuration
@@ -289,16 +346,43 @@ func (c *Config) Validate() error {
 	}
 
 	// Validate Media config
-	if c.Media.BasePath == "" {
+	switch c.Media.Backend {
+	case "", MediaBackendLocal:
+		if c.Media.BasePath == "" {
+			errs = append(errs, ValidationError{
+				Field:   "media.base_path",
+				Message: "is required",
+			})
+		}
+		if c.Media.BaseURL == "" {
+			errs = append(errs, ValidationError{
+				Field:   "media.base_url",
+				Message: "is required",
+			})
+		}
+	case MediaBackendS3:
+		if c.Media.S3.Bucket == "" {
+			errs = append(errs, ValidationError{
+				Field:   "media.s3.bucket",
+				Message: "is required when the s3 backend is used",
+			})
+		}
+		if c.Media.S3.AccessKeyID == "" || c.Media.S3.SecretAccessKey == "" {
+			errs = append(errs, ValidationError{
+				Field:   "media.s3.access_key_id",
+				Message: "access key ID and secret access key are required when the s3 backend is used",
+			})
+		}
+	default:
 		errs = append(errs, ValidationError{
-			Field:   "media.base_path",
-			Message: "is required",
+			Field:   "media.backend",
+			Message: "must be 'local' or 's3'",
 		})
 	}
-	if c.Media.BaseURL == "" {
+	if c.Media.URLExpiry < 0 || c.Media.URLExpiry > 7*24*time.Hour {
 		errs = append(errs, ValidationError{
-			Field:   "media.base_url",
-			Message: "is required",
+			Field:   "media.url_expiry",
+			Message: "must not be negative or exceed 168h",
 		})
 	}
 	if c.Media.MaxFileSize <= 0 {
@@ -307,6 +391,32 @@ func (c *Config) Validate() error {
 			Message: "must be positive",
 		})
 	}
+	if c.Media.Cleanup.Enabled {
+		if c.Media.Cleanup.RetentionDays < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "media.cleanup.retention_days",
+				Message: "must be non-negative (0 = forever)",
+			})
+		}
+		if c.Media.Cleanup.MaxTotalSize < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "media.cleanup.max_total_size",
+				Message: "must be non-negative (0 = unlimited)",
+			})
+		}
+		if c.Media.Cleanup.OrphanGracePeriod < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "media.cleanup.orphan_grace_period",
+				Message: "must be non-negative",
+			})
+		}
+		if c.Media.Cleanup.CleanupInterval <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "media.cleanup.cleanup_interval",
+				Message: "must be positive",
+			})
+		}
+	}
 
 	// Validate API Key config
 	if c.APIKey.Enabled {
@@ -315,6 +425,26 @@ func (c *Config) Validate() error {
 		if c.APIKey.Header == "" {
 			c.APIKey.Header = "X-API-Key" // Set default header
 		}
+		if c.APIKey.LockoutThreshold < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "apikey.lockout_threshold",
+				Message: "must be non-negative (0 = disabled)",
+			})
+		}
+		if c.APIKey.LockoutThreshold > 0 {
+			if c.APIKey.LockoutWindow <= 0 {
+				errs = append(errs, ValidationError{
+					Field:   "apikey.lockout_window",
+					Message: "must be positive when lockout is enabled",
+				})
+			}
+			if c.APIKey.LockoutDuration <= 0 {
+				errs = append(errs, ValidationError{
+					Field:   "apikey.lockout_duration",
+					Message: "must be positive when lockout is enabled",
+				})
+			}
+		}
 	}
 
 	// Validate Database config
@@ -379,6 +509,51 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate Audit config
+	if c.Audit.RetentionDays < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "audit.retention_days",
+			Message: "must be non-negative (0 = forever)",
+		})
+	}
+	if c.Audit.RetentionDays > 0 {
+		if c.Audit.CleanupTime != "" {
+			// Validate HH:MM format
+			parts := strings.Split(c.Audit.CleanupTime, ":")
+			if len(parts) != 2 {
+				errs = append(errs, ValidationError{
+					Field:   "audit.cleanup_time",
+					Message: "must be in HH:MM format (e.g., 03:00)",
+				})
+			}
+		}
+		if c.Audit.CleanupInterval <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "audit.cleanup_interval",
+				Message: "must be positive",
+			})
+		}
+	}
+
+	// Validate Webhook config
+	if c.Webhook.Enabled {
+		for _, delay := range c.Webhook.RetrySchedule {
+			if delay <= 0 {
+				errs = append(errs, ValidationError{
+					Field:   "webhook.retry_schedule",
+					Message: "delays must be positive",
+				})
+				break
+			}
+		}
+		if c.Webhook.PollInterval <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "webhook.poll_interval",
+				Message: "must be positive",
+			})
+		}
+	}
+
 	if len(errs) > 0 {
 		return errs
 	}
@@ -500,6 +675,9 @@ func setDefaults(v *viper.Viper) {
 	// APIKey defaults
 	v.SetDefault("apikey.enabled", true)
 	v.SetDefault("apikey.header", "X-API-Key")
+	v.SetDefault("apikey.lockout_threshold", 10)
+	v.SetDefault("apikey.lockout_window", 5*time.Minute)
+	v.SetDefault("apikey.lockout_duration", 15*time.Minute)
 
 	// Metrics defaults
 	v.SetDefault("metrics.enabled", true)
@@ -515,9 +693,19 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("circuitbreaker.success_threshold", 2)
 
 	// Media defaults
+	v.SetDefault("media.backend", MediaBackendLocal)
 	v.SetDefault("media.base_path", "/data/media")
-	v.SetDefault("media.base_url", "http://localhost:8080/media")
+	v.SetDefault("media.base_url", "http://localhost:8080/api/media")
 	v.SetDefault("media.max_file_size", 16*1024*1024) // 16MB
+	v.SetDefault("media.url_expiry", time.Hour)
+	v.SetDefault("media.s3.region", "us-east-1")
+	v.SetDefault("media.s3.path_style", false)
+	v.SetDefault("media.cleanup.enabled", false)
+	v.SetDefault("media.cleanup.retention_days", 30)
+	v.SetDefault("media.cleanup.max_total_size", 0)
+	v.SetDefault("media.cleanup.orphan_sweep", false)
+	v.SetDefault("media.cleanup.orphan_grace_period", 24*time.Hour)
+	v.SetDefault("media.cleanup.cleanup_interval", time.Hour)
 
 	// Database defaults
 	v.SetDefault("database.driver", "sqlite")
@@ -532,6 +720,19 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("events.retention_days", 30)
 	v.SetDefault("events.cleanup_time", "02:00")
 	v.SetDefault("events.cleanup_interval", time.Hour)
+
+	// Audit defaults
+	v.SetDefault("audit.retention_days", 90)
+	v.SetDefault("audit.cleanup_time", "03:00")
+	v.SetDefault("audit.cleanup_interval", time.Hour)
+
+	// Webhook defaults
+	v.SetDefault("webhook.enabled", true)
+	v.SetDefault("webhook.retry_schedule", []time.Duration{
+		30 * time.Second, 2 * time.Minute, 10 * time.Minute, 30 * time.Minute,
+		time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour,
+	}) // ~22h before a delivery is dead-lettered
+	v.SetDefault("webhook.poll_interval", 5*time.Second)
 }
 
 func bindEnvVars(v *viper.Viper) {
@@ -579,6 +780,9 @@ func bindEnvVars(v *viper.Viper) {
 	// APIKey
 	_ = v.BindEnv("apikey.enabled", "WHATSAPP_API_KEY_ENABLED")
 	_ = v.BindEnv("apikey.header", "WHATSAPP_API_KEY_HEADER")
+	_ = v.BindEnv("apikey.lockout_threshold", "WHATSAPP_API_KEY_LOCKOUT_THRESHOLD")
+	_ = v.BindEnv("apikey.lockout_window", "WHATSAPP_API_KEY_LOCKOUT_WINDOW")
+	_ = v.BindEnv("apikey.lockout_duration", "WHATSAPP_API_KEY_LOCKOUT_DURATION")
 
 	// Metrics
 	_ = v.BindEnv("metrics.enabled", "WHATSAPP_METRICS_ENABLED")
@@ -597,6 +801,23 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("media.base_path", "WHATSAPP_MEDIA_BASE_PATH")
 	_ = v.BindEnv("media.base_url", "WHATSAPP_MEDIA_BASE_URL")
 	_ = v.BindEnv("media.max_file_size", "WHATSAPP_MEDIA_MAX_FILE_SIZE")
+	_ = v.BindEnv("media.backend", "WHATSAPP_MEDIA_BACKEND")
+	_ = v.BindEnv("media.url_expiry", "WHATSAPP_MEDIA_URL_EXPIRY")
+	_ = v.BindEnv("media.signing_key", "WHATSAPP_MEDIA_SIGNING_KEY")
+	_ = v.BindEnv("media.temp_dir", "WHATSAPP_MEDIA_TEMP_DIR")
+	_ = v.BindEnv("media.s3.endpoint", "WHATSAPP_MEDIA_S3_ENDPOINT")
+	_ = v.BindEnv("media.s3.region", "WHATSAPP_MEDIA_S3_REGION")
+	_ = v.BindEnv("media.s3.bucket", "WHATSAPP_MEDIA_S3_BUCKET")
+	_ = v.BindEnv("media.s3.access_key_id", "WHATSAPP_MEDIA_S3_ACCESS_KEY_ID")
+	_ = v.BindEnv("media.s3.secret_access_key", "WHATSAPP_MEDIA_S3_SECRET_ACCESS_KEY")
+	_ = v.BindEnv("media.s3.prefix", "WHATSAPP_MEDIA_S3_PREFIX")
+	_ = v.BindEnv("media.s3.path_style", "WHATSAPP_MEDIA_S3_PATH_STYLE")
+	_ = v.BindEnv("media.cleanup.enabled", "WHATSAPP_MEDIA_CLEANUP_ENABLED")
+	_ = v.BindEnv("media.cleanup.retention_days", "WHATSAPP_MEDIA_CLEANUP_RETENTION_DAYS")
+	_ = v.BindEnv("media.cleanup.max_total_size", "WHATSAPP_MEDIA_CLEANUP_MAX_TOTAL_SIZE")
+	_ = v.BindEnv("media.cleanup.orphan_sweep", "WHATSAPP_MEDIA_CLEANUP_ORPHAN_SWEEP")
+	_ = v.BindEnv("media.cleanup.orphan_grace_period", "WHATSAPP_MEDIA_CLEANUP_ORPHAN_GRACE_PERIOD")
+	_ = v.BindEnv("media.cleanup.cleanup_interval", "WHATSAPP_MEDIA_CLEANUP_INTERVAL")
 
 	// Database
 	_ = v.BindEnv("database.driver", "WHATSAPP_DATABASE_DRIVER")
@@ -611,6 +832,16 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("events.retention_days", "WHATSAPP_EVENTS_RETENTION_DAYS")
 	_ = v.BindEnv("events.cleanup_time", "WHATSAPP_EVENTS_CLEANUP_TIME")
 	_ = v.BindEnv("events.cleanup_interval", "WHATSAPP_EVENTS_CLEANUP_INTERVAL")
+
+	// Audit
+	_ = v.BindEnv("audit.retention_days", "WHATSAPP_AUDIT_RETENTION_DAYS")
+	_ = v.BindEnv("audit.cleanup_time", "WHATSAPP_AUDIT_CLEANUP_TIME")
+	_ = v.BindEnv("audit.cleanup_interval", "WHATSAPP_AUDIT_CLEANUP_INTERVAL")
+
+	// Webhook
+	_ = v.BindEnv("webhook.enabled", "WHATSAPP_WEBHOOK_ENABLED")
+	_ = v.BindEnv("webhook.retry_schedule", "WHATSAPP_WEBHOOK_RETRY_SCHEDULE")
+	_ = v.BindEnv("webhook.poll_interval", "WHATSAPP_WEBHOOK_POLL_INTERVAL")
 }
 
 // MustLoad loads configuration and panics on error (for use in main)
@@ -229,6 +229,14 @@ func (w *ConfigWatcher) logConfigChanges(oldCfg, newCfg *Config) {
 			"new_retention_days": newCfg.Events.RetentionDays,
 		}).Info("Event retention policy changed")
 	}
+
+	// Audit retention changes
+	if oldCfg.Audit.RetentionDays != newCfg.Audit.RetentionDays {
+		w.logger.WithFields(map[string]interface{}{
+			"old_retention_days": oldCfg.Audit.RetentionDays,
+			"new_retention_days": newCfg.Audit.RetentionDays,
+		}).Info("Audit log retention policy changed")
+	}
 }
 
 // IsRunning returns whether the watcher is currently running
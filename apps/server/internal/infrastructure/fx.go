@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,8 +15,10 @@ import (
 	"whatspire/internal/infrastructure/health"
 	"whatspire/internal/infrastructure/jobs"
 	"whatspire/internal/infrastructure/logger"
+	"whatspire/internal/infrastructure/metrics"
 	"whatspire/internal/infrastructure/persistence"
 	"whatspire/internal/infrastructure/storage"
+	"whatspire/internal/infrastructure/webhook"
 	"whatspire/internal/infrastructure/websocket"
 	"whatspire/internal/infrastructure/whatsapp"
 
@@ -51,6 +54,10 @@ var Module = fx.Module("infrastructure",
 			func(c *whatsapp.WhatsmeowClient) *whatsapp.WhatsmeowClient { return c },
 			fx.As(new(repository.GroupFetcher)),
 		),
+		fx.Annotate(
+			func(c *whatsapp.WhatsmeowClient) *whatsapp.WhatsmeowClient { return c },
+			fx.As(new(repository.GroupManager)),
+		),
 		fx.Annotate(
 			NewGorillaEventPublisher,
 			fx.ResultTags(`name:"websocket"`),
@@ -58,9 +65,15 @@ var Module = fx.Module("infrastructure",
 		NewAuditLogger,
 		NewAuditLogRepository,
 		fx.Annotate(
-			NewGorillaEventPublisher,
+			func(r *persistence.AuditLogRepository) *persistence.AuditLogRepository { return r },
+			fx.As(new(repository.AuditLogRepository)),
+		),
+		fx.Annotate(
+			NewEventPublisher,
+			fx.ParamTags(`name:"websocket"`),
 			fx.As(new(repository.EventPublisher)),
 		),
+		NewWebhookDeliveryQueue,
 		NewEventHub,
 		NewHealthCheckers,
 		NewMediaUploader,
@@ -88,14 +101,44 @@ var Module = fx.Module("infrastructure",
 			NewWebhookConfigRepository,
 			fx.As(new(repository.WebhookConfigRepository)),
 		),
-		NewLocalMediaStorage,
+		fx.Annotate(
+			NewWebhookEndpointRepository,
+			fx.As(new(repository.WebhookEndpointRepository)),
+		),
+		fx.Annotate(
+			NewWebhookDeliveryRepository,
+			fx.As(new(repository.WebhookDeliveryRepository)),
+		),
+		fx.Annotate(
+			NewMessageRepository,
+			fx.As(new(repository.MessageRepository)),
+		),
+		fx.Annotate(
+			NewOutboxRepository,
+			fx.As(new(repository.OutboxRepository)),
+		),
+		fx.Annotate(
+			NewGroupRepository,
+			fx.As(new(repository.GroupRepository)),
+		),
+		NewMediaURLSigner,
+		NewMediaStorage,
 		NewEventCleanupJob,
+		NewAuditLogCleanupJob,
+		NewMetrics,
+		NewMediaCleanupJob,
+		fx.Annotate(
+			func(j *jobs.MediaCleanupJob) *jobs.MediaCleanupJob { return j },
+			fx.As(new(repository.MediaCleaner)),
+		),
 	),
 	// Wire EventHub to WhatsApp client events
 	fx.Invoke(WireEventHubToWhatsAppClient),
 	fx.Invoke(WireMessageHandler),
 	fx.Invoke(RunMigrations),
 	fx.Invoke(StartEventCleanupJob),
+	fx.Invoke(StartAuditLogCleanupJob),
+	fx.Invoke(StartMediaCleanupJob),
 	fx.Invoke(StartAutoReconnect),
 )
 
@@ -173,8 +216,42 @@ func NewEventRepository(db *gorm.DB) repository.EventRepository {
 }
 
 // NewWebhookConfigRepository creates a new webhook config repository
+// Configurations are cached because webhook delivery resolves one for every event;
+// writes through the repository invalidate the cache
 func NewWebhookConfigRepository(db *gorm.DB) repository.WebhookConfigRepository {
-	return persistence.NewWebhookConfigRepository(db)
+	return persistence.NewCachedWebhookConfigRepository(
+		persistence.NewWebhookConfigRepository(db),
+		persistence.DefaultWebhookConfigCacheTTL,
+	)
+}
+
+// NewWebhookEndpointRepository creates a new webhook endpoint repository
+// Like webhook configurations, endpoints are cached because every event is matched against them
+func NewWebhookEndpointRepository(db *gorm.DB) repository.WebhookEndpointRepository {
+	return persistence.NewCachedWebhookEndpointRepository(
+		persistence.NewWebhookEndpointRepository(db),
+		persistence.DefaultWebhookConfigCacheTTL,
+	)
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository
+func NewWebhookDeliveryRepository(db *gorm.DB) repository.WebhookDeliveryRepository {
+	return persistence.NewWebhookDeliveryRepository(db)
+}
+
+// NewMessageRepository creates a new message repository
+func NewMessageRepository(db *gorm.DB) repository.MessageRepository {
+	return persistence.NewMessageRepository(db)
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *gorm.DB) repository.OutboxRepository {
+	return persistence.NewOutboxRepository(db)
+}
+
+// NewGroupRepository creates a new group repository
+func NewGroupRepository(db *gorm.DB) repository.GroupRepository {
+	return persistence.NewGroupRepository(db)
 }
 
 // NewAuditLogRepository creates a new audit log repository
@@ -269,12 +346,60 @@ func NewGorillaEventPublisher(lc fx.Lifecycle, cfg *config.Config, log *logger.L
 	return publisher
 }
 
+// NewEventPublisher creates the event publisher used by the application
+// Events go to the WebSocket publisher and, when webhooks are enabled, to the durable webhook delivery queue
+func NewEventPublisher(websocketPublisher repository.EventPublisher, webhookQueue *webhook.DeliveryQueue, log *logger.Logger) repository.EventPublisher {
+	if webhookQueue == nil {
+		return websocketPublisher
+	}
+	return webhook.NewCompositeEventPublisher(websocketPublisher, webhookQueue, log)
+}
+
+// NewWebhookDeliveryQueue creates the durable webhook delivery queue, or nil if webhooks are disabled
+// Events are delivered to the webhooks stored for their session
+func NewWebhookDeliveryQueue(
+	lc fx.Lifecycle,
+	configRepo repository.WebhookConfigRepository,
+	endpointRepo repository.WebhookEndpointRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	auditLogger repository.AuditLogger,
+	cfg *config.Config,
+	log *logger.Logger,
+) *webhook.DeliveryQueue {
+	if !cfg.Webhook.Enabled {
+		return nil
+	}
+
+	// The publisher only sends; endpoints and secrets come from each session's webhooks
+	publisher := webhook.NewWebhookPublisher(webhook.WebhookConfig{}, log, auditLogger)
+
+	queue := webhook.NewDeliveryQueue(deliveryRepo, webhook.NewDispatcher(configRepo, endpointRepo), publisher, webhook.DeliveryQueueConfig{
+		RetrySchedule: cfg.Webhook.RetrySchedule,
+		PollInterval:  cfg.Webhook.PollInterval,
+	}, log)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			queue.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			log.Info("Stopping webhook delivery queue")
+			queue.Stop()
+			return nil
+		},
+	})
+
+	return queue
+}
+
 // NewAuditLogger creates a new audit logger
-func NewAuditLogger(log *logger.Logger) repository.AuditLogger {
+// Events are stored in the audit_logs table and also written to the application log
+func NewAuditLogger(log *logger.Logger, auditLogRepo *persistence.AuditLogRepository) repository.AuditLogger {
 	// Create audit logger from existing logger
 	auditLogger := logger.NewAuditLogger(log)
 
-	return auditLogger
+	return persistence.NewPersistentAuditLogger(auditLogRepo, auditLogger, log)
 }
 
 // HealthCheckers holds all health checker instances
@@ -301,6 +426,7 @@ func NewMediaUploader(waClient *whatsapp.WhatsmeowClient, cfg *config.Config) re
 
 	// Create downloader config
 	downloaderConfig := whatsapp.DefaultDownloaderConfig()
+	downloaderConfig.TempDir = cfg.Media.TempDir
 
 	// Create downloader
 	downloader := whatsapp.NewHTTPMediaDownloader(downloaderConfig, constraints)
@@ -325,11 +451,35 @@ func WireEventHubToWhatsAppClient(
 	cfg *config.Config,
 	log *logger.Logger,
 ) {
-	// Register an event handler that broadcasts events to the EventHub (for frontend WebSocket clients)
+	sequencer := persistence.NewEventSequencer(eventRepo)
+
+	// Register an event handler that numbers, persists (if enabled) and broadcasts events to the EventHub
+	// Events are stored before they are broadcast so WebSocket clients resuming the stream can replay them;
+	// storing numbers them, and an event that could not be stored is broadcast without a number
 	waClient.RegisterEventHandler(func(event *entity.Event) {
-		// Broadcast the event to all connected WebSocket clients
+		ctx := context.Background()
+		if cfg.Events.Enabled {
+			if err := eventRepo.Create(ctx, event); err != nil {
+				log.WithError(err).
+					WithFields(map[string]interface{}{
+						"event_id":   event.ID,
+						"event_type": event.Type,
+						"session_id": event.SessionID,
+					}).
+					Warn("Failed to persist event to database")
+			}
+		} else if err := sequencer.Assign(ctx, event); err != nil {
+			log.WithError(err).
+				WithFields(map[string]interface{}{"session_id": event.SessionID}).
+				Warn("Failed to assign event sequence number")
+		}
+
+		// Broadcast the event to all subscribed WebSocket clients
 		hub.Broadcast(event)
 	})
+	if cfg.Events.Enabled {
+		log.Info("Event persistence enabled for WhatsApp events")
+	}
 
 	// Register an event handler that publishes events via the CompositeEventPublisher
 	// This will publish to both WebSocket (API server) and Webhook (if configured)
@@ -342,26 +492,6 @@ func WireEventHubToWhatsAppClient(
 		}()
 	})
 
-	// Register an event handler that persists events to the database (if enabled)
-	if cfg.Events.Enabled {
-		waClient.RegisterEventHandler(func(event *entity.Event) {
-			// Persist the event (non-blocking)
-			go func() {
-				ctx := context.Background()
-				if err := eventRepo.Create(ctx, event); err != nil {
-					log.WithError(err).
-						WithFields(map[string]interface{}{
-							"event_id":   event.ID,
-							"event_type": event.Type,
-							"session_id": event.SessionID,
-						}).
-						Warn("Failed to persist event to database")
-				}
-			}()
-		})
-		log.Info("Event persistence enabled for WhatsApp events")
-	}
-
 	// Register an event handler that updates session status based on connection events
 	waClient.RegisterEventHandler(func(event *entity.Event) {
 		go func() {
@@ -397,7 +527,7 @@ func WireEventHubToWhatsAppClient(
 }
 
 // NewEventHub creates a new WebSocket event hub for broadcasting events to connected clients
-func NewEventHub(lc fx.Lifecycle, cfg *config.Config, log *logger.Logger) *websocket.EventHub {
+func NewEventHub(lc fx.Lifecycle, cfg *config.Config, apiKeyRepo repository.APIKeyRepository, eventRepo repository.EventRepository, log *logger.Logger) *websocket.EventHub {
 	hubConfig := websocket.EventHubConfig{
 		APIKey:       cfg.WebSocket.APIKey,
 		PingInterval: cfg.WebSocket.PingInterval,
@@ -405,6 +535,16 @@ func NewEventHub(lc fx.Lifecycle, cfg *config.Config, log *logger.Logger) *webso
 		AuthTimeout:  10 * time.Second,
 	}
 
+	// Authenticate event clients with the same stored keys as the HTTP API when it is protected
+	if cfg.APIKey.Enabled {
+		hubConfig.APIKeyRepository = apiKeyRepo
+	}
+
+	// Clients can only resume the event stream when events are persisted
+	if cfg.Events.Enabled {
+		hubConfig.EventRepository = eventRepo
+	}
+
 	hub := websocket.NewEventHub(hubConfig)
 
 	lc.Append(fx.Hook{
@@ -434,18 +574,47 @@ func NewEventHub(lc fx.Lifecycle, cfg *config.Config, log *logger.Logger) *webso
 	return hub
 }
 
-// NewLocalMediaStorage creates a new local media storage
-func NewLocalMediaStorage(cfg *config.Config) (repository.MediaStorage, error) {
+// NewMediaURLSigner creates the signer for media links served by the /api/media route
+// Without a configured key, links are signed with a random key and stop working on restart
+func NewMediaURLSigner(cfg *config.Config, log *logger.Logger) (repository.MediaURLSigner, error) {
+	key := []byte(cfg.Media.SigningKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate media signing key: %w", err)
+		}
+		log.Warn("No media signing key configured; signed media links will not survive a restart or work across replicas")
+	}
+
+	return storage.NewHMACMediaURLSigner(key, cfg.Media.URLExpiry), nil
+}
+
+// NewMediaStorage creates the media storage for the configured backend
+func NewMediaStorage(cfg *config.Config, signer repository.MediaURLSigner) (repository.MediaStorage, error) {
 	storageConfig := repository.MediaStorageConfig{
 		BasePath:    cfg.Media.BasePath,
 		BaseURL:     cfg.Media.BaseURL,
 		MaxFileSize: cfg.Media.MaxFileSize,
 	}
 
+	if cfg.Media.Backend == config.MediaBackendS3 {
+		return storage.NewS3MediaStorage(storageConfig, storage.S3Config{
+			Endpoint:        cfg.Media.S3.Endpoint,
+			Region:          cfg.Media.S3.Region,
+			Bucket:          cfg.Media.S3.Bucket,
+			AccessKeyID:     cfg.Media.S3.AccessKeyID,
+			SecretAccessKey: cfg.Media.S3.SecretAccessKey,
+			Prefix:          cfg.Media.S3.Prefix,
+			PathStyle:       cfg.Media.S3.PathStyle,
+			URLExpiry:       cfg.Media.URLExpiry,
+		})
+	}
+
 	storage, err := storage.NewLocalMediaStorage(storageConfig)
 	if err != nil {
 		return nil, err
 	}
+	storage.SetURLSigner(signer)
 
 	return storage, nil
 }
@@ -457,6 +626,8 @@ func WireMessageHandler(
 	mediaStorage repository.MediaStorage,
 	reactionRepo repository.ReactionRepository,
 	presenceRepo repository.PresenceRepository,
+	messageRepo repository.MessageRepository,
+	groupRepo repository.GroupRepository,
 	publisher repository.EventPublisher,
 	log *logger.Logger,
 ) {
@@ -484,12 +655,22 @@ func WireMessageHandler(
 	// Wire reaction handler to message handler
 	messageHandler.SetReactionHandler(reactionHandler)
 
+	// Wire message history persistence
+	messageHandler.SetMessageRepository(messageRepo)
+	messageHandler.SetSessionSettingsProvider(waClient)
+
 	// Wire message handler to the client
 	waClient.SetMessageHandler(messageHandler)
 
 	// Wire presence repository to the client
 	waClient.SetPresenceRepository(presenceRepo)
 
+	// Wire message repository to the client for receipt status tracking
+	waClient.SetMessageRepository(messageRepo)
+
+	// Wire group repository to the client so group events keep the local copy fresh
+	waClient.SetGroupRepository(groupRepo)
+
 	log.Info("Message handler and reaction handler wired to WhatsApp client successfully")
 }
 
@@ -554,6 +735,77 @@ func StartEventCleanupJob(lc fx.Lifecycle, job *jobs.EventCleanupJob, cfg *confi
 	})
 }
 
+// NewAuditLogCleanupJob creates a new audit log cleanup job
+func NewAuditLogCleanupJob(auditLogRepo repository.AuditLogRepository, cfg *config.Config, log *logger.Logger) *jobs.AuditLogCleanupJob {
+	return jobs.NewAuditLogCleanupJob(auditLogRepo, &cfg.Audit, log)
+}
+
+// StartAuditLogCleanupJob starts the audit log cleanup job if a retention period is configured
+func StartAuditLogCleanupJob(lc fx.Lifecycle, job *jobs.AuditLogCleanupJob, cfg *config.Config, log *logger.Logger) {
+	// Retention of 0 keeps audit logs forever
+	if cfg.Audit.RetentionDays == 0 {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// The start context is cancelled once the app has started, so the job gets its own
+			return job.Start(context.Background())
+		},
+		OnStop: func(ctx context.Context) error {
+			log.Info("Stopping audit log cleanup job")
+			return job.Stop()
+		},
+	})
+}
+
+// NewMetrics creates the Prometheus metrics, or returns nil if metrics are disabled
+func NewMetrics(cfg *config.Config) *metrics.Metrics {
+	if !cfg.Metrics.Enabled {
+		return nil
+	}
+
+	return metrics.NewMetrics(metrics.Config{
+		Enabled:   cfg.Metrics.Enabled,
+		Path:      cfg.Metrics.Path,
+		Namespace: cfg.Metrics.Namespace,
+	})
+}
+
+// NewMediaCleanupJob creates a new media cleanup job
+// Orphaned media can only be recognised when events are stored
+func NewMediaCleanupJob(
+	mediaStorage repository.MediaStorage,
+	sessionRepo repository.SessionRepository,
+	eventRepo repository.EventRepository,
+	cfg *config.Config,
+	m *metrics.Metrics,
+	log *logger.Logger,
+) *jobs.MediaCleanupJob {
+	if !cfg.Events.Enabled {
+		eventRepo = nil
+	}
+	return jobs.NewMediaCleanupJob(mediaStorage, sessionRepo, eventRepo, &cfg.Media.Cleanup, m, log)
+}
+
+// StartMediaCleanupJob starts the media cleanup job if it is enabled
+func StartMediaCleanupJob(lc fx.Lifecycle, job *jobs.MediaCleanupJob, cfg *config.Config, log *logger.Logger) {
+	if !cfg.Media.Cleanup.Enabled {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// The start context is cancelled once the app has started, so the job gets its own
+			return job.Start(context.Background())
+		},
+		OnStop: func(ctx context.Context) error {
+			log.Info("Stopping media cleanup job")
+			return job.Stop()
+		},
+	})
+}
+
 // StartAutoReconnect starts the auto-reconnect process for stored WhatsApp sessions
 func StartAutoReconnect(
 	lc fx.Lifecycle,
@@ -0,0 +1,169 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/config"
+	"whatspire/internal/infrastructure/logger"
+)
+
+// AuditLogCleanupJob handles periodic cleanup of old audit logs based on retention policy
+type AuditLogCleanupJob struct {
+	auditLogRepo  repository.AuditLogRepository
+	cfg           *config.AuditConfig
+	ticker        *time.Ticker
+	stopCh        chan struct{}
+	running       bool
+	lastRunTime   time.Time
+	lastRunResult *CleanupResult
+	logger        *logger.Logger
+}
+
+// NewAuditLogCleanupJob creates a new audit log cleanup job
+func NewAuditLogCleanupJob(auditLogRepo repository.AuditLogRepository, cfg *config.AuditConfig, log *logger.Logger) *AuditLogCleanupJob {
+	return &AuditLogCleanupJob{
+		auditLogRepo: auditLogRepo,
+		cfg:          cfg,
+		stopCh:       make(chan struct{}),
+		logger:       log.Sub("audit_log_cleanup_job"),
+	}
+}
+
+// Start starts the cleanup job
+func (j *AuditLogCleanupJob) Start(ctx context.Context) error {
+	if j.running {
+		return nil
+	}
+
+	j.running = true
+	j.ticker = time.NewTicker(j.cfg.CleanupInterval)
+
+	j.logger.WithInt("retention_days", j.cfg.RetentionDays).
+		WithFields(map[string]interface{}{
+			"interval":     j.cfg.CleanupInterval.String(),
+			"cleanup_time": j.cfg.CleanupTime,
+		}).
+		Info("Audit log cleanup job started successfully")
+
+	// Run initial cleanup if we're past the scheduled time today
+	go j.runIfScheduled(ctx)
+
+	// Start the ticker loop
+	go j.run(ctx)
+
+	return nil
+}
+
+// Stop stops the cleanup job
+func (j *AuditLogCleanupJob) Stop() error {
+	if !j.running {
+		return nil
+	}
+
+	j.running = false
+	close(j.stopCh)
+
+	if j.ticker != nil {
+		j.ticker.Stop()
+	}
+
+	j.logger.Info("Audit log cleanup job stopped gracefully")
+	return nil
+}
+
+// run is the main loop that checks if cleanup should run
+func (j *AuditLogCleanupJob) run(ctx context.Context) {
+	for {
+		select {
+		case <-j.ticker.C:
+			j.runIfScheduled(ctx)
+		case <-j.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runIfScheduled runs cleanup if we're at or past the scheduled time
+func (j *AuditLogCleanupJob) runIfScheduled(ctx context.Context) {
+	// Skip if retention is 0 (keep forever)
+	if j.cfg.RetentionDays == 0 {
+		return
+	}
+
+	now := time.Now()
+
+	// Check if we've already run today
+	if j.lastRunTime.Year() == now.Year() &&
+		j.lastRunTime.YearDay() == now.YearDay() {
+		return
+	}
+
+	scheduledTime, err := parseCleanupTime(j.cfg.CleanupTime)
+	if err != nil {
+		j.logger.WithError(err).
+			WithFields(map[string]interface{}{"cleanup_time": j.cfg.CleanupTime}).
+			Warn("Invalid cleanup time format, skipping scheduled cleanup")
+		return
+	}
+
+	currentMinutes := now.Hour()*60 + now.Minute()
+	scheduledMinutes := scheduledTime.Hour()*60 + scheduledTime.Minute()
+
+	if currentMinutes >= scheduledMinutes {
+		j.runCleanup(ctx)
+	}
+}
+
+// runCleanup performs the actual cleanup operation
+func (j *AuditLogCleanupJob) runCleanup(ctx context.Context) {
+	startTime := time.Now()
+
+	j.logger.WithInt("retention_days", j.cfg.RetentionDays).
+		Info("Starting scheduled audit log cleanup operation")
+
+	cutoff := time.Now().AddDate(0, 0, -j.cfg.RetentionDays)
+	deleted, err := j.auditLogRepo.DeleteOlderThan(ctx, cutoff)
+
+	duration := time.Since(startTime)
+
+	// Store result
+	j.lastRunTime = startTime
+	j.lastRunResult = &CleanupResult{
+		DeletedCount: deleted,
+		Error:        err,
+		Timestamp:    startTime,
+		Duration:     duration,
+	}
+
+	fields := map[string]interface{}{
+		"duration":       duration.String(),
+		"retention_days": j.cfg.RetentionDays,
+		"cutoff_date":    cutoff.Format(time.RFC3339),
+	}
+
+	if err != nil {
+		j.logger.WithError(err).WithFields(fields).Error("Audit log cleanup operation failed")
+		return
+	}
+
+	if deleted > 0 {
+		fields["deleted_count"] = deleted
+		j.logger.WithFields(fields).Info("Audit log cleanup completed successfully, audit logs deleted")
+	} else {
+		j.logger.WithFields(fields).Debug("Audit log cleanup completed, no audit logs to delete")
+	}
+}
+
+// GetLastRunResult returns the result of the last cleanup run
+func (j *AuditLogCleanupJob) GetLastRunResult() *CleanupResult {
+	return j.lastRunResult
+}
+
+// IsRunning returns whether the job is currently running
+func (j *AuditLogCleanupJob) IsRunning() bool {
+	return j.running
+}
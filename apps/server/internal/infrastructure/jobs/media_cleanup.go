@@ -0,0 +1,368 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/config"
+	"whatspire/internal/infrastructure/logger"
+	"whatspire/internal/infrastructure/metrics"
+)
+
+// mediaEventPageSize is how many events are read at a time when looking for references to media
+const mediaEventPageSize = 500
+
+// MediaCleanupJob handles periodic removal of stored media
+// Media is deleted once it is older than its session's retention period or no stored event refers
+// to it any more, and the least recently used media is evicted while storage exceeds the disk budget
+type MediaCleanupJob struct {
+	storage       repository.MediaStorage
+	sessionRepo   repository.SessionRepository
+	eventRepo     repository.EventRepository
+	cfg           *config.MediaCleanupConfig
+	metrics       *metrics.Metrics
+	ticker        *time.Ticker
+	stopCh        chan struct{}
+	running       bool
+	runMu         sync.Mutex // Serializes cleanup runs
+	mu            sync.Mutex // Guards lastRunResult
+	lastRunResult *entity.MediaCleanupReport
+	logger        *logger.Logger
+}
+
+// NewMediaCleanupJob creates a new media cleanup job
+// eventRepo is nil when events are not stored, which disables the orphan sweep; m may be nil
+func NewMediaCleanupJob(
+	storage repository.MediaStorage,
+	sessionRepo repository.SessionRepository,
+	eventRepo repository.EventRepository,
+	cfg *config.MediaCleanupConfig,
+	m *metrics.Metrics,
+	log *logger.Logger,
+) *MediaCleanupJob {
+	return &MediaCleanupJob{
+		storage:     storage,
+		sessionRepo: sessionRepo,
+		eventRepo:   eventRepo,
+		cfg:         cfg,
+		metrics:     m,
+		stopCh:      make(chan struct{}),
+		logger:      log.Sub("media_cleanup_job"),
+	}
+}
+
+// Start starts the cleanup job
+func (j *MediaCleanupJob) Start(ctx context.Context) error {
+	if j.running {
+		return nil
+	}
+
+	j.running = true
+	j.ticker = time.NewTicker(j.cfg.CleanupInterval)
+
+	j.logger.WithInt("retention_days", j.cfg.RetentionDays).
+		WithFields(map[string]interface{}{
+			"interval":       j.cfg.CleanupInterval.String(),
+			"max_total_size": j.cfg.MaxTotalSize,
+			"orphan_sweep":   j.cfg.OrphanSweep && j.eventRepo != nil,
+		}).
+		Info("Media cleanup job started successfully")
+
+	if j.cfg.OrphanSweep && j.eventRepo == nil {
+		j.logger.Warn("Media orphan sweep requires event persistence, skipping it")
+	}
+
+	// Run an initial cleanup, then one per interval
+	go j.runCleanup(ctx)
+	go j.run(ctx)
+
+	return nil
+}
+
+// Stop stops the cleanup job
+func (j *MediaCleanupJob) Stop() error {
+	if !j.running {
+		return nil
+	}
+
+	j.running = false
+	close(j.stopCh)
+
+	if j.ticker != nil {
+		j.ticker.Stop()
+	}
+
+	j.logger.Info("Media cleanup job stopped gracefully")
+	return nil
+}
+
+// run is the main loop that triggers cleanup on every tick
+func (j *MediaCleanupJob) run(ctx context.Context) {
+	for {
+		select {
+		case <-j.ticker.C:
+			j.runCleanup(ctx)
+		case <-j.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runCleanup performs a scheduled cleanup, logging its outcome
+func (j *MediaCleanupJob) runCleanup(ctx context.Context) {
+	report, err := j.RunCleanup(ctx)
+	if err != nil {
+		j.logger.WithError(err).Error("Media cleanup operation failed")
+		return
+	}
+
+	fields := map[string]interface{}{
+		"scanned_files":   report.ScannedFiles,
+		"deleted_files":   report.DeletedFiles,
+		"deleted_bytes":   report.DeletedBytes,
+		"remaining_bytes": report.RemainingBytes,
+		"duration":        report.Duration.String(),
+	}
+	if report.FailedFiles > 0 {
+		fields["failed_files"] = report.FailedFiles
+		j.logger.WithFields(fields).Warn("Media cleanup completed, some media could not be deleted")
+	} else if report.DeletedFiles > 0 {
+		j.logger.WithFields(fields).Info("Media cleanup completed successfully, media deleted")
+	} else {
+		j.logger.WithFields(fields).Debug("Media cleanup completed, no media to delete")
+	}
+}
+
+// PlanCleanup reports what a cleanup run would delete now, without deleting anything
+func (j *MediaCleanupJob) PlanCleanup(ctx context.Context) (*entity.MediaCleanupReport, error) {
+	report, err := j.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report.DryRun = true
+	report.Duration = time.Since(report.StartedAt)
+	return report, nil
+}
+
+// RunCleanup deletes the media selected by the retention policy, orphan sweep and disk budget
+// Media that cannot be deleted is counted in the report and retried on the next run
+func (j *MediaCleanupJob) RunCleanup(ctx context.Context) (*entity.MediaCleanupReport, error) {
+	j.runMu.Lock()
+	defer j.runMu.Unlock()
+
+	report, err := j.plan(ctx)
+	if err != nil {
+		j.recordRun("failed")
+		return nil, err
+	}
+
+	for _, candidate := range report.Candidates {
+		if err := j.storage.DeleteMedia(ctx, candidate.Path); err != nil && !errors.IsNotFound(err) {
+			report.FailedFiles++
+			j.logger.WithError(err).
+				WithFields(map[string]interface{}{"path": candidate.Path, "reason": string(candidate.Reason)}).
+				Warn("Failed to delete media")
+			continue
+		}
+
+		report.DeletedFiles++
+		report.DeletedBytes += candidate.Size
+		if j.metrics != nil {
+			j.metrics.RecordMediaDeleted(string(candidate.Reason), candidate.Size)
+		}
+	}
+
+	report.RemainingBytes = report.ScannedBytes - report.DeletedBytes
+	report.Duration = time.Since(report.StartedAt)
+
+	if j.metrics != nil {
+		j.metrics.SetMediaStored(report.ScannedFiles-report.DeletedFiles, report.RemainingBytes)
+	}
+	if report.FailedFiles > 0 {
+		j.recordRun("partial")
+	} else {
+		j.recordRun("success")
+	}
+
+	j.mu.Lock()
+	j.lastRunResult = report
+	j.mu.Unlock()
+
+	return report, nil
+}
+
+// plan lists stored media and selects what to delete, in order: media past retention,
+// orphaned media, then least recently used media until the rest fits the disk budget
+func (j *MediaCleanupJob) plan(ctx context.Context) (*entity.MediaCleanupReport, error) {
+	report := &entity.MediaCleanupReport{StartedAt: time.Now()}
+
+	media, err := j.storage.ListMedia(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range media {
+		report.ScannedFiles++
+		report.ScannedBytes += m.Size
+	}
+
+	selected := make(map[string]bool)
+	selectMedia := func(m repository.StoredMedia, reason entity.MediaCleanupReason) {
+		selected[m.Path] = true
+		report.Candidates = append(report.Candidates, entity.MediaCleanupCandidate{
+			Path:         m.Path,
+			SessionID:    m.SessionID,
+			Size:         m.Size,
+			StoredAt:     m.ModTime,
+			LastAccessed: m.LastAccessed,
+			Reason:       reason,
+		})
+	}
+
+	// Retention
+	retentionDays, err := j.sessionRetentionDays(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range media {
+		days := j.cfg.RetentionDays
+		if sessionDays := retentionDays[m.SessionID]; sessionDays > 0 {
+			days = sessionDays
+		}
+		if days > 0 && m.ModTime.Before(report.StartedAt.AddDate(0, 0, -days)) {
+			selectMedia(m, entity.MediaCleanupReasonRetention)
+		}
+	}
+
+	// Orphans
+	if j.cfg.OrphanSweep && j.eventRepo != nil {
+		report.OrphanSweep = true
+		referenced := make(map[string]map[string]bool)
+		graceCutoff := report.StartedAt.Add(-j.cfg.OrphanGracePeriod)
+
+		for _, m := range media {
+			// Recent media may not have its event stored yet
+			if selected[m.Path] || !m.ModTime.Before(graceCutoff) {
+				continue
+			}
+
+			if m.SessionID != "" && referenced[m.SessionID] == nil {
+				messageIDs, err := j.referencedMessages(ctx, m.SessionID)
+				if err != nil {
+					return nil, err
+				}
+				referenced[m.SessionID] = messageIDs
+			}
+			if !referenced[m.SessionID][m.MessageID] {
+				selectMedia(m, entity.MediaCleanupReasonOrphan)
+			}
+		}
+	}
+
+	// Disk budget
+	if j.cfg.MaxTotalSize > 0 {
+		var kept []repository.StoredMedia
+		var total int64
+		for _, m := range media {
+			if !selected[m.Path] {
+				kept = append(kept, m)
+				total += m.Size
+			}
+		}
+
+		sort.SliceStable(kept, func(a, b int) bool {
+			if !kept[a].LastAccessed.Equal(kept[b].LastAccessed) {
+				return kept[a].LastAccessed.Before(kept[b].LastAccessed)
+			}
+			return kept[a].ModTime.Before(kept[b].ModTime)
+		})
+		for _, m := range kept {
+			if total <= j.cfg.MaxTotalSize {
+				break
+			}
+			selectMedia(m, entity.MediaCleanupReasonDiskBudget)
+			total -= m.Size
+		}
+	}
+
+	report.RemainingBytes = report.ScannedBytes - report.CandidateBytes()
+	return report, nil
+}
+
+// sessionRetentionDays returns the media retention periods sessions override the default with
+func (j *MediaCleanupJob) sessionRetentionDays(ctx context.Context) (map[string]int, error) {
+	retentionDays := make(map[string]int)
+	if j.sessionRepo == nil {
+		return retentionDays, nil
+	}
+
+	sessions, err := j.sessionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.Settings.MediaRetentionDays > 0 {
+			retentionDays[session.ID] = session.Settings.MediaRetentionDays
+		}
+	}
+
+	return retentionDays, nil
+}
+
+// referencedMessages returns the IDs of the messages of a session that have a stored message.received event
+func (j *MediaCleanupJob) referencedMessages(ctx context.Context, sessionID string) (map[string]bool, error) {
+	messageIDs := make(map[string]bool)
+	eventType := entity.EventTypeMessageReceived
+
+	for offset := 0; ; offset += mediaEventPageSize {
+		events, err := j.eventRepo.List(ctx, repository.EventFilter{
+			SessionID: &sessionID,
+			EventType: &eventType,
+			Limit:     mediaEventPageSize,
+			Offset:    offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			var payload struct {
+				MessageID string `json:"messageId"`
+			}
+			if err := json.Unmarshal(event.Data, &payload); err == nil && payload.MessageID != "" {
+				messageIDs[payload.MessageID] = true
+			}
+		}
+
+		if len(events) < mediaEventPageSize {
+			return messageIDs, nil
+		}
+	}
+}
+
+// recordRun records the outcome of a cleanup run when metrics are enabled
+func (j *MediaCleanupJob) recordRun(status string) {
+	if j.metrics != nil {
+		j.metrics.RecordMediaCleanupRun(status)
+	}
+}
+
+// GetLastRunResult returns the report of the last cleanup run
+func (j *MediaCleanupJob) GetLastRunResult() *entity.MediaCleanupReport {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.lastRunResult
+}
+
+// IsRunning returns whether the job is currently running
+func (j *MediaCleanupJob) IsRunning() bool {
+	return j.running
+}
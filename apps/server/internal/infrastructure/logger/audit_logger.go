@@ -82,7 +82,8 @@ func (al *AuditLogger) LogMessageSent(ctx context.Context, event repository.Mess
 func (al *AuditLogger) LogAuthFailure(ctx context.Context, event repository.AuthFailureEvent) {
 	al.logger.WithContext(ctx).
 		WithStr("event_type", "auth_failure").
-		WithStr("api_key", event.APIKey).
+		WithStr("masked_key", event.MaskedKey).
+		WithStr("key_hash", event.KeyHash).
 		WithStr("endpoint", event.Endpoint).
 		WithStr("reason", event.Reason).
 		WithStr("ip_address", event.IPAddress).
@@ -29,6 +29,13 @@ type Metrics struct {
 	// Event publisher metrics
 	EventsPublished *prometheus.CounterVec
 	EventQueueSize  prometheus.Gauge
+
+	// Media cleanup metrics
+	MediaCleanupRuns  *prometheus.CounterVec
+	MediaFilesDeleted *prometheus.CounterVec
+	MediaBytesDeleted *prometheus.CounterVec
+	MediaStoredFiles  prometheus.Gauge
+	MediaStoredBytes  prometheus.Gauge
 }
 
 // Config holds configuration for metrics
@@ -165,6 +172,46 @@ func NewMetrics(cfg Config) *Metrics {
 				Help:      "Current number of events in the publish queue",
 			},
 		),
+
+		// Media cleanup metrics
+		MediaCleanupRuns: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "media_cleanup_runs_total",
+				Help:      "Total number of media cleanup runs",
+			},
+			[]string{"status"},
+		),
+		MediaFilesDeleted: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "media_files_deleted_total",
+				Help:      "Total number of media files deleted by the cleanup job",
+			},
+			[]string{"reason"},
+		),
+		MediaBytesDeleted: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "media_bytes_deleted_total",
+				Help:      "Total size in bytes of media files deleted by the cleanup job",
+			},
+			[]string{"reason"},
+		),
+		MediaStoredFiles: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "media_stored_files",
+				Help:      "Number of stored media files after the last cleanup run",
+			},
+		),
+		MediaStoredBytes: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "media_stored_bytes",
+				Help:      "Total size in bytes of stored media after the last cleanup run",
+			},
+		),
 	}
 }
 
@@ -229,6 +276,23 @@ func (m *Metrics) SetEventQueueSize(size float64) {
 	m.EventQueueSize.Set(size)
 }
 
+// RecordMediaCleanupRun records a media cleanup run
+func (m *Metrics) RecordMediaCleanupRun(status string) {
+	m.MediaCleanupRuns.WithLabelValues(status).Inc()
+}
+
+// RecordMediaDeleted records a media file deleted by the cleanup job
+func (m *Metrics) RecordMediaDeleted(reason string, size int64) {
+	m.MediaFilesDeleted.WithLabelValues(reason).Inc()
+	m.MediaBytesDeleted.WithLabelValues(reason).Add(float64(size))
+}
+
+// SetMediaStored sets the number and total size of stored media files
+func (m *Metrics) SetMediaStored(files int, bytes int64) {
+	m.MediaStoredFiles.Set(float64(files))
+	m.MediaStoredBytes.Set(float64(bytes))
+}
+
 // IncrementInFlight increments the in-flight request counter
 func (m *Metrics) IncrementInFlight() {
 	m.HTTPRequestsInFlight.Inc()
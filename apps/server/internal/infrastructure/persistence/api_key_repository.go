@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"whatspire/internal/infrastructure/persistence/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // APIKeyRepository implements APIKeyRepository with GORM
@@ -24,17 +26,9 @@ func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
 
 // Save stores an API key in the repository
 func (r *APIKeyRepository) Save(ctx context.Context, apiKey *entity.APIKey) error {
-	model := &models.APIKey{
-		ID:               apiKey.ID,
-		KeyHash:          apiKey.KeyHash,
-		Role:             apiKey.Role,
-		Description:      apiKey.Description,
-		CreatedAt:        apiKey.CreatedAt,
-		LastUsedAt:       apiKey.LastUsedAt,
-		IsActive:         apiKey.IsActive,
-		RevokedAt:        apiKey.RevokedAt,
-		RevokedBy:        apiKey.RevokedBy,
-		RevocationReason: apiKey.RevocationReason,
+	model, err := toAPIKeyModel(apiKey)
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
 	}
 
 	result := r.db.WithContext(ctx).Create(model)
@@ -60,21 +54,7 @@ func (r *APIKeyRepository) FindByKeyHash(ctx context.Context, keyHash string) (*
 		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
 	}
 
-	// Convert model to domain entity
-	apiKey := &entity.APIKey{
-		ID:               model.ID,
-		KeyHash:          model.KeyHash,
-		Role:             model.Role,
-		Description:      model.Description,
-		CreatedAt:        model.CreatedAt,
-		LastUsedAt:       model.LastUsedAt,
-		IsActive:         model.IsActive,
-		RevokedAt:        model.RevokedAt,
-		RevokedBy:        model.RevokedBy,
-		RevocationReason: model.RevocationReason,
-	}
-
-	return apiKey, nil
+	return toAPIKeyEntity(model)
 }
 
 // FindByID retrieves an API key by its ID
@@ -89,21 +69,7 @@ func (r *APIKeyRepository) FindByID(ctx context.Context, id string) (*entity.API
 		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
 	}
 
-	// Convert model to domain entity
-	apiKey := &entity.APIKey{
-		ID:               model.ID,
-		KeyHash:          model.KeyHash,
-		Role:             model.Role,
-		Description:      model.Description,
-		CreatedAt:        model.CreatedAt,
-		LastUsedAt:       model.LastUsedAt,
-		IsActive:         model.IsActive,
-		RevokedAt:        model.RevokedAt,
-		RevokedBy:        model.RevokedBy,
-		RevocationReason: model.RevocationReason,
-	}
-
-	return apiKey, nil
+	return toAPIKeyEntity(model)
 }
 
 // UpdateLastUsed updates the last used timestamp for an API key
@@ -124,8 +90,12 @@ func (r *APIKeyRepository) UpdateLastUsed(ctx context.Context, keyHash string) e
 	return nil
 }
 
-// Delete removes an API key by its ID
+// Delete removes an API key by its ID along with its usage counters
 func (r *APIKeyRepository) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Delete(&models.APIKeyUsage{}, "api_key_id = ?", id).Error; err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
 	result := r.db.WithContext(ctx).Delete(&models.APIKey{}, "id = ?", id)
 
 	if result.Error != nil {
@@ -168,17 +138,9 @@ func (r *APIKeyRepository) List(ctx context.Context, limit, offset int, role *st
 	// Convert models to domain entities
 	apiKeys := make([]*entity.APIKey, 0, len(modelKeys))
 	for _, model := range modelKeys {
-		apiKey := &entity.APIKey{
-			ID:               model.ID,
-			KeyHash:          model.KeyHash,
-			Role:             model.Role,
-			Description:      model.Description,
-			CreatedAt:        model.CreatedAt,
-			LastUsedAt:       model.LastUsedAt,
-			IsActive:         model.IsActive,
-			RevokedAt:        model.RevokedAt,
-			RevokedBy:        model.RevokedBy,
-			RevocationReason: model.RevocationReason,
+		apiKey, err := toAPIKeyEntity(model)
+		if err != nil {
+			return nil, err
 		}
 		apiKeys = append(apiKeys, apiKey)
 	}
@@ -199,17 +161,9 @@ func (r *APIKeyRepository) Update(ctx context.Context, apiKey *entity.APIKey) er
 	}
 
 	// Update the existing record
-	model := &models.APIKey{
-		ID:               apiKey.ID,
-		KeyHash:          apiKey.KeyHash,
-		Role:             apiKey.Role,
-		Description:      apiKey.Description,
-		CreatedAt:        apiKey.CreatedAt,
-		LastUsedAt:       apiKey.LastUsedAt,
-		IsActive:         apiKey.IsActive,
-		RevokedAt:        apiKey.RevokedAt,
-		RevokedBy:        apiKey.RevokedBy,
-		RevocationReason: apiKey.RevocationReason,
+	model, err := toAPIKeyModel(apiKey)
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
 	}
 
 	result = r.db.WithContext(ctx).Save(model)
@@ -243,3 +197,150 @@ func (r *APIKeyRepository) Count(ctx context.Context, role *string, isActive *bo
 
 	return count, nil
 }
+
+// IncrementUsage adds to the usage counters of an API key for the day of at
+// and returns the counters after the increment
+func (r *APIKeyRepository) IncrementUsage(ctx context.Context, id string, at time.Time, requests, messages int) (*entity.APIKeyUsage, error) {
+	day := entity.APIKeyUsageDay(at)
+	var model models.APIKeyUsage
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Upsert so concurrent requests of the same key never lose an increment
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "api_key_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"requests":   gorm.Expr("api_key_usage.requests + ?", requests),
+				"messages":   gorm.Expr("api_key_usage.messages + ?", messages),
+				"updated_at": time.Now(),
+			}),
+		}).Create(&models.APIKeyUsage{
+			APIKeyID:  id,
+			Day:       day,
+			Requests:  int64(requests),
+			Messages:  int64(messages),
+			UpdatedAt: time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.First(&model, "api_key_id = ? AND day = ?", id, day).Error
+	})
+	if err != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return toAPIKeyUsageEntity(model), nil
+}
+
+// GetUsage retrieves the usage counters of an API key for the day of at
+func (r *APIKeyRepository) GetUsage(ctx context.Context, id string, at time.Time) (*entity.APIKeyUsage, error) {
+	day := entity.APIKeyUsageDay(at)
+
+	var usage []models.APIKeyUsage
+	result := r.db.WithContext(ctx).Where("api_key_id = ? AND day = ?", id, day).Limit(1).Find(&usage)
+	if result.Error != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+	if len(usage) == 0 {
+		return &entity.APIKeyUsage{APIKeyID: id, Day: day}, nil
+	}
+
+	return toAPIKeyUsageEntity(usage[0]), nil
+}
+
+// toAPIKeyUsageEntity converts an API key usage database model to a domain usage
+func toAPIKeyUsageEntity(model models.APIKeyUsage) *entity.APIKeyUsage {
+	return &entity.APIKeyUsage{
+		APIKeyID: model.APIKeyID,
+		Day:      model.Day,
+		Requests: model.Requests,
+		Messages: model.Messages,
+	}
+}
+
+// toAPIKeyModel converts a domain API key to its database model
+func toAPIKeyModel(apiKey *entity.APIKey) (*models.APIKey, error) {
+	allowedSessions, err := encodeStringList(apiKey.AllowedSessions)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := encodeStringList(apiKey.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.APIKey{
+		ID:                apiKey.ID,
+		KeyHash:           apiKey.KeyHash,
+		Role:              apiKey.Role,
+		AllowedSessions:   allowedSessions,
+		Scopes:            scopes,
+		Description:       apiKey.Description,
+		CreatedAt:         apiKey.CreatedAt,
+		LastUsedAt:        apiKey.LastUsedAt,
+		IsActive:          apiKey.IsActive,
+		RevokedAt:         apiKey.RevokedAt,
+		RevokedBy:         apiKey.RevokedBy,
+		RevocationReason:  apiKey.RevocationReason,
+		ExpiresAt:         apiKey.ExpiresAt,
+		ReplacedBy:        apiKey.ReplacedBy,
+		DailyRequestQuota: apiKey.DailyRequestQuota,
+		DailyMessageQuota: apiKey.DailyMessageQuota,
+	}, nil
+}
+
+// toAPIKeyEntity converts an API key database model to a domain API key
+func toAPIKeyEntity(model models.APIKey) (*entity.APIKey, error) {
+	allowedSessions, err := decodeStringList(model.AllowedSessions)
+	if err != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(err)
+	}
+	scopes, err := decodeStringList(model.Scopes)
+	if err != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return &entity.APIKey{
+		ID:                model.ID,
+		KeyHash:           model.KeyHash,
+		Role:              model.Role,
+		AllowedSessions:   allowedSessions,
+		Scopes:            scopes,
+		Description:       model.Description,
+		CreatedAt:         model.CreatedAt,
+		LastUsedAt:        model.LastUsedAt,
+		IsActive:          model.IsActive,
+		RevokedAt:         model.RevokedAt,
+		RevokedBy:         model.RevokedBy,
+		RevocationReason:  model.RevocationReason,
+		ExpiresAt:         model.ExpiresAt,
+		ReplacedBy:        model.ReplacedBy,
+		DailyRequestQuota: model.DailyRequestQuota,
+		DailyMessageQuota: model.DailyMessageQuota,
+	}, nil
+}
+
+// encodeStringList encodes a list as a JSON array, or an empty string for an empty list
+func encodeStringList(values []string) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// decodeStringList decodes a list stored by encodeStringList
+func decodeStringList(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
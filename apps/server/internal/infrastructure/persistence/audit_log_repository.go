@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"whatspire/internal/domain/entity"
 	domainErrors "whatspire/internal/domain/errors"
 	"whatspire/internal/domain/repository"
 	"whatspire/internal/infrastructure/persistence/models"
@@ -36,12 +37,77 @@ func (r *AuditLogRepository) SaveAPIKeyUsage(ctx context.Context, event reposito
 
 	model := &models.AuditLog{
 		ID:        uuid.New().String(),
-		EventType: "api_key_usage",
+		EventType: entity.AuditEventAPIKeyUsage.String(),
 		APIKeyID:  &event.APIKeyID,
 		Endpoint:  &event.Endpoint,
 		Details:   string(details),
 		IPAddress: &event.IPAddress,
-		CreatedAt: event.Timestamp,
+		CreatedAt: auditTimestamp(event.Timestamp),
+	}
+
+	result := r.db.WithContext(ctx).Create(model)
+	if result.Error != nil {
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return nil
+}
+
+// SaveAPIKeyCreated saves an API key creation event
+func (r *AuditLogRepository) SaveAPIKeyCreated(ctx context.Context, event repository.APIKeyCreatedEvent) error {
+	detailsMap := map[string]interface{}{
+		"role":       event.Role,
+		"created_by": event.CreatedBy,
+	}
+	if event.Description != nil {
+		detailsMap["description"] = *event.Description
+	}
+
+	details, err := json.Marshal(detailsMap)
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	action := "created"
+	model := &models.AuditLog{
+		ID:        uuid.New().String(),
+		EventType: entity.AuditEventAPIKeyCreated.String(),
+		APIKeyID:  &event.APIKeyID,
+		Action:    &action,
+		Details:   string(details),
+		CreatedAt: auditTimestamp(event.Timestamp),
+	}
+
+	result := r.db.WithContext(ctx).Create(model)
+	if result.Error != nil {
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return nil
+}
+
+// SaveAPIKeyRevoked saves an API key revocation event
+func (r *AuditLogRepository) SaveAPIKeyRevoked(ctx context.Context, event repository.APIKeyRevokedEvent) error {
+	detailsMap := map[string]interface{}{
+		"revoked_by": event.RevokedBy,
+	}
+	if event.RevocationReason != nil {
+		detailsMap["revocation_reason"] = *event.RevocationReason
+	}
+
+	details, err := json.Marshal(detailsMap)
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	action := "revoked"
+	model := &models.AuditLog{
+		ID:        uuid.New().String(),
+		EventType: entity.AuditEventAPIKeyRevoked.String(),
+		APIKeyID:  &event.APIKeyID,
+		Action:    &action,
+		Details:   string(details),
+		CreatedAt: auditTimestamp(event.Timestamp),
 	}
 
 	result := r.db.WithContext(ctx).Create(model)
@@ -65,12 +131,12 @@ func (r *AuditLogRepository) SaveSessionAction(ctx context.Context, event reposi
 
 	model := &models.AuditLog{
 		ID:        uuid.New().String(),
-		EventType: "session_action",
+		EventType: entity.AuditEventSessionAction.String(),
 		APIKeyID:  &event.APIKeyID,
 		SessionID: &event.SessionID,
 		Action:    &event.Action,
 		Details:   string(details),
-		CreatedAt: event.Timestamp,
+		CreatedAt: auditTimestamp(event.Timestamp),
 	}
 
 	result := r.db.WithContext(ctx).Create(model)
@@ -94,10 +160,10 @@ func (r *AuditLogRepository) SaveMessageSent(ctx context.Context, event reposito
 
 	model := &models.AuditLog{
 		ID:        uuid.New().String(),
-		EventType: "message_sent",
+		EventType: entity.AuditEventMessageSent.String(),
 		SessionID: &event.SessionID,
 		Details:   string(details),
-		CreatedAt: event.Timestamp,
+		CreatedAt: auditTimestamp(event.Timestamp),
 	}
 
 	result := r.db.WithContext(ctx).Create(model)
@@ -111,7 +177,8 @@ func (r *AuditLogRepository) SaveMessageSent(ctx context.Context, event reposito
 // SaveAuthFailure saves an authentication failure event
 func (r *AuditLogRepository) SaveAuthFailure(ctx context.Context, event repository.AuthFailureEvent) error {
 	details, err := json.Marshal(map[string]interface{}{
-		"api_key":    event.APIKey,
+		"masked_key": event.MaskedKey,
+		"key_hash":   event.KeyHash,
 		"endpoint":   event.Endpoint,
 		"reason":     event.Reason,
 		"ip_address": event.IPAddress,
@@ -122,11 +189,11 @@ func (r *AuditLogRepository) SaveAuthFailure(ctx context.Context, event reposito
 
 	model := &models.AuditLog{
 		ID:        uuid.New().String(),
-		EventType: "auth_failure",
+		EventType: entity.AuditEventAuthFailure.String(),
 		Endpoint:  &event.Endpoint,
 		Details:   string(details),
 		IPAddress: &event.IPAddress,
-		CreatedAt: event.Timestamp,
+		CreatedAt: auditTimestamp(event.Timestamp),
 	}
 
 	result := r.db.WithContext(ctx).Create(model)
@@ -156,9 +223,9 @@ func (r *AuditLogRepository) SaveWebhookDelivery(ctx context.Context, event repo
 
 	model := &models.AuditLog{
 		ID:        uuid.New().String(),
-		EventType: "webhook_delivery",
+		EventType: entity.AuditEventWebhookDelivery.String(),
 		Details:   string(details),
-		CreatedAt: event.Timestamp,
+		CreatedAt: auditTimestamp(event.Timestamp),
 	}
 
 	result := r.db.WithContext(ctx).Create(model)
@@ -193,7 +260,7 @@ func (r *AuditLogRepository) CountAPIKeyUsage(ctx context.Context, apiKeyID stri
 
 	result := r.db.WithContext(ctx).
 		Model(&models.AuditLog{}).
-		Where("event_type = ? AND api_key_id = ?", "api_key_usage", apiKeyID).
+		Where("event_type = ? AND api_key_id = ?", entity.AuditEventAPIKeyUsage.String(), apiKeyID).
 		Count(&count)
 
 	if result.Error != nil {
@@ -209,7 +276,7 @@ func (r *AuditLogRepository) CountAPIKeyUsageSince(ctx context.Context, apiKeyID
 
 	result := r.db.WithContext(ctx).
 		Model(&models.AuditLog{}).
-		Where("event_type = ? AND api_key_id = ? AND created_at >= ?", "api_key_usage", apiKeyID, since).
+		Where("event_type = ? AND api_key_id = ? AND created_at >= ?", entity.AuditEventAPIKeyUsage.String(), apiKeyID, since.UTC()).
 		Count(&count)
 
 	if result.Error != nil {
@@ -218,3 +285,89 @@ func (r *AuditLogRepository) CountAPIKeyUsageSince(ctx context.Context, apiKeyID
 
 	return count, nil
 }
+
+// List retrieves audit logs matching the filter, newest first
+func (r *AuditLogRepository) List(ctx context.Context, filter repository.AuditLogFilter) ([]*entity.AuditLog, error) {
+	var modelLogs []models.AuditLog
+
+	query := r.db.WithContext(ctx)
+
+	if filter.EventType != nil {
+		query = query.Where("event_type = ?", filter.EventType.String())
+	}
+	if filter.APIKeyID != nil {
+		query = query.Where("api_key_id = ?", *filter.APIKeyID)
+	}
+	if filter.SessionID != nil {
+		query = query.Where("session_id = ?", *filter.SessionID)
+	}
+	if filter.IPAddress != nil {
+		query = query.Where("ip_address = ?", *filter.IPAddress)
+	}
+	if filter.Since != nil {
+		query = query.Where("created_at >= ?", filter.Since.UTC())
+	}
+	if filter.Until != nil {
+		query = query.Where("created_at <= ?", filter.Until.UTC())
+	}
+	if filter.Before != nil {
+		before := filter.Before.UTC()
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", before, before, filter.BeforeID)
+	}
+
+	query = query.Order("created_at DESC").Order("id DESC")
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if result := query.Find(&modelLogs); result.Error != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	logs := make([]*entity.AuditLog, 0, len(modelLogs))
+	for _, model := range modelLogs {
+		logs = append(logs, toAuditLogEntity(model))
+	}
+
+	return logs, nil
+}
+
+// DeleteOlderThan removes audit logs created before the cutoff and returns how many were removed
+func (r *AuditLogRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("created_at < ?", cutoff.UTC()).
+		Delete(&models.AuditLog{})
+
+	if result.Error != nil {
+		return 0, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// auditTimestamp normalizes an event timestamp for storage so range and cursor queries compare consistently
+func auditTimestamp(timestamp time.Time) time.Time {
+	if timestamp.IsZero() {
+		return time.Now().UTC()
+	}
+	return timestamp.UTC()
+}
+
+// toAuditLogEntity converts an audit log database model to a domain audit log
+func toAuditLogEntity(model models.AuditLog) *entity.AuditLog {
+	auditLog := &entity.AuditLog{
+		ID:        model.ID,
+		EventType: entity.AuditEventType(model.EventType),
+		APIKeyID:  model.APIKeyID,
+		SessionID: model.SessionID,
+		Endpoint:  model.Endpoint,
+		Action:    model.Action,
+		IPAddress: model.IPAddress,
+		CreatedAt: model.CreatedAt,
+	}
+	if model.Details != "" && json.Valid([]byte(model.Details)) {
+		auditLog.Details = json.RawMessage(model.Details)
+	}
+	return auditLog
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/logger"
+)
+
+// PersistentAuditLogger implements repository.AuditLogger by storing every event in the audit_logs table
+// Events are also forwarded to the wrapped audit logger so they keep appearing in the application logs
+type PersistentAuditLogger struct {
+	repo   *AuditLogRepository
+	next   repository.AuditLogger
+	logger *logger.Logger
+}
+
+// NewPersistentAuditLogger creates an audit logger that persists events and forwards them to next (optional)
+func NewPersistentAuditLogger(repo *AuditLogRepository, next repository.AuditLogger, log *logger.Logger) *PersistentAuditLogger {
+	return &PersistentAuditLogger{
+		repo:   repo,
+		next:   next,
+		logger: log,
+	}
+}
+
+// LogAPIKeyUsage records an API key usage event
+func (al *PersistentAuditLogger) LogAPIKeyUsage(ctx context.Context, event repository.APIKeyUsageEvent) {
+	if al.next != nil {
+		al.next.LogAPIKeyUsage(ctx, event)
+	}
+	al.warnOnError(entity.AuditEventAPIKeyUsage, al.repo.SaveAPIKeyUsage(ctx, event))
+}
+
+// LogAPIKeyCreated records an API key creation event
+func (al *PersistentAuditLogger) LogAPIKeyCreated(ctx context.Context, event repository.APIKeyCreatedEvent) {
+	if al.next != nil {
+		al.next.LogAPIKeyCreated(ctx, event)
+	}
+	al.warnOnError(entity.AuditEventAPIKeyCreated, al.repo.SaveAPIKeyCreated(ctx, event))
+}
+
+// LogAPIKeyRevoked records an API key revocation event
+func (al *PersistentAuditLogger) LogAPIKeyRevoked(ctx context.Context, event repository.APIKeyRevokedEvent) {
+	if al.next != nil {
+		al.next.LogAPIKeyRevoked(ctx, event)
+	}
+	al.warnOnError(entity.AuditEventAPIKeyRevoked, al.repo.SaveAPIKeyRevoked(ctx, event))
+}
+
+// LogSessionAction records a session action event
+func (al *PersistentAuditLogger) LogSessionAction(ctx context.Context, event repository.SessionActionEvent) {
+	if al.next != nil {
+		al.next.LogSessionAction(ctx, event)
+	}
+	al.warnOnError(entity.AuditEventSessionAction, al.repo.SaveSessionAction(ctx, event))
+}
+
+// LogMessageSent records a message sent event
+func (al *PersistentAuditLogger) LogMessageSent(ctx context.Context, event repository.MessageSentEvent) {
+	if al.next != nil {
+		al.next.LogMessageSent(ctx, event)
+	}
+	al.warnOnError(entity.AuditEventMessageSent, al.repo.SaveMessageSent(ctx, event))
+}
+
+// LogAuthFailure records an authentication failure event
+func (al *PersistentAuditLogger) LogAuthFailure(ctx context.Context, event repository.AuthFailureEvent) {
+	if al.next != nil {
+		al.next.LogAuthFailure(ctx, event)
+	}
+	al.warnOnError(entity.AuditEventAuthFailure, al.repo.SaveAuthFailure(ctx, event))
+}
+
+// LogWebhookDelivery records a webhook delivery event
+func (al *PersistentAuditLogger) LogWebhookDelivery(ctx context.Context, event repository.WebhookDeliveryEvent) {
+	if al.next != nil {
+		al.next.LogWebhookDelivery(ctx, event)
+	}
+	al.warnOnError(entity.AuditEventWebhookDelivery, al.repo.SaveWebhookDelivery(ctx, event))
+}
+
+// warnOnError logs a failed write; audit persistence never fails the audited operation
+func (al *PersistentAuditLogger) warnOnError(eventType entity.AuditEventType, err error) {
+	if err == nil || al.logger == nil {
+		return
+	}
+	al.logger.WithError(err).
+		WithStr("event_type", eventType.String()).
+		Warn("Failed to persist audit event")
+}
@@ -0,0 +1,96 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	domainErrors "whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+)
+
+// DefaultWebhookConfigCacheTTL bounds how long a cached configuration is used without being reloaded
+const DefaultWebhookConfigCacheTTL = time.Minute
+
+// CachedWebhookConfigRepository caches webhook configurations by session ID
+// Writes go through to the wrapped repository and invalidate the session's entry,
+// so configurations are resolved once per session rather than once per event
+type CachedWebhookConfigRepository struct {
+	repo  repository.WebhookConfigRepository
+	cache *sessionCache[*entity.WebhookConfig] // A nil config records that the session has none
+}
+
+// NewCachedWebhookConfigRepository creates a caching decorator around a webhook config repository
+func NewCachedWebhookConfigRepository(repo repository.WebhookConfigRepository, ttl time.Duration) *CachedWebhookConfigRepository {
+	if ttl <= 0 {
+		ttl = DefaultWebhookConfigCacheTTL
+	}
+
+	return &CachedWebhookConfigRepository{
+		repo:  repo,
+		cache: newSessionCache[*entity.WebhookConfig](ttl),
+	}
+}
+
+// Create creates a new webhook configuration
+func (r *CachedWebhookConfigRepository) Create(ctx context.Context, config *entity.WebhookConfig) error {
+	defer r.Invalidate(config.SessionID)
+	return r.repo.Create(ctx, config)
+}
+
+// GetBySessionID retrieves webhook configuration for a session, from the cache when possible
+// Callers receive their own copy and may modify it freely
+func (r *CachedWebhookConfigRepository) GetBySessionID(ctx context.Context, sessionID string) (*entity.WebhookConfig, error) {
+	config, err := r.cache.get(sessionID, func() (*entity.WebhookConfig, error) {
+		config, err := r.repo.GetBySessionID(ctx, sessionID)
+		if err != nil && !domainErrors.IsNotFound(err) {
+			return nil, err
+		}
+		return config, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		return nil, domainErrors.ErrNotFound.WithMessage("webhook configuration not found")
+	}
+	return copyWebhookConfig(config), nil
+}
+
+// Update updates an existing webhook configuration
+func (r *CachedWebhookConfigRepository) Update(ctx context.Context, config *entity.WebhookConfig) error {
+	defer r.Invalidate(config.SessionID)
+	return r.repo.Update(ctx, config)
+}
+
+// Delete removes a webhook configuration by session ID
+func (r *CachedWebhookConfigRepository) Delete(ctx context.Context, sessionID string) error {
+	defer r.Invalidate(sessionID)
+	return r.repo.Delete(ctx, sessionID)
+}
+
+// Exists checks if a webhook configuration exists for a session
+func (r *CachedWebhookConfigRepository) Exists(ctx context.Context, sessionID string) (bool, error) {
+	return r.repo.Exists(ctx, sessionID)
+}
+
+// Invalidate drops the cached configuration of a session
+func (r *CachedWebhookConfigRepository) Invalidate(sessionID string) {
+	r.cache.invalidate(sessionID)
+}
+
+// copyWebhookConfig returns a copy of the configuration that shares no slices with the original
+func copyWebhookConfig(config *entity.WebhookConfig) *entity.WebhookConfig {
+	c := *config
+	c.Events = copyStrings(config.Events)
+	return &c
+}
+
+// copyStrings copies a slice, keeping nil and empty slices distinct
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(values)), values...)
+}
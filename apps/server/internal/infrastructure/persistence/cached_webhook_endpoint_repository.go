@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/repository"
+)
+
+// CachedWebhookEndpointRepository caches each session's webhook endpoints
+// Writes go through to the wrapped repository and invalidate the session's entry,
+// so endpoints are listed once per session rather than once per event
+type CachedWebhookEndpointRepository struct {
+	repo  repository.WebhookEndpointRepository
+	cache *sessionCache[[]*entity.WebhookEndpoint]
+}
+
+// NewCachedWebhookEndpointRepository creates a caching decorator around a webhook endpoint repository
+func NewCachedWebhookEndpointRepository(repo repository.WebhookEndpointRepository, ttl time.Duration) *CachedWebhookEndpointRepository {
+	if ttl <= 0 {
+		ttl = DefaultWebhookConfigCacheTTL
+	}
+
+	return &CachedWebhookEndpointRepository{
+		repo:  repo,
+		cache: newSessionCache[[]*entity.WebhookEndpoint](ttl),
+	}
+}
+
+// Create creates a new webhook endpoint
+func (r *CachedWebhookEndpointRepository) Create(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	defer r.Invalidate(endpoint.SessionID)
+	return r.repo.Create(ctx, endpoint)
+}
+
+// GetByID retrieves one of a session's webhook endpoints
+func (r *CachedWebhookEndpointRepository) GetByID(ctx context.Context, sessionID, id string) (*entity.WebhookEndpoint, error) {
+	return r.repo.GetByID(ctx, sessionID, id)
+}
+
+// ListBySessionID retrieves a session's webhook endpoints, from the cache when possible
+// Callers receive their own copies and may modify them freely
+func (r *CachedWebhookEndpointRepository) ListBySessionID(ctx context.Context, sessionID string) ([]*entity.WebhookEndpoint, error) {
+	endpoints, err := r.cache.get(sessionID, func() ([]*entity.WebhookEndpoint, error) {
+		return r.repo.ListBySessionID(ctx, sessionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	copies := make([]*entity.WebhookEndpoint, len(endpoints))
+	for i, endpoint := range endpoints {
+		copies[i] = copyWebhookEndpoint(endpoint)
+	}
+	return copies, nil
+}
+
+// Update updates an existing webhook endpoint
+func (r *CachedWebhookEndpointRepository) Update(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	defer r.Invalidate(endpoint.SessionID)
+	return r.repo.Update(ctx, endpoint)
+}
+
+// Delete removes one of a session's webhook endpoints
+func (r *CachedWebhookEndpointRepository) Delete(ctx context.Context, sessionID, id string) error {
+	defer r.Invalidate(sessionID)
+	return r.repo.Delete(ctx, sessionID, id)
+}
+
+// Invalidate drops the cached endpoints of a session
+func (r *CachedWebhookEndpointRepository) Invalidate(sessionID string) {
+	r.cache.invalidate(sessionID)
+}
+
+// copyWebhookEndpoint returns a copy of the endpoint that shares no slices or maps with the original
+func copyWebhookEndpoint(endpoint *entity.WebhookEndpoint) *entity.WebhookEndpoint {
+	e := *endpoint
+	e.Events = copyStrings(endpoint.Events)
+	e.Headers = maps.Clone(endpoint.Headers)
+	return &e
+}
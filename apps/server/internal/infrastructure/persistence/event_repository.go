@@ -24,7 +24,9 @@ func NewEventRepository(db *gorm.DB) *EventRepository {
 	return &EventRepository{db: db}
 }
 
-// Create stores a new event in the repository
+// Create stores a new event in the repository and sets the next sequence number of its session on it
+// The number is taken in the same transaction as the insert, so it is unique across processes
+// sharing the database and an event that fails to store leaves no gap
 func (r *EventRepository) Create(ctx context.Context, event *entity.Event) error {
 	model := &models.Event{
 		ID:        event.ID,
@@ -35,14 +37,45 @@ func (r *EventRepository) Create(ctx context.Context, event *entity.Event) error
 		CreatedAt: time.Now().UTC(),
 	}
 
-	result := r.db.WithContext(ctx).Create(model)
-	if result.Error != nil {
-		return domainErrors.ErrDatabase.WithCause(result.Error)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sequence, err := nextEventSequence(tx, event.SessionID)
+		if err != nil {
+			return err
+		}
+		model.Sequence = sequence
+
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
 	}
 
+	event.Sequence = model.Sequence
 	return nil
 }
 
+// nextEventSequence increments the sequence counter of a session and returns the new value
+// The counter row stays locked until the transaction ends, so concurrent inserts for a session wait
+// for each other; a session without a counter continues from its highest stored sequence
+func nextEventSequence(tx *gorm.DB, sessionID string) (int64, error) {
+	var sequence int64
+	result := tx.Raw(`INSERT INTO event_sequences (session_id, last_sequence, updated_at)
+		SELECT ?, COALESCE(MAX(sequence), 0) + 1, ? FROM events WHERE session_id = ?
+		ON CONFLICT (session_id) DO UPDATE SET
+			last_sequence = event_sequences.last_sequence + 1,
+			updated_at = excluded.updated_at
+		RETURNING last_sequence`, sessionID, time.Now().UTC(), sessionID).
+		Scan(&sequence)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if sequence == 0 {
+		return 0, fmt.Errorf("no sequence number allocated for session %s", sessionID)
+	}
+
+	return sequence, nil
+}
+
 // GetByID retrieves an event by its ID
 func (r *EventRepository) GetByID(ctx context.Context, id string) (*entity.Event, error) {
 	var model models.Event
@@ -60,6 +93,7 @@ func (r *EventRepository) GetByID(ctx context.Context, id string) (*entity.Event
 		ID:        model.ID,
 		Type:      entity.EventType(model.Type),
 		SessionID: model.SessionID,
+		Sequence:  model.Sequence,
 		Data:      model.Data,
 		Timestamp: model.Timestamp,
 	}
@@ -121,6 +155,7 @@ func (r *EventRepository) List(ctx context.Context, filter repository.EventFilte
 			ID:        model.ID,
 			Type:      entity.EventType(model.Type),
 			SessionID: model.SessionID,
+			Sequence:  model.Sequence,
 			Data:      model.Data,
 			Timestamp: model.Timestamp,
 		}
@@ -184,6 +219,50 @@ func (r *EventRepository) Count(ctx context.Context, filter repository.EventFilt
 	return count, nil
 }
 
+// LatestSequence returns the last sequence number given to an event of a session, 0 if none
+func (r *EventRepository) LatestSequence(ctx context.Context, sessionID string) (int64, error) {
+	var sequence int64
+	result := r.db.WithContext(ctx).Raw(`SELECT COALESCE(
+			(SELECT last_sequence FROM event_sequences WHERE session_id = ?),
+			(SELECT MAX(sequence) FROM events WHERE session_id = ?),
+			0)`, sessionID, sessionID).
+		Scan(&sequence)
+	if result.Error != nil {
+		return 0, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return sequence, nil
+}
+
+// ListAfterSequence retrieves events of a session with a sequence number above the given one, oldest first
+func (r *EventRepository) ListAfterSequence(ctx context.Context, sessionID string, afterSequence int64, limit int) ([]*entity.Event, error) {
+	query := r.db.WithContext(ctx).
+		Where("session_id = ? AND sequence > ?", sessionID, afterSequence).
+		Order("sequence ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var modelEvents []models.Event
+	if result := query.Find(&modelEvents); result.Error != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	events := make([]*entity.Event, 0, len(modelEvents))
+	for _, model := range modelEvents {
+		events = append(events, &entity.Event{
+			ID:        model.ID,
+			Type:      entity.EventType(model.Type),
+			SessionID: model.SessionID,
+			Sequence:  model.Sequence,
+			Data:      model.Data,
+			Timestamp: model.Timestamp,
+		})
+	}
+
+	return events, nil
+}
+
 // EventStats represents statistics about stored events
 type EventStats struct {
 	TotalEvents      int64            `json:"total_events"`
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/repository"
+)
+
+// EventSequencer assigns monotonically increasing per-session sequence numbers to events that are not stored
+// Stored events are numbered by EventRepository.Create; counters here are kept in memory and seeded
+// from the last stored sequence so numbering continues across restarts
+type EventSequencer struct {
+	repo repository.EventRepository
+	mu   sync.Mutex
+	last map[string]int64
+}
+
+// NewEventSequencer creates a new event sequencer backed by the event repository
+func NewEventSequencer(repo repository.EventRepository) *EventSequencer {
+	return &EventSequencer{
+		repo: repo,
+		last: make(map[string]int64),
+	}
+}
+
+// Assign sets the next sequence number of the event's session on the event
+// Events that already carry a sequence number are left unchanged
+func (s *EventSequencer) Assign(ctx context.Context, event *entity.Event) error {
+	if event.Sequence > 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last, ok := s.last[event.SessionID]
+	if !ok {
+		latest, err := s.repo.LatestSequence(ctx, event.SessionID)
+		if err != nil {
+			return err
+		}
+		last = latest
+	}
+
+	last++
+	s.last[event.SessionID] = last
+	event.Sequence = last
+	return nil
+}
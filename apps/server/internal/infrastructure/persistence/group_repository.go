@@ -0,0 +1,325 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	domainErrors "whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/persistence/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GroupRepository implements repository.GroupRepository with GORM
+type GroupRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupRepository creates a new GORM group repository
+func NewGroupRepository(db *gorm.DB) *GroupRepository {
+	return &GroupRepository{db: db}
+}
+
+// Upsert stores a group, replacing its stored settings and participants
+// The group ID is assigned on first insert and kept on later upserts
+func (r *GroupRepository) Upsert(ctx context.Context, group *entity.Group) error {
+	now := time.Now().UTC()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Find rather than First so first inserts don't log a not-found error
+		var existing []models.Group
+		if err := tx.Where("session_id = ? AND jid = ?", group.SessionID, group.JID).Limit(1).Find(&existing).Error; err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			group.ID = existing[0].ID
+			group.CreatedAt = existing[0].CreatedAt
+		} else {
+			if group.ID == "" {
+				group.ID = uuid.New().String()
+			}
+			if group.CreatedAt.IsZero() {
+				group.CreatedAt = now
+			}
+		}
+
+		model := toGroupModel(group)
+		model.UpdatedAt = now
+		if err := tx.Save(model).Error; err != nil {
+			return err
+		}
+
+		// Participants are replaced as a whole so removed members don't linger
+		if err := tx.Where("group_id = ?", group.ID).Delete(&models.GroupParticipant{}).Error; err != nil {
+			return err
+		}
+		if len(group.Participants) == 0 {
+			return nil
+		}
+
+		participants := make([]models.GroupParticipant, 0, len(group.Participants))
+		seen := make(map[string]bool, len(group.Participants))
+		for i := range group.Participants {
+			participant := &group.Participants[i]
+			if seen[participant.JID] {
+				continue
+			}
+			seen[participant.JID] = true
+
+			if participant.ID == "" {
+				participant.ID = uuid.New().String()
+			}
+			participant.GroupID = group.ID
+			participants = append(participants, toGroupParticipantModel(participant, now))
+		}
+		return tx.Create(&participants).Error
+	})
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return nil
+}
+
+// FindByJID retrieves a group of a session, including its participants
+func (r *GroupRepository) FindByJID(ctx context.Context, sessionID, jid string) (*entity.Group, error) {
+	var model models.Group
+
+	result := r.db.WithContext(ctx).
+		Where("session_id = ? AND jid = ?", sessionID, jid).
+		First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrGroupNotFound
+		}
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	var participantModels []models.GroupParticipant
+	result = r.db.WithContext(ctx).
+		Where("group_id = ?", model.ID).
+		Order("joined_at ASC, jid ASC").
+		Find(&participantModels)
+	if result.Error != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	group := toGroupEntity(model)
+	group.Participants = make([]entity.Participant, 0, len(participantModels))
+	for _, participant := range participantModels {
+		group.Participants = append(group.Participants, toParticipantEntity(participant))
+	}
+
+	return group, nil
+}
+
+// List retrieves groups matching the filter ordered by name, without participants
+func (r *GroupRepository) List(ctx context.Context, filter repository.GroupFilter) ([]*entity.Group, error) {
+	var modelGroups []models.Group
+
+	query := applyGroupFilter(r.db.WithContext(ctx), filter).Order("name ASC, id ASC")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	if result := query.Find(&modelGroups); result.Error != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	groups := make([]*entity.Group, 0, len(modelGroups))
+	for _, model := range modelGroups {
+		groups = append(groups, toGroupEntity(model))
+	}
+
+	return groups, nil
+}
+
+// Count returns the number of groups matching the filter, ignoring Limit and Offset
+func (r *GroupRepository) Count(ctx context.Context, filter repository.GroupFilter) (int64, error) {
+	var count int64
+
+	result := applyGroupFilter(r.db.WithContext(ctx).Model(&models.Group{}), filter).Count(&count)
+	if result.Error != nil {
+		return 0, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return count, nil
+}
+
+// Delete removes a group of a session and its participants
+func (r *GroupRepository) Delete(ctx context.Context, sessionID, jid string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model models.Group
+		err := tx.Where("session_id = ? AND jid = ?", sessionID, jid).First(&model).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("group_id = ?", model.ID).Delete(&models.GroupParticipant{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainErrors.ErrGroupNotFound
+		}
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return nil
+}
+
+// DeleteExcept removes all groups of a session whose JID is not in keepJIDs and returns how many were removed
+func (r *GroupRepository) DeleteExcept(ctx context.Context, sessionID string, keepJIDs []string) (int64, error) {
+	var deleted int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stale := tx.Model(&models.Group{}).Select("id").Where("session_id = ?", sessionID)
+		if len(keepJIDs) > 0 {
+			stale = stale.Where("jid NOT IN ?", keepJIDs)
+		}
+
+		var staleIDs []string
+		if err := stale.Pluck("id", &staleIDs).Error; err != nil {
+			return err
+		}
+		if len(staleIDs) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("group_id IN ?", staleIDs).Delete(&models.GroupParticipant{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id IN ?", staleIDs).Delete(&models.Group{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return deleted, nil
+}
+
+// applyGroupFilter adds the filter conditions to a groups query
+func applyGroupFilter(query *gorm.DB, filter repository.GroupFilter) *gorm.DB {
+	query = query.Where("session_id = ?", filter.SessionID)
+
+	if filter.Name != nil && *filter.Name != "" {
+		query = query.Where("LOWER(name) LIKE ? ESCAPE '\\'", "%"+escapeLike(strings.ToLower(*filter.Name))+"%")
+	}
+	if filter.IsArchived != nil {
+		query = query.Where("is_archived = ?", *filter.IsArchived)
+	}
+	if filter.IsMuted != nil {
+		query = query.Where("is_muted = ?", *filter.IsMuted)
+	}
+	if filter.AdminOnly {
+		query = query.Where("is_admin = ?", true)
+	}
+
+	return query
+}
+
+// escapeLike escapes LIKE wildcards so user input is matched literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// toGroupModel converts a domain group to its database model
+func toGroupModel(group *entity.Group) *models.Group {
+	return &models.Group{
+		ID:             group.ID,
+		SessionID:      group.SessionID,
+		JID:            group.JID,
+		Name:           group.Name,
+		Description:    group.Description,
+		AvatarURL:      group.AvatarURL,
+		OwnerJID:       group.OwnerJID,
+		IsAnnounce:     group.IsAnnounce,
+		IsLocked:       group.IsLocked,
+		IsEphemeral:    group.IsEphemeral,
+		EphemeralTime:  group.EphemeralTime,
+		IsAdmin:        group.IsAdmin,
+		IsArchived:     group.IsArchived,
+		IsMuted:        group.IsMuted,
+		MutedUntil:     group.MutedUntil,
+		MemberCount:    group.MemberCount,
+		GroupCreatedAt: group.GroupCreatedAt,
+		LastSyncAt:     group.LastSyncAt,
+		CreatedAt:      group.CreatedAt,
+		UpdatedAt:      group.UpdatedAt,
+	}
+}
+
+// toGroupEntity converts a group database model to a domain group
+func toGroupEntity(model models.Group) *entity.Group {
+	return &entity.Group{
+		ID:             model.ID,
+		JID:            model.JID,
+		Name:           model.Name,
+		Description:    model.Description,
+		AvatarURL:      model.AvatarURL,
+		IsAnnounce:     model.IsAnnounce,
+		IsLocked:       model.IsLocked,
+		IsEphemeral:    model.IsEphemeral,
+		EphemeralTime:  model.EphemeralTime,
+		OwnerJID:       model.OwnerJID,
+		SessionID:      model.SessionID,
+		IsAdmin:        model.IsAdmin,
+		IsArchived:     model.IsArchived,
+		IsMuted:        model.IsMuted,
+		MutedUntil:     model.MutedUntil,
+		MemberCount:    model.MemberCount,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+		GroupCreatedAt: model.GroupCreatedAt,
+		LastSyncAt:     model.LastSyncAt,
+	}
+}
+
+// toGroupParticipantModel converts a domain participant to its database model
+func toGroupParticipantModel(participant *entity.Participant, now time.Time) models.GroupParticipant {
+	joinedAt := participant.JoinedAt
+	if joinedAt.IsZero() {
+		joinedAt = now
+	}
+	role := participant.Role
+	if !role.IsValid() {
+		role = entity.ParticipantRoleMember
+	}
+
+	return models.GroupParticipant{
+		ID:          participant.ID,
+		GroupID:     participant.GroupID,
+		JID:         participant.JID,
+		Role:        role.String(),
+		DisplayName: participant.DisplayName,
+		AddedBy:     participant.AddedBy,
+		JoinedAt:    joinedAt,
+		UpdatedAt:   now,
+	}
+}
+
+// toParticipantEntity converts a participant database model to a domain participant
+func toParticipantEntity(model models.GroupParticipant) entity.Participant {
+	return entity.Participant{
+		ID:          model.ID,
+		JID:         model.JID,
+		Role:        entity.ParticipantRole(model.Role),
+		DisplayName: model.DisplayName,
+		GroupID:     model.GroupID,
+		JoinedAt:    model.JoinedAt,
+		AddedBy:     model.AddedBy,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
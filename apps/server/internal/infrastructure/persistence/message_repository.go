@@ -0,0 +1,280 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	domainErrors "whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/persistence/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MessageRepository implements repository.MessageRepository with GORM
+type MessageRepository struct {
+	db *gorm.DB
+}
+
+// NewMessageRepository creates a new GORM message repository
+func NewMessageRepository(db *gorm.DB) *MessageRepository {
+	return &MessageRepository{db: db}
+}
+
+// Save stores a message, replacing any existing message with the same ID except for its status
+func (r *MessageRepository) Save(ctx context.Context, msg *entity.Message) error {
+	contentJSON, err := json.Marshal(msg.Content)
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	now := time.Now().UTC()
+	model := &models.Message{
+		ID:         msg.ID,
+		SessionID:  msg.SessionID,
+		ChatJID:    msg.ChatJID,
+		FromJID:    msg.From,
+		ToJID:      msg.To,
+		WhatsAppID: msg.WhatsAppID,
+		Direction:  msg.Direction.String(),
+		Type:       msg.Type.String(),
+		Status:     msg.GetStatus().String(),
+		Content:    string(contentJSON),
+		Timestamp:  msg.Timestamp.UTC(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	// The status is only set on insert; later changes go through UpdateStatus so a re-delivered copy
+	// cannot move a message back to an earlier status
+	updateColumns := []string{"from_jid", "to_jid", "type", "content", "updated_at"}
+	if msg.WhatsAppID != "" {
+		updateColumns = append(updateColumns, "whatsapp_id")
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Upsert so re-delivered messages (e.g. after reconnect) don't fail
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns(updateColumns),
+		}).Create(model).Error; err != nil {
+			return err
+		}
+
+		return addStatusHistory(tx, msg.ID, msg.GetStatus(), msg.Timestamp)
+	})
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a message by its ID
+func (r *MessageRepository) FindByID(ctx context.Context, id string) (*entity.Message, error) {
+	var model models.Message
+
+	result := r.db.WithContext(ctx).First(&model, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrMessageNotFound
+		}
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return toMessageEntity(model)
+}
+
+// FindByWhatsAppID retrieves a message of a session by the ID assigned on the WhatsApp network
+func (r *MessageRepository) FindByWhatsAppID(ctx context.Context, sessionID, whatsappID string) (*entity.Message, error) {
+	var model models.Message
+
+	result := r.db.WithContext(ctx).
+		Where("session_id = ? AND whatsapp_id = ?", sessionID, whatsappID).
+		First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrMessageNotFound
+		}
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return toMessageEntity(model)
+}
+
+// SetWhatsAppID records the WhatsApp network ID of a message
+func (r *MessageRepository) SetWhatsAppID(ctx context.Context, id, whatsappID string) error {
+	result := r.db.WithContext(ctx).Model(&models.Message{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"whatsapp_id": whatsappID,
+			"updated_at":  time.Now().UTC(),
+		})
+
+	if result.Error != nil {
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// UpdateContent replaces the content of a message (e.g. after an edit or revocation)
+func (r *MessageRepository) UpdateContent(ctx context.Context, id string, content entity.MessageContent) error {
+	contentJSON, err := json.Marshal(content)
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.Message{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"content":    string(contentJSON),
+			"updated_at": time.Now().UTC(),
+		})
+
+	if result.Error != nil {
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// UpdateStatus updates the delivery status of a message and records it in the status history
+func (r *MessageRepository) UpdateStatus(ctx context.Context, id string, status entity.MessageStatus, at time.Time) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Message{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"status":     status.String(),
+				"updated_at": time.Now().UTC(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return domainErrors.ErrMessageNotFound
+		}
+
+		return addStatusHistory(tx, id, status, at)
+	})
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrMessageNotFound) {
+			return domainErrors.ErrMessageNotFound
+		}
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return nil
+}
+
+// AddStatusHistory records a status in the history without changing the current status
+func (r *MessageRepository) AddStatusHistory(ctx context.Context, id string, status entity.MessageStatus, at time.Time) error {
+	if err := addStatusHistory(r.db.WithContext(ctx), id, status, at); err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+	return nil
+}
+
+// GetStatusHistory retrieves the status history of a message, oldest first
+func (r *MessageRepository) GetStatusHistory(ctx context.Context, id string) ([]entity.MessageStatusChange, error) {
+	var modelHistory []models.MessageStatusHistory
+
+	result := r.db.WithContext(ctx).
+		Where("message_id = ?", id).
+		Order("timestamp ASC").
+		Order("id ASC").
+		Find(&modelHistory)
+	if result.Error != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	history := make([]entity.MessageStatusChange, len(modelHistory))
+	for i, model := range modelHistory {
+		history[i] = entity.MessageStatusChange{
+			Status:    entity.MessageStatus(model.Status),
+			Timestamp: model.Timestamp,
+		}
+	}
+
+	return history, nil
+}
+
+// addStatusHistory records the first time a message reached a status; later duplicates are ignored
+func addStatusHistory(tx *gorm.DB, id string, status entity.MessageStatus, at time.Time) error {
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.MessageStatusHistory{
+		MessageID: id,
+		Status:    status.String(),
+		Timestamp: at.UTC(),
+	}).Error
+}
+
+// ListByChat retrieves messages for a chat, newest first, using keyset pagination
+func (r *MessageRepository) ListByChat(ctx context.Context, filter repository.MessageFilter) ([]*entity.Message, error) {
+	query := r.db.WithContext(ctx).Model(&models.Message{}).
+		Where("session_id = ? AND chat_jid = ?", filter.SessionID, filter.ChatJID)
+
+	// Apply cursor (strictly older than the last message of the previous page)
+	if filter.Before != nil {
+		before := filter.Before.UTC()
+		query = query.Where("(timestamp < ?) OR (timestamp = ? AND id < ?)", before, before, filter.BeforeID)
+	}
+
+	query = query.Order("timestamp DESC").Order("id DESC")
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var modelMessages []models.Message
+	result := query.Find(&modelMessages)
+	if result.Error != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	// Convert models to domain entities
+	messages := make([]*entity.Message, 0, len(modelMessages))
+	for _, model := range modelMessages {
+		msg, err := toMessageEntity(model)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// toMessageEntity converts a message model to a domain entity
+func toMessageEntity(model models.Message) (*entity.Message, error) {
+	var content entity.MessageContent
+	if model.Content != "" {
+		if err := json.Unmarshal([]byte(model.Content), &content); err != nil {
+			return nil, domainErrors.ErrDatabase.WithCause(err)
+		}
+	}
+
+	return entity.NewMessageBuilder(model.ID, model.SessionID).
+		InChat(model.ChatJID).
+		From(model.FromJID).
+		To(model.ToJID).
+		WithWhatsAppID(model.WhatsAppID).
+		WithDirection(entity.MessageDirection(model.Direction)).
+		WithContent(content).
+		WithType(entity.MessageType(model.Type)).
+		WithStatus(entity.MessageStatus(model.Status)).
+		WithTimestamp(model.Timestamp).
+		Build(), nil
+}
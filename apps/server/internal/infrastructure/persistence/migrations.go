@@ -21,9 +21,18 @@ func RunAutoMigration(db *gorm.DB, log *logger.Logger) error {
 		&models.Receipt{},
 		&models.Presence{},
 		&models.APIKey{},
+		&models.APIKeyUsage{},
 		&models.AuditLog{},
 		&models.Event{},
+		&models.EventSequence{},
 		&models.WebhookConfig{},
+		&models.WebhookEndpoint{},
+		&models.WebhookDelivery{},
+		&models.Message{},
+		&models.MessageStatusHistory{},
+		&models.OutboxMessage{},
+		&models.Group{},
+		&models.GroupParticipant{},
 	}
 
 	// Run auto-migration
@@ -46,9 +55,18 @@ func VerifySchema(db *gorm.DB, log *logger.Logger) error {
 		"receipts",
 		"presence",
 		"api_keys",
+		"api_key_usage",
 		"audit_logs",
 		"events",
+		"event_sequences",
 		"webhook_configs",
+		"webhook_endpoints",
+		"webhook_deliveries",
+		"messages",
+		"message_status_history",
+		"outbox_messages",
+		"groups",
+		"group_participants",
 	}
 
 	for _, table := range tables {
@@ -6,19 +6,39 @@ import (
 
 // APIKey represents an API key in the database
 type APIKey struct {
-	ID               string     `gorm:"column:id;primaryKey;type:text;not null"`
-	KeyHash          string     `gorm:"column:key_hash;type:text;not null;uniqueIndex:idx_api_keys_key_hash"`
-	Role             string     `gorm:"column:role;type:text;not null;check:role IN ('read', 'write', 'admin')"`
-	Description      *string    `gorm:"column:description;type:text"`
-	CreatedAt        time.Time  `gorm:"column:created_at;not null;index:idx_api_keys_created_at"`
-	LastUsedAt       *time.Time `gorm:"column:last_used_at;type:timestamp"`
-	IsActive         bool       `gorm:"column:is_active;not null;default:true;index:idx_api_keys_is_active"`
-	RevokedAt        *time.Time `gorm:"column:revoked_at;type:timestamp"`
-	RevokedBy        *string    `gorm:"column:revoked_by;type:text"`
-	RevocationReason *string    `gorm:"column:revocation_reason;type:text"`
+	ID                string     `gorm:"column:id;primaryKey;type:text;not null"`
+	KeyHash           string     `gorm:"column:key_hash;type:text;not null;uniqueIndex:idx_api_keys_key_hash"`
+	Role              string     `gorm:"column:role;type:text;not null;check:role IN ('read', 'write', 'admin')"`
+	AllowedSessions   string     `gorm:"column:allowed_sessions;type:text"` // JSON array of session IDs, empty for all sessions
+	Scopes            string     `gorm:"column:scopes;type:text"`           // JSON array of permission scopes, empty for unrestricted
+	Description       *string    `gorm:"column:description;type:text"`
+	CreatedAt         time.Time  `gorm:"column:created_at;not null;index:idx_api_keys_created_at"`
+	LastUsedAt        *time.Time `gorm:"column:last_used_at;type:timestamp"`
+	IsActive          bool       `gorm:"column:is_active;not null;default:true;index:idx_api_keys_is_active"`
+	RevokedAt         *time.Time `gorm:"column:revoked_at;type:timestamp"`
+	RevokedBy         *string    `gorm:"column:revoked_by;type:text"`
+	RevocationReason  *string    `gorm:"column:revocation_reason;type:text"`
+	ExpiresAt         *time.Time `gorm:"column:expires_at;type:timestamp"`
+	ReplacedBy        *string    `gorm:"column:replaced_by;type:text"`
+	DailyRequestQuota int        `gorm:"column:daily_request_quota;not null;default:0"`
+	DailyMessageQuota int        `gorm:"column:daily_message_quota;not null;default:0"`
 }
 
 // TableName specifies the table name for APIKey model
 func (APIKey) TableName() string {
 	return "api_keys"
 }
+
+// APIKeyUsage represents the daily usage counters of an API key in the database
+type APIKeyUsage struct {
+	APIKeyID  string    `gorm:"column:api_key_id;primaryKey;type:text;not null"`
+	Day       string    `gorm:"column:day;primaryKey;type:text;not null"`
+	Requests  int64     `gorm:"column:requests;not null;default:0"`
+	Messages  int64     `gorm:"column:messages;not null;default:0"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName specifies the table name for APIKeyUsage model
+func (APIKeyUsage) TableName() string {
+	return "api_key_usage"
+}
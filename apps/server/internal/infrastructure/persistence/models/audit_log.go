@@ -9,11 +9,11 @@ type AuditLog struct {
 	ID        string    `gorm:"column:id;primaryKey;type:text;not null"`
 	EventType string    `gorm:"column:event_type;type:text;not null;index:idx_audit_logs_event_type"`
 	APIKeyID  *string   `gorm:"column:api_key_id;type:text;index:idx_audit_logs_api_key_id"`
-	SessionID *string   `gorm:"column:session_id;type:text"`
+	SessionID *string   `gorm:"column:session_id;type:text;index:idx_audit_logs_session_id"`
 	Endpoint  *string   `gorm:"column:endpoint;type:text"`
 	Action    *string   `gorm:"column:action;type:text"`
 	Details   string    `gorm:"column:details;type:text"` // JSON
-	IPAddress *string   `gorm:"column:ip_address;type:text"`
+	IPAddress *string   `gorm:"column:ip_address;type:text;index:idx_audit_logs_ip_address"`
 	CreatedAt time.Time `gorm:"column:created_at;not null;index:idx_audit_logs_created_at"`
 }
 
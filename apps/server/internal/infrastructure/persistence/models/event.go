@@ -8,8 +8,9 @@ import (
 type Event struct {
 	ID        string    `gorm:"column:id;primaryKey;type:text;not null"`
 	Type      string    `gorm:"column:type;type:text;not null;index:idx_events_type"`
-	SessionID string    `gorm:"column:session_id;type:text;not null;index:idx_events_session_id"`
-	Data      []byte    `gorm:"column:data;type:bytea"` // JSON data stored as bytes (works with both SQLite and PostgreSQL)
+	SessionID string    `gorm:"column:session_id;type:text;not null;index:idx_events_session_id;uniqueIndex:idx_events_session_sequence,priority:1,where:sequence > 0"`
+	Sequence  int64     `gorm:"column:sequence;not null;default:0;uniqueIndex:idx_events_session_sequence,priority:2"` // 0 for events stored before numbering
+	Data      []byte    `gorm:"column:data;type:bytea"`                                                                // JSON data stored as bytes (works with both SQLite and PostgreSQL)
 	Timestamp time.Time `gorm:"column:timestamp;not null;index:idx_events_timestamp"`
 	CreatedAt time.Time `gorm:"column:created_at;not null"`
 }
@@ -31,3 +32,16 @@ func (Event) Indexes() []interface{} {
 		"CREATE INDEX IF NOT EXISTS idx_events_session_type ON events(session_id, type)",
 	}
 }
+
+// EventSequence holds the last sequence number given to an event of a session
+// It outlives the events themselves, so numbering never restarts after old events are cleaned up
+type EventSequence struct {
+	SessionID    string    `gorm:"column:session_id;primaryKey;type:text;not null"`
+	LastSequence int64     `gorm:"column:last_sequence;not null"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName specifies the table name for EventSequence model
+func (EventSequence) TableName() string {
+	return "event_sequences"
+}
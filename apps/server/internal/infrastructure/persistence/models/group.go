@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// Group represents the local copy of a WhatsApp group a session is a member of
+type Group struct {
+	ID             string     `gorm:"column:id;primaryKey;type:text;not null"`
+	SessionID      string     `gorm:"column:session_id;type:text;not null;uniqueIndex:idx_groups_session_jid,priority:1"`
+	JID            string     `gorm:"column:jid;type:text;not null;uniqueIndex:idx_groups_session_jid,priority:2"`
+	Name           string     `gorm:"column:name;type:text;not null;index:idx_groups_name"`
+	Description    *string    `gorm:"column:description;type:text"`
+	AvatarURL      *string    `gorm:"column:avatar_url;type:text"`
+	OwnerJID       *string    `gorm:"column:owner_jid;type:text"`
+	IsAnnounce     bool       `gorm:"column:is_announce;not null;default:false"`
+	IsLocked       bool       `gorm:"column:is_locked;not null;default:false"`
+	IsEphemeral    bool       `gorm:"column:is_ephemeral;not null;default:false"`
+	EphemeralTime  *int       `gorm:"column:ephemeral_time"`
+	IsAdmin        bool       `gorm:"column:is_admin;not null;default:false"`
+	IsArchived     bool       `gorm:"column:is_archived;not null;default:false"`
+	IsMuted        bool       `gorm:"column:is_muted;not null;default:false"`
+	MutedUntil     *time.Time `gorm:"column:muted_until;type:timestamp"`
+	MemberCount    int        `gorm:"column:member_count;not null;default:0"`
+	GroupCreatedAt *time.Time `gorm:"column:group_created_at;type:timestamp"`
+	LastSyncAt     *time.Time `gorm:"column:last_sync_at;type:timestamp"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;not null"`
+}
+
+// TableName specifies the table name for Group model
+func (Group) TableName() string {
+	return "groups"
+}
+
+// GroupParticipant represents a participant of a locally stored group
+type GroupParticipant struct {
+	ID          string    `gorm:"column:id;primaryKey;type:text;not null"`
+	GroupID     string    `gorm:"column:group_id;type:text;not null;uniqueIndex:idx_group_participants_group_jid,priority:1"`
+	JID         string    `gorm:"column:jid;type:text;not null;uniqueIndex:idx_group_participants_group_jid,priority:2"`
+	Role        string    `gorm:"column:role;type:text;not null;check:role IN ('member', 'admin', 'superadmin')"`
+	DisplayName *string   `gorm:"column:display_name;type:text"`
+	AddedBy     *string   `gorm:"column:added_by;type:text"`
+	JoinedAt    time.Time `gorm:"column:joined_at;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName specifies the table name for GroupParticipant model
+func (GroupParticipant) TableName() string {
+	return "group_participants"
+}
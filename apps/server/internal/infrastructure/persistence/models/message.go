@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// Message represents an inbound or outbound WhatsApp message in the database
+type Message struct {
+	ID         string    `gorm:"column:id;primaryKey;type:text;not null"`
+	SessionID  string    `gorm:"column:session_id;type:text;not null;index:idx_messages_chat_timestamp,priority:1"`
+	ChatJID    string    `gorm:"column:chat_jid;type:text;not null;index:idx_messages_chat_timestamp,priority:2"`
+	FromJID    string    `gorm:"column:from_jid;type:text"`
+	ToJID      string    `gorm:"column:to_jid;type:text"`
+	WhatsAppID string    `gorm:"column:whatsapp_id;type:text;index:idx_messages_whatsapp_id"`
+	Direction  string    `gorm:"column:direction;type:text;not null;check:direction IN ('inbound', 'outbound')"`
+	Type       string    `gorm:"column:type;type:text;not null"`
+	Status     string    `gorm:"column:status;type:text;not null;index:idx_messages_status"`
+	Content    string    `gorm:"column:content;type:text"` // JSON encoded MessageContent
+	Timestamp  time.Time `gorm:"column:timestamp;not null;index:idx_messages_chat_timestamp,priority:3"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName specifies the table name for Message model
+func (Message) TableName() string {
+	return "messages"
+}
+
+// MessageStatusHistory records when a message reached a delivery status
+type MessageStatusHistory struct {
+	ID        uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	MessageID string    `gorm:"column:message_id;type:text;not null;uniqueIndex:idx_message_status_history_message_status,priority:1"`
+	Status    string    `gorm:"column:status;type:text;not null;uniqueIndex:idx_message_status_history_message_status,priority:2"`
+	Timestamp time.Time `gorm:"column:timestamp;not null"`
+}
+
+// TableName specifies the table name for MessageStatusHistory model
+func (MessageStatusHistory) TableName() string {
+	return "message_status_history"
+}
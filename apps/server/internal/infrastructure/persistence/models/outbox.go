@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// OutboxMessage represents an outbound message queued for delivery in the database
+type OutboxMessage struct {
+	ID            string    `gorm:"column:id;primaryKey;type:text;not null"`
+	SessionID     string    `gorm:"column:session_id;type:text;not null;index:idx_outbox_session_id"`
+	ChatJID       string    `gorm:"column:chat_jid;type:text"`
+	ToJID         string    `gorm:"column:to_jid;type:text;not null"`
+	Type          string    `gorm:"column:type;type:text;not null"`
+	Content       string    `gorm:"column:content;type:text"` // JSON encoded MessageContent
+	Timestamp     time.Time `gorm:"column:timestamp;not null"`
+	Status        string    `gorm:"column:status;type:text;not null;index:idx_outbox_due,priority:1;check:status IN ('pending', 'in_flight', 'sent', 'failed')"`
+	Attempts      int       `gorm:"column:attempts;not null;default:0"`
+	NextAttemptAt time.Time `gorm:"column:next_attempt_at;not null;index:idx_outbox_due,priority:2"`
+	LastError     string    `gorm:"column:last_error;type:text"`
+	CreatedAt     time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt     time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName specifies the table name for OutboxMessage model
+func (OutboxMessage) TableName() string {
+	return "outbox_messages"
+}
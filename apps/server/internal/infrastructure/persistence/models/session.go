@@ -12,6 +12,18 @@ type Session struct {
 	Status    string    `gorm:"column:status;type:text;not null;check:status IN ('disconnected', 'connecting', 'connected', 'qr_pending', 'authenticating', 'authenticated', 'failed', 'pending', 'logged_out');index:idx_sessions_status"`
 	CreatedAt time.Time `gorm:"column:created_at;not null"`
 	UpdatedAt time.Time `gorm:"column:updated_at;not null"`
+
+	// Behaviour settings
+	AccountProtection bool  `gorm:"column:account_protection;not null;default:false"`
+	MessageLogging    *bool `gorm:"column:message_logging;not null;default:true"` // Pointer so false isn't replaced by the default
+	ReadMessages      bool  `gorm:"column:read_messages;not null;default:false"`
+	AutoRejectCalls   bool  `gorm:"column:auto_reject_calls;not null;default:false"`
+	AlwaysOnline      bool  `gorm:"column:always_online;not null;default:false"`
+	IgnoreGroups      bool  `gorm:"column:ignore_groups;not null;default:false"`
+	IgnoreBroadcasts  bool  `gorm:"column:ignore_broadcasts;not null;default:false"`
+	IgnoreChannels    bool  `gorm:"column:ignore_channels;not null;default:false"`
+
+	MediaRetentionDays int `gorm:"column:media_retention_days;not null;default:0"`
 }
 
 // TableName specifies the table name for Session model
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// WebhookDelivery represents an event queued for delivery to a webhook endpoint in the database
+type WebhookDelivery struct {
+	ID             string     `gorm:"column:id;primaryKey;type:text;not null"`
+	SessionID      string     `gorm:"column:session_id;type:text;not null;index:idx_webhook_deliveries_session_created,priority:1"`
+	EventID        string     `gorm:"column:event_id;type:text;not null"`
+	EventType      string     `gorm:"column:event_type;type:text;not null"`
+	EndpointID     string     `gorm:"column:endpoint_id;type:text;not null;default:''"` // Empty for the session's default webhook
+	URL            string     `gorm:"column:url;type:text;not null"`
+	Payload        string     `gorm:"column:payload;type:text;not null"` // JSON encoded event
+	Status         string     `gorm:"column:status;type:text;not null;index:idx_webhook_deliveries_due,priority:1;check:status IN ('pending', 'in_flight', 'delivered', 'dead_letter')"`
+	Attempts       int        `gorm:"column:attempts;not null;default:0"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at;not null;index:idx_webhook_deliveries_due,priority:2"`
+	LastStatusCode int        `gorm:"column:last_status_code;not null;default:0"`
+	LastResponse   string     `gorm:"column:last_response;type:text"` // Truncated response body
+	LastError      string     `gorm:"column:last_error;type:text"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null;index:idx_webhook_deliveries_session_created,priority:2"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;not null"`
+}
+
+// TableName specifies the table name for WebhookDelivery model
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
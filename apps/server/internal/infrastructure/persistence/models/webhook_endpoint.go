@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// WebhookEndpoint represents one of a session's webhook endpoints in the database
+type WebhookEndpoint struct {
+	ID        string `gorm:"column:id;primaryKey;type:text;not null"`
+	SessionID string `gorm:"column:session_id;type:text;not null;index:idx_webhook_endpoints_session_created,priority:1"`
+	Name      string `gorm:"column:name;type:text"`
+	Enabled   bool   `gorm:"column:enabled;not null;default:false"`
+	URL       string `gorm:"column:url;type:text;not null"`
+	Secret    string `gorm:"column:secret;type:text"`  // HMAC secret for signature verification
+	Events    string `gorm:"column:events;type:text"`  // JSON array of event types or wildcards to deliver
+	Headers   string `gorm:"column:headers;type:text"` // JSON object of extra request headers
+
+	// Message filtering options
+	IgnoreGroups     bool `gorm:"column:ignore_groups;not null;default:false"`
+	IgnoreBroadcasts bool `gorm:"column:ignore_broadcasts;not null;default:false"`
+	IgnoreChannels   bool `gorm:"column:ignore_channels;not null;default:false"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;index:idx_webhook_endpoints_session_created,priority:2"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName specifies the table name for WebhookEndpoint model
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
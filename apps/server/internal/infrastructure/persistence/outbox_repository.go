@@ -0,0 +1,187 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	domainErrors "whatspire/internal/domain/errors"
+	"whatspire/internal/infrastructure/persistence/models"
+
+	"gorm.io/gorm"
+)
+
+// OutboxRepository implements repository.OutboxRepository with GORM
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new GORM outbox repository
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Enqueue stores a new pending outbox entry
+func (r *OutboxRepository) Enqueue(ctx context.Context, entry *entity.OutboxEntry) error {
+	msg := entry.Message
+	contentJSON, err := json.Marshal(msg.Content)
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	model := &models.OutboxMessage{
+		ID:            msg.ID,
+		SessionID:     msg.SessionID,
+		ChatJID:       msg.ChatJID,
+		ToJID:         msg.To,
+		Type:          msg.Type.String(),
+		Content:       string(contentJSON),
+		Timestamp:     msg.Timestamp.UTC(),
+		Status:        entry.Status.String(),
+		Attempts:      entry.Attempts,
+		NextAttemptAt: entry.NextAttemptAt.UTC(),
+		LastError:     entry.LastError,
+		CreatedAt:     entry.CreatedAt.UTC(),
+		UpdatedAt:     entry.UpdatedAt.UTC(),
+	}
+
+	result := r.db.WithContext(ctx).Create(model)
+	if result.Error != nil {
+		if isUniqueConstraintError(result.Error) {
+			return domainErrors.ErrDuplicate.WithMessage("outbox entry already exists")
+		}
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return nil
+}
+
+// ClaimDue atomically marks up to limit due pending entries as in-flight and returns them
+// Entries are claimed by one caller only, even with several processes sharing the outbox
+func (r *OutboxRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*entity.OutboxEntry, error) {
+	var claimed []models.OutboxMessage
+	if err := claimDue(ctx, r.db, &claimed, entity.OutboxStatusPending.String(), entity.OutboxStatusInFlight.String(), now, limit); err != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	sort.Slice(claimed, func(i, j int) bool {
+		if !claimed[i].NextAttemptAt.Equal(claimed[j].NextAttemptAt) {
+			return claimed[i].NextAttemptAt.Before(claimed[j].NextAttemptAt)
+		}
+		return claimed[i].CreatedAt.Before(claimed[j].CreatedAt)
+	})
+
+	// Convert models to domain entities
+	entries := make([]*entity.OutboxEntry, 0, len(claimed))
+	for _, model := range claimed {
+		entry, err := toOutboxEntity(model)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// MarkSent marks an entry as sent
+func (r *OutboxRepository) MarkSent(ctx context.Context, id string) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"status":     entity.OutboxStatusSent.String(),
+		"last_error": "",
+	})
+}
+
+// MarkFailed marks an entry as permanently failed
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id string, attempts int, lastErr string) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"status":     entity.OutboxStatusFailed.String(),
+		"attempts":   attempts,
+		"last_error": lastErr,
+	})
+}
+
+// Reschedule returns an entry to pending with an updated attempt counter and next attempt time
+func (r *OutboxRepository) Reschedule(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, lastErr string) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"status":          entity.OutboxStatusPending.String(),
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt.UTC(),
+		"last_error":      lastErr,
+	})
+}
+
+// ResetInFlight returns entries claimed before claimedBefore that are still in-flight to pending
+func (r *OutboxRepository) ResetInFlight(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	reset, err := resetStaleClaims(ctx, r.db, &models.OutboxMessage{},
+		entity.OutboxStatusPending.String(), entity.OutboxStatusInFlight.String(), claimedBefore)
+	if err != nil {
+		return 0, domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return reset, nil
+}
+
+// CountPending returns the number of entries still waiting to be sent
+func (r *OutboxRepository) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+
+	result := r.db.WithContext(ctx).Model(&models.OutboxMessage{}).
+		Where("status IN ?", []string{entity.OutboxStatusPending.String(), entity.OutboxStatusInFlight.String()}).
+		Count(&count)
+
+	if result.Error != nil {
+		return 0, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return count, nil
+}
+
+// update applies column updates to a single outbox entry
+func (r *OutboxRepository) update(ctx context.Context, id string, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+
+	result := r.db.WithContext(ctx).Model(&models.OutboxMessage{}).
+		Where("id = ?", id).
+		Updates(updates)
+
+	if result.Error != nil {
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrNotFound.WithMessage("outbox entry not found")
+	}
+
+	return nil
+}
+
+// toOutboxEntity converts an outbox model to a domain entity
+func toOutboxEntity(model models.OutboxMessage) (*entity.OutboxEntry, error) {
+	var content entity.MessageContent
+	if model.Content != "" {
+		if err := json.Unmarshal([]byte(model.Content), &content); err != nil {
+			return nil, domainErrors.ErrDatabase.WithCause(err)
+		}
+	}
+
+	msg := entity.NewMessageBuilder(model.ID, model.SessionID).
+		InChat(model.ChatJID).
+		To(model.ToJID).
+		WithContent(content).
+		WithType(entity.MessageType(model.Type)).
+		WithTimestamp(model.Timestamp).
+		Build()
+
+	return &entity.OutboxEntry{
+		Message:       msg,
+		Status:        entity.OutboxStatus(model.Status),
+		Attempts:      model.Attempts,
+		NextAttemptAt: model.NextAttemptAt,
+		LastError:     model.LastError,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+	}, nil
+}
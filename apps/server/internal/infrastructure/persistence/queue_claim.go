@@ -0,0 +1,64 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// claimDue moves up to limit pending rows of a queue table that are due at or before now to in-flight,
+// oldest due first, and loads the rows it moved into dest, a pointer to a slice of the table's model
+// A single UPDATE ... RETURNING matches only rows that are still pending when they are written,
+// so concurrent claimers, in this process or another, never receive the same row
+// The rows are returned in no particular order
+func claimDue(ctx context.Context, db *gorm.DB, dest any, pending, inFlight string, now time.Time, limit int) error {
+	due := db.Model(dest).Select("id").
+		Where("status = ? AND next_attempt_at <= ?", pending, now.UTC()).
+		Order("next_attempt_at ASC").
+		Order("created_at ASC")
+	if limit > 0 {
+		due = due.Limit(limit)
+	}
+	if db.Name() == "postgres" {
+		// Pass over rows another claimer is moving instead of waiting for its transaction
+		due = due.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+	}
+
+	return db.WithContext(ctx).Model(dest).
+		Clauses(clause.Returning{}).
+		Where("id IN (?) AND status = ?", due, pending).
+		Updates(map[string]interface{}{
+			"status":     inFlight,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+// resetStaleClaims returns rows of a queue table that were claimed before claimedBefore and are still
+// in-flight to pending
+// Claims that old belong to a claimer that stopped or crashed, so this is safe while other claimers run
+func resetStaleClaims(ctx context.Context, db *gorm.DB, model any, pending, inFlight string, claimedBefore time.Time) (int64, error) {
+	result := db.WithContext(ctx).Model(model).
+		Where("status = ? AND updated_at < ?", inFlight, claimedBefore.UTC()).
+		Updates(map[string]interface{}{
+			"status":     pending,
+			"updated_at": time.Now().UTC(),
+		})
+
+	return result.RowsAffected, result.Error
+}
+
+// releaseClaims returns claimed rows of a queue table that were not attempted to pending
+func releaseClaims(ctx context.Context, db *gorm.DB, model any, ids []string, pending, inFlight string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return db.WithContext(ctx).Model(model).
+		Where("id IN ? AND status = ?", ids, inFlight).
+		Updates(map[string]interface{}{
+			"status":     pending,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
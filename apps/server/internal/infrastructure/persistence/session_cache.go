@@ -0,0 +1,63 @@
+package persistence
+
+import (
+	"sync"
+	"time"
+)
+
+// sessionCacheEntry is a cached value with its expiry
+type sessionCacheEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// sessionCache caches a value per session ID for repositories that are read far more often than written
+// Entries expire after the TTL as a safety net against writes made outside the repository
+type sessionCache[T any] struct {
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[string]sessionCacheEntry[T]
+	version uint64 // Incremented on every invalidation
+}
+
+// newSessionCache creates an empty session cache
+func newSessionCache[T any](ttl time.Duration) *sessionCache[T] {
+	return &sessionCache[T]{
+		ttl:     ttl,
+		entries: make(map[string]sessionCacheEntry[T]),
+	}
+}
+
+// get returns the session's cached value, calling load on a miss or after expiry
+func (c *sessionCache[T]) get(sessionID string, load func() (T, error)) (T, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[sessionID]
+	version := c.version
+	c.mu.RUnlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	// Don't cache a load that raced with a write, it may already be stale
+	if c.version == version {
+		c.entries[sessionID] = sessionCacheEntry[T]{value: value, expiresAt: time.Now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+
+	return value, nil
+}
+
+// invalidate drops the session's cached value
+func (c *sessionCache[T]) invalidate(sessionID string) {
+	c.mu.Lock()
+	delete(c.entries, sessionID)
+	c.version++
+	c.mu.Unlock()
+}
@@ -33,6 +33,7 @@ func (r *SessionRepository) Create(ctx context.Context, session *entity.Session)
 		CreatedAt: session.CreatedAt,
 		UpdatedAt: session.UpdatedAt,
 	}
+	applySettingsToModel(model, session.Settings)
 
 	result := r.db.WithContext(ctx).Create(model)
 	if result.Error != nil {
@@ -57,17 +58,7 @@ func (r *SessionRepository) GetByID(ctx context.Context, id string) (*entity.Ses
 		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
 	}
 
-	// Convert model to domain entity
-	session := &entity.Session{
-		ID:        model.ID,
-		Name:      model.Name,
-		JID:       model.JID,
-		CreatedAt: model.CreatedAt,
-		UpdatedAt: model.UpdatedAt,
-	}
-	session.SetStatus(entity.Status(model.Status))
-
-	return session, nil
+	return toSessionEntity(model), nil
 }
 
 // GetAll retrieves all sessions
@@ -82,15 +73,7 @@ func (r *SessionRepository) GetAll(ctx context.Context) ([]*entity.Session, erro
 	// Convert models to domain entities
 	sessions := make([]*entity.Session, 0, len(models))
 	for _, model := range models {
-		session := &entity.Session{
-			ID:        model.ID,
-			Name:      model.Name,
-			JID:       model.JID,
-			CreatedAt: model.CreatedAt,
-			UpdatedAt: model.UpdatedAt,
-		}
-		session.SetStatus(entity.Status(model.Status))
-		sessions = append(sessions, session)
+		sessions = append(sessions, toSessionEntity(model))
 	}
 
 	return sessions, nil
@@ -103,6 +86,17 @@ func (r *SessionRepository) Update(ctx context.Context, session *entity.Session)
 		"jid":        session.JID,
 		"status":     session.Status.String(),
 		"updated_at": time.Now(),
+
+		"account_protection": session.Settings.AccountProtection,
+		"message_logging":    session.Settings.MessageLogging,
+		"read_messages":      session.Settings.ReadMessages,
+		"auto_reject_calls":  session.Settings.AutoRejectCalls,
+		"always_online":      session.Settings.AlwaysOnline,
+		"ignore_groups":      session.Settings.IgnoreGroups,
+		"ignore_broadcasts":  session.Settings.IgnoreBroadcasts,
+		"ignore_channels":    session.Settings.IgnoreChannels,
+
+		"media_retention_days": session.Settings.MediaRetentionDays,
 	}
 
 	result := r.db.WithContext(ctx).Model(&models.Session{}).
@@ -157,6 +151,45 @@ func (r *SessionRepository) UpdateStatus(ctx context.Context, id string, status
 	return nil
 }
 
+// toSessionEntity converts a session model to a domain entity
+func toSessionEntity(model models.Session) *entity.Session {
+	session := &entity.Session{
+		ID:        model.ID,
+		Name:      model.Name,
+		JID:       model.JID,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+		Settings: entity.SessionSettings{
+			AccountProtection: model.AccountProtection,
+			MessageLogging:    model.MessageLogging == nil || *model.MessageLogging,
+			ReadMessages:      model.ReadMessages,
+			AutoRejectCalls:   model.AutoRejectCalls,
+			AlwaysOnline:      model.AlwaysOnline,
+			IgnoreGroups:      model.IgnoreGroups,
+			IgnoreBroadcasts:  model.IgnoreBroadcasts,
+			IgnoreChannels:    model.IgnoreChannels,
+
+			MediaRetentionDays: model.MediaRetentionDays,
+		},
+	}
+	session.SetStatus(entity.Status(model.Status))
+
+	return session
+}
+
+// applySettingsToModel copies session settings onto a session model
+func applySettingsToModel(model *models.Session, settings entity.SessionSettings) {
+	model.AccountProtection = settings.AccountProtection
+	model.MessageLogging = &settings.MessageLogging
+	model.ReadMessages = settings.ReadMessages
+	model.AutoRejectCalls = settings.AutoRejectCalls
+	model.AlwaysOnline = settings.AlwaysOnline
+	model.IgnoreGroups = settings.IgnoreGroups
+	model.IgnoreBroadcasts = settings.IgnoreBroadcasts
+	model.IgnoreChannels = settings.IgnoreChannels
+	model.MediaRetentionDays = settings.MediaRetentionDays
+}
+
 // isUniqueConstraintError checks if the error is a SQLite unique constraint violation
 func isUniqueConstraintError(err error) bool {
 	if err == nil {
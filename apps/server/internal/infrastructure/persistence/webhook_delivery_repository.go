@@ -0,0 +1,247 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	domainErrors "whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/persistence/models"
+
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryRepository implements repository.WebhookDeliveryRepository with GORM
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository creates a new GORM webhook delivery repository
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+// Enqueue stores a new pending delivery
+func (r *WebhookDeliveryRepository) Enqueue(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	model := &models.WebhookDelivery{
+		ID:             delivery.ID,
+		SessionID:      delivery.SessionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType.String(),
+		EndpointID:     delivery.EndpointID,
+		URL:            delivery.URL,
+		Payload:        string(delivery.Payload),
+		Status:         delivery.Status.String(),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt.UTC(),
+		LastStatusCode: delivery.LastStatusCode,
+		LastResponse:   delivery.LastResponse,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt.UTC(),
+		UpdatedAt:      delivery.UpdatedAt.UTC(),
+	}
+
+	result := r.db.WithContext(ctx).Create(model)
+	if result.Error != nil {
+		if isUniqueConstraintError(result.Error) {
+			return domainErrors.ErrDuplicate.WithMessage("webhook delivery already exists")
+		}
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return nil
+}
+
+// ClaimDue atomically marks up to limit due pending deliveries as in-flight and returns them
+// Deliveries are claimed by one caller only, even with several processes sharing the queue
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	var claimed []models.WebhookDelivery
+	if err := claimDue(ctx, r.db, &claimed, entity.WebhookDeliveryStatusPending.String(), entity.WebhookDeliveryStatusInFlight.String(), now, limit); err != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	sort.Slice(claimed, func(i, j int) bool {
+		if !claimed[i].NextAttemptAt.Equal(claimed[j].NextAttemptAt) {
+			return claimed[i].NextAttemptAt.Before(claimed[j].NextAttemptAt)
+		}
+		return claimed[i].CreatedAt.Before(claimed[j].CreatedAt)
+	})
+
+	deliveries := make([]*entity.WebhookDelivery, len(claimed))
+	for i, model := range claimed {
+		deliveries[i] = toWebhookDeliveryEntity(model)
+	}
+
+	return deliveries, nil
+}
+
+// Release returns claimed deliveries that were not attempted to pending
+func (r *WebhookDeliveryRepository) Release(ctx context.Context, ids []string) error {
+	if err := releaseClaims(ctx, r.db, &models.WebhookDelivery{}, ids,
+		entity.WebhookDeliveryStatusPending.String(), entity.WebhookDeliveryStatusInFlight.String()); err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return nil
+}
+
+// RecordAttempt stores the outcome of a delivery attempt and the URL it was made to
+func (r *WebhookDeliveryRepository) RecordAttempt(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	result := r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"url":              delivery.URL,
+			"status":           delivery.Status.String(),
+			"attempts":         delivery.Attempts,
+			"next_attempt_at":  delivery.NextAttemptAt.UTC(),
+			"last_status_code": delivery.LastStatusCode,
+			"last_response":    delivery.LastResponse,
+			"last_error":       delivery.LastError,
+			"delivered_at":     delivery.DeliveredAt,
+			"updated_at":       time.Now().UTC(),
+		})
+
+	if result.Error != nil {
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrNotFound.WithMessage("webhook delivery not found")
+	}
+
+	return nil
+}
+
+// Requeue returns a delivery that is not in flight to pending with a fresh retry schedule
+func (r *WebhookDeliveryRepository) Requeue(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	result := r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status <> ?", delivery.ID, entity.WebhookDeliveryStatusInFlight.String()).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status.String(),
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt.UTC(),
+			"updated_at":      time.Now().UTC(),
+		})
+
+	if result.Error != nil {
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		// Distinguish a delivery claimed by the worker from one that does not exist
+		if _, err := r.GetByID(ctx, delivery.ID); err != nil {
+			return err
+		}
+		return domainErrors.ErrWebhookDeliveryInFlight
+	}
+
+	return nil
+}
+
+// ResetInFlight returns deliveries claimed before claimedBefore that are still in-flight to pending
+func (r *WebhookDeliveryRepository) ResetInFlight(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	reset, err := resetStaleClaims(ctx, r.db, &models.WebhookDelivery{},
+		entity.WebhookDeliveryStatusPending.String(), entity.WebhookDeliveryStatusInFlight.String(), claimedBefore)
+	if err != nil {
+		return 0, domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return reset, nil
+}
+
+// GetByID retrieves a delivery by its ID
+func (r *WebhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	var model models.WebhookDelivery
+
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound.WithMessage("webhook delivery not found")
+		}
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return toWebhookDeliveryEntity(model), nil
+}
+
+// List retrieves deliveries matching the filter, newest first
+func (r *WebhookDeliveryRepository) List(ctx context.Context, filter repository.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	query := r.filterQuery(ctx, filter).
+		Order("created_at DESC").
+		Order("id DESC")
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var modelDeliveries []models.WebhookDelivery
+	if result := query.Find(&modelDeliveries); result.Error != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	deliveries := make([]*entity.WebhookDelivery, len(modelDeliveries))
+	for i, model := range modelDeliveries {
+		deliveries[i] = toWebhookDeliveryEntity(model)
+	}
+
+	return deliveries, nil
+}
+
+// Count returns the number of deliveries matching the filter, ignoring pagination
+func (r *WebhookDeliveryRepository) Count(ctx context.Context, filter repository.WebhookDeliveryFilter) (int64, error) {
+	var count int64
+	if result := r.filterQuery(ctx, filter).Count(&count); result.Error != nil {
+		return 0, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return count, nil
+}
+
+// filterQuery builds a query applying the filter's conditions
+func (r *WebhookDeliveryRepository) filterQuery(ctx context.Context, filter repository.WebhookDeliveryFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.WebhookDelivery{})
+
+	if filter.SessionID != nil {
+		query = query.Where("session_id = ?", *filter.SessionID)
+	}
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", filter.Status.String())
+	}
+
+	if filter.EndpointID != nil {
+		query = query.Where("endpoint_id = ?", *filter.EndpointID)
+	}
+
+	return query
+}
+
+// toWebhookDeliveryEntity converts a webhook delivery model to a domain entity
+func toWebhookDeliveryEntity(model models.WebhookDelivery) *entity.WebhookDelivery {
+	return &entity.WebhookDelivery{
+		ID:             model.ID,
+		SessionID:      model.SessionID,
+		EventID:        model.EventID,
+		EventType:      entity.EventType(model.EventType),
+		EndpointID:     model.EndpointID,
+		URL:            model.URL,
+		Payload:        []byte(model.Payload),
+		Status:         entity.WebhookDeliveryStatus(model.Status),
+		Attempts:       model.Attempts,
+		NextAttemptAt:  model.NextAttemptAt,
+		LastStatusCode: model.LastStatusCode,
+		LastResponse:   model.LastResponse,
+		LastError:      model.LastError,
+		DeliveredAt:    model.DeliveredAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
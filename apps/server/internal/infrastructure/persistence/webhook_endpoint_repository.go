@@ -0,0 +1,203 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	domainErrors "whatspire/internal/domain/errors"
+	"whatspire/internal/infrastructure/persistence/models"
+
+	"gorm.io/gorm"
+)
+
+// WebhookEndpointRepository implements repository.WebhookEndpointRepository with GORM
+type WebhookEndpointRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookEndpointRepository creates a new GORM webhook endpoint repository
+func NewWebhookEndpointRepository(db *gorm.DB) *WebhookEndpointRepository {
+	return &WebhookEndpointRepository{db: db}
+}
+
+// Create creates a new webhook endpoint
+func (r *WebhookEndpointRepository) Create(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	model, err := toWebhookEndpointModel(endpoint)
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	result := r.db.WithContext(ctx).Create(model)
+	if result.Error != nil {
+		if isUniqueConstraintError(result.Error) {
+			return domainErrors.ErrDuplicate.WithMessage("webhook endpoint already exists")
+		}
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	return nil
+}
+
+// GetByID retrieves one of a session's webhook endpoints
+func (r *WebhookEndpointRepository) GetByID(ctx context.Context, sessionID, id string) (*entity.WebhookEndpoint, error) {
+	var model models.WebhookEndpoint
+
+	result := r.db.WithContext(ctx).Where("id = ? AND session_id = ?", id, sessionID).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound.WithMessage("webhook endpoint not found")
+		}
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	endpoint, err := toWebhookEndpointEntity(model)
+	if err != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	return endpoint, nil
+}
+
+// ListBySessionID retrieves a session's webhook endpoints, oldest first
+func (r *WebhookEndpointRepository) ListBySessionID(ctx context.Context, sessionID string) ([]*entity.WebhookEndpoint, error) {
+	var modelEndpoints []models.WebhookEndpoint
+
+	result := r.db.WithContext(ctx).
+		Where("session_id = ?", sessionID).
+		Order("created_at ASC").
+		Order("id ASC").
+		Find(&modelEndpoints)
+	if result.Error != nil {
+		return nil, domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	endpoints := make([]*entity.WebhookEndpoint, len(modelEndpoints))
+	for i, model := range modelEndpoints {
+		endpoint, err := toWebhookEndpointEntity(model)
+		if err != nil {
+			return nil, domainErrors.ErrDatabase.WithCause(err)
+		}
+		endpoints[i] = endpoint
+	}
+
+	return endpoints, nil
+}
+
+// Update updates an existing webhook endpoint
+func (r *WebhookEndpointRepository) Update(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	model, err := toWebhookEndpointModel(endpoint)
+	if err != nil {
+		return domainErrors.ErrDatabase.WithCause(err)
+	}
+
+	updates := map[string]interface{}{
+		"name":              model.Name,
+		"enabled":           model.Enabled,
+		"url":               model.URL,
+		"secret":            model.Secret,
+		"events":            model.Events,
+		"headers":           model.Headers,
+		"ignore_groups":     model.IgnoreGroups,
+		"ignore_broadcasts": model.IgnoreBroadcasts,
+		"ignore_channels":   model.IgnoreChannels,
+		"updated_at":        time.Now(),
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.WebhookEndpoint{}).
+		Where("id = ? AND session_id = ?", endpoint.ID, endpoint.SessionID).
+		Updates(updates)
+
+	if result.Error != nil {
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrNotFound.WithMessage("webhook endpoint not found")
+	}
+
+	return nil
+}
+
+// Delete removes one of a session's webhook endpoints
+func (r *WebhookEndpointRepository) Delete(ctx context.Context, sessionID, id string) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND session_id = ?", id, sessionID).
+		Delete(&models.WebhookEndpoint{})
+
+	if result.Error != nil {
+		return domainErrors.ErrDatabase.WithCause(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrNotFound.WithMessage("webhook endpoint not found")
+	}
+
+	return nil
+}
+
+// toWebhookEndpointModel converts a webhook endpoint entity to a database model
+func toWebhookEndpointModel(endpoint *entity.WebhookEndpoint) (*models.WebhookEndpoint, error) {
+	eventsJSON, err := json.Marshal(endpoint.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	var headersJSON []byte
+	if len(endpoint.Headers) > 0 {
+		if headersJSON, err = json.Marshal(endpoint.Headers); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.WebhookEndpoint{
+		ID:               endpoint.ID,
+		SessionID:        endpoint.SessionID,
+		Name:             endpoint.Name,
+		Enabled:          endpoint.Enabled,
+		URL:              endpoint.URL,
+		Secret:           endpoint.Secret,
+		Events:           string(eventsJSON),
+		Headers:          string(headersJSON),
+		IgnoreGroups:     endpoint.IgnoreGroups,
+		IgnoreBroadcasts: endpoint.IgnoreBroadcasts,
+		IgnoreChannels:   endpoint.IgnoreChannels,
+		CreatedAt:        endpoint.CreatedAt,
+		UpdatedAt:        endpoint.UpdatedAt,
+	}, nil
+}
+
+// toWebhookEndpointEntity converts a webhook endpoint model to a domain entity
+func toWebhookEndpointEntity(model models.WebhookEndpoint) (*entity.WebhookEndpoint, error) {
+	events := []string{}
+	if model.Events != "" {
+		if err := json.Unmarshal([]byte(model.Events), &events); err != nil {
+			return nil, err
+		}
+	}
+
+	var headers map[string]string
+	if model.Headers != "" {
+		if err := json.Unmarshal([]byte(model.Headers), &headers); err != nil {
+			return nil, err
+		}
+	}
+
+	return &entity.WebhookEndpoint{
+		ID:               model.ID,
+		SessionID:        model.SessionID,
+		Name:             model.Name,
+		Enabled:          model.Enabled,
+		URL:              model.URL,
+		Secret:           model.Secret,
+		Events:           events,
+		Headers:          headers,
+		IgnoreGroups:     model.IgnoreGroups,
+		IgnoreBroadcasts: model.IgnoreBroadcasts,
+		IgnoreChannels:   model.IgnoreChannels,
+		CreatedAt:        model.CreatedAt,
+		UpdatedAt:        model.UpdatedAt,
+	}, nil
+}
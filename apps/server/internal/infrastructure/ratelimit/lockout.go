@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// LockoutConfig holds the configuration for locking out clients after repeated failures.
+type LockoutConfig struct {
+	Threshold int           // Failures within Window that trigger a lockout
+	Window    time.Duration // Period failures are counted over
+	Duration  time.Duration // How long a client stays locked out
+}
+
+// lockoutEntry tracks the recent failures of a single client.
+type lockoutEntry struct {
+	failures    int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+// AuthLockout counts failures per client key and locks clients out once they reach the threshold.
+type AuthLockout struct {
+	config    LockoutConfig
+	mu        sync.Mutex
+	entries   map[string]*lockoutEntry
+	lastSweep time.Time
+}
+
+// NewAuthLockout creates a new lockout tracker with the given configuration.
+func NewAuthLockout(config LockoutConfig) *AuthLockout {
+	return &AuthLockout{
+		config:    config,
+		entries:   make(map[string]*lockoutEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// LockedUntil reports whether the client is locked out and until when.
+func (l *AuthLockout) LockedUntil(key string) (time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[key]
+	if !ok || !time.Now().Before(entry.lockedUntil) {
+		return time.Time{}, false
+	}
+	return entry.lockedUntil, true
+}
+
+// RecordFailure counts a failure of the client.
+// It returns true, with the end of the lockout, only for the failure that starts a lockout.
+func (l *AuthLockout) RecordFailure(key string) (time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	entry, ok := l.entries[key]
+	if !ok || now.Sub(entry.windowStart) > l.config.Window {
+		entry = &lockoutEntry{windowStart: now}
+		l.entries[key] = entry
+	}
+
+	entry.failures++
+	if entry.failures < l.config.Threshold {
+		return time.Time{}, false
+	}
+
+	// Start counting afresh once the lockout ends
+	entry.lockedUntil = now.Add(l.config.Duration)
+	entry.failures = 0
+	entry.windowStart = entry.lockedUntil
+	return entry.lockedUntil, true
+}
+
+// Reset clears the recorded failures of the client.
+func (l *AuthLockout) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if entry, ok := l.entries[key]; ok && !time.Now().Before(entry.lockedUntil) {
+		delete(l.entries, key)
+	}
+}
+
+// sweep removes clients whose failures and lockout have both expired.
+// It runs at most once per window so failure recording stays cheap.
+func (l *AuthLockout) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.config.Window {
+		return
+	}
+	l.lastSweep = now
+
+	for key, entry := range l.entries {
+		if now.Sub(entry.windowStart) > l.config.Window && !now.Before(entry.lockedUntil) {
+			delete(l.entries, key)
+		}
+	}
+}
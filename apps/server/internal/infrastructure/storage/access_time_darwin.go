@@ -0,0 +1,15 @@
+package storage
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileAccessTime returns when a file was last accessed
+func fileAccessTime(info os.FileInfo) time.Time {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return time.Unix(stat.Atimespec.Unix())
+	}
+	return info.ModTime()
+}
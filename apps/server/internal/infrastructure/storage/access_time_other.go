@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package storage
+
+import (
+	"os"
+	"time"
+)
+
+// fileAccessTime returns when a file was last accessed
+// Access times are not read on this platform, so media is evicted oldest first
+func fileAccessTime(info os.FileInfo) time.Time {
+	return info.ModTime()
+}
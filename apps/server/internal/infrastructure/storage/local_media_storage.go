@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"mime"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"whatspire/internal/domain/errors"
 	"whatspire/internal/domain/repository"
@@ -15,6 +18,7 @@ import (
 // LocalMediaStorage implements MediaStorage using local filesystem
 type LocalMediaStorage struct {
 	config repository.MediaStorageConfig
+	signer repository.MediaURLSigner
 }
 
 // NewLocalMediaStorage creates a new local media storage instance
@@ -32,7 +36,7 @@ func NewLocalMediaStorage(config repository.MediaStorageConfig) (*LocalMediaStor
 // DownloadAndStore downloads media from WhatsApp and stores it locally
 func (s *LocalMediaStorage) DownloadAndStore(ctx context.Context, sessionID, messageID string, mediaData io.Reader, mimeType, extension string) (string, string, error) {
 	// Generate unique filename
-	filename := s.generateFilename(sessionID, messageID, extension)
+	filename := mediaFilename(messageID, extension)
 
 	// Create session directory if it doesn't exist
 	sessionDir := filepath.Join(s.config.BasePath, sessionID)
@@ -74,11 +78,22 @@ func (s *LocalMediaStorage) DownloadAndStore(ctx context.Context, sessionID, mes
 	return relativePath, publicURL, nil
 }
 
+// SetURLSigner sets the signer used to add expiring access tokens to media URLs
+func (s *LocalMediaStorage) SetURLSigner(signer repository.MediaURLSigner) {
+	s.signer = signer
+}
+
 // GetMediaURL returns the public URL for accessing stored media
+// When a URL signer is set, the URL carries a token granting access until it expires
 func (s *LocalMediaStorage) GetMediaURL(filePath string) string {
 	// Normalize path separators for URLs
 	urlPath := strings.ReplaceAll(filePath, "\\", "/")
-	return fmt.Sprintf("%s/%s", strings.TrimSuffix(s.config.BaseURL, "/"), urlPath)
+	mediaURL := fmt.Sprintf("%s/%s", strings.TrimSuffix(s.config.BaseURL, "/"), urlPath)
+
+	if s.signer != nil {
+		mediaURL += "?" + s.signer.Sign(filePath)
+	}
+	return mediaURL
 }
 
 // DeleteMedia removes a media file from storage
@@ -95,6 +110,83 @@ func (s *LocalMediaStorage) DeleteMedia(ctx context.Context, filePath string) er
 	return nil
 }
 
+// OpenMedia opens a stored media file for reading
+func (s *LocalMediaStorage) OpenMedia(ctx context.Context, filePath string) (*repository.MediaFile, error) {
+	cleanPath, ok := cleanMediaPath(filePath)
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+
+	file, err := os.Open(filepath.Join(s.config.BasePath, filepath.FromSlash(cleanPath)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to open media file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to stat media file: %w", err)
+	}
+	if info.IsDir() {
+		_ = file.Close()
+		return nil, errors.ErrNotFound
+	}
+
+	// Record the access for least recently used eviction, keeping the modification time the ETag is built from
+	// Setting it explicitly works on filesystems mounted with noatime
+	_ = os.Chtimes(file.Name(), time.Now(), info.ModTime())
+
+	return &repository.MediaFile{
+		Content:     file,
+		ContentType: mime.TypeByExtension(filepath.Ext(cleanPath)),
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+		ETag:        fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()),
+	}, nil
+}
+
+// ListMedia returns every media file under the base path
+func (s *LocalMediaStorage) ListMedia(ctx context.Context) ([]repository.StoredMedia, error) {
+	var media []repository.StoredMedia
+
+	err := filepath.WalkDir(s.config.BasePath, func(fullPath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Deleted while walking
+				return nil
+			}
+			return err
+		}
+
+		relativePath, err := filepath.Rel(s.config.BasePath, fullPath)
+		if err != nil {
+			return err
+		}
+
+		media = append(media, storedMedia(relativePath, info.Size(), info.ModTime(), fileAccessTime(info)))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media files: %w", err)
+	}
+
+	return media, nil
+}
+
 // GetMediaPath returns the full local path for a media file
 func (s *LocalMediaStorage) GetMediaPath(filePath string) string {
 	return filepath.Join(s.config.BasePath, filePath)
@@ -110,8 +202,8 @@ func (s *LocalMediaStorage) ValidateSize(size int64) error {
 	return nil
 }
 
-// generateFilename generates a unique filename for media
-func (s *LocalMediaStorage) generateFilename(sessionID, messageID, extension string) string {
+// mediaFilename generates a unique filename for media
+func mediaFilename(messageID, extension string) string {
 	// Ensure extension starts with a dot
 	if extension != "" && !strings.HasPrefix(extension, ".") {
 		extension = "." + extension
@@ -121,6 +213,43 @@ func (s *LocalMediaStorage) generateFilename(sessionID, messageID, extension str
 	return fmt.Sprintf("%s%s", messageID, extension)
 }
 
+// storedMedia describes a stored media file, reading the session and message from its path
+func storedMedia(relativePath string, size int64, modTime, lastAccessed time.Time) repository.StoredMedia {
+	relativePath = normalizeMediaPath(relativePath)
+
+	sessionID, filename, ok := strings.Cut(relativePath, "/")
+	if !ok || strings.Contains(filename, "/") {
+		// Not stored by DownloadAndStore, so it belongs to no session
+		sessionID, filename = "", path.Base(relativePath)
+	}
+
+	if lastAccessed.Before(modTime) {
+		lastAccessed = modTime
+	}
+
+	return repository.StoredMedia{
+		Path:         relativePath,
+		SessionID:    sessionID,
+		MessageID:    strings.TrimSuffix(filename, path.Ext(filename)),
+		Size:         size,
+		ModTime:      modTime,
+		LastAccessed: lastAccessed,
+	}
+}
+
+// normalizeMediaPath returns a media path relative to the storage root with forward slashes
+func normalizeMediaPath(filePath string) string {
+	return strings.TrimPrefix(strings.ReplaceAll(filePath, "\\", "/"), "/")
+}
+
+// cleanMediaPath resolves the dot segments of a media path requested by a client
+// Cleaning the path as a rooted one keeps it inside the storage root
+// Returns false if the path does not name a file
+func cleanMediaPath(filePath string) (string, bool) {
+	cleanPath := strings.TrimPrefix(path.Clean("/"+normalizeMediaPath(filePath)), "/")
+	return cleanPath, cleanPath != ""
+}
+
 // GetConfig returns the storage configuration
 func (s *LocalMediaStorage) GetConfig() repository.MediaStorageConfig {
 	return s.config
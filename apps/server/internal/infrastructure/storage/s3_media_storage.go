@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+)
+
+const (
+	// DefaultS3URLExpiry is how long presigned media URLs stay valid when no expiry is configured
+	DefaultS3URLExpiry = time.Hour
+
+	// MaxS3URLExpiry is the longest expiry Signature Version 4 allows for presigned URLs
+	MaxS3URLExpiry = 7 * 24 * time.Hour
+
+	// s3ContentHashMetadata is the object metadata key holding the hex SHA-256 of the media
+	s3ContentHashMetadata = "Sha256"
+)
+
+// S3Config holds the settings for storing media in an S3-compatible object store
+type S3Config struct {
+	Endpoint        string        // Service URL, e.g. https://s3.eu-west-1.amazonaws.com or http://minio:9000
+	Region          string        // Region requests are signed for
+	Bucket          string        // Bucket media objects are stored in
+	AccessKeyID     string        // Access key ID
+	SecretAccessKey string        // Secret access key
+	Prefix          string        // Key prefix media objects are stored under (optional)
+	PathStyle       bool          // Address the bucket in the URL path instead of the host name (MinIO and most stand-ins)
+	URLExpiry       time.Duration // How long presigned media URLs stay valid
+}
+
+// S3MediaStorage implements MediaStorage using an S3-compatible object store
+// Media is shared between replicas and served to clients through presigned, time-limited URLs
+type S3MediaStorage struct {
+	config   repository.MediaStorageConfig
+	s3Config S3Config
+	client   *s3Client
+}
+
+// NewS3MediaStorage creates a new S3 media storage instance
+func NewS3MediaStorage(config repository.MediaStorageConfig, s3Config S3Config) (*S3MediaStorage, error) {
+	if s3Config.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is required")
+	}
+	if s3Config.Region == "" {
+		s3Config.Region = "us-east-1"
+	}
+	if s3Config.Endpoint == "" {
+		s3Config.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", s3Config.Region)
+	}
+	if s3Config.URLExpiry <= 0 {
+		s3Config.URLExpiry = DefaultS3URLExpiry
+	}
+	if s3Config.URLExpiry > MaxS3URLExpiry {
+		return nil, fmt.Errorf("s3 url expiry must not exceed %s", MaxS3URLExpiry)
+	}
+	s3Config.Prefix = strings.Trim(s3Config.Prefix, "/")
+
+	endpoint, err := url.Parse(s3Config.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", s3Config.Endpoint)
+	}
+
+	return &S3MediaStorage{
+		config:   config,
+		s3Config: s3Config,
+		client: &s3Client{
+			endpoint:        endpoint,
+			region:          s3Config.Region,
+			bucket:          s3Config.Bucket,
+			accessKeyID:     s3Config.AccessKeyID,
+			secretAccessKey: s3Config.SecretAccessKey,
+			pathStyle:       s3Config.PathStyle,
+			httpClient:      &http.Client{Timeout: 5 * time.Minute},
+			now:             time.Now,
+		},
+	}, nil
+}
+
+// DownloadAndStore downloads media from WhatsApp and uploads it to the bucket
+// The SHA-256 of the content is sent with the upload, so the object store rejects corrupted
+// uploads, and is kept in the object's metadata
+func (s *S3MediaStorage) DownloadAndStore(ctx context.Context, sessionID, messageID string, mediaData io.Reader, mimeType, extension string) (string, string, error) {
+	// Read at most one byte past the limit, which is enough to tell the media is too large
+	data, err := io.ReadAll(io.LimitReader(mediaData, s.config.MaxFileSize+1))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read media data: %w", err)
+	}
+
+	if err := s.ValidateSize(int64(len(data))); err != nil {
+		return "", "", err
+	}
+
+	relativePath := path.Join(sessionID, mediaFilename(messageID, extension))
+
+	sum := sha256.Sum256(data)
+	metadata := map[string]string{s3ContentHashMetadata: hex.EncodeToString(sum[:])}
+	if err := s.client.putObject(ctx, s.objectKey(relativePath), data, mimeType, metadata); err != nil {
+		return "", "", fmt.Errorf("failed to upload media: %w", err)
+	}
+
+	return relativePath, s.GetMediaURL(relativePath), nil
+}
+
+// GetMediaURL returns a presigned URL for downloading stored media, valid for the configured expiry
+func (s *S3MediaStorage) GetMediaURL(filePath string) string {
+	return s.client.presignGetObject(s.objectKey(filePath), s.s3Config.URLExpiry)
+}
+
+// DeleteMedia removes a media object from the bucket
+func (s *S3MediaStorage) DeleteMedia(ctx context.Context, filePath string) error {
+	key := s.objectKey(filePath)
+
+	// S3 reports success when deleting missing objects, so check first
+	if _, err := s.client.headObject(ctx, key); err != nil {
+		if isS3NotFound(err) {
+			return errors.ErrNotFound
+		}
+		return fmt.Errorf("failed to delete media object: %w", err)
+	}
+
+	if err := s.client.deleteObject(ctx, key); err != nil {
+		return fmt.Errorf("failed to delete media object: %w", err)
+	}
+
+	return nil
+}
+
+// GetMediaPath returns the location of a media object as an s3:// URI
+func (s *S3MediaStorage) GetMediaPath(filePath string) string {
+	return fmt.Sprintf("s3://%s/%s", s.s3Config.Bucket, s.objectKey(filePath))
+}
+
+// ValidateSize checks if the media size is within allowed limits
+func (s *S3MediaStorage) ValidateSize(size int64) error {
+	if size > s.config.MaxFileSize {
+		return errors.ErrMediaTooLarge.WithMessage(
+			fmt.Sprintf("media size %d bytes exceeds maximum allowed size %d bytes", size, s.config.MaxFileSize),
+		)
+	}
+	return nil
+}
+
+// OpenMedia downloads a media object for reading
+// Media is bounded by the maximum file size, so the object is held in memory
+func (s *S3MediaStorage) OpenMedia(ctx context.Context, filePath string) (*repository.MediaFile, error) {
+	cleanPath, ok := cleanMediaPath(filePath)
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+
+	header, data, err := s.client.getObject(ctx, s.objectKey(cleanPath))
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to download media object: %w", err)
+	}
+
+	etag := header.Get("ETag")
+	if hash := header.Get("X-Amz-Meta-" + s3ContentHashMetadata); hash != "" {
+		etag = `"` + hash + `"`
+	}
+	modTime, _ := http.ParseTime(header.Get("Last-Modified"))
+
+	return &repository.MediaFile{
+		Content:     nopSeekCloser{bytes.NewReader(data)},
+		ContentType: header.Get("Content-Type"),
+		Size:        int64(len(data)),
+		ModTime:     modTime,
+		ETag:        etag,
+	}, nil
+}
+
+// ListMedia returns every media object under the configured prefix
+// Downloads through presigned URLs bypass the server, so LastAccessed is when the object was stored
+func (s *S3MediaStorage) ListMedia(ctx context.Context) ([]repository.StoredMedia, error) {
+	prefix := ""
+	if s.s3Config.Prefix != "" {
+		prefix = s.s3Config.Prefix + "/"
+	}
+
+	objects, err := s.client.listObjects(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media objects: %w", err)
+	}
+
+	media := make([]repository.StoredMedia, 0, len(objects))
+	for _, object := range objects {
+		media = append(media, storedMedia(strings.TrimPrefix(object.Key, prefix), object.Size, object.LastModified, object.LastModified))
+	}
+
+	return media, nil
+}
+
+// ContentHash returns the hex SHA-256 of a media object, as recorded when it was stored
+func (s *S3MediaStorage) ContentHash(ctx context.Context, filePath string) (string, error) {
+	header, err := s.client.headObject(ctx, s.objectKey(filePath))
+	if err != nil {
+		if isS3NotFound(err) {
+			return "", errors.ErrNotFound
+		}
+		return "", fmt.Errorf("failed to read media metadata: %w", err)
+	}
+
+	return header.Get("X-Amz-Meta-" + s3ContentHashMetadata), nil
+}
+
+// GetConfig returns the storage configuration
+func (s *S3MediaStorage) GetConfig() repository.MediaStorageConfig {
+	return s.config
+}
+
+// objectKey returns the key a media file is stored under, including the configured prefix
+func (s *S3MediaStorage) objectKey(filePath string) string {
+	key := normalizeMediaPath(filePath)
+	if s.s3Config.Prefix == "" {
+		return key
+	}
+	return s.s3Config.Prefix + "/" + key
+}
+
+// nopSeekCloser adds a no-op Close to an in-memory reader
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+// Close implements io.Closer
+func (nopSeekCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"time"
+
+	"whatspire/internal/domain/errors"
+)
+
+// DefaultMediaURLExpiry is how long signed media links stay valid when no expiry is configured
+const DefaultMediaURLExpiry = time.Hour
+
+// HMACMediaURLSigner implements MediaURLSigner with HMAC-SHA256 tokens
+// A token is the link's expiry time and a signature over the file path and that time,
+// so a link grants access to one file only and cannot be extended
+type HMACMediaURLSigner struct {
+	key    []byte
+	expiry time.Duration
+	now    func() time.Time
+}
+
+// NewHMACMediaURLSigner creates a signer minting links valid for the given duration
+func NewHMACMediaURLSigner(key []byte, expiry time.Duration) *HMACMediaURLSigner {
+	if expiry <= 0 {
+		expiry = DefaultMediaURLExpiry
+	}
+
+	return &HMACMediaURLSigner{
+		key:    key,
+		expiry: expiry,
+		now:    time.Now,
+	}
+}
+
+// Sign returns the query string granting access to a media file until it expires
+func (s *HMACMediaURLSigner) Sign(filePath string) string {
+	expires := strconv.FormatInt(s.now().Add(s.expiry).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.signature(filePath, expires))
+	return query.Encode()
+}
+
+// Verify checks the expiry and signature of a token for a media file
+func (s *HMACMediaURLSigner) Verify(filePath, expires, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || signature == "" {
+		return errors.ErrInvalidMediaToken
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(filePath, expires))) {
+		return errors.ErrInvalidMediaToken
+	}
+
+	if s.now().Unix() > expiresAt {
+		return errors.ErrInvalidMediaToken.WithMessage("media link has expired")
+	}
+
+	return nil
+}
+
+// signature computes the hex HMAC of a media file path and expiry time
+func (s *HMACMediaURLSigner) signature(filePath, expires string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(normalizeMediaPath(filePath) + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/errors"
+)
+
+func TestHMACMediaURLSigner(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer := NewHMACMediaURLSigner([]byte("test-key"), 15*time.Minute)
+	signer.now = func() time.Time { return now }
+
+	query, err := url.ParseQuery(signer.Sign("session-1/msg-1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires, signature := query.Get("expires"), query.Get("signature")
+	if expires != "1704111300" {
+		t.Errorf("expires = %q, want 15 minutes after signing", expires)
+	}
+
+	tests := []struct {
+		name      string
+		filePath  string
+		expires   string
+		signature string
+		at        time.Time
+		valid     bool
+	}{
+		{name: "valid token", filePath: "session-1/msg-1.jpg", expires: expires, signature: signature, at: now, valid: true},
+		{name: "leading slash and backslashes are ignored", filePath: "/session-1\\msg-1.jpg", expires: expires, signature: signature, at: now, valid: true},
+		{name: "valid until expiry", filePath: "session-1/msg-1.jpg", expires: expires, signature: signature, at: now.Add(15 * time.Minute), valid: true},
+		{name: "expired token", filePath: "session-1/msg-1.jpg", expires: expires, signature: signature, at: now.Add(16 * time.Minute)},
+		{name: "other file", filePath: "session-1/msg-2.jpg", expires: expires, signature: signature, at: now},
+		{name: "extended expiry", filePath: "session-1/msg-1.jpg", expires: "1704200000", signature: signature, at: now},
+		{name: "missing signature", filePath: "session-1/msg-1.jpg", expires: expires, at: now},
+		{name: "malformed expiry", filePath: "session-1/msg-1.jpg", expires: "soon", signature: signature, at: now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer.now = func() time.Time { return tt.at }
+
+			err := signer.Verify(tt.filePath, tt.expires, tt.signature)
+			if tt.valid && err != nil {
+				t.Errorf("Verify() = %v, want nil", err)
+			}
+			if domainErr := errors.GetDomainError(err); !tt.valid && (domainErr == nil || domainErr.Code != "INVALID_MEDIA_TOKEN") {
+				t.Errorf("Verify() = %v, want ErrInvalidMediaToken", err)
+			}
+		})
+	}
+
+	// Tokens minted with another key are rejected
+	other := NewHMACMediaURLSigner([]byte("other-key"), 15*time.Minute)
+	other.now = func() time.Time { return now }
+	if err := other.Verify("session-1/msg-1.jpg", expires, signature); err == nil {
+		t.Error("Verify() with another key = nil, want error")
+	}
+}
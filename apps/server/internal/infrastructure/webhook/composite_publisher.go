@@ -11,19 +11,19 @@ import (
 // CompositeEventPublisher publishes events to multiple destinations (WebSocket + Webhook)
 type CompositeEventPublisher struct {
 	websocketPublisher repository.EventPublisher
-	webhookPublisher   *WebhookPublisher
+	webhookQueue       *DeliveryQueue
 	logger             *logger.Logger
 }
 
 // NewCompositeEventPublisher creates a new composite event publisher
 func NewCompositeEventPublisher(
 	websocketPublisher repository.EventPublisher,
-	webhookPublisher *WebhookPublisher,
+	webhookQueue *DeliveryQueue,
 	log *logger.Logger,
 ) *CompositeEventPublisher {
 	return &CompositeEventPublisher{
 		websocketPublisher: websocketPublisher,
-		webhookPublisher:   webhookPublisher,
+		webhookQueue:       webhookQueue,
 		logger:             log,
 	}
 }
@@ -36,15 +36,11 @@ func (p *CompositeEventPublisher) Publish(ctx context.Context, event *entity.Eve
 		// Continue to webhook even if WebSocket fails
 	}
 
-	// Publish to Webhook (secondary channel) - don't block on webhook failures
-	if p.webhookPublisher != nil {
-		go func() {
-			// Use background context to avoid cancellation
-			bgCtx := context.Background()
-			if err := p.webhookPublisher.Publish(bgCtx, event); err != nil {
-				p.logger.WithError(err).WithStr("event_type", string(event.Type)).Warn("Failed to publish event to webhook")
-			}
-		}()
+	// Queue the webhook delivery (secondary channel) - attempts happen in the background
+	if p.webhookQueue != nil {
+		if err := p.webhookQueue.Enqueue(ctx, event); err != nil {
+			p.logger.WithError(err).WithStr("event_type", string(event.Type)).Warn("Failed to queue event for webhook delivery")
+		}
 	}
 
 	return nil
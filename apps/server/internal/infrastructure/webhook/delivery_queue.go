@@ -0,0 +1,305 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/logger"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryQueueConfig holds configuration for the durable webhook delivery queue
+type DeliveryQueueConfig struct {
+	RetrySchedule []time.Duration // Delay before each retry; deliveries are dead-lettered once it is used up
+	PollInterval  time.Duration   // How often the queue is polled for due deliveries
+	BatchSize     int             // Maximum number of deliveries claimed at once
+	ClaimTimeout  time.Duration   // How long a claimed delivery may stay in flight before it is considered abandoned
+}
+
+// DefaultRetrySchedule returns the default retry delays, spanning roughly a day
+func DefaultRetrySchedule() []time.Duration {
+	return []time.Duration{
+		30 * time.Second,
+		2 * time.Minute,
+		10 * time.Minute,
+		30 * time.Minute,
+		time.Hour,
+		3 * time.Hour,
+		6 * time.Hour,
+		12 * time.Hour,
+	}
+}
+
+// DefaultDeliveryQueueConfig returns default delivery queue configuration
+func DefaultDeliveryQueueConfig() DeliveryQueueConfig {
+	return DeliveryQueueConfig{
+		RetrySchedule: DefaultRetrySchedule(),
+		PollInterval:  5 * time.Second,
+		BatchSize:     50,
+		ClaimTimeout:  5 * time.Minute,
+	}
+}
+
+// DeliveryQueue persists webhook deliveries and attempts them until they succeed or are dead-lettered
+// An event is queued once for each of its session's webhooks that accepts it, so a failing
+// endpoint is retried without affecting the others; each delivery is signed with its webhook's secret
+type DeliveryQueue struct {
+	repo       repository.WebhookDeliveryRepository
+	dispatcher *Dispatcher
+	publisher  *WebhookPublisher
+	config     DeliveryQueueConfig
+	logger     *logger.Logger
+
+	wake     chan struct{}
+	done     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDeliveryQueue creates a new delivery queue sending through the publisher
+func NewDeliveryQueue(
+	repo repository.WebhookDeliveryRepository,
+	dispatcher *Dispatcher,
+	publisher *WebhookPublisher,
+	config DeliveryQueueConfig,
+	log *logger.Logger,
+) *DeliveryQueue {
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+	if config.ClaimTimeout <= 0 {
+		config.ClaimTimeout = 5 * time.Minute
+	}
+
+	return &DeliveryQueue{
+		repo:       repo,
+		dispatcher: dispatcher,
+		publisher:  publisher,
+		config:     config,
+		logger:     log,
+		wake:       make(chan struct{}, 1),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+}
+
+// Enqueue stores a pending delivery of the event for each of the session's webhooks that accepts it
+func (q *DeliveryQueue) Enqueue(ctx context.Context, event *entity.Event) error {
+	targets, err := q.dispatcher.Resolve(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to resolve webhooks: %w", err)
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	for _, target := range targets {
+		delivery := entity.NewWebhookDelivery(uuid.New().String(), event, target.EndpointID, target.URL, payload)
+		if err := q.repo.Enqueue(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	// Wake the processor without blocking if it is already signalled
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Start begins processing due deliveries in the background
+func (q *DeliveryQueue) Start() {
+	go q.run()
+}
+
+// Stop stops processing and waits for the processor to exit
+// Deliveries interrupted by the shutdown are retried on the next start
+func (q *DeliveryQueue) Stop() {
+	q.stopOnce.Do(func() {
+		close(q.done)
+	})
+	<-q.stopped
+}
+
+// run delivers due deliveries until the queue is stopped
+func (q *DeliveryQueue) run() {
+	defer close(q.stopped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-q.done
+		cancel()
+	}()
+
+	ticker := time.NewTicker(q.config.PollInterval)
+	defer ticker.Stop()
+
+	q.logger.WithStr("poll_interval", q.config.PollInterval.String()).
+		Debug("Webhook delivery queue started")
+
+	for {
+		q.resetAbandonedClaims(ctx)
+		q.drain(ctx)
+
+		select {
+		case <-q.done:
+			q.logger.Info("Webhook delivery queue stopped gracefully")
+			return
+		case <-ticker.C:
+		case <-q.wake:
+		}
+	}
+}
+
+// drain claims and attempts due deliveries until none are left
+func (q *DeliveryQueue) drain(ctx context.Context) {
+	for {
+		deliveries, err := q.repo.ClaimDue(ctx, time.Now(), q.config.BatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				q.logger.WithError(err).Error("Failed to claim webhook deliveries")
+			}
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		for i, delivery := range deliveries {
+			if ctx.Err() != nil {
+				q.release(deliveries[i:])
+				return
+			}
+
+			// Deliveries claimed too long ago may be claimed again by another queue, so they are handed back
+			if time.Since(delivery.UpdatedAt) > q.config.ClaimTimeout/2 {
+				q.release(deliveries[i:])
+				break
+			}
+
+			q.attempt(ctx, delivery)
+		}
+	}
+}
+
+// resetAbandonedClaims returns deliveries claimed by a queue that stopped or crashed to pending
+func (q *DeliveryQueue) resetAbandonedClaims(ctx context.Context) {
+	reset, err := q.repo.ResetInFlight(ctx, time.Now().Add(-q.config.ClaimTimeout))
+	if err != nil {
+		if ctx.Err() == nil {
+			q.logger.WithError(err).Error("Failed to reset abandoned webhook deliveries")
+		}
+	} else if reset > 0 {
+		q.logger.WithInt("count", int(reset)).Info("Resuming abandoned webhook deliveries")
+	}
+}
+
+// release returns claimed deliveries that were not attempted to pending
+func (q *DeliveryQueue) release(deliveries []*entity.WebhookDelivery) {
+	ids := make([]string, len(deliveries))
+	for i, delivery := range deliveries {
+		ids[i] = delivery.ID
+	}
+
+	// Use a fresh context, the queue's is cancelled on shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := q.repo.Release(ctx, ids); err != nil {
+		q.logger.WithError(err).Error("Failed to release webhook deliveries")
+	}
+}
+
+// attempt makes a single delivery attempt and records the outcome
+func (q *DeliveryQueue) attempt(ctx context.Context, delivery *entity.WebhookDelivery) {
+	result := q.send(ctx, delivery)
+	if ctx.Err() != nil {
+		// Shutting down: leave the delivery in flight so it is retried once its claim times out
+		return
+	}
+
+	l := q.logger.WithFields(map[string]interface{}{
+		"delivery_id": delivery.ID,
+		"session_id":  delivery.SessionID,
+		"event_type":  delivery.EventType.String(),
+		"endpoint_id": delivery.EndpointID,
+		"url":         delivery.URL,
+		"status_code": result.StatusCode,
+	})
+
+	if result.Err == nil {
+		delivery.RecordSuccess(result.StatusCode, result.Response)
+		l.WithInt("attempt", delivery.Attempts).Info("Webhook delivered successfully")
+	} else {
+		delivery.RecordFailure(result.StatusCode, result.Response, result.Err.Error(), result.Retryable, q.config.RetrySchedule)
+		l = l.WithError(result.Err).WithInt("attempt", delivery.Attempts)
+		if delivery.Status == entity.WebhookDeliveryStatusDeadLetter {
+			l.Error("Webhook delivery failed permanently, moved to dead letter")
+		} else {
+			l.Warnf("Webhook delivery failed, retrying at %s", delivery.NextAttemptAt.Format(time.RFC3339))
+		}
+	}
+
+	// Use a fresh context so the outcome is recorded even if the attempt took long
+	recordCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := q.repo.RecordAttempt(recordCtx, delivery); err != nil {
+		l.WithError(err).Error("Failed to record webhook delivery attempt")
+	}
+
+	if delivery.Status != entity.WebhookDeliveryStatusPending {
+		q.audit(recordCtx, delivery)
+	}
+}
+
+// send delivers using the webhook's current settings, so URL changes and secret rotations
+// apply to deliveries that are already queued
+func (q *DeliveryQueue) send(ctx context.Context, delivery *entity.WebhookDelivery) DeliveryResult {
+	target, err := q.dispatcher.Target(ctx, delivery)
+	if err != nil {
+		return DeliveryResult{Err: fmt.Errorf("failed to resolve webhook: %w", err), Retryable: true}
+	}
+	if target == nil {
+		return DeliveryResult{Err: fmt.Errorf("webhook is not configured or disabled for session %s", delivery.SessionID)}
+	}
+
+	delivery.URL = target.URL
+	return q.publisher.Send(ctx, delivery, *target)
+}
+
+// audit records the final outcome of a delivery in the audit log
+func (q *DeliveryQueue) audit(ctx context.Context, delivery *entity.WebhookDelivery) {
+	if q.publisher.auditLogger == nil {
+		return
+	}
+
+	var errMsg *string
+	if delivery.Status != entity.WebhookDeliveryStatusDelivered {
+		msg := fmt.Sprintf("%s after %d attempts", delivery.LastError, delivery.Attempts)
+		errMsg = &msg
+	}
+
+	q.publisher.auditLogger.LogWebhookDelivery(ctx, repository.WebhookDeliveryEvent{
+		WebhookURL: delivery.URL,
+		EventType:  delivery.EventType.String(),
+		StatusCode: delivery.LastStatusCode,
+		Success:    delivery.Status == entity.WebhookDeliveryStatusDelivered,
+		Error:      errMsg,
+		Timestamp:  time.Now(),
+	})
+}
@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+)
+
+// Target is a webhook endpoint an event is delivered to
+type Target struct {
+	EndpointID string            // Empty for the session's default webhook
+	URL        string            // Endpoint URL
+	Secret     string            // Secret for HMAC signing (optional)
+	Headers    map[string]string // Extra request headers
+}
+
+// Dispatcher resolves the webhooks stored for the session an event belongs to:
+// the session's default webhook and each of its webhook endpoints
+// The repositories are expected to cache, since they are consulted for every event
+type Dispatcher struct {
+	configs   repository.WebhookConfigRepository
+	endpoints repository.WebhookEndpointRepository
+}
+
+// NewDispatcher creates a new dispatcher reading webhooks from the repositories
+func NewDispatcher(configs repository.WebhookConfigRepository, endpoints repository.WebhookEndpointRepository) *Dispatcher {
+	return &Dispatcher{configs: configs, endpoints: endpoints}
+}
+
+// webhookFilter is implemented by the session's default webhook and its webhook endpoints
+type webhookFilter interface {
+	ShouldDeliverEvent(eventType entity.EventType) bool
+	ShouldDeliverChat(chatJID string) bool
+}
+
+// Resolve returns every webhook of the session that accepts the event
+func (d *Dispatcher) Resolve(ctx context.Context, event *entity.Event) ([]Target, error) {
+	chatJID := eventChatJID(event)
+	accepts := func(f webhookFilter) bool {
+		return f.ShouldDeliverEvent(event.Type) && f.ShouldDeliverChat(chatJID)
+	}
+
+	var targets []Target
+
+	config, err := d.defaultWebhook(ctx, event.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if config != nil && accepts(config) {
+		targets = append(targets, Target{URL: config.URL, Secret: config.Secret})
+	}
+
+	endpoints, err := d.endpoints.ListBySessionID(ctx, event.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.URL != "" && accepts(endpoint) {
+			targets = append(targets, endpointTarget(endpoint))
+		}
+	}
+
+	return targets, nil
+}
+
+// Target returns the current settings of the webhook a delivery goes to,
+// or nil if it has been deleted or disabled
+func (d *Dispatcher) Target(ctx context.Context, delivery *entity.WebhookDelivery) (*Target, error) {
+	if delivery.EndpointID == "" {
+		config, err := d.defaultWebhook(ctx, delivery.SessionID)
+		if err != nil || config == nil {
+			return nil, err
+		}
+		return &Target{URL: config.URL, Secret: config.Secret}, nil
+	}
+
+	// Listing goes through the cache, unlike fetching a single endpoint
+	endpoints, err := d.endpoints.ListBySessionID(ctx, delivery.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.ID == delivery.EndpointID && endpoint.Enabled && endpoint.URL != "" {
+			target := endpointTarget(endpoint)
+			return &target, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// defaultWebhook returns the session's default webhook, or nil if it has none or it is disabled
+func (d *Dispatcher) defaultWebhook(ctx context.Context, sessionID string) (*entity.WebhookConfig, error) {
+	config, err := d.configs.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if !config.Enabled || config.URL == "" {
+		return nil, nil
+	}
+
+	return config, nil
+}
+
+// endpointTarget converts a webhook endpoint to a delivery target
+func endpointTarget(endpoint *entity.WebhookEndpoint) Target {
+	return Target{
+		EndpointID: endpoint.ID,
+		URL:        endpoint.URL,
+		Secret:     endpoint.Secret,
+		Headers:    endpoint.Headers,
+	}
+}
+
+// eventChat holds the payload fields identifying the chat an event belongs to
+// Payloads name the chat differently depending on where the event comes from
+type eventChat struct {
+	ChatJID    string `json:"chat_jid"`
+	ChatJIDAlt string `json:"chatJid"`
+	GroupJID   string `json:"group_jid"`
+	From       string `json:"from"`
+}
+
+// eventChatJID returns the JID of the chat the event belongs to, or "" if it doesn't belong to one
+func eventChatJID(event *entity.Event) string {
+	var chat eventChat
+	if len(event.Data) == 0 || json.Unmarshal(event.Data, &chat) != nil {
+		// Payloads that aren't objects don't belong to a chat
+		return ""
+	}
+
+	for _, jid := range []string{chat.ChatJID, chat.ChatJIDAlt, chat.GroupJID, chat.From} {
+		if jid != "" {
+			return jid
+		}
+	}
+
+	return ""
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -58,12 +59,73 @@ func (wp *WebhookPublisher) Publish(ctx context.Context, event *entity.Event) er
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wp.config.URL, bytes.NewReader(payload))
+	req, err := wp.newRequest(ctx, wp.config.URL, wp.config.Secret, payload)
 	if err != nil {
 		wp.logger.WithError(err).WithStr("url", wp.config.URL).Error("Failed to create webhook request")
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
+	// Send with retry logic
+	return wp.sendWithRetry(ctx, req, payload)
+}
+
+// DeliveryResult describes the outcome of a single delivery attempt
+type DeliveryResult struct {
+	StatusCode int    // HTTP status returned by the receiver (0 if no response was received)
+	Response   string // Start of the response body
+	Err        error  // Transport error or non-2xx status
+	Retryable  bool   // Whether a later attempt could succeed
+}
+
+// Send makes a single delivery attempt of a queued delivery to the target without retrying
+// The payload is signed with the target's secret rather than the publisher's own
+func (wp *WebhookPublisher) Send(ctx context.Context, delivery *entity.WebhookDelivery, target Target) DeliveryResult {
+	req, err := wp.newRequest(ctx, target.URL, target.Secret, delivery.Payload)
+	if err != nil {
+		return DeliveryResult{Err: fmt.Errorf("failed to create request: %w", err)}
+	}
+	for name, value := range target.Headers {
+		// Custom headers never replace the content type or signature headers
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+	req.Header.Set("X-Webhook-Delivery", delivery.ID)
+
+	resp, err := wp.httpClient.Do(req)
+	if err != nil {
+		return DeliveryResult{Err: err, Retryable: true}
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	result := DeliveryResult{
+		StatusCode: resp.StatusCode,
+		Response:   string(body),
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return result
+	}
+
+	// Client errors will not change on retry, except for timeouts and rate limiting
+	result.Err = fmt.Errorf("webhook delivery failed with status %d", resp.StatusCode)
+	result.Retryable = resp.StatusCode >= 500 ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests
+	return result
+}
+
+// maxResponseBodySize is the number of response body bytes read from a receiver
+const maxResponseBodySize = 4096
+
+// newRequest creates a webhook POST request with timestamp and signature headers
+func (wp *WebhookPublisher) newRequest(ctx context.Context, url, secret string, payload []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 
@@ -72,18 +134,17 @@ func (wp *WebhookPublisher) Publish(ctx context.Context, event *entity.Event) er
 	req.Header.Set("X-Webhook-Timestamp", fmt.Sprintf("%d", timestamp))
 
 	// Add HMAC signature if secret is configured
-	if wp.config.Secret != "" {
-		signature := wp.computeHMAC(payload)
+	if secret != "" {
+		signature := computeHMAC(secret, payload)
 		req.Header.Set("X-Webhook-Signature", signature)
 	}
 
-	// Send with retry logic
-	return wp.sendWithRetry(ctx, req, payload)
+	return req, nil
 }
 
 // computeHMAC computes HMAC-SHA256 signature for the payload
-func (wp *WebhookPublisher) computeHMAC(payload []byte) string {
-	mac := hmac.New(sha256.New, []byte(wp.config.Secret))
+func computeHMAC(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
 	mac.Write(payload)
 	return hex.EncodeToString(mac.Sum(nil))
 }
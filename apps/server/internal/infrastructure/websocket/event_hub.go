@@ -1,11 +1,17 @@
 package websocket
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/repository"
 
 	"github.com/gorilla/websocket"
 )
@@ -16,6 +22,11 @@ type EventHubConfig struct {
 	PingInterval time.Duration
 	WriteTimeout time.Duration
 	AuthTimeout  time.Duration
+	// APIKeyRepository authenticates clients against stored API keys (optional)
+	// When set, it takes precedence over the shared APIKey
+	APIKeyRepository repository.APIKeyRepository
+	// EventRepository serves missed events to clients resuming the stream (optional)
+	EventRepository repository.EventRepository
 }
 
 // DefaultEventHubConfig returns default configuration
@@ -41,13 +52,95 @@ type AuthResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
+// SubscribeMessage represents a subscription request from an authenticated client
+// Empty lists subscribe to every session or event type the client may receive
+type SubscribeMessage struct {
+	Type       string   `json:"type"`
+	SessionIDs []string `json:"session_ids,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"` // Exact types, "*", or wildcards such as "message.*"
+}
+
+// SubscribeResponse represents a subscription response to a client
+type SubscribeResponse struct {
+	Type       string   `json:"type"`
+	Success    bool     `json:"success"`
+	Message    string   `json:"message,omitempty"`
+	SessionIDs []string `json:"session_ids,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+}
+
+// ReplayResponse tells a resuming client that missed events were replayed and live delivery follows
+type ReplayResponse struct {
+	Type          string           `json:"type"`
+	Success       bool             `json:"success"`
+	Message       string           `json:"message,omitempty"`
+	Replayed      int              `json:"replayed"`
+	LastSequences map[string]int64 `json:"last_sequences,omitempty"` // Last replayed sequence per session
+}
+
+// maxPendingEvents caps the live events held back for a client while it replays
+const maxPendingEvents = 1024
+
+// ParseResumeFrom parses a resume point list of the form "session-a:42,session-b:7"
+// Each entry names a session and the last sequence number the client received for it
+func ParseResumeFrom(value string) (map[string]int64, error) {
+	resumeFrom := make(map[string]int64)
+	if strings.TrimSpace(value) == "" {
+		return resumeFrom, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		separator := strings.LastIndex(entry, ":")
+		if separator <= 0 {
+			return nil, fmt.Errorf("invalid resume point %q, expected session_id:sequence", entry)
+		}
+
+		sequence, err := strconv.ParseInt(entry[separator+1:], 10, 64)
+		if err != nil || sequence < 0 {
+			return nil, fmt.Errorf("invalid sequence in resume point %q", entry)
+		}
+		resumeFrom[entry[:separator]] = sequence
+	}
+
+	return resumeFrom, nil
+}
+
+// Subscription holds the sessions and event types a client receives events for
+type Subscription struct {
+	SessionIDs []string
+	EventTypes []string
+}
+
+// Matches reports whether an event falls within the subscription
+func (s Subscription) Matches(event *entity.Event) bool {
+	if len(s.SessionIDs) > 0 && !containsString(s.SessionIDs, event.SessionID) {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, pattern := range s.EventTypes {
+		if event.Type.Matches(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 // Client represents a connected WebSocket client
 type Client struct {
 	conn          *websocket.Conn
 	hub           *EventHub
 	send          chan []byte
 	authenticated bool
+	apiKey        *entity.APIKey // Stored key the client authenticated with, nil for shared key auth
+	subscription  Subscription
+	resumeFrom    map[string]int64 // Last sequence received per session when resuming the stream
+	replaying     bool             // Live events are held in pending until missed events are replayed
+	pending       []*entity.Event
 	mu            sync.RWMutex
+	writeMu       sync.Mutex // Serializes writes to the connection
 }
 
 // NewClient creates a new client
@@ -74,16 +167,133 @@ func (c *Client) SetAuthenticated(auth bool) {
 	c.authenticated = auth
 }
 
+// APIKey returns the stored API key the client authenticated with, if any
+func (c *Client) APIKey() *entity.APIKey {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.apiKey
+}
+
+// Subscription returns the client's current subscription
+func (c *Client) Subscription() Subscription {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.subscription
+}
+
+// SetSubscription replaces the client's subscription
+func (c *Client) SetSubscription(subscription Subscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscription = subscription
+}
+
+// Accepts reports whether the client should receive an event
+// Events must match the subscription and belong to a session the client's key may access
+func (c *Client) Accepts(event *entity.Event) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.authenticated && acceptsEvent(c.apiKey, c.subscription, event)
+}
+
+// acceptsEvent reports whether an event matches the subscription and belongs to a session the key may access
+// A nil key has access to every session
+func acceptsEvent(apiKey *entity.APIKey, subscription Subscription, event *entity.Event) bool {
+	if apiKey != nil && apiKey.IsSessionScoped() &&
+		(event.SessionID == "" || !apiKey.CanAccessSession(event.SessionID)) {
+		return false
+	}
+	return subscription.Matches(event)
+}
+
+// SetResumeFrom marks the client as resuming the stream from the given per-session sequence numbers
+// Live events are held back until Replay has sent the events missed since then
+func (c *Client) SetResumeFrom(resumeFrom map[string]int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resumeFrom = resumeFrom
+	c.replaying = len(resumeFrom) > 0
+}
+
+// ResumeFrom returns a copy of the client's resume points
+func (c *Client) ResumeFrom() map[string]int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	resumeFrom := make(map[string]int64, len(c.resumeFrom))
+	for sessionID, sequence := range c.resumeFrom {
+		resumeFrom[sessionID] = sequence
+	}
+	return resumeFrom
+}
+
+// deliver queues a live event for the client, holding it back while the client replays
+// Returns false if the client cannot keep up and should be disconnected
+func (c *Client) deliver(event *entity.Event, data []byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.replaying {
+		if len(c.pending) >= maxPendingEvents {
+			return false
+		}
+		c.pending = append(c.pending, event)
+		return true
+	}
+
+	select {
+	case c.send <- data:
+		return true
+	default:
+		return false
+	}
+}
+
+// takePending returns the held back live events, ending the replay once none are left
+func (c *Client) takePending() []*entity.Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending := c.pending
+	c.pending = nil
+	if len(pending) == 0 {
+		c.replaying = false
+	}
+	return pending
+}
+
+// writeEvent writes an event directly to the connection
+func (c *Client) writeEvent(event *entity.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return c.writeMessage(websocket.TextMessage, data)
+}
+
 // Close closes the client connection and channels
 func (c *Client) Close() {
 	close(c.send)
 }
 
+// writeMessage writes a message to the connection, serialized with other writers
+func (c *Client) writeMessage(messageType int, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.hub.config.WriteTimeout))
+	return c.conn.WriteMessage(messageType, data)
+}
+
 // EventHub manages WebSocket connections for event broadcasting
 type EventHub struct {
 	// Registered clients
 	clients map[*Client]bool
 
+	// Open event streams of non-WebSocket subscribers
+	streams map[*Stream]bool
+
 	// Broadcast channel for events
 	broadcast chan *entity.Event
 
@@ -107,6 +317,7 @@ type EventHub struct {
 func NewEventHub(config EventHubConfig) *EventHub {
 	return &EventHub{
 		clients:    make(map[*Client]bool),
+		streams:    make(map[*Stream]bool),
 		broadcast:  make(chan *entity.Event, 256),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
@@ -125,6 +336,9 @@ func (h *EventHub) Run() {
 			for client := range h.clients {
 				h.removeClient(client)
 			}
+			for stream := range h.streams {
+				h.removeStream(stream)
+			}
 			h.mu.Unlock()
 			return
 
@@ -161,7 +375,7 @@ func (h *EventHub) Unregister(client *Client) {
 	h.unregister <- client
 }
 
-// Broadcast sends an event to all connected and authenticated clients
+// Broadcast sends an event to all authenticated clients subscribed to it
 func (h *EventHub) Broadcast(event *entity.Event) {
 	select {
 	case h.broadcast <- event:
@@ -197,25 +411,98 @@ func (h *EventHub) removeClient(client *Client) {
 	_ = client.conn.Close()
 }
 
-// broadcastEvent sends an event to all authenticated clients
+// broadcastEvent sends an event to all authenticated clients and open streams subscribed to it
 func (h *EventHub) broadcastEvent(event *entity.Event) {
 	data, err := json.Marshal(event)
 	if err != nil {
 		return
 	}
 
-	h.mu.RLock()
-	defer h.mu.RUnlock()
+	var slowClients []*Client
+	var slowStreams []*Stream
 
+	h.mu.RLock()
 	for client := range h.clients {
-		if !client.IsAuthenticated() {
+		if !client.Accepts(event) {
+			continue
+		}
+
+		if !client.deliver(event, data) {
+			slowClients = append(slowClients, client)
+		}
+	}
+	for stream := range h.streams {
+		if !stream.Accepts(event) {
 			continue
 		}
 
 		select {
-		case client.send <- data:
+		case stream.events <- event:
 		default:
-			// Client's send buffer is full, skip this event for this client
+			slowStreams = append(slowStreams, stream)
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(slowClients) == 0 && len(slowStreams) == 0 {
+		return
+	}
+
+	// Disconnect subscribers that cannot keep up rather than silently dropping their events
+	// They can reconnect and resume from the last sequence number they received
+	h.mu.Lock()
+	for _, client := range slowClients {
+		if _, ok := h.clients[client]; ok {
+			h.removeClient(client)
+		}
+	}
+	for _, stream := range slowStreams {
+		if _, ok := h.streams[stream]; ok {
+			h.removeStream(stream)
+		}
+	}
+	h.mu.Unlock()
+}
+
+// Replay sends a resuming client the stored events it missed, then switches it to live delivery
+// Live events broadcast meanwhile are sent afterwards, skipping any that were already replayed
+func (h *EventHub) Replay(client *Client) {
+	resumeFrom := client.ResumeFrom()
+	if len(resumeFrom) == 0 {
+		return
+	}
+
+	response := ReplayResponse{
+		Type:    "replay_complete",
+		Success: true,
+	}
+
+	lastSequences, replayed, err := h.ReplayEvents(context.Background(), resumeFrom, client.Accepts, client.writeEvent)
+	response.Replayed = replayed
+	response.LastSequences = lastSequences
+	if err != nil {
+		response.Success = false
+		response.Message = err.Error()
+	}
+
+	if data, err := json.Marshal(response); err == nil {
+		_ = client.writeMessage(websocket.TextMessage, data)
+	}
+
+	// Flush the live events held back during the replay
+	for {
+		pending := client.takePending()
+		if len(pending) == 0 {
+			return
+		}
+
+		for _, event := range pending {
+			if event.Sequence > 0 && event.Sequence <= lastSequences[event.SessionID] {
+				continue
+			}
+			if err := client.writeEvent(event); err != nil {
+				return
+			}
 		}
 	}
 }
@@ -223,6 +510,10 @@ func (h *EventHub) broadcastEvent(event *entity.Event) {
 // AuthenticateClient handles client authentication
 // Returns true if authentication succeeds, false otherwise
 func (h *EventHub) AuthenticateClient(client *Client, apiKey string) bool {
+	if h.config.APIKeyRepository != nil {
+		return h.authenticateStoredKey(client, apiKey)
+	}
+
 	// If no API key is configured, allow all connections
 	if h.config.APIKey == "" {
 		client.SetAuthenticated(true)
@@ -238,6 +529,53 @@ func (h *EventHub) AuthenticateClient(client *Client, apiKey string) bool {
 	return false
 }
 
+// authenticateStoredKey validates the key against the API key repository
+// The key must be active, unexpired, and allowed to read events
+func (h *EventHub) authenticateStoredKey(client *Client, apiKey string) bool {
+	if apiKey == "" {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.config.AuthTimeout)
+	defer cancel()
+
+	// Keys are stored as SHA-256 hashes, matching APIKeyUseCase
+	storedKey, err := h.config.APIKeyRepository.FindByKeyHash(ctx, fmt.Sprintf("%x", sha256.Sum256([]byte(apiKey))))
+	if err != nil || !storedKey.IsActive || storedKey.IsExpired() || !storedKey.HasScope(entity.ScopeEventsRead) {
+		return false
+	}
+
+	client.mu.Lock()
+	client.apiKey = storedKey
+	client.authenticated = true
+	client.mu.Unlock()
+	return true
+}
+
+// Subscribe validates and applies a subscription request of a client
+// Session-scoped keys may only subscribe to sessions they can access
+func (h *EventHub) Subscribe(client *Client, msg SubscribeMessage) error {
+	for _, pattern := range msg.EventTypes {
+		if !entity.IsValidEventTypePattern(pattern) {
+			return fmt.Errorf("unknown event type: %s", pattern)
+		}
+	}
+
+	if apiKey := client.APIKey(); apiKey != nil {
+		for _, sessionID := range msg.SessionIDs {
+			if !apiKey.CanAccessSession(sessionID) {
+				return fmt.Errorf("access to session %s is not allowed", sessionID)
+			}
+		}
+	}
+
+	client.SetSubscription(Subscription{
+		SessionIDs: msg.SessionIDs,
+		EventTypes: msg.EventTypes,
+	})
+	return nil
+}
+
 // SendAuthResponse sends an authentication response to a client
 func (h *EventHub) SendAuthResponse(client *Client, success bool, message string) error {
 	response := AuthResponse{
@@ -251,15 +589,32 @@ func (h *EventHub) SendAuthResponse(client *Client, success bool, message string
 		return err
 	}
 
-	_ = client.conn.SetWriteDeadline(time.Now().Add(h.config.WriteTimeout))
-	return client.conn.WriteMessage(websocket.TextMessage, data)
+	return client.writeMessage(websocket.TextMessage, data)
+}
+
+// SendSubscribeResponse sends a subscription response to a client, echoing its current subscription
+func (h *EventHub) SendSubscribeResponse(client *Client, success bool, message string) error {
+	subscription := client.Subscription()
+	response := SubscribeResponse{
+		Type:       "subscribe_response",
+		Success:    success,
+		Message:    message,
+		SessionIDs: subscription.SessionIDs,
+		EventTypes: subscription.EventTypes,
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	return client.writeMessage(websocket.TextMessage, data)
 }
 
 // CloseWithError closes a client connection with an error code and message
 func (h *EventHub) CloseWithError(client *Client, code int, message string) {
 	closeMsg := websocket.FormatCloseMessage(code, message)
-	_ = client.conn.SetWriteDeadline(time.Now().Add(h.config.WriteTimeout))
-	_ = client.conn.WriteMessage(websocket.CloseMessage, closeMsg)
+	_ = client.writeMessage(websocket.CloseMessage, closeMsg)
 	_ = client.conn.Close()
 }
 
@@ -280,19 +635,17 @@ func (c *Client) WritePump() {
 	for {
 		select {
 		case message, ok := <-c.send:
-			_ = c.conn.SetWriteDeadline(time.Now().Add(c.hub.config.WriteTimeout))
 			if !ok {
 				// Hub closed the channel
-				_ = c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				_ = c.writeMessage(websocket.CloseMessage, []byte{})
 				return
 			}
 
-			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+			if err := c.writeMessage(websocket.TextMessage, message); err != nil {
 				return
 			}
 
 		case <-ticker.C:
-			_ = c.conn.SetWriteDeadline(time.Now().Add(c.hub.config.WriteTimeout))
 			if err := c.conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(c.hub.config.WriteTimeout)); err != nil {
 				return
 			}
@@ -301,7 +654,7 @@ func (c *Client) WritePump() {
 }
 
 // ReadPump pumps messages from the websocket connection to the hub
-// This is primarily used for handling authentication and keeping the connection alive
+// It handles authentication, subscription requests, and keeps the connection alive
 func (c *Client) ReadPump() {
 	defer func() {
 		c.hub.Unregister(c)
@@ -331,6 +684,7 @@ func (c *Client) ReadPump() {
 			if authMsg.Type == "auth" {
 				if c.hub.AuthenticateClient(c, authMsg.APIKey) {
 					_ = c.hub.SendAuthResponse(c, true, "Authentication successful")
+					go c.hub.Replay(c)
 				} else {
 					_ = c.hub.SendAuthResponse(c, false, "Invalid API key")
 					// Close connection with 4001 status code
@@ -338,6 +692,29 @@ func (c *Client) ReadPump() {
 					return
 				}
 			}
+			continue
+		}
+
+		// Handle subscription messages from authenticated clients
+		var subscribeMsg SubscribeMessage
+		if err := json.Unmarshal(message, &subscribeMsg); err != nil || subscribeMsg.Type != "subscribe" {
+			continue
+		}
+
+		if err := c.hub.Subscribe(c, subscribeMsg); err != nil {
+			_ = c.hub.SendSubscribeResponse(c, false, err.Error())
+			continue
 		}
+		_ = c.hub.SendSubscribeResponse(c, true, "Subscription updated")
 	}
 }
+
+// containsString checks if a string slice contains a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"whatspire/internal/domain/entity"
+)
+
+// replayPageSize is the number of stored events read per query while replaying
+const replayPageSize = 500
+
+// ErrReplayUnavailable is returned when missed events cannot be replayed because events are not persisted
+var ErrReplayUnavailable = errors.New("event replay is not available")
+
+// Stream receives broadcast events over a channel, for push transports other than WebSocket
+type Stream struct {
+	events       chan *entity.Event
+	apiKey       *entity.APIKey
+	subscription Subscription
+}
+
+// Events returns the channel events are delivered on
+// The channel is closed when the stream is closed or falls too far behind
+func (s *Stream) Events() <-chan *entity.Event {
+	return s.events
+}
+
+// Accepts reports whether the stream should receive an event
+func (s *Stream) Accepts(event *entity.Event) bool {
+	return acceptsEvent(s.apiKey, s.subscription, event)
+}
+
+// OpenStream registers a stream receiving the broadcast events matching the subscription
+// Session-scoped keys only receive events of the sessions they may access, nil keys receive all
+func (h *EventHub) OpenStream(subscription Subscription, apiKey *entity.APIKey, bufferSize int) *Stream {
+	stream := &Stream{
+		events:       make(chan *entity.Event, bufferSize),
+		apiKey:       apiKey,
+		subscription: subscription,
+	}
+
+	h.mu.Lock()
+	h.streams[stream] = true
+	h.mu.Unlock()
+
+	return stream
+}
+
+// CloseStream unregisters a stream and closes its channel
+func (h *EventHub) CloseStream(stream *Stream) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.streams[stream]; ok {
+		h.removeStream(stream)
+	}
+}
+
+// StreamCount returns the number of open streams
+func (h *EventHub) StreamCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.streams)
+}
+
+// removeStream removes a stream from the hub (must be called with lock held)
+func (h *EventHub) removeStream(stream *Stream) {
+	delete(h.streams, stream)
+	close(stream.events)
+}
+
+// ReplayEvents emits the stored events with sequence numbers above the given per-session ones, oldest first
+// Only events accepted by accept are emitted. It returns the last sequence read per session
+// and the number of events emitted, stopping at the first error.
+func (h *EventHub) ReplayEvents(ctx context.Context, resumeFrom map[string]int64, accept func(*entity.Event) bool, emit func(*entity.Event) error) (map[string]int64, int, error) {
+	lastSequences := make(map[string]int64, len(resumeFrom))
+	for sessionID, sequence := range resumeFrom {
+		lastSequences[sessionID] = sequence
+	}
+
+	if h.config.EventRepository == nil {
+		return lastSequences, 0, ErrReplayUnavailable
+	}
+
+	replayed := 0
+	for sessionID := range resumeFrom {
+		for {
+			events, err := h.config.EventRepository.ListAfterSequence(ctx, sessionID, lastSequences[sessionID], replayPageSize)
+			if err != nil {
+				return lastSequences, replayed, fmt.Errorf("failed to replay events of session %s: %w", sessionID, err)
+			}
+
+			for _, event := range events {
+				lastSequences[sessionID] = event.Sequence
+				if !accept(event) {
+					continue
+				}
+				if err := emit(event); err != nil {
+					return lastSequences, replayed, err
+				}
+				replayed++
+			}
+
+			if len(events) < replayPageSize {
+				break
+			}
+		}
+	}
+
+	return lastSequences, replayed, nil
+}
+
+// FormatResumeFrom formats per-session sequence numbers in the form accepted by ParseResumeFrom
+func FormatResumeFrom(sequences map[string]int64) string {
+	sessionIDs := make([]string, 0, len(sequences))
+	for sessionID := range sequences {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	sort.Strings(sessionIDs)
+
+	entries := make([]string, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		entries = append(entries, sessionID+":"+strconv.FormatInt(sequences[sessionID], 10))
+	}
+	return strings.Join(entries, ",")
+}
@@ -62,10 +62,18 @@ type WhatsmeowClient struct {
 	messageHandler  *MessageHandler
 	reactionHandler *ReactionHandler
 	presenceRepo    repository.PresenceRepository
+	messageRepo     repository.MessageRepository
+	groupRepo       repository.GroupRepository
 
 	// History sync configuration per session
 	historySyncConfig map[string]HistorySyncConfig
 	historySyncMu     sync.RWMutex
+
+	// Behaviour settings per session
+	sessionSettings map[string]entity.SessionSettings
+	alwaysOnline    map[string]chan struct{} // Stop channels of running always-online loops
+	lastSendAt      map[string]time.Time     // Last reserved send slot for account protection
+	settingsMu      sync.RWMutex
 }
 
 // HistorySyncConfig holds history sync configuration for a session
@@ -100,6 +108,9 @@ func NewWhatsmeowClient(ctx context.Context, config ClientConfig, log *logger.Lo
 		logger:            log,
 		messageParser:     NewMessageParser(),
 		historySyncConfig: make(map[string]HistorySyncConfig),
+		sessionSettings:   make(map[string]entity.SessionSettings),
+		alwaysOnline:      make(map[string]chan struct{}),
+		lastSendAt:        make(map[string]time.Time),
 	}
 
 	// Initialize circuit breaker if enabled
@@ -168,8 +179,9 @@ func (c *WhatsmeowClient) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, client := range c.clients {
+	for sessionID, client := range c.clients {
 		client.Disconnect()
+		c.stopAlwaysOnline(sessionID)
 	}
 	c.clients = make(map[string]*whatsmeow.Client)
 
@@ -239,6 +251,20 @@ func (c *WhatsmeowClient) SetPresenceRepository(repo repository.PresenceReposito
 	c.presenceRepo = repo
 }
 
+// SetMessageRepository sets the message repository used to track delivery status from receipts
+func (c *WhatsmeowClient) SetMessageRepository(repo repository.MessageRepository) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messageRepo = repo
+}
+
+// SetGroupRepository sets the group repository kept up to date from group events
+func (c *WhatsmeowClient) SetGroupRepository(repo repository.GroupRepository) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.groupRepo = repo
+}
+
 // getOrCreateDevice gets or creates a device store for the session
 func (c *WhatsmeowClient) getOrCreateDevice(ctx context.Context, sessionID string) (*store.Device, error) {
 	// Try to get existing device
@@ -270,12 +296,23 @@ func (c *WhatsmeowClient) handleEvent(sessionID string, client *whatsmeow.Client
 
 	switch v := evt.(type) {
 	case *events.Message:
+		// Drop traffic from chats the session ignores before anything else sees it
+		if c.isIgnoredChat(sessionID, v.Info.Chat) {
+			return
+		}
+		c.autoReadMessage(sessionID, client, v)
 		event, err = c.handleMessageEvent(sessionID, client, v)
+	case *events.CallOffer:
+		c.handleCallOffer(sessionID, client, v)
+		return // Don't emit domain event for calls
 	case *events.Connected:
 		// Notify message handler of connection
 		if c.messageHandler != nil {
 			c.messageHandler.SetSessionConnected(sessionID, true)
 		}
+		if c.GetSessionSettings(sessionID).AlwaysOnline {
+			c.startAlwaysOnline(sessionID)
+		}
 		event, err = entity.NewEventWithPayload(
 			generateEventID(),
 			entity.EventTypeConnected,
@@ -287,6 +324,7 @@ func (c *WhatsmeowClient) handleEvent(sessionID string, client *whatsmeow.Client
 		if c.messageHandler != nil {
 			c.messageHandler.SetSessionConnected(sessionID, false)
 		}
+		c.stopAlwaysOnline(sessionID)
 		event, err = entity.NewEventWithPayload(
 			generateEventID(),
 			entity.EventTypeDisconnected,
@@ -304,10 +342,34 @@ func (c *WhatsmeowClient) handleEvent(sessionID string, client *whatsmeow.Client
 		c.mu.Lock()
 		delete(c.clients, sessionID)
 		c.mu.Unlock()
+		c.stopAlwaysOnline(sessionID)
 	case *events.Receipt:
+		if c.isIgnoredChat(sessionID, v.Chat) {
+			return
+		}
 		event, err = c.handleReceiptEvent(sessionID, v)
 	case *events.Presence:
 		event, err = c.handlePresenceEvent(sessionID, v)
+	case *events.GroupInfo:
+		if c.isIgnoredChat(sessionID, v.JID) {
+			return
+		}
+		// One notification can carry several changes
+		for _, groupEvent := range c.handleGroupInfoEvent(sessionID, client, v) {
+			if c.groupRepo != nil {
+				c.applyGroupEvent(context.Background(), client, groupEvent)
+			}
+			c.dispatchEvent(groupEvent)
+		}
+		return
+	case *events.JoinedGroup:
+		if c.isIgnoredChat(sessionID, v.JID) {
+			return
+		}
+		event, err = c.handleJoinedGroupEvent(sessionID, client, v)
+		if err == nil && c.groupRepo != nil {
+			c.applyGroupEvent(context.Background(), client, event)
+		}
 	case *events.HistorySync:
 		// Handle history sync to extract pushnames and emit message events
 		c.handleHistorySyncEvent(sessionID, client, v)
@@ -321,7 +383,11 @@ func (c *WhatsmeowClient) handleEvent(sessionID string, client *whatsmeow.Client
 		return
 	}
 
-	// Dispatch to all handlers
+	c.dispatchEvent(event)
+}
+
+// dispatchEvent passes an event to all registered handlers
+func (c *WhatsmeowClient) dispatchEvent(event *entity.Event) {
 	c.mu.RLock()
 	handlers := make([]repository.EventHandler, len(c.handlers))
 	copy(handlers, c.handlers)
@@ -362,16 +428,24 @@ func (c *WhatsmeowClient) handleMessageEvent(sessionID string, client *whatsmeow
 // handleReceiptEvent converts a WhatsApp receipt event to a domain event
 func (c *WhatsmeowClient) handleReceiptEvent(sessionID string, receipt *events.Receipt) (*entity.Event, error) {
 	var eventType entity.EventType
+	var status entity.MessageStatus
 
 	switch receipt.Type {
 	case types.ReceiptTypeDelivered:
 		eventType = entity.EventTypeMessageDelivered
+		status = entity.MessageStatusDelivered
 	case types.ReceiptTypeRead:
 		eventType = entity.EventTypeMessageRead
+		status = entity.MessageStatusRead
 	default:
 		return nil, nil // Ignore other receipt types
 	}
 
+	// Update tracked message status if available
+	if c.messageRepo != nil {
+		c.applyReceiptStatus(context.Background(), sessionID, receipt.MessageIDs, status, receipt.Timestamp)
+	}
+
 	payload := map[string]interface{}{
 		"message_ids": receipt.MessageIDs,
 		"from":        receipt.MessageSource.Sender.String(),
@@ -386,6 +460,27 @@ func (c *WhatsmeowClient) handleReceiptEvent(sessionID string, receipt *events.R
 	)
 }
 
+// applyReceiptStatus records a receipt against the stored messages it refers to
+// The timeline always records the receipt; the current status only moves forward
+func (c *WhatsmeowClient) applyReceiptStatus(ctx context.Context, sessionID string, whatsappIDs []types.MessageID, status entity.MessageStatus, at time.Time) {
+	for _, whatsappID := range whatsappIDs {
+		msg, err := c.messageRepo.FindByWhatsAppID(ctx, sessionID, string(whatsappID))
+		if err != nil {
+			// Receipts for messages we never stored (e.g. sent from the phone) are expected
+			continue
+		}
+
+		if msg.GetStatus().CanTransitionTo(status) {
+			err = c.messageRepo.UpdateStatus(ctx, msg.ID, status, at)
+		} else {
+			err = c.messageRepo.AddStatusHistory(ctx, msg.ID, status, at)
+		}
+		if err != nil {
+			c.logger.Warnf("Failed to record %s receipt for message %s: %v", status, msg.ID, err)
+		}
+	}
+}
+
 // handlePresenceEvent converts a WhatsApp presence event to a domain event
 func (c *WhatsmeowClient) handlePresenceEvent(sessionID string, presence *events.Presence) (*entity.Event, error) {
 	// Map whatsmeow presence to our domain presence state
@@ -484,6 +579,11 @@ func (c *WhatsmeowClient) handleHistorySyncEvent(sessionID string, client *whats
 				chatJID = *conv.ID
 			}
 
+			// Skip conversations the session ignores
+			if jid, err := types.ParseJID(chatJID); err == nil && c.isIgnoredChat(sessionID, jid) {
+				continue
+			}
+
 			// Process messages in the conversation
 			if conv.Messages != nil {
 				for _, histMsg := range conv.Messages {
@@ -97,6 +97,7 @@ func (c *WhatsmeowClient) Disconnect(ctx context.Context, sessionID string) erro
 
 	client.Disconnect()
 	delete(c.clients, sessionID)
+	c.stopAlwaysOnline(sessionID)
 	return nil
 }
 
@@ -246,6 +247,9 @@ func (c *WhatsmeowClient) AutoReconnect(ctx context.Context, sessionRepo reposit
 		// Set JID mapping so the client knows which device to use
 		c.SetSessionJIDMapping(session.ID, session.JID)
 
+		// Restore behaviour settings before events start flowing
+		c.SetSessionSettings(session.ID, session.Settings)
+
 		// Attempt to connect
 		err := c.Connect(ctx, session.ID)
 		if err != nil {
@@ -0,0 +1,272 @@
+package whatsapp
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/valueobject"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// CreateGroup creates a new group with the given name and initial participants
+func (c *WhatsmeowClient) CreateGroup(ctx context.Context, sessionID, name string, participants []string) (*entity.Group, error) {
+	client, err := c.connectedClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	participantJIDs, err := parseParticipantJIDs(participants)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.CreateGroup(ctx, whatsmeow.ReqCreateGroup{
+		Name:         name,
+		Participants: participantJIDs,
+	})
+	if err != nil {
+		return nil, mapGroupError(err, "failed to create group")
+	}
+
+	return c.toGroupEntity(ctx, client, sessionID, info, time.Now()), nil
+}
+
+// GetGroupInfo fetches the current metadata and participants of a group
+func (c *WhatsmeowClient) GetGroupInfo(ctx context.Context, sessionID, groupJID string) (*entity.Group, error) {
+	client, jid, err := c.groupClient(sessionID, groupJID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.GetGroupInfo(ctx, jid)
+	if err != nil {
+		return nil, mapGroupError(err, "failed to fetch group info")
+	}
+
+	return c.toGroupEntity(ctx, client, sessionID, info, time.Now()), nil
+}
+
+// UpdateGroupSettings applies the non-nil fields of the update to a group
+func (c *WhatsmeowClient) UpdateGroupSettings(ctx context.Context, sessionID, groupJID string, update entity.GroupSettingsUpdate) error {
+	client, jid, err := c.groupClient(sessionID, groupJID)
+	if err != nil {
+		return err
+	}
+
+	// WhatsApp has no batch update, so each setting is a separate request
+	if update.Name != nil {
+		if err := client.SetGroupName(ctx, jid, *update.Name); err != nil {
+			return mapGroupError(err, "failed to set group name")
+		}
+	}
+
+	if update.Description != nil {
+		if err := client.SetGroupDescription(ctx, jid, *update.Description); err != nil {
+			return mapGroupError(err, "failed to set group description")
+		}
+	}
+
+	if update.IsAnnounce != nil {
+		if err := client.SetGroupAnnounce(ctx, jid, *update.IsAnnounce); err != nil {
+			return mapGroupError(err, "failed to set group announce mode")
+		}
+	}
+
+	if update.IsLocked != nil {
+		if err := client.SetGroupLocked(ctx, jid, *update.IsLocked); err != nil {
+			return mapGroupError(err, "failed to set group locked mode")
+		}
+	}
+
+	if update.Ephemeral != nil {
+		if err := client.SetDisappearingTimer(ctx, jid, *update.Ephemeral, time.Now()); err != nil {
+			return mapGroupError(err, "failed to set disappearing messages timer")
+		}
+	}
+
+	return nil
+}
+
+// SetGroupPhoto replaces the group picture with the image at the given URL and returns the new picture ID
+func (c *WhatsmeowClient) SetGroupPhoto(ctx context.Context, sessionID, groupJID, imageURL string) (string, error) {
+	client, jid, err := c.groupClient(sessionID, groupJID)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.RLock()
+	mediaUploader := c.mediaUploader
+	c.mu.RUnlock()
+
+	if mediaUploader == nil {
+		return "", errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
+	}
+
+	media, err := mediaUploader.downloader.ValidateAndDownload(ctx, &entity.MediaDownloadInfo{URL: imageURL}, valueobject.MediaTypeImage)
+	if err != nil {
+		return "", err
+	}
+
+	// WhatsApp only accepts JPEG group pictures
+	if media.MimeType != "image/jpeg" {
+		return "", errors.ErrUnsupportedMimeType.WithMessage("group picture must be a JPEG image")
+	}
+
+	pictureID, err := client.SetGroupPhoto(ctx, jid, media.Data)
+	if err != nil {
+		return "", mapGroupError(err, "failed to set group picture")
+	}
+
+	return pictureID, nil
+}
+
+// UpdateGroupParticipants adds, removes, promotes or demotes group participants
+func (c *WhatsmeowClient) UpdateGroupParticipants(ctx context.Context, sessionID, groupJID string, participants []string, action entity.ParticipantAction) ([]entity.ParticipantChangeResult, error) {
+	client, jid, err := c.groupClient(sessionID, groupJID)
+	if err != nil {
+		return nil, err
+	}
+
+	participantJIDs, err := parseParticipantJIDs(participants)
+	if err != nil {
+		return nil, err
+	}
+
+	var change whatsmeow.ParticipantChange
+	switch action {
+	case entity.ParticipantActionAdd:
+		change = whatsmeow.ParticipantChangeAdd
+	case entity.ParticipantActionRemove:
+		change = whatsmeow.ParticipantChangeRemove
+	case entity.ParticipantActionPromote:
+		change = whatsmeow.ParticipantChangePromote
+	case entity.ParticipantActionDemote:
+		change = whatsmeow.ParticipantChangeDemote
+	default:
+		return nil, errors.ErrInvalidInput.WithMessage("invalid participant action")
+	}
+
+	updated, err := client.UpdateGroupParticipants(ctx, jid, participantJIDs, change)
+	if err != nil {
+		return nil, mapGroupError(err, "failed to update group participants")
+	}
+
+	results := make([]entity.ParticipantChangeResult, 0, len(updated))
+	for _, participant := range updated {
+		results = append(results, entity.ParticipantChangeResult{
+			JID:       c.resolveJID(ctx, client, participant.JID),
+			Success:   participant.Error == 0,
+			ErrorCode: participant.Error,
+		})
+	}
+
+	return results, nil
+}
+
+// GetGroupInviteLink returns the group invite link, generating a new one (and revoking the old one) if reset is true
+func (c *WhatsmeowClient) GetGroupInviteLink(ctx context.Context, sessionID, groupJID string, reset bool) (string, error) {
+	client, jid, err := c.groupClient(sessionID, groupJID)
+	if err != nil {
+		return "", err
+	}
+
+	link, err := client.GetGroupInviteLink(ctx, jid, reset)
+	if err != nil {
+		return "", mapGroupError(err, "failed to get group invite link")
+	}
+
+	return link, nil
+}
+
+// JoinGroupWithLink joins a group using an invite link or code and returns the group JID
+func (c *WhatsmeowClient) JoinGroupWithLink(ctx context.Context, sessionID, link string) (string, error) {
+	client, err := c.connectedClient(sessionID)
+	if err != nil {
+		return "", err
+	}
+
+	jid, err := client.JoinGroupWithLink(ctx, link)
+	if err != nil {
+		return "", mapGroupError(err, "failed to join group")
+	}
+
+	return jid.String(), nil
+}
+
+// LeaveGroup leaves a group
+func (c *WhatsmeowClient) LeaveGroup(ctx context.Context, sessionID, groupJID string) error {
+	client, jid, err := c.groupClient(sessionID, groupJID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.LeaveGroup(ctx, jid); err != nil {
+		return mapGroupError(err, "failed to leave group")
+	}
+
+	return nil
+}
+
+// connectedClient returns the whatsmeow client of a session, which must be connected
+func (c *WhatsmeowClient) connectedClient(sessionID string) (*whatsmeow.Client, error) {
+	c.mu.RLock()
+	client, exists := c.clients[sessionID]
+	c.mu.RUnlock()
+
+	if !exists {
+		return nil, errors.ErrSessionNotFound
+	}
+
+	if !client.IsConnected() {
+		return nil, errors.ErrDisconnected
+	}
+
+	return client, nil
+}
+
+// groupClient returns the connected client of a session along with the parsed group JID
+func (c *WhatsmeowClient) groupClient(sessionID, groupJID string) (*whatsmeow.Client, types.JID, error) {
+	client, err := c.connectedClient(sessionID)
+	if err != nil {
+		return nil, types.EmptyJID, err
+	}
+
+	jid, err := types.ParseJID(groupJID)
+	if err != nil || jid.Server != types.GroupServer {
+		return nil, types.EmptyJID, errors.ErrInvalidJID.WithMessage("invalid group JID")
+	}
+
+	return client, jid, nil
+}
+
+// parseParticipantJIDs parses participant phone numbers or JIDs
+func parseParticipantJIDs(participants []string) ([]types.JID, error) {
+	jids := make([]types.JID, 0, len(participants))
+	for _, participant := range participants {
+		jid, err := types.ParseJID(ToUserJID(participant))
+		if err != nil {
+			return nil, errors.ErrInvalidJID.WithMessage("invalid participant: " + participant).WithCause(err)
+		}
+		jids = append(jids, jid)
+	}
+	return jids, nil
+}
+
+// mapGroupError converts whatsmeow group errors to domain errors
+func mapGroupError(err error, message string) error {
+	switch {
+	case stderrors.Is(err, whatsmeow.ErrGroupNotFound), stderrors.Is(err, whatsmeow.ErrIQNotFound):
+		return errors.ErrGroupNotFound.WithCause(err)
+	case stderrors.Is(err, whatsmeow.ErrNotInGroup), stderrors.Is(err, whatsmeow.ErrGroupInviteLinkUnauthorized),
+		stderrors.Is(err, whatsmeow.ErrIQForbidden), stderrors.Is(err, whatsmeow.ErrIQNotAuthorized):
+		return errors.ErrGroupForbidden.WithCause(err)
+	case stderrors.Is(err, whatsmeow.ErrInviteLinkInvalid), stderrors.Is(err, whatsmeow.ErrInviteLinkRevoked):
+		return errors.ErrInvalidInviteLink.WithCause(err)
+	}
+	return errors.ErrWhatsApp.WithMessage(message).WithCause(err)
+}
@@ -0,0 +1,230 @@
+package whatsapp
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Reasons reported in group.left events
+const (
+	GroupLeftReasonLeft    = "left"
+	GroupLeftReasonRemoved = "removed"
+	GroupLeftReasonDeleted = "deleted"
+)
+
+// handleGroupInfoEvent converts a group change notification into group events
+func (c *WhatsmeowClient) handleGroupInfoEvent(sessionID string, client *whatsmeow.Client, info *events.GroupInfo) []*entity.Event {
+	ctx := context.Background()
+
+	groupEvents, err := BuildGroupInfoEvents(sessionID, info, sessionOwnJIDs(client), func(jid types.JID) string {
+		return c.resolveJID(ctx, client, jid)
+	})
+	if err != nil {
+		c.logger.Warnf("Failed to create group events for %s: %v", info.JID, err)
+		return nil
+	}
+
+	return groupEvents
+}
+
+// handleJoinedGroupEvent creates a group.joined event when the session is added to or joins a group
+func (c *WhatsmeowClient) handleJoinedGroupEvent(sessionID string, client *whatsmeow.Client, joined *events.JoinedGroup) (*entity.Event, error) {
+	ctx := context.Background()
+	now := time.Now()
+
+	payload := entity.GroupEvent{
+		GroupJID:  joined.JID.String(),
+		Reason:    joined.Reason,
+		Timestamp: now,
+		Group:     c.toGroupEntity(ctx, client, sessionID, &joined.GroupInfo, now),
+	}
+	if joined.Sender != nil {
+		payload.ActorJID = c.resolveJID(ctx, client, *joined.Sender)
+	}
+
+	return entity.NewEventWithPayload(generateEventID(), entity.EventTypeGroupJoined, sessionID, payload)
+}
+
+// applyGroupEvent keeps the local copy of a group in sync with a group event
+// Changes to groups that were never synced are ignored; the next sync picks them up.
+func (c *WhatsmeowClient) applyGroupEvent(ctx context.Context, client *whatsmeow.Client, event *entity.Event) {
+	var change entity.GroupEvent
+	if err := event.UnmarshalData(&change); err != nil {
+		return
+	}
+
+	switch event.Type {
+	case entity.EventTypeGroupJoined:
+		if change.Group == nil {
+			return
+		}
+		if err := c.groupRepo.Upsert(ctx, change.Group); err != nil {
+			c.logger.Warnf("Failed to store joined group %s: %v", change.GroupJID, err)
+		}
+		return
+	case entity.EventTypeGroupLeft:
+		if err := c.groupRepo.Delete(ctx, event.SessionID, change.GroupJID); err != nil && !stderrors.Is(err, errors.ErrGroupNotFound) {
+			c.logger.Warnf("Failed to remove left group %s: %v", change.GroupJID, err)
+		}
+		return
+	}
+
+	group, err := c.groupRepo.FindByJID(ctx, event.SessionID, change.GroupJID)
+	if err != nil {
+		return
+	}
+
+	group.ApplyEvent(event.Type, &change)
+	if event.Type == entity.EventTypeGroupParticipantsPromoted || event.Type == entity.EventTypeGroupParticipantsDemoted {
+		group.IsAdmin = group.HasAdmin(ownJIDStrings(ctx, c, client)...)
+	}
+
+	if err := c.groupRepo.Upsert(ctx, group); err != nil {
+		c.logger.Warnf("Failed to apply %s to group %s: %v", event.Type, change.GroupJID, err)
+	}
+}
+
+// sessionOwnJIDs returns the JIDs identifying the session itself (phone number and LID)
+func sessionOwnJIDs(client *whatsmeow.Client) []types.JID {
+	if client.Store == nil {
+		return nil
+	}
+	return []types.JID{client.Store.GetJID(), client.Store.GetLID()}
+}
+
+// ownJIDStrings returns the session's own JIDs in the form stored for group participants
+func ownJIDStrings(ctx context.Context, c *WhatsmeowClient, client *whatsmeow.Client) []string {
+	var jids []string
+	for _, jid := range sessionOwnJIDs(client) {
+		if !jid.IsEmpty() {
+			jids = append(jids, c.resolveJID(ctx, client, jid.ToNonAD()))
+		}
+	}
+	return jids
+}
+
+// BuildGroupInfoEvents converts a group change notification into group events.
+// A single notification can carry several changes, so it may produce more than one event.
+// ownJIDs identify the session itself (phone number and LID) so its own removal is reported as group.left;
+// resolveJID maps LIDs to phone number JIDs and may be nil.
+func BuildGroupInfoEvents(sessionID string, info *events.GroupInfo, ownJIDs []types.JID, resolveJID func(types.JID) string) ([]*entity.Event, error) {
+	if resolveJID == nil {
+		resolveJID = func(jid types.JID) string { return jid.String() }
+	}
+
+	base := entity.GroupEvent{
+		GroupJID:  info.JID.String(),
+		Timestamp: info.Timestamp,
+	}
+	if info.Sender != nil {
+		base.ActorJID = resolveJID(*info.Sender)
+	}
+
+	var groupEvents []*entity.Event
+	add := func(eventType entity.EventType, payload entity.GroupEvent) error {
+		event, err := entity.NewEventWithPayload(generateEventID(), eventType, sessionID, payload)
+		if err != nil {
+			return err
+		}
+		groupEvents = append(groupEvents, event)
+		return nil
+	}
+
+	// Settings changes are reported together as a single group.updated event
+	updated := base
+	if info.Name != nil {
+		updated.Name = &info.Name.Name
+	}
+	if info.Topic != nil {
+		description := info.Topic.Topic
+		if info.Topic.TopicDeleted {
+			description = ""
+		}
+		updated.Description = &description
+	}
+	if info.Announce != nil {
+		updated.IsAnnounce = &info.Announce.IsAnnounce
+	}
+	if info.Locked != nil {
+		updated.IsLocked = &info.Locked.IsLocked
+	}
+	if info.Ephemeral != nil {
+		ephemeralTime := 0
+		if info.Ephemeral.IsEphemeral {
+			ephemeralTime = int(info.Ephemeral.DisappearingTimer)
+		}
+		updated.EphemeralTime = &ephemeralTime
+	}
+	if updated.HasSettingsChange() {
+		if err := add(entity.EventTypeGroupUpdated, updated); err != nil {
+			return nil, err
+		}
+	}
+
+	participantChanges := []struct {
+		eventType entity.EventType
+		jids      []types.JID
+	}{
+		{entity.EventTypeGroupParticipantsAdded, info.Join},
+		{entity.EventTypeGroupParticipantsRemoved, info.Leave},
+		{entity.EventTypeGroupParticipantsPromoted, info.Promote},
+		{entity.EventTypeGroupParticipantsDemoted, info.Demote},
+	}
+	for _, change := range participantChanges {
+		if len(change.jids) == 0 {
+			continue
+		}
+
+		payload := base
+		payload.Participants = make([]string, 0, len(change.jids))
+		for _, jid := range change.jids {
+			payload.Participants = append(payload.Participants, resolveJID(jid))
+		}
+		if change.eventType == entity.EventTypeGroupParticipantsAdded {
+			payload.Reason = info.JoinReason
+		}
+
+		if err := add(change.eventType, payload); err != nil {
+			return nil, err
+		}
+	}
+
+	// The session itself is no longer part of the group
+	left := base
+	switch {
+	case info.Delete != nil:
+		left.Reason = GroupLeftReasonDeleted
+	case containsJID(info.Leave, ownJIDs):
+		left.Reason = GroupLeftReasonRemoved
+		if info.Sender == nil || containsJID([]types.JID{*info.Sender}, ownJIDs) {
+			left.Reason = GroupLeftReasonLeft
+		}
+	}
+	if left.Reason != "" {
+		if err := add(entity.EventTypeGroupLeft, left); err != nil {
+			return nil, err
+		}
+	}
+
+	return groupEvents, nil
+}
+
+// containsJID reports whether any of the candidates is in the list, ignoring device parts
+func containsJID(list []types.JID, candidates []types.JID) bool {
+	for _, jid := range list {
+		for _, candidate := range candidates {
+			if !candidate.IsEmpty() && jid.ToNonAD() == candidate.ToNonAD() {
+				return true
+			}
+		}
+	}
+	return false
+}
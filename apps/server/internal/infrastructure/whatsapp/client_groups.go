@@ -36,80 +36,96 @@ func (c *WhatsmeowClient) GetJoinedGroups(ctx context.Context, sessionID string)
 	now := time.Now()
 
 	for _, waGroup := range waGroups {
-		// Fetch chat settings (archived/muted) from whatsmeow store
-		isArchived := false
-		isMuted := false
-		var mutedUntil *time.Time
-
-		if client.Store != nil && client.Store.ChatSettings != nil {
-			chatSettings, err := client.Store.ChatSettings.GetChatSettings(ctx, waGroup.JID)
-			if err == nil {
-				isArchived = chatSettings.Archived
-				isMuted = chatSettings.MutedUntil != time.Time{}
-				if isMuted {
-					mutedUntil = &chatSettings.MutedUntil
-				}
+		groups = append(groups, c.toGroupEntity(ctx, client, sessionID, waGroup, now))
+	}
+
+	return groups, nil
+}
+
+// toGroupEntity converts whatsmeow group info to a domain group
+func (c *WhatsmeowClient) toGroupEntity(ctx context.Context, client *whatsmeow.Client, sessionID string, waGroup *types.GroupInfo, now time.Time) *entity.Group {
+	// Fetch chat settings (archived/muted) from whatsmeow store
+	isArchived := false
+	isMuted := false
+	var mutedUntil *time.Time
+
+	if client.Store != nil && client.Store.ChatSettings != nil {
+		chatSettings, err := client.Store.ChatSettings.GetChatSettings(ctx, waGroup.JID)
+		if err == nil {
+			isArchived = chatSettings.Archived
+			isMuted = chatSettings.MutedUntil != time.Time{}
+			if isMuted {
+				mutedUntil = &chatSettings.MutedUntil
 			}
 		}
+	}
 
-		group := &entity.Group{
-			JID:         waGroup.JID.String(),
-			Name:        waGroup.Name,
-			SessionID:   sessionID,
-			MemberCount: len(waGroup.Participants),
-			IsAnnounce:  waGroup.IsAnnounce,
-			IsLocked:    waGroup.IsLocked,
-			IsArchived:  isArchived,
-			IsMuted:     isMuted,
-			MutedUntil:  mutedUntil,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
+	group := &entity.Group{
+		JID:         waGroup.JID.String(),
+		Name:        waGroup.Name,
+		SessionID:   sessionID,
+		MemberCount: len(waGroup.Participants),
+		IsAnnounce:  waGroup.IsAnnounce,
+		IsLocked:    waGroup.IsLocked,
+		IsArchived:  isArchived,
+		IsMuted:     isMuted,
+		MutedUntil:  mutedUntil,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
 
-		// Set optional fields
-		if waGroup.Topic != "" {
-			group.Description = &waGroup.Topic
-		}
+	// Set optional fields
+	if waGroup.Topic != "" {
+		group.Description = &waGroup.Topic
+	}
 
-		// Resolve owner JID from LID if needed
-		if !waGroup.OwnerJID.IsEmpty() {
-			ownerJID := c.resolveJID(ctx, client, waGroup.OwnerJID)
-			group.OwnerJID = &ownerJID
-		}
+	// Resolve owner JID from LID if needed
+	if !waGroup.OwnerJID.IsEmpty() {
+		ownerJID := c.resolveJID(ctx, client, waGroup.OwnerJID)
+		group.OwnerJID = &ownerJID
+	}
 
-		if !waGroup.GroupCreated.IsZero() {
-			group.GroupCreatedAt = &waGroup.GroupCreated
-		}
+	if !waGroup.GroupCreated.IsZero() {
+		group.GroupCreatedAt = &waGroup.GroupCreated
+	}
 
-		// Convert participants
-		participants := make([]entity.Participant, 0, len(waGroup.Participants))
-		for _, waParticipant := range waGroup.Participants {
-			role := convertParticipantRole(waParticipant)
+	if waGroup.IsEphemeral {
+		ephemeralTime := int(waGroup.DisappearingTimer)
+		group.IsEphemeral = true
+		group.EphemeralTime = &ephemeralTime
+	}
 
-			// Resolve participant JID from LID if needed
-			resolvedJID := c.resolveJID(ctx, client, waParticipant.JID)
+	// Convert participants
+	ownJIDs := sessionOwnJIDs(client)
+	participants := make([]entity.Participant, 0, len(waGroup.Participants))
+	for _, waParticipant := range waGroup.Participants {
+		role := convertParticipantRole(waParticipant)
+		if role != entity.ParticipantRoleMember &&
+			containsJID([]types.JID{waParticipant.JID, waParticipant.PhoneNumber, waParticipant.LID}, ownJIDs) {
+			group.IsAdmin = true
+		}
 
-			participant := entity.Participant{
-				JID:       resolvedJID,
-				Role:      role,
-				JoinedAt:  now,
-				UpdatedAt: now,
-			}
+		// Resolve participant JID from LID if needed
+		resolvedJID := c.resolveJID(ctx, client, waParticipant.JID)
 
-			// Try to get display name from multiple sources
-			displayName := c.getParticipantDisplayName(ctx, client, waParticipant)
-			if displayName != "" {
-				participant.DisplayName = &displayName
-			}
+		participant := entity.Participant{
+			JID:       resolvedJID,
+			Role:      role,
+			JoinedAt:  now,
+			UpdatedAt: now,
+		}
 
-			participants = append(participants, participant)
+		// Try to get display name from multiple sources
+		displayName := c.getParticipantDisplayName(ctx, client, waParticipant)
+		if displayName != "" {
+			participant.DisplayName = &displayName
 		}
-		group.Participants = participants
 
-		groups = append(groups, group)
+		participants = append(participants, participant)
 	}
+	group.Participants = participants
 
-	return groups, nil
+	return group
 }
 
 // resolveJID resolves a JID, converting LID to phone number if needed
@@ -2,15 +2,16 @@ package whatsapp
 
 import (
 	"context"
-	"strings"
 	"time"
 
 	"whatspire/internal/domain/entity"
 	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/valueobject"
 
 	"go.mau.fi/whatsmeow"
 	waE2E "go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
 )
 
 // SendMessage sends a message through WhatsApp
@@ -51,12 +52,15 @@ func (c *WhatsmeowClient) sendMessageInternal(ctx context.Context, msg *entity.M
 		return errors.ErrDisconnected
 	}
 
+	// Space out sends for sessions with account protection enabled
+	if err := c.waitForSendSlot(ctx, msg.SessionID); err != nil {
+		return err
+	}
+
 	c.logger.Infof("sendMessageInternal: client is connected, sending to %s", msg.To)
 
-	// Parse recipient JID - strip leading + from phone number
-	phoneNumber := string(msg.To)
-	phoneNumber = strings.TrimPrefix(phoneNumber, "+")
-	recipientJID, err := types.ParseJID(phoneNumber + "@s.whatsapp.net")
+	// Parse recipient JID - a group JID, or a phone number sent to the user's chat
+	recipientJID, err := types.ParseJID(valueobject.RecipientChatJID(msg.To))
 	if err != nil {
 		return errors.ErrInvalidPhoneNumber.WithCause(err)
 	}
@@ -66,15 +70,11 @@ func (c *WhatsmeowClient) sendMessageInternal(ctx context.Context, msg *entity.M
 
 	switch msg.Type {
 	case entity.MessageTypeImage:
-		if mediaUploader == nil {
-			return errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
-		}
-		if msg.Content.ImageURL == nil || *msg.Content.ImageURL == "" {
-			return errors.ErrEmptyContent.WithMessage("image URL is required")
-		}
-		uploadResult, err := mediaUploader.UploadImage(ctx, msg.SessionID, *msg.Content.ImageURL)
+		uploadResult, err := messageMedia(msg, mediaUploader, msg.Content.ImageURL, "image", func(url string) (*entity.MediaUploadResult, error) {
+			return mediaUploader.UploadImage(ctx, msg.SessionID, url)
+		})
 		if err != nil {
-			return errors.ErrMediaUploadFailed.WithCause(err)
+			return err
 		}
 		caption := ""
 		if msg.Content.Caption != nil {
@@ -83,19 +83,17 @@ func (c *WhatsmeowClient) sendMessageInternal(ctx context.Context, msg *entity.M
 		waMsg = BuildImageMessage(uploadResult, caption)
 
 	case entity.MessageTypeDocument:
-		if mediaUploader == nil {
-			return errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
-		}
-		if msg.Content.DocURL == nil || *msg.Content.DocURL == "" {
-			return errors.ErrEmptyContent.WithMessage("document URL is required")
-		}
 		filename := ""
-		if msg.Content.Caption != nil {
+		if msg.Content.Filename != nil {
+			filename = *msg.Content.Filename
+		} else if msg.Content.Caption != nil {
 			filename = *msg.Content.Caption // Use caption as filename for documents
 		}
-		uploadResult, err := mediaUploader.UploadDocument(ctx, msg.SessionID, *msg.Content.DocURL, filename)
+		uploadResult, err := messageMedia(msg, mediaUploader, msg.Content.DocURL, "document", func(url string) (*entity.MediaUploadResult, error) {
+			return mediaUploader.UploadDocument(ctx, msg.SessionID, url, filename)
+		})
 		if err != nil {
-			return errors.ErrMediaUploadFailed.WithCause(err)
+			return err
 		}
 		caption := ""
 		if msg.Content.Caption != nil {
@@ -104,28 +102,20 @@ func (c *WhatsmeowClient) sendMessageInternal(ctx context.Context, msg *entity.M
 		waMsg = BuildDocumentMessage(uploadResult, filename, caption)
 
 	case entity.MessageTypeAudio:
-		if mediaUploader == nil {
-			return errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
-		}
-		if msg.Content.AudioURL == nil || *msg.Content.AudioURL == "" {
-			return errors.ErrEmptyContent.WithMessage("audio URL is required")
-		}
-		uploadResult, err := mediaUploader.UploadAudio(ctx, msg.SessionID, *msg.Content.AudioURL)
+		uploadResult, err := messageMedia(msg, mediaUploader, msg.Content.AudioURL, "audio", func(url string) (*entity.MediaUploadResult, error) {
+			return mediaUploader.UploadAudio(ctx, msg.SessionID, url)
+		})
 		if err != nil {
-			return errors.ErrMediaUploadFailed.WithCause(err)
+			return err
 		}
 		waMsg = BuildAudioMessage(uploadResult)
 
 	case entity.MessageTypeVideo:
-		if mediaUploader == nil {
-			return errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
-		}
-		if msg.Content.VideoURL == nil || *msg.Content.VideoURL == "" {
-			return errors.ErrEmptyContent.WithMessage("video URL is required")
-		}
-		uploadResult, err := mediaUploader.UploadVideo(ctx, msg.SessionID, *msg.Content.VideoURL)
+		uploadResult, err := messageMedia(msg, mediaUploader, msg.Content.VideoURL, "video", func(url string) (*entity.MediaUploadResult, error) {
+			return mediaUploader.UploadVideo(ctx, msg.SessionID, url)
+		})
 		if err != nil {
-			return errors.ErrMediaUploadFailed.WithCause(err)
+			return err
 		}
 		caption := ""
 		if msg.Content.Caption != nil {
@@ -133,6 +123,33 @@ func (c *WhatsmeowClient) sendMessageInternal(ctx context.Context, msg *entity.M
 		}
 		waMsg = BuildVideoMessage(uploadResult, caption)
 
+	case entity.MessageTypeSticker:
+		uploadResult, err := messageMedia(msg, mediaUploader, msg.Content.StickerURL, "sticker", func(url string) (*entity.MediaUploadResult, error) {
+			return mediaUploader.UploadSticker(ctx, msg.SessionID, url)
+		})
+		if err != nil {
+			return err
+		}
+		waMsg = BuildStickerMessage(uploadResult)
+
+	case entity.MessageTypeLocation:
+		waMsg, err = BuildLocationMessage(msg)
+		if err != nil {
+			return err
+		}
+
+	case entity.MessageTypeContact:
+		waMsg, err = BuildContactMessage(msg)
+		if err != nil {
+			return err
+		}
+
+	case entity.MessageTypePoll:
+		waMsg, err = BuildPollMessage(msg)
+		if err != nil {
+			return err
+		}
+
 	default:
 		// Text message
 		waMsg, err = BuildTextMessage(msg)
@@ -141,15 +158,51 @@ func (c *WhatsmeowClient) sendMessageInternal(ctx context.Context, msg *entity.M
 		}
 	}
 
+	// Attach reply and mention context
+	ownJID := ""
+	if client.Store.ID != nil {
+		ownJID = client.Store.ID.ToNonAD().String()
+	}
+	ApplyContextInfo(waMsg, BuildContextInfo(msg, ownJID))
+
 	// Send message with retry
-	_, err = c.sendWithRetry(ctx, client, recipientJID, waMsg)
+	resp, err := c.sendWithRetry(ctx, client, recipientJID, waMsg)
 	if err != nil {
 		return errors.ErrMessageSendFailed.WithCause(err)
 	}
 
+	// Record the ID WhatsApp knows the message by so receipts can be matched
+	msg.WhatsAppID = resp.ID
+
 	return nil
 }
 
+// messageMedia returns the media uploaded with the send request, or uploads the media at url
+func messageMedia(
+	msg *entity.Message,
+	mediaUploader *WhatsmeowMediaUploader,
+	url *string,
+	kind string,
+	upload func(url string) (*entity.MediaUploadResult, error),
+) (*entity.MediaUploadResult, error) {
+	if msg.Content.Media != nil {
+		return msg.Content.Media, nil
+	}
+
+	if mediaUploader == nil {
+		return nil, errors.ErrMediaUploadFailed.WithMessage("media uploader not available")
+	}
+	if url == nil || *url == "" {
+		return nil, errors.ErrEmptyContent.WithMessage(kind + " URL is required")
+	}
+
+	uploadResult, err := upload(*url)
+	if err != nil {
+		return nil, errors.ErrMediaUploadFailed.WithCause(err)
+	}
+	return uploadResult, nil
+}
+
 // sendWithRetry sends a message with exponential backoff retry
 func (c *WhatsmeowClient) sendWithRetry(ctx context.Context, client *whatsmeow.Client, to types.JID, msg *waE2E.Message) (whatsmeow.SendResponse, error) {
 	retryPolicy := NewRetryPolicy(RetryConfig{
@@ -160,8 +213,11 @@ func (c *WhatsmeowClient) sendWithRetry(ctx context.Context, client *whatsmeow.C
 		JitterFactor: 0.1,
 	})
 
+	// Reuse one message ID across attempts so a retry cannot deliver a duplicate
+	extra := whatsmeow.SendRequestExtra{ID: client.GenerateMessageID()}
+
 	result, err := retryPolicy.ExecuteWithResult(ctx, func() (any, error) {
-		return client.SendMessage(ctx, to, msg)
+		return client.SendMessage(ctx, to, msg, extra)
 	})
 
 	if err != nil {
@@ -214,6 +270,108 @@ func (c *WhatsmeowClient) sendReactionInternal(ctx context.Context, sessionID, c
 	return nil
 }
 
+// EditMessage replaces the text, or the caption of a media message, previously sent by the session
+func (c *WhatsmeowClient) EditMessage(ctx context.Context, sessionID, chatJID, messageID string, messageType entity.MessageType, text string) error {
+	// Use circuit breaker if enabled
+	if c.circuitBreaker != nil {
+		_, err := c.circuitBreaker.Execute(ctx, func() (any, error) {
+			return nil, c.editMessageInternal(ctx, sessionID, chatJID, messageID, messageType, text)
+		})
+		return err
+	}
+	return c.editMessageInternal(ctx, sessionID, chatJID, messageID, messageType, text)
+}
+
+// editMessageInternal performs the actual message edit logic
+func (c *WhatsmeowClient) editMessageInternal(ctx context.Context, sessionID, chatJID, messageID string, messageType entity.MessageType, text string) error {
+	c.mu.RLock()
+	client, exists := c.clients[sessionID]
+	c.mu.RUnlock()
+
+	if !exists {
+		return errors.ErrSessionNotFound
+	}
+
+	if !client.IsConnected() {
+		return errors.ErrDisconnected
+	}
+
+	// Parse chat JID
+	jid, err := types.ParseJID(chatJID)
+	if err != nil {
+		return errors.ErrInvalidInput.WithMessage("invalid chat JID").WithCause(err)
+	}
+
+	// Build edit message wrapping the new text
+	editMsg := client.BuildEdit(jid, types.MessageID(messageID), editedContent(messageType, text))
+
+	// Send edit with retry
+	_, err = c.sendWithRetry(ctx, client, jid, editMsg)
+	if err != nil {
+		return errors.ErrMessageSendFailed.WithMessage("failed to edit message").WithCause(err)
+	}
+
+	return nil
+}
+
+// editedContent builds the replacement content of an edit: the caption of a media message or the text otherwise
+func editedContent(messageType entity.MessageType, text string) *waE2E.Message {
+	switch messageType {
+	case entity.MessageTypeImage:
+		return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{Caption: proto.String(text)}}
+	case entity.MessageTypeVideo:
+		return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{Caption: proto.String(text)}}
+	case entity.MessageTypeDocument:
+		return &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{Caption: proto.String(text)}}
+	default:
+		return &waE2E.Message{Conversation: proto.String(text)}
+	}
+}
+
+// RevokeMessage deletes a message previously sent by the session for everyone in the chat
+func (c *WhatsmeowClient) RevokeMessage(ctx context.Context, sessionID, chatJID, messageID string) error {
+	// Use circuit breaker if enabled
+	if c.circuitBreaker != nil {
+		_, err := c.circuitBreaker.Execute(ctx, func() (any, error) {
+			return nil, c.revokeMessageInternal(ctx, sessionID, chatJID, messageID)
+		})
+		return err
+	}
+	return c.revokeMessageInternal(ctx, sessionID, chatJID, messageID)
+}
+
+// revokeMessageInternal performs the actual message revoke logic
+func (c *WhatsmeowClient) revokeMessageInternal(ctx context.Context, sessionID, chatJID, messageID string) error {
+	c.mu.RLock()
+	client, exists := c.clients[sessionID]
+	c.mu.RUnlock()
+
+	if !exists {
+		return errors.ErrSessionNotFound
+	}
+
+	if !client.IsConnected() {
+		return errors.ErrDisconnected
+	}
+
+	// Parse chat JID
+	jid, err := types.ParseJID(chatJID)
+	if err != nil {
+		return errors.ErrInvalidInput.WithMessage("invalid chat JID").WithCause(err)
+	}
+
+	// An empty sender revokes our own message
+	revokeMsg := client.BuildRevoke(jid, types.EmptyJID, types.MessageID(messageID))
+
+	// Send revoke with retry
+	_, err = c.sendWithRetry(ctx, client, jid, revokeMsg)
+	if err != nil {
+		return errors.ErrMessageSendFailed.WithMessage("failed to revoke message").WithCause(err)
+	}
+
+	return nil
+}
+
 // SendReadReceipt sends read receipts for multiple messages atomically
 func (c *WhatsmeowClient) SendReadReceipt(ctx context.Context, sessionID, chatJID string, messageIDs []string) error {
 	// Use circuit breaker if enabled
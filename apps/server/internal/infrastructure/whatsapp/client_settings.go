@@ -0,0 +1,180 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	"whatspire/internal/domain/entity"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+const (
+	// alwaysOnlineInterval is how often presence is re-sent for always-online sessions
+	alwaysOnlineInterval = 1 * time.Minute
+
+	// accountProtectionInterval is the minimum gap between outgoing messages of a protected session
+	accountProtectionInterval = 3 * time.Second
+)
+
+// SetSessionSettings sets the behaviour settings enforced for a session
+func (c *WhatsmeowClient) SetSessionSettings(sessionID string, settings entity.SessionSettings) {
+	c.settingsMu.Lock()
+	c.sessionSettings[sessionID] = settings
+	c.settingsMu.Unlock()
+
+	c.logger.Debugf("SetSessionSettings: sessionID=%s", sessionID)
+
+	// Apply presence changes to an already connected session
+	if settings.AlwaysOnline && c.IsConnected(sessionID) {
+		c.startAlwaysOnline(sessionID)
+	} else if !settings.AlwaysOnline {
+		c.stopAlwaysOnline(sessionID)
+	}
+}
+
+// GetSessionSettings gets the behaviour settings enforced for a session
+func (c *WhatsmeowClient) GetSessionSettings(sessionID string) entity.SessionSettings {
+	c.settingsMu.RLock()
+	defer c.settingsMu.RUnlock()
+
+	settings, exists := c.sessionSettings[sessionID]
+	if !exists {
+		return entity.DefaultSessionSettings()
+	}
+
+	return settings
+}
+
+// isIgnoredChat reports whether traffic from a chat must be dropped for a session
+func (c *WhatsmeowClient) isIgnoredChat(sessionID string, chat types.JID) bool {
+	settings := c.GetSessionSettings(sessionID)
+
+	switch chat.Server {
+	case types.GroupServer:
+		return settings.IgnoreGroups
+	case types.BroadcastServer:
+		return settings.IgnoreBroadcasts
+	case types.NewsletterServer:
+		return settings.IgnoreChannels
+	}
+	return false
+}
+
+// autoReadMessage marks an incoming message as read if the session has read_messages enabled
+func (c *WhatsmeowClient) autoReadMessage(sessionID string, client *whatsmeow.Client, msg *events.Message) {
+	if msg.Info.IsFromMe || !c.GetSessionSettings(sessionID).ReadMessages {
+		return
+	}
+
+	go func() {
+		ctx := context.Background()
+		err := client.MarkRead(ctx, []types.MessageID{msg.Info.ID}, time.Now(), msg.Info.Chat, msg.Info.Sender)
+		if err != nil {
+			c.logger.Warnf("Failed to auto-read message %s for session %s: %v", msg.Info.ID, sessionID, err)
+		}
+	}()
+}
+
+// handleCallOffer rejects an incoming call if the session has auto_reject_calls enabled
+func (c *WhatsmeowClient) handleCallOffer(sessionID string, client *whatsmeow.Client, call *events.CallOffer) {
+	if !c.GetSessionSettings(sessionID).AutoRejectCalls {
+		return
+	}
+
+	go func() {
+		ctx := context.Background()
+		if err := client.RejectCall(ctx, call.From, call.CallID); err != nil {
+			c.logger.Warnf("Failed to reject call %s for session %s: %v", call.CallID, sessionID, err)
+			return
+		}
+		c.logger.Infof("Rejected call %s from %s for session %s", call.CallID, call.From, sessionID)
+	}()
+}
+
+// startAlwaysOnline keeps the session presence "available" until stopAlwaysOnline is called
+func (c *WhatsmeowClient) startAlwaysOnline(sessionID string) {
+	c.settingsMu.Lock()
+	if _, running := c.alwaysOnline[sessionID]; running {
+		c.settingsMu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	c.alwaysOnline[sessionID] = stop
+	c.settingsMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(alwaysOnlineInterval)
+		defer ticker.Stop()
+
+		for {
+			c.sendAvailable(sessionID)
+
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// stopAlwaysOnline stops the always-online presence loop of a session, if any
+func (c *WhatsmeowClient) stopAlwaysOnline(sessionID string) {
+	c.settingsMu.Lock()
+	defer c.settingsMu.Unlock()
+
+	if stop, running := c.alwaysOnline[sessionID]; running {
+		close(stop)
+		delete(c.alwaysOnline, sessionID)
+	}
+}
+
+// sendAvailable sends an "available" presence for a connected session
+func (c *WhatsmeowClient) sendAvailable(sessionID string) {
+	c.mu.RLock()
+	client, exists := c.clients[sessionID]
+	c.mu.RUnlock()
+
+	if !exists || !client.IsConnected() {
+		return
+	}
+
+	if err := client.SendPresence(context.Background(), types.PresenceAvailable); err != nil {
+		c.logger.Warnf("Failed to send always-online presence for session %s: %v", sessionID, err)
+	}
+}
+
+// waitForSendSlot delays an outgoing message so protected sessions don't send in bursts
+func (c *WhatsmeowClient) waitForSendSlot(ctx context.Context, sessionID string) error {
+	if !c.GetSessionSettings(sessionID).AccountProtection {
+		return nil
+	}
+
+	// Reserve the next free slot before waiting so concurrent sends queue up behind it
+	c.settingsMu.Lock()
+	now := time.Now()
+	slot := c.lastSendAt[sessionID].Add(accountProtectionInterval)
+	if slot.Before(now) {
+		slot = now
+	}
+	c.lastSendAt[sessionID] = slot
+	c.settingsMu.Unlock()
+
+	wait := time.Until(slot)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -26,6 +26,9 @@ type DownloaderConfig struct {
 
 	// UserAgent is the User-Agent header to use for requests
 	UserAgent string
+
+	// TempDir is where downloads are spooled before upload (empty means the system temp directory)
+	TempDir string
 }
 
 // DefaultDownloaderConfig returns default downloader configuration
@@ -61,24 +64,79 @@ func NewHTTPMediaDownloader(config DownloaderConfig, constraints *valueobject.Me
 
 // Download downloads media from a URL and returns the content with metadata
 func (d *HTTPMediaDownloader) Download(ctx context.Context, info *entity.MediaDownloadInfo) (*repository.DownloadedMedia, error) {
+	resp, parsedURL, maxSize, err := d.fetch(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read body with size limit
+	limitedReader := io.LimitReader(resp.Body, maxSize+1) // +1 to detect if exceeded
+	data, err := io.ReadAll(limitedReader)
+	if err != nil {
+		return nil, errors.ErrMediaDownloadFailed.WithCause(err)
+	}
+
+	// Check if we hit the limit
+	if int64(len(data)) > maxSize {
+		return nil, errors.ErrMediaTooLarge.WithMessage(
+			fmt.Sprintf("file size exceeds maximum %d bytes", maxSize))
+	}
+
+	// Determine MIME type
+	mimeType := d.determineMimeType(resp, data, info)
+
+	// Determine filename
+	filename := d.determineFilename(resp, parsedURL, info)
+
+	return repository.NewDownloadedMedia(data, mimeType, filename), nil
+}
+
+// DownloadToFile downloads media from a URL into a temporary file, hashing it on the way
+// Memory use does not depend on the file size; the caller must Close the returned file
+func (d *HTTPMediaDownloader) DownloadToFile(ctx context.Context, info *entity.MediaDownloadInfo) (*MediaFile, error) {
+	resp, parsedURL, maxSize, err := d.fetch(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	media, err := spoolMedia(resp.Body, d.config.TempDir, maxSize)
+	if err != nil {
+		if errors.GetDomainError(err) != nil {
+			return nil, err
+		}
+		return nil, errors.ErrMediaDownloadFailed.WithCause(err)
+	}
+
+	// The MIME type is sniffed from the first bytes when the server does not declare it
+	media.MimeType = d.determineMimeType(resp, media.Head(), info)
+	media.Filename = d.determineFilename(resp, parsedURL, info)
+
+	return media, nil
+}
+
+// fetch requests media from a URL and returns the response once its status and declared size are acceptable,
+// along with the parsed URL and the maximum number of bytes to read from the body
+func (d *HTTPMediaDownloader) fetch(ctx context.Context, info *entity.MediaDownloadInfo) (*http.Response, *url.URL, int64, error) {
 	if info == nil || !info.IsValid() {
-		return nil, errors.ErrInvalidInput.WithMessage("invalid download info")
+		return nil, nil, 0, errors.ErrInvalidInput.WithMessage("invalid download info")
 	}
 
 	// Validate URL
 	parsedURL, err := url.Parse(info.URL)
 	if err != nil {
-		return nil, errors.ErrMediaDownloadFailed.WithCause(err).WithMessage("invalid URL")
+		return nil, nil, 0, errors.ErrMediaDownloadFailed.WithCause(err).WithMessage("invalid URL")
 	}
 
 	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-		return nil, errors.ErrMediaDownloadFailed.WithMessage("only HTTP and HTTPS URLs are supported")
+		return nil, nil, 0, errors.ErrMediaDownloadFailed.WithMessage("only HTTP and HTTPS URLs are supported")
 	}
 
 	// Create request with context
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, info.URL, nil)
 	if err != nil {
-		return nil, errors.ErrMediaDownloadFailed.WithCause(err)
+		return nil, nil, 0, errors.ErrMediaDownloadFailed.WithCause(err)
 	}
 
 	req.Header.Set("User-Agent", d.config.UserAgent)
@@ -86,13 +144,13 @@ func (d *HTTPMediaDownloader) Download(ctx context.Context, info *entity.MediaDo
 	// Execute request
 	resp, err := d.client.Do(req)
 	if err != nil {
-		return nil, errors.ErrMediaDownloadFailed.WithCause(err)
+		return nil, nil, 0, errors.ErrMediaDownloadFailed.WithCause(err)
 	}
-	defer resp.Body.Close()
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, errors.ErrMediaDownloadFailed.WithMessage(
+		resp.Body.Close()
+		return nil, nil, 0, errors.ErrMediaDownloadFailed.WithMessage(
 			fmt.Sprintf("unexpected status code: %d", resp.StatusCode))
 	}
 
@@ -104,30 +162,12 @@ func (d *HTTPMediaDownloader) Download(ctx context.Context, info *entity.MediaDo
 
 	// Check Content-Length if available
 	if resp.ContentLength > 0 && resp.ContentLength > maxSize {
-		return nil, errors.ErrMediaTooLarge.WithMessage(
+		resp.Body.Close()
+		return nil, nil, 0, errors.ErrMediaTooLarge.WithMessage(
 			fmt.Sprintf("file size %d exceeds maximum %d", resp.ContentLength, maxSize))
 	}
 
-	// Read body with size limit
-	limitedReader := io.LimitReader(resp.Body, maxSize+1) // +1 to detect if exceeded
-	data, err := io.ReadAll(limitedReader)
-	if err != nil {
-		return nil, errors.ErrMediaDownloadFailed.WithCause(err)
-	}
-
-	// Check if we hit the limit
-	if int64(len(data)) > maxSize {
-		return nil, errors.ErrMediaTooLarge.WithMessage(
-			fmt.Sprintf("file size exceeds maximum %d bytes", maxSize))
-	}
-
-	// Determine MIME type
-	mimeType := d.determineMimeType(resp, data, info)
-
-	// Determine filename
-	filename := d.determineFilename(resp, parsedURL, info)
-
-	return repository.NewDownloadedMedia(data, mimeType, filename), nil
+	return resp, parsedURL, maxSize, nil
 }
 
 // DetectMimeType detects the MIME type of the downloaded content
@@ -204,7 +244,41 @@ func extractFilenameFromContentDisposition(header string) string {
 	return ""
 }
 
-// ValidateAndDownload downloads media and validates it against constraints
+// ValidateAndDownloadToFile downloads media into a temporary file and validates it against constraints
+// The caller must Close the returned file
+func (d *HTTPMediaDownloader) ValidateAndDownloadToFile(
+	ctx context.Context,
+	info *entity.MediaDownloadInfo,
+	expectedMediaType valueobject.MediaType,
+) (*MediaFile, error) {
+	// Set max size based on expected media type
+	if info.MaxSize == 0 {
+		info.MaxSize = d.constraints.GetMaxSize(expectedMediaType)
+	}
+
+	// Download the media
+	media, err := d.DownloadToFile(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate MIME type
+	if err := d.constraints.ValidateMimeType(expectedMediaType, media.MimeType); err != nil {
+		_ = media.Close()
+		return nil, err
+	}
+
+	// Validate size
+	if err := d.constraints.ValidateSize(expectedMediaType, media.Size); err != nil {
+		_ = media.Close()
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// ValidateAndDownload downloads media into memory and validates it against constraints
+// Only use it for small media such as profile and group pictures; prefer ValidateAndDownloadToFile
 func (d *HTTPMediaDownloader) ValidateAndDownload(
 	ctx context.Context,
 	info *entity.MediaDownloadInfo,
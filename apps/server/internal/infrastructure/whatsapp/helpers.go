@@ -1,7 +1,10 @@
 package whatsapp
 
 import (
+	"crypto/rand"
 	"encoding/base64"
+	"fmt"
+	"strings"
 	"time"
 
 	"whatspire/internal/domain/entity"
@@ -111,6 +114,112 @@ func BuildVideoMessage(uploadResult *entity.MediaUploadResult, caption string) *
 	}
 }
 
+// BuildStickerMessage builds a WhatsApp sticker message from upload result
+func BuildStickerMessage(uploadResult *entity.MediaUploadResult) *waE2E.Message {
+	stickerMsg := &waE2E.StickerMessage{
+		URL:           proto.String(uploadResult.URL),
+		DirectPath:    proto.String(uploadResult.DirectPath),
+		MediaKey:      uploadResult.MediaKey,
+		FileEncSHA256: uploadResult.FileEncHash,
+		FileSHA256:    uploadResult.FileHash,
+		FileLength:    proto.Uint64(uploadResult.FileLength),
+		Mimetype:      proto.String(uploadResult.MimeType),
+	}
+
+	return &waE2E.Message{
+		StickerMessage: stickerMsg,
+	}
+}
+
+// BuildLocationMessage builds a WhatsApp location message from a domain message
+func BuildLocationMessage(msg *entity.Message) (*waE2E.Message, error) {
+	location := msg.Content.Location
+	if location == nil {
+		return nil, errors.ErrEmptyContent.WithMessage("location is required")
+	}
+
+	locationMsg := &waE2E.LocationMessage{
+		DegreesLatitude:  proto.Float64(location.Latitude),
+		DegreesLongitude: proto.Float64(location.Longitude),
+	}
+
+	if location.Name != "" {
+		locationMsg.Name = proto.String(location.Name)
+	}
+
+	if location.Address != "" {
+		locationMsg.Address = proto.String(location.Address)
+	}
+
+	return &waE2E.Message{
+		LocationMessage: locationMsg,
+	}, nil
+}
+
+// BuildContactMessage builds a WhatsApp contact message from a domain message
+// A single card is sent as a contact message, several as a contacts array
+func BuildContactMessage(msg *entity.Message) (*waE2E.Message, error) {
+	if len(msg.Content.Contacts) == 0 {
+		return nil, errors.ErrEmptyContent.WithMessage("at least one contact is required")
+	}
+
+	contacts := make([]*waE2E.ContactMessage, len(msg.Content.Contacts))
+	for i, card := range msg.Content.Contacts {
+		contacts[i] = &waE2E.ContactMessage{
+			DisplayName: proto.String(card.DisplayName),
+			Vcard:       proto.String(card.VCard),
+		}
+	}
+
+	if len(contacts) == 1 {
+		return &waE2E.Message{
+			ContactMessage: contacts[0],
+		}, nil
+	}
+
+	return &waE2E.Message{
+		ContactsArrayMessage: &waE2E.ContactsArrayMessage{
+			DisplayName: proto.String(fmt.Sprintf("%d contacts", len(contacts))),
+			Contacts:    contacts,
+		},
+	}, nil
+}
+
+// BuildPollMessage builds a WhatsApp poll creation message from a domain message
+func BuildPollMessage(msg *entity.Message) (*waE2E.Message, error) {
+	poll := msg.Content.Poll
+	if poll == nil || poll.Question == "" || len(poll.Options) < 2 {
+		return nil, errors.ErrEmptyContent.WithMessage("poll question and at least two options are required")
+	}
+
+	selectableCount := poll.SelectableCount
+	if selectableCount < 0 || selectableCount > len(poll.Options) {
+		selectableCount = 0
+	}
+
+	options := make([]*waE2E.PollCreationMessage_Option, len(poll.Options))
+	for i, option := range poll.Options {
+		options[i] = &waE2E.PollCreationMessage_Option{OptionName: proto.String(option)}
+	}
+
+	// Votes are encrypted with the message secret, so every poll needs its own
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.ErrInternal.WithCause(err)
+	}
+
+	return &waE2E.Message{
+		PollCreationMessage: &waE2E.PollCreationMessage{
+			Name:                   proto.String(poll.Question),
+			Options:                options,
+			SelectableOptionsCount: proto.Uint32(uint32(selectableCount)),
+		},
+		MessageContextInfo: &waE2E.MessageContextInfo{
+			MessageSecret: secret,
+		},
+	}, nil
+}
+
 // BuildTextMessage builds a WhatsApp text message from a domain message
 func BuildTextMessage(msg *entity.Message) (*waE2E.Message, error) {
 	if msg.Content.Text == nil || *msg.Content.Text == "" {
@@ -121,6 +230,93 @@ func BuildTextMessage(msg *entity.Message) (*waE2E.Message, error) {
 	}, nil
 }
 
+// BuildContextInfo builds the reply and mention context of a domain message
+// ownJID is used as the quoted author when replying to one of the session's own messages
+// Returns nil if the message neither replies to nor mentions anyone
+func BuildContextInfo(msg *entity.Message, ownJID string) *waE2E.ContextInfo {
+	reply := msg.Content.ReplyTo
+	if reply == nil && len(msg.Content.Mentions) == 0 {
+		return nil
+	}
+
+	ctxInfo := &waE2E.ContextInfo{}
+
+	if len(msg.Content.Mentions) > 0 {
+		mentions := make([]string, len(msg.Content.Mentions))
+		for i, mention := range msg.Content.Mentions {
+			mentions[i] = ToUserJID(mention)
+		}
+		ctxInfo.MentionedJID = mentions
+	}
+
+	if reply != nil && reply.MessageID != "" {
+		ctxInfo.StanzaID = proto.String(reply.MessageID)
+
+		participant := reply.Sender
+		if participant == "" && reply.FromMe {
+			participant = ownJID
+		}
+		if participant != "" {
+			ctxInfo.Participant = proto.String(ToUserJID(participant))
+		}
+
+		// Only needed when quoting a message from another chat (e.g. replying privately to a group message)
+		if reply.ChatJID != "" && reply.ChatJID != msg.ChatJID {
+			ctxInfo.RemoteJID = proto.String(reply.ChatJID)
+		}
+
+		// Clients render the reply preview from the quoted message
+		ctxInfo.QuotedMessage = &waE2E.Message{Conversation: proto.String(reply.QuotedText)}
+	}
+
+	return ctxInfo
+}
+
+// ApplyContextInfo attaches reply and mention context to a built WhatsApp message
+// Plain conversation messages are upgraded to extended text messages, which can carry context
+func ApplyContextInfo(waMsg *waE2E.Message, ctxInfo *waE2E.ContextInfo) {
+	if waMsg == nil || ctxInfo == nil {
+		return
+	}
+
+	switch {
+	case waMsg.Conversation != nil:
+		waMsg.ExtendedTextMessage = &waE2E.ExtendedTextMessage{
+			Text:        waMsg.Conversation,
+			ContextInfo: ctxInfo,
+		}
+		waMsg.Conversation = nil
+	case waMsg.ExtendedTextMessage != nil:
+		waMsg.ExtendedTextMessage.ContextInfo = ctxInfo
+	case waMsg.ImageMessage != nil:
+		waMsg.ImageMessage.ContextInfo = ctxInfo
+	case waMsg.DocumentMessage != nil:
+		waMsg.DocumentMessage.ContextInfo = ctxInfo
+	case waMsg.AudioMessage != nil:
+		waMsg.AudioMessage.ContextInfo = ctxInfo
+	case waMsg.VideoMessage != nil:
+		waMsg.VideoMessage.ContextInfo = ctxInfo
+	case waMsg.StickerMessage != nil:
+		waMsg.StickerMessage.ContextInfo = ctxInfo
+	case waMsg.LocationMessage != nil:
+		waMsg.LocationMessage.ContextInfo = ctxInfo
+	case waMsg.ContactMessage != nil:
+		waMsg.ContactMessage.ContextInfo = ctxInfo
+	case waMsg.ContactsArrayMessage != nil:
+		waMsg.ContactsArrayMessage.ContextInfo = ctxInfo
+	case waMsg.PollCreationMessage != nil:
+		waMsg.PollCreationMessage.ContextInfo = ctxInfo
+	}
+}
+
+// ToUserJID converts a phone number (with or without +) to a user JID; full JIDs are returned unchanged
+func ToUserJID(value string) string {
+	if strings.Contains(value, "@") {
+		return value
+	}
+	return strings.TrimPrefix(value, "+") + "@s.whatsapp.net"
+}
+
 // BuildReactionMessage builds a WhatsApp reaction message
 func BuildReactionMessage(chatJID, messageID, emoji string) *waE2E.Message {
 	return &waE2E.Message{
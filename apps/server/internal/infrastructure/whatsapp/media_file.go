@@ -0,0 +1,116 @@
+package whatsapp
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"whatspire/internal/domain/errors"
+)
+
+// mediaSniffLen is how many leading bytes are kept to detect the MIME type of media
+const mediaSniffLen = 512
+
+// MediaFile is media spooled to a temporary file, so it is never held in memory as a whole
+// The file is removed by Close
+type MediaFile struct {
+	file     *os.File
+	head     []byte
+	MimeType string
+	Filename string
+	Size     int64
+	SHA256   []byte // SHA-256 of the content, computed while it was written
+}
+
+// spoolMedia copies r to a temporary file in dir (the system temp directory if empty),
+// hashing the content and keeping its first bytes for MIME sniffing on the way
+// Content larger than maxSize is rejected with ErrMediaTooLarge
+func spoolMedia(r io.Reader, dir string, maxSize int64) (*MediaFile, error) {
+	file, err := os.CreateTemp(dir, "whatspire-media-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	media := &MediaFile{file: file}
+	hasher := sha256.New()
+	head := &headWriter{limit: mediaSniffLen}
+
+	// Read one byte past the limit to detect oversized content
+	written, err := io.Copy(io.MultiWriter(file, hasher, head), io.LimitReader(r, maxSize+1))
+	if err != nil {
+		_ = media.Close()
+		return nil, err
+	}
+	if written > maxSize {
+		_ = media.Close()
+		return nil, errors.ErrMediaTooLarge.WithMessage(
+			fmt.Sprintf("file size exceeds maximum %d bytes", maxSize))
+	}
+
+	media.head = head.data
+	media.Size = written
+	media.SHA256 = hasher.Sum(nil)
+	return media, nil
+}
+
+// resolveMimeType returns the declared MIME type of media, falling back to the type its filename
+// extension implies and then to sniffing its leading bytes
+func resolveMimeType(declared, filename string, head []byte) string {
+	if mimeType := baseMimeType(declared); mimeType != "" && mimeType != "application/octet-stream" {
+		return mimeType
+	}
+
+	if ext := filepath.Ext(filename); ext != "" {
+		if mimeType := baseMimeType(mime.TypeByExtension(ext)); mimeType != "" {
+			return mimeType
+		}
+	}
+
+	return baseMimeType(http.DetectContentType(head))
+}
+
+// baseMimeType strips parameters from a MIME type (e.g., "text/html; charset=utf-8" -> "text/html")
+func baseMimeType(mimeType string) string {
+	if idx := strings.Index(mimeType, ";"); idx != -1 {
+		mimeType = mimeType[:idx]
+	}
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
+// Path returns the location of the temporary file
+func (m *MediaFile) Path() string {
+	return m.file.Name()
+}
+
+// Head returns the leading bytes of the content
+func (m *MediaFile) Head() []byte {
+	return m.head
+}
+
+// Close closes and removes the temporary file
+func (m *MediaFile) Close() error {
+	// The content is discarded, so only a failure to remove it matters
+	_ = m.file.Close()
+	if err := os.Remove(m.file.Name()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// headWriter keeps the first limit bytes written to it and discards the rest
+type headWriter struct {
+	data  []byte
+	limit int
+}
+
+func (w *headWriter) Write(p []byte) (int, error) {
+	if room := w.limit - len(w.data); room > 0 {
+		w.data = append(w.data, p[:min(room, len(p))]...)
+	}
+	return len(p), nil
+}
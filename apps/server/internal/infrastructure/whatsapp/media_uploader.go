@@ -1,7 +1,9 @@
 package whatsapp
 
 import (
+	"bytes"
 	"context"
+	"io"
 
 	"whatspire/internal/domain/entity"
 	"whatspire/internal/domain/errors"
@@ -61,6 +63,12 @@ func (u *WhatsmeowMediaUploader) UploadVideo(ctx context.Context, sessionID stri
 	return u.uploadMedia(ctx, sessionID, info, valueobject.MediaTypeVideo, whatsmeow.MediaVideo)
 }
 
+// UploadSticker uploads a WebP sticker from a URL to WhatsApp servers
+func (u *WhatsmeowMediaUploader) UploadSticker(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error) {
+	info := entity.NewMediaDownloadInfo(url)
+	return u.uploadMedia(ctx, sessionID, info, valueobject.MediaTypeSticker, whatsmeow.MediaImage)
+}
+
 // Upload is a generic upload method that determines the media type from the MIME type
 func (u *WhatsmeowMediaUploader) Upload(ctx context.Context, sessionID string, info *entity.MediaDownloadInfo) (*entity.MediaUploadResult, error) {
 	if info == nil || !info.IsValid() {
@@ -68,10 +76,11 @@ func (u *WhatsmeowMediaUploader) Upload(ctx context.Context, sessionID string, i
 	}
 
 	// Download the media first to detect MIME type
-	media, err := u.downloader.Download(ctx, info)
+	media, err := u.downloader.DownloadToFile(ctx, info)
 	if err != nil {
 		return nil, err
 	}
+	defer media.Close()
 
 	// Detect media type from MIME type
 	mediaType, err := u.constraints.DetectMediaType(media.MimeType)
@@ -83,7 +92,45 @@ func (u *WhatsmeowMediaUploader) Upload(ctx context.Context, sessionID string, i
 	waMediaType := u.mapToWhatsmeowMediaType(mediaType)
 
 	// Upload to WhatsApp
-	return u.uploadData(ctx, sessionID, media.Data, media.MimeType, mediaType, waMediaType)
+	return u.uploadFile(ctx, sessionID, media, mediaType, waMediaType)
+}
+
+// UploadContent uploads media supplied by the caller to WhatsApp servers
+// The content is spooled to disk and validated against the constraints of its media type before upload
+func (u *WhatsmeowMediaUploader) UploadContent(ctx context.Context, sessionID string, info *entity.MediaUploadInfo) (*entity.MediaUploadResult, error) {
+	if info == nil || !info.IsValid() {
+		return nil, errors.ErrInvalidInput.WithMessage("invalid upload info")
+	}
+
+	maxSize := u.constraints.GetMaxSize(info.MediaType)
+	if maxSize == 0 {
+		return nil, errors.ErrUnsupportedMediaType
+	}
+
+	media, err := spoolMedia(info.Content, u.downloader.config.TempDir, maxSize)
+	if err != nil {
+		if errors.GetDomainError(err) != nil {
+			return nil, err
+		}
+		return nil, errors.ErrMediaUploadFailed.WithCause(err)
+	}
+	defer media.Close()
+
+	media.MimeType = resolveMimeType(info.MimeType, info.Filename, media.Head())
+	media.Filename = info.Filename
+
+	// Validate MIME type
+	if err := u.constraints.ValidateMimeType(info.MediaType, media.MimeType); err != nil {
+		return nil, err
+	}
+
+	// Validate size
+	if err := u.constraints.ValidateSize(info.MediaType, media.Size); err != nil {
+		return nil, err
+	}
+
+	// Upload to WhatsApp
+	return u.uploadFile(ctx, sessionID, media, info.MediaType, u.mapToWhatsmeowMediaType(info.MediaType))
 }
 
 // GetConstraints returns the media constraints used for validation
@@ -100,21 +147,22 @@ func (u *WhatsmeowMediaUploader) uploadMedia(
 	waMediaType whatsmeow.MediaType,
 ) (*entity.MediaUploadResult, error) {
 	// Download and validate the media
-	media, err := u.downloader.ValidateAndDownload(ctx, info, mediaType)
+	media, err := u.downloader.ValidateAndDownloadToFile(ctx, info, mediaType)
 	if err != nil {
 		return nil, err
 	}
+	defer media.Close()
 
 	// Upload to WhatsApp
-	return u.uploadData(ctx, sessionID, media.Data, media.MimeType, mediaType, waMediaType)
+	return u.uploadFile(ctx, sessionID, media, mediaType, waMediaType)
 }
 
-// uploadData uploads raw data to WhatsApp servers
-func (u *WhatsmeowMediaUploader) uploadData(
+// uploadFile uploads spooled media to WhatsApp servers
+// The file is encrypted in place and streamed from disk, so it is no longer usable afterwards
+func (u *WhatsmeowMediaUploader) uploadFile(
 	ctx context.Context,
 	sessionID string,
-	data []byte,
-	mimeType string,
+	media *MediaFile,
 	mediaType valueobject.MediaType,
 	waMediaType whatsmeow.MediaType,
 ) (*entity.MediaUploadResult, error) {
@@ -124,12 +172,21 @@ func (u *WhatsmeowMediaUploader) uploadData(
 		return nil, err
 	}
 
-	// Upload to WhatsApp servers
-	uploadResp, err := waClient.Upload(ctx, data, waMediaType)
+	if _, err := media.file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.ErrMediaUploadFailed.WithCause(err)
+	}
+
+	// Upload to WhatsApp servers, using the spool file as the encryption buffer
+	uploadResp, err := waClient.UploadReader(ctx, media.file, media.file, waMediaType)
 	if err != nil {
 		return nil, errors.ErrMediaUploadFailed.WithCause(err)
 	}
 
+	// The content hashed while downloading must be what was encrypted
+	if !bytes.Equal(uploadResp.FileSHA256, media.SHA256) {
+		return nil, errors.ErrMediaUploadFailed.WithMessage("media changed on disk before upload")
+	}
+
 	// Create the result
 	result := entity.NewMediaUploadResult(
 		uploadResp.URL,
@@ -137,8 +194,8 @@ func (u *WhatsmeowMediaUploader) uploadData(
 		uploadResp.MediaKey,
 		uploadResp.FileEncSHA256,
 		uploadResp.FileSHA256,
-		uint64(len(data)),
-		mimeType,
+		uploadResp.FileLength,
+		media.MimeType,
 		mediaType,
 	)
 
@@ -173,6 +230,8 @@ func (u *WhatsmeowMediaUploader) mapToWhatsmeowMediaType(mediaType valueobject.M
 		return whatsmeow.MediaAudio
 	case valueobject.MediaTypeVideo:
 		return whatsmeow.MediaVideo
+	case valueobject.MediaTypeSticker:
+		return whatsmeow.MediaImage // Stickers are uploaded as images
 	default:
 		return whatsmeow.MediaDocument // Default to document
 	}
@@ -13,12 +13,19 @@ import (
 	"go.mau.fi/whatsmeow/types/events"
 )
 
+// SessionSettingsProvider supplies the behaviour settings of a session
+type SessionSettingsProvider interface {
+	GetSessionSettings(sessionID string) entity.SessionSettings
+}
+
 // MessageHandler handles incoming WhatsApp messages with media download support
 type MessageHandler struct {
 	messageParser      *MessageParser
 	mediaDownloader    *MediaDownloadHelper
 	mediaStorage       repository.MediaStorage
 	reactionHandler    *ReactionHandler
+	messageRepo        repository.MessageRepository
+	settingsProvider   SessionSettingsProvider
 	logger             *logger.Logger
 	eventQueue         *EventQueue
 	sessionConnections map[string]bool // Track session connection status
@@ -46,6 +53,16 @@ func (h *MessageHandler) SetReactionHandler(handler *ReactionHandler) {
 	h.reactionHandler = handler
 }
 
+// SetMessageRepository sets the repository used to persist message history
+func (h *MessageHandler) SetMessageRepository(repo repository.MessageRepository) {
+	h.messageRepo = repo
+}
+
+// SetSessionSettingsProvider sets the provider used to look up per-session settings
+func (h *MessageHandler) SetSessionSettingsProvider(provider SessionSettingsProvider) {
+	h.settingsProvider = provider
+}
+
 // HandleIncomingMessage processes an incoming WhatsApp message
 // It parses the message, downloads media if present, and returns a domain event
 func (h *MessageHandler) HandleIncomingMessage(
@@ -106,6 +123,11 @@ func (h *MessageHandler) HandleIncomingMessage(
 		}
 	}
 
+	// Edits and revocations change the original message instead of adding a new one
+	if parsedMsg.MessageType == ParsedMessageTypeEdit || parsedMsg.MessageType == ParsedMessageTypeRevoke {
+		return h.handleMessageUpdate(ctx, sessionID, parsedMsg)
+	}
+
 	// Handle reactions separately if reaction handler is available
 	if parsedMsg.MessageType == ParsedMessageTypeReaction && h.reactionHandler != nil {
 		if err := h.reactionHandler.HandleIncomingReaction(ctx, sessionID, parsedMsg); err != nil {
@@ -116,6 +138,9 @@ func (h *MessageHandler) HandleIncomingMessage(
 		return nil, nil
 	}
 
+	// Persist the message to history (best effort)
+	h.persistMessage(ctx, parsedMsg)
+
 	// Create the event
 	event, err := entity.NewEventWithPayload(
 		generateEventID(),
@@ -130,6 +155,175 @@ func (h *MessageHandler) HandleIncomingMessage(
 	return event, nil
 }
 
+// handleMessageUpdate applies an incoming edit or revocation and returns the matching event
+func (h *MessageHandler) handleMessageUpdate(ctx context.Context, sessionID string, parsedMsg *ParsedMessage) (*entity.Event, error) {
+	if parsedMsg.TargetMessageID == nil {
+		h.logger.Warnf("Received %s without a target message from %s (message ID: %s)",
+			parsedMsg.MessageType, parsedMsg.SenderJID, parsedMsg.MessageID)
+		return nil, nil
+	}
+
+	update := entity.MessageUpdate{
+		MessageID:  *parsedMsg.TargetMessageID,
+		WhatsAppID: *parsedMsg.TargetMessageID,
+		ChatJID:    parsedMsg.ChatJID,
+		Sender:     parsedMsg.SenderJID,
+		Timestamp:  parsedMsg.MessageTimestamp,
+	}
+
+	eventType := entity.EventTypeMessageRevoked
+	if parsedMsg.MessageType == ParsedMessageTypeEdit {
+		eventType = entity.EventTypeMessageEdited
+		text := parsedMsg.GetTextContent()
+		update.Text = &text
+	}
+
+	h.applyMessageUpdate(ctx, sessionID, &update)
+
+	event, err := entity.NewEventWithPayload(
+		generateEventID(),
+		eventType,
+		sessionID,
+		update,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	return event, nil
+}
+
+// applyMessageUpdate records an edit or revocation on the stored message, if it is known
+// The update's MessageID is set to the stored message's ID so clients can correlate it with what they sent
+func (h *MessageHandler) applyMessageUpdate(ctx context.Context, sessionID string, update *entity.MessageUpdate) {
+	if h.messageRepo == nil {
+		return
+	}
+
+	stored, err := h.messageRepo.FindByWhatsAppID(ctx, sessionID, update.WhatsAppID)
+	if err != nil {
+		return // Message predates the history or was never stored
+	}
+	update.MessageID = stored.ID
+
+	if update.Text != nil {
+		stored.Edit(*update.Text, update.Timestamp)
+	} else {
+		stored.Revoke(update.Timestamp)
+	}
+
+	content := stored.Content
+	if h.settingsProvider != nil && !h.settingsProvider.GetSessionSettings(sessionID).MessageLogging {
+		content = content.WithoutPayload()
+	}
+
+	if err := h.messageRepo.UpdateContent(ctx, stored.ID, content); err != nil {
+		h.logger.Warnf("Failed to update message %s: %v", stored.ID, err)
+	}
+}
+
+// persistMessage stores a parsed message in the message history if a repository is configured
+func (h *MessageHandler) persistMessage(ctx context.Context, parsedMsg *ParsedMessage) {
+	if h.messageRepo == nil {
+		return
+	}
+
+	msg := toHistoryMessage(parsedMsg)
+	if msg == nil {
+		return
+	}
+
+	// Keep only delivery metadata when message logging is disabled
+	if h.settingsProvider != nil && !h.settingsProvider.GetSessionSettings(msg.SessionID).MessageLogging {
+		msg.Content = entity.MessageContent{}
+	}
+
+	if err := h.messageRepo.Save(ctx, msg); err != nil {
+		h.logger.Warnf("Failed to persist message %s: %v", parsedMsg.MessageID, err)
+	}
+}
+
+// toHistoryMessage converts a parsed message to a domain message for history storage
+// Returns nil for message types that are not stored in history
+func toHistoryMessage(parsedMsg *ParsedMessage) *entity.Message {
+	var content entity.MessageContent
+	var msgType entity.MessageType
+
+	switch parsedMsg.MessageType {
+	case ParsedMessageTypeText:
+		msgType = entity.MessageTypeText
+		content.Text = parsedMsg.Text
+	case ParsedMessageTypeImage:
+		msgType = entity.MessageTypeImage
+		content.ImageURL = parsedMsg.MediaURL
+	case ParsedMessageTypeVideo:
+		msgType = entity.MessageTypeVideo
+		content.VideoURL = parsedMsg.MediaURL
+	case ParsedMessageTypeAudio:
+		msgType = entity.MessageTypeAudio
+		content.AudioURL = parsedMsg.MediaURL
+	case ParsedMessageTypeDocument:
+		msgType = entity.MessageTypeDocument
+		content.DocURL = parsedMsg.MediaURL
+	case ParsedMessageTypeSticker:
+		msgType = entity.MessageTypeSticker
+		content.StickerURL = parsedMsg.MediaURL
+	case ParsedMessageTypeLocation:
+		msgType = entity.MessageTypeLocation
+		content.Location = &entity.LocationContent{}
+		if parsedMsg.Latitude != nil && parsedMsg.Longitude != nil {
+			content.Location.Latitude = *parsedMsg.Latitude
+			content.Location.Longitude = *parsedMsg.Longitude
+		}
+		if parsedMsg.Text != nil {
+			content.Location.Name = *parsedMsg.Text
+		}
+		if parsedMsg.Address != nil {
+			content.Location.Address = *parsedMsg.Address
+		}
+	case ParsedMessageTypeContact:
+		msgType = entity.MessageTypeContact
+		card := entity.ContactCard{}
+		if parsedMsg.Text != nil {
+			card.DisplayName = *parsedMsg.Text
+		}
+		if parsedMsg.VCard != nil {
+			card.VCard = *parsedMsg.VCard
+		}
+		content.Contacts = []entity.ContactCard{card}
+	case ParsedMessageTypePoll:
+		msgType = entity.MessageTypePoll
+		content.Poll = &entity.PollContent{Options: parsedMsg.PollOptions}
+		if parsedMsg.PollName != nil {
+			content.Poll.Question = *parsedMsg.PollName
+		}
+	default:
+		return nil
+	}
+	content.Caption = parsedMsg.Caption
+	content.Filename = parsedMsg.Filename
+
+	// Messages sent from another device of the same account are outbound
+	direction := entity.MessageDirectionInbound
+	status := entity.MessageStatusDelivered
+	if parsedMsg.IsFromMe {
+		direction = entity.MessageDirectionOutbound
+		status = entity.MessageStatusSent
+	}
+
+	return entity.NewMessageBuilder(parsedMsg.MessageID, parsedMsg.SessionID).
+		InChat(parsedMsg.ChatJID).
+		From(parsedMsg.SenderJID).
+		To(parsedMsg.ChatJID).
+		WithWhatsAppID(parsedMsg.MessageID).
+		WithDirection(direction).
+		WithContent(content).
+		WithType(msgType).
+		WithStatus(status).
+		WithTimestamp(parsedMsg.MessageTimestamp).
+		Build()
+}
+
 // isMediaMessage checks if the parsed message contains media
 func (h *MessageHandler) isMediaMessage(msg *ParsedMessage) bool {
 	switch msg.MessageType {
@@ -25,6 +25,8 @@ const (
 	ParsedMessageTypeLocation ParsedMessageType = "location"
 	ParsedMessageTypePoll     ParsedMessageType = "poll"
 	ParsedMessageTypeReaction ParsedMessageType = "reaction"
+	ParsedMessageTypeEdit     ParsedMessageType = "edit"
+	ParsedMessageTypeRevoke   ParsedMessageType = "revoke"
 	ParsedMessageTypeProtocol ParsedMessageType = "protocol"
 	ParsedMessageTypeUnknown  ParsedMessageType = "unknown"
 )
@@ -79,6 +81,9 @@ type ParsedMessage struct {
 	ReactionEmoji     *string `json:"reactionEmoji,omitempty"`
 	ReactionMessageID *string `json:"reactionMessageId,omitempty"`
 
+	// Edit/revoke data (ID of the message that was edited or deleted for everyone)
+	TargetMessageID *string `json:"targetMessageId,omitempty"`
+
 	// Message flags
 	IsFromMe    bool `json:"isFromMe"`
 	IsForwarded bool `json:"isForwarded"`
@@ -230,7 +235,15 @@ func (p *MessageParser) parseMessageContent(msg *ParsedMessage, waMsg *waE2E.Mes
 		p.parseReactionMessage(msg, waMsg.GetReactionMessage())
 
 	case waMsg.GetProtocolMessage() != nil:
-		msg.MessageType = ParsedMessageTypeProtocol
+		p.parseProtocolMessage(msg, waMsg.GetProtocolMessage())
+
+	case waMsg.GetEditedMessage() != nil:
+		// Edits from history sync are still wrapped; real-time ones are unwrapped by whatsmeow
+		if inner := waMsg.GetEditedMessage().GetMessage(); inner != nil {
+			p.parseMessageContent(msg, inner)
+		} else {
+			msg.MessageType = ParsedMessageTypeUnknown
+		}
 
 	case waMsg.GetViewOnceMessage() != nil:
 		msg.IsViewOnce = true
@@ -544,6 +557,44 @@ func (p *MessageParser) parseReactionMessage(msg *ParsedMessage, reactMsg *waE2E
 	}
 }
 
+func (p *MessageParser) parseProtocolMessage(msg *ParsedMessage, protoMsg *waE2E.ProtocolMessage) {
+	switch protoMsg.GetType() {
+	case waE2E.ProtocolMessage_MESSAGE_EDIT:
+		msg.MessageType = ParsedMessageTypeEdit
+
+		edited := protoMsg.GetEditedMessage()
+		switch {
+		case edited.GetConversation() != "":
+			text := edited.GetConversation()
+			msg.Text = &text
+		case edited.GetExtendedTextMessage().GetText() != "":
+			text := edited.GetExtendedTextMessage().GetText()
+			msg.Text = &text
+		case edited.GetImageMessage().GetCaption() != "":
+			caption := edited.GetImageMessage().GetCaption()
+			msg.Caption = &caption
+		case edited.GetVideoMessage().GetCaption() != "":
+			caption := edited.GetVideoMessage().GetCaption()
+			msg.Caption = &caption
+		case edited.GetDocumentMessage().GetCaption() != "":
+			caption := edited.GetDocumentMessage().GetCaption()
+			msg.Caption = &caption
+		}
+
+	case waE2E.ProtocolMessage_REVOKE:
+		msg.MessageType = ParsedMessageTypeRevoke
+
+	default:
+		msg.MessageType = ParsedMessageTypeProtocol
+		return
+	}
+
+	if protoMsg.GetKey().GetID() != "" {
+		targetID := protoMsg.GetKey().GetID()
+		msg.TargetMessageID = &targetID
+	}
+}
+
 // IsGroupMessage returns true if the message is from a group chat
 func (pm *ParsedMessage) IsGroupMessage() bool {
 	// Group JIDs end with @g.us
@@ -39,6 +39,9 @@ func NewHTTPHandler(
 	eventUC *usecase.EventUseCase,
 	apikeyUC *usecase.APIKeyUseCase,
 	webhookUC *usecase.WebhookUseCase,
+	auditLogUC *usecase.AuditLogUseCase,
+	mediaUC *usecase.MediaUseCase,
+	hub *infraWs.EventHub,
 	log *logger.Logger,
 ) *http.Handler {
 	return http.NewHandlerBuilder(log).
@@ -53,6 +56,9 @@ func NewHTTPHandler(
 		WithEventUseCase(eventUC).
 		WithAPIKeyUseCase(apikeyUC).
 		WithWebhookUseCase(webhookUC).
+		WithAuditLogUseCase(auditLogUC).
+		WithMediaUseCase(mediaUC).
+		WithEventHub(hub).
 		Build()
 }
 
@@ -62,6 +68,7 @@ func NewRouter(
 	cfg *config.Config,
 	auditLogger repository.AuditLogger,
 	apiKeyRepo repository.APIKeyRepository,
+	publisher repository.EventPublisher,
 	log *logger.Logger,
 ) *gin.Engine {
 	// Create rate limiter if enabled
@@ -87,6 +94,8 @@ func NewRouter(
 		APIKeyConfig:         &cfg.APIKey,
 		APIKeyRepository:     apiKeyRepo,
 		AuditLogger:          auditLogger,
+		EventPublisher:       publisher,
+		MetricsConfig:        &cfg.Metrics,
 		Logger:               log,
 	}
 
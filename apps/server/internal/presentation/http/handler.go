@@ -1,10 +1,16 @@
 package http
 
 import (
+	"time"
+
 	"whatspire/internal/application/usecase"
 	"whatspire/internal/infrastructure/logger"
+	infraWs "whatspire/internal/infrastructure/websocket"
 )
 
+// defaultEventStreamHeartbeat is how often idle event streams receive a heartbeat comment
+const defaultEventStreamHeartbeat = 15 * time.Second
+
 // Handler defines HTTP handlers for the WhatsApp service
 type Handler struct {
 	sessionUC  *usecase.SessionUseCase
@@ -18,7 +24,12 @@ type Handler struct {
 	eventUC    *usecase.EventUseCase
 	apikeyUC   *usecase.APIKeyUseCase
 	webhookUC  *usecase.WebhookUseCase
+	auditLogUC *usecase.AuditLogUseCase
+	mediaUC    *usecase.MediaUseCase
+	eventHub   *infraWs.EventHub
 	logger     *logger.Logger
+
+	eventStreamHeartbeat time.Duration
 }
 
 // HandlerBuilder provides a builder pattern for creating Handler instances
@@ -30,7 +41,8 @@ type HandlerBuilder struct {
 func NewHandlerBuilder(log *logger.Logger) *HandlerBuilder {
 	return &HandlerBuilder{
 		handler: &Handler{
-			logger: log,
+			logger:               log,
+			eventStreamHeartbeat: defaultEventStreamHeartbeat,
 		},
 	}
 }
@@ -95,12 +107,36 @@ func (b *HandlerBuilder) WithAPIKeyUseCase(uc *usecase.APIKeyUseCase) *HandlerBu
 	return b
 }
 
+// WithMediaUseCase sets the media use case
+func (b *HandlerBuilder) WithMediaUseCase(uc *usecase.MediaUseCase) *HandlerBuilder {
+	b.handler.mediaUC = uc
+	return b
+}
+
 // WithWebhookUseCase sets the webhook use case
 func (b *HandlerBuilder) WithWebhookUseCase(uc *usecase.WebhookUseCase) *HandlerBuilder {
 	b.handler.webhookUC = uc
 	return b
 }
 
+// WithAuditLogUseCase sets the audit log use case
+func (b *HandlerBuilder) WithAuditLogUseCase(uc *usecase.AuditLogUseCase) *HandlerBuilder {
+	b.handler.auditLogUC = uc
+	return b
+}
+
+// WithEventHub sets the event hub live event streams subscribe to
+func (b *HandlerBuilder) WithEventHub(hub *infraWs.EventHub) *HandlerBuilder {
+	b.handler.eventHub = hub
+	return b
+}
+
+// WithEventStreamHeartbeat sets how often idle event streams receive a heartbeat comment
+func (b *HandlerBuilder) WithEventStreamHeartbeat(interval time.Duration) *HandlerBuilder {
+	b.handler.eventStreamHeartbeat = interval
+	return b
+}
+
 // Build returns the constructed Handler
 func (b *HandlerBuilder) Build() *Handler {
 	return b.handler
@@ -3,8 +3,11 @@ package http
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"whatspire/internal/application/dto"
+	"whatspire/internal/application/usecase"
+	"whatspire/internal/domain/entity"
 	"whatspire/pkg/validator"
 
 	"github.com/gin-gonic/gin"
@@ -47,12 +50,24 @@ func (h *Handler) CreateAPIKey(c *gin.Context) {
 		return
 	}
 
+	// A restricted key must not create a key with broader access than its own
+	if apiKey := GetAuthenticatedAPIKey(c); apiKey != nil && !apiKey.CanGrant(req.SessionIDs, req.Scopes) {
+		respondWithError(c, http.StatusForbidden, "INSUFFICIENT_SCOPE", "API key cannot grant access beyond its own sessions and scopes", nil)
+		return
+	}
+
 	// Get the authenticated user/API key ID from context (set by auth middleware)
 	// For now, we'll use a placeholder - this will be properly extracted from auth context
 	createdBy := "system" // TODO: Extract from auth context
 
 	// Create API key
-	plainKey, apiKey, err := h.apikeyUC.CreateAPIKey(c.Request.Context(), req.Role, req.Description, createdBy)
+	plainKey, apiKey, err := h.apikeyUC.CreateAPIKeyWithOptions(c.Request.Context(), req.Role, req.Description, usecase.APIKeyOptions{
+		SessionIDs:        req.SessionIDs,
+		Scopes:            req.Scopes,
+		ExpiresAt:         req.ExpiresAt,
+		DailyRequestQuota: req.DailyRequestQuota,
+		DailyMessageQuota: req.DailyMessageQuota,
+	}, createdBy)
 	if err != nil {
 		handleDomainError(c, err, h.logger)
 		return
@@ -60,19 +75,8 @@ func (h *Handler) CreateAPIKey(c *gin.Context) {
 
 	// Build response with plain-text key (shown only once)
 	response := dto.CreateAPIKeyResponse{
-		APIKey: dto.APIKeyResponse{
-			ID:               apiKey.ID,
-			MaskedKey:        "", // Will be masked on frontend
-			Role:             apiKey.Role,
-			Description:      apiKey.Description,
-			CreatedAt:        apiKey.CreatedAt,
-			LastUsedAt:       apiKey.LastUsedAt,
-			IsActive:         apiKey.IsActive,
-			RevokedAt:        apiKey.RevokedAt,
-			RevokedBy:        apiKey.RevokedBy,
-			RevocationReason: apiKey.RevocationReason,
-		},
-		PlainKey: plainKey, // Plain-text key - shown only once
+		APIKey:   newAPIKeyResponse(apiKey, ""), // Will be masked on frontend
+		PlainKey: plainKey,                      // Plain-text key - shown only once
 	}
 
 	respondWithSuccess(c, http.StatusCreated, response)
@@ -141,6 +145,82 @@ func (h *Handler) RevokeAPIKey(c *gin.Context) {
 	respondWithSuccess(c, http.StatusOK, response)
 }
 
+// RotateAPIKey handles POST /api/apikeys/:id/rotate
+// Issues a successor key while the previous key keeps working for a grace period
+//
+// @Summary Rotate an API key
+// @Description Issues a new API key with the same role, description and restrictions as the given key. The previous key keeps working until the end of the grace period (default 24 hours), after which it expires. The plain-text successor key is returned only once.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param id path string true "API Key ID"
+// @Param request body dto.RotateAPIKeyRequest false "Rotation options"
+// @Success 201 {object} dto.RotateAPIKeyResponse "API key rotated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request, or the key is revoked, expired or already rotated"
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing API key"
+// @Failure 403 {object} ErrorResponse "Forbidden - insufficient permissions (admin role required)"
+// @Failure 404 {object} ErrorResponse "API key not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/apikeys/{id}/rotate [post]
+func (h *Handler) RotateAPIKey(c *gin.Context) {
+	// Extract API key ID from URL parameter
+	id := c.Param("id")
+	if id == "" {
+		respondWithError(c, http.StatusBadRequest, "MISSING_ID", "API key ID is required", nil)
+		return
+	}
+
+	// The request body is optional, but a malformed one is rejected
+	var req dto.RotateAPIKeyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			if strings.Contains(err.Error(), "Field validation") {
+				respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", err.Error(), nil)
+				return
+			}
+			respondWithError(c, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", nil)
+			return
+		}
+	}
+
+	gracePeriod := usecase.DefaultAPIKeyRotationGracePeriod
+	if req.GracePeriodSeconds != nil {
+		gracePeriod = time.Duration(*req.GracePeriodSeconds) * time.Second
+	}
+
+	// The successor inherits the key's access, so a restricted key may only rotate keys it could create
+	if authenticated := GetAuthenticatedAPIKey(c); authenticated != nil {
+		current, err := h.apikeyUC.GetAPIKey(c.Request.Context(), id)
+		if err != nil {
+			handleDomainError(c, err, h.logger)
+			return
+		}
+		if !authenticated.CanGrant(current.AllowedSessions, current.Scopes) {
+			respondWithError(c, http.StatusForbidden, "INSUFFICIENT_SCOPE", "API key cannot grant access beyond its own sessions and scopes", nil)
+			return
+		}
+	}
+
+	// Get the authenticated user/API key ID from context (set by auth middleware)
+	// For now, we'll use a placeholder - this will be properly extracted from auth context
+	rotatedBy := "system" // TODO: Extract from auth context
+
+	plainKey, successor, previous, err := h.apikeyUC.RotateAPIKey(c.Request.Context(), id, gracePeriod, req.ExpiresAt, rotatedBy)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	response := dto.RotateAPIKeyResponse{
+		APIKey:      newAPIKeyResponse(successor, ""), // Will be masked on frontend
+		PlainKey:    plainKey,                         // Plain-text key - shown only once
+		PreviousKey: newAPIKeyResponse(previous, h.apikeyUC.MaskAPIKey(previous.KeyHash)),
+	}
+
+	respondWithSuccess(c, http.StatusCreated, response)
+}
+
 // ListAPIKeys handles GET /api/apikeys
 // Lists all API keys with optional filtering and pagination
 //
@@ -198,18 +278,7 @@ func (h *Handler) ListAPIKeys(c *gin.Context) {
 		// Mask the key for display
 		maskedKey := h.apikeyUC.MaskAPIKey(key.KeyHash) // Note: This masks the hash, not the original key
 
-		apiKeyResponses[i] = dto.APIKeyResponse{
-			ID:               key.ID,
-			MaskedKey:        maskedKey,
-			Role:             key.Role,
-			Description:      key.Description,
-			CreatedAt:        key.CreatedAt,
-			LastUsedAt:       key.LastUsedAt,
-			IsActive:         key.IsActive,
-			RevokedAt:        key.RevokedAt,
-			RevokedBy:        key.RevokedBy,
-			RevocationReason: key.RevocationReason,
-		}
+		apiKeyResponses[i] = newAPIKeyResponse(key, maskedKey)
 	}
 
 	// Calculate total pages
@@ -268,23 +337,40 @@ func (h *Handler) GetAPIKeyDetails(c *gin.Context) {
 
 	// Build response
 	response := dto.APIKeyDetailsResponse{
-		APIKey: dto.APIKeyResponse{
-			ID:               apiKey.ID,
-			MaskedKey:        maskedKey,
-			Role:             apiKey.Role,
-			Description:      apiKey.Description,
-			CreatedAt:        apiKey.CreatedAt,
-			LastUsedAt:       apiKey.LastUsedAt,
-			IsActive:         apiKey.IsActive,
-			RevokedAt:        apiKey.RevokedAt,
-			RevokedBy:        apiKey.RevokedBy,
-			RevocationReason: apiKey.RevocationReason,
-		},
+		APIKey: newAPIKeyResponse(apiKey, maskedKey),
 		UsageStats: dto.UsageStats{
 			TotalRequests: totalRequests,
 			Last7Days:     last7Days,
 		},
 	}
 
+	// Today's usage is supplementary, so a lookup failure leaves it at zero
+	if usage, err := h.apikeyUC.GetDailyUsage(c.Request.Context(), apiKey.ID); err == nil {
+		response.UsageStats.TodayRequests = usage.Requests
+		response.UsageStats.TodayMessages = usage.Messages
+	}
+
 	respondWithSuccess(c, http.StatusOK, response)
 }
+
+// newAPIKeyResponse converts an API key to its response representation
+func newAPIKeyResponse(apiKey *entity.APIKey, maskedKey string) dto.APIKeyResponse {
+	return dto.APIKeyResponse{
+		ID:                apiKey.ID,
+		MaskedKey:         maskedKey,
+		Role:              apiKey.Role,
+		AllowedSessions:   apiKey.AllowedSessions,
+		Scopes:            apiKey.Scopes,
+		Description:       apiKey.Description,
+		CreatedAt:         apiKey.CreatedAt,
+		LastUsedAt:        apiKey.LastUsedAt,
+		IsActive:          apiKey.IsActive,
+		RevokedAt:         apiKey.RevokedAt,
+		RevokedBy:         apiKey.RevokedBy,
+		RevocationReason:  apiKey.RevocationReason,
+		ExpiresAt:         apiKey.ExpiresAt,
+		ReplacedBy:        apiKey.ReplacedBy,
+		DailyRequestQuota: apiKey.DailyRequestQuota,
+		DailyMessageQuota: apiKey.DailyMessageQuota,
+	}
+}
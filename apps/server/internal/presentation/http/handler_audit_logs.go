@@ -0,0 +1,200 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"whatspire/internal/application/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// auditLogCSVHeader lists the columns of a CSV audit log export
+var auditLogCSVHeader = []string{"id", "event_type", "created_at", "api_key_id", "session_id", "endpoint", "action", "ip_address", "details"}
+
+// csvCell escapes a value that spreadsheet applications would evaluate as a formula
+// Audit logs hold client-controlled values such as endpoints, so they are prefixed with a quote to be shown as text
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// ListAuditLogs handles GET /api/audit-logs
+// @Summary List audit logs
+// @Description Retrieve recorded audit events, newest first, with optional filters and cursor pagination
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param event_type query string false "Filter by event kind" Enums(api_key_usage, api_key_created, api_key_revoked, session_action, message_sent, auth_failure, webhook_delivery)
+// @Param api_key_id query string false "Filter by API key ID"
+// @Param session_id query string false "Filter by session ID"
+// @Param ip_address query string false "Filter by client IP address"
+// @Param since query string false "Start timestamp in RFC3339 format"
+// @Param until query string false "End timestamp in RFC3339 format"
+// @Param limit query int false "Maximum number of results (1-1000, default: 100)"
+// @Param cursor query string false "Cursor from a previous response to fetch older entries"
+// @Success 200 {object} dto.ListAuditLogsResponse "Successfully retrieved audit logs"
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing API key"
+// @Failure 403 {object} ErrorResponse "Forbidden - insufficient permissions (admin role required)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/audit-logs [get]
+func (h *Handler) ListAuditLogs(c *gin.Context) {
+	var req dto.ListAuditLogsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters", nil)
+		return
+	}
+
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
+	if h.auditLogUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Audit log use case not configured", nil)
+		return
+	}
+
+	response, err := h.auditLogUC.ListAuditLogs(c.Request.Context(), req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, response)
+}
+
+// ExportAuditLogs handles GET /api/audit-logs/export
+// @Summary Export audit logs
+// @Description Stream every audit event matching the filters as CSV or newline-delimited JSON, newest first
+// @Tags audit
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "Export format (default: csv)" Enums(csv, ndjson)
+// @Param event_type query string false "Filter by event kind"
+// @Param api_key_id query string false "Filter by API key ID"
+// @Param session_id query string false "Filter by session ID"
+// @Param ip_address query string false "Filter by client IP address"
+// @Param since query string false "Start timestamp in RFC3339 format"
+// @Param until query string false "End timestamp in RFC3339 format"
+// @Success 200 {string} string "Audit log export"
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing API key"
+// @Failure 403 {object} ErrorResponse "Forbidden - insufficient permissions (admin role required)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/audit-logs/export [get]
+func (h *Handler) ExportAuditLogs(c *gin.Context) {
+	var req dto.ExportAuditLogsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters", nil)
+		return
+	}
+
+	// Resolve the default format before choosing how to encode the export
+	if err := req.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", err.Error(), nil)
+		return
+	}
+
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
+	if h.auditLogUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Audit log use case not configured", nil)
+		return
+	}
+
+	// Headers are only written with the first batch so validation errors can still be returned as JSON
+	var csvWriter *csv.Writer
+	var jsonEncoder *json.Encoder
+	started := false
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+
+		contentType := "text/csv; charset=utf-8"
+		if req.Format == dto.AuditLogExportFormatNDJSON {
+			contentType = "application/x-ndjson"
+		}
+		filename := fmt.Sprintf("audit-logs-%s.%s", time.Now().UTC().Format("20060102T150405Z"), req.Format)
+
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Status(http.StatusOK)
+
+		if req.Format == dto.AuditLogExportFormatNDJSON {
+			jsonEncoder = json.NewEncoder(c.Writer)
+		} else {
+			csvWriter = csv.NewWriter(c.Writer)
+			_ = csvWriter.Write(auditLogCSVHeader)
+		}
+	}
+
+	err := h.auditLogUC.ExportAuditLogs(c.Request.Context(), req, func(auditLogs []dto.AuditLogDTO) error {
+		start()
+
+		for _, auditLog := range auditLogs {
+			if jsonEncoder != nil {
+				if err := jsonEncoder.Encode(auditLog); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := csvWriter.Write([]string{
+				csvCell(auditLog.ID),
+				csvCell(auditLog.EventType),
+				csvCell(auditLog.CreatedAt),
+				csvCell(auditLog.APIKeyID),
+				csvCell(auditLog.SessionID),
+				csvCell(auditLog.Endpoint),
+				csvCell(auditLog.Action),
+				csvCell(auditLog.IPAddress),
+				csvCell(string(auditLog.Details)),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		if !started {
+			handleDomainError(c, err, h.logger)
+			return
+		}
+
+		// The response is already streaming, so the export can only be cut short
+		h.logger.WithError(err).Warn("Audit log export aborted")
+		return
+	}
+
+	// An export without matching entries still produces a valid (empty) document
+	start()
+	if csvWriter != nil {
+		csvWriter.Flush()
+	}
+	c.Writer.Flush()
+}
@@ -30,6 +30,10 @@ func (h *Handler) CheckPhoneNumber(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
 	if h.contactUC == nil {
 		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Contact use case not configured", nil)
 		return
@@ -65,6 +69,10 @@ func (h *Handler) GetUserProfile(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
 	if h.contactUC == nil {
 		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Contact use case not configured", nil)
 		return
@@ -1,14 +1,23 @@
 package http
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"whatspire/internal/application/dto"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	infraWs "whatspire/internal/infrastructure/websocket"
 	"whatspire/pkg/validator"
 
 	"github.com/gin-gonic/gin"
 )
 
+// errEventNotFound is what a missing event is reported as, so events of other sessions look the same
+var errEventNotFound = errors.ErrNotFound.WithMessage("event not found")
+
 // QueryEvents handles GET /api/events
 // @Summary Query events with filtering and pagination
 // @Description Retrieve events from the database with optional filters for session, type, and time range
@@ -35,6 +44,10 @@ func (h *Handler) QueryEvents(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
 	if h.eventUC == nil {
 		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Event use case not configured", nil)
 		return
@@ -50,6 +63,148 @@ func (h *Handler) QueryEvents(c *gin.Context) {
 	respondWithSuccess(c, http.StatusOK, response)
 }
 
+// eventStreamBufferSize is the number of live events buffered for a stream before it is considered too slow
+const eventStreamBufferSize = 256
+
+// StreamEvents handles GET /api/events/stream
+// @Summary Stream live events
+// @Description Push events as Server-Sent Events, using the same JSON shape and filters as the WebSocket event stream.
+// @Description Each event's id lists the last sequence per session; reconnecting with Last-Event-ID replays missed events.
+// @Tags events
+// @Produce text/event-stream
+// @Param session_id query string false "Comma-separated session IDs (required for session-scoped API keys)"
+// @Param event_type query string false "Comma-separated event types or wildcards (e.g., message.*)"
+// @Param last_event_id query string false "Resume point when the Last-Event-ID header cannot be sent"
+// @Param Last-Event-ID header string false "Resume point in the form session_id:sequence[,session_id:sequence...]"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} ErrorResponse "Invalid query parameters or resume point"
+// @Failure 401 {object} ErrorResponse "Unauthorized - invalid or missing API key"
+// @Failure 403 {object} ErrorResponse "Forbidden - session access denied"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/events/stream [get]
+func (h *Handler) StreamEvents(c *gin.Context) {
+	var req dto.StreamEventsRequest
+
+	// Bind query parameters
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters", nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", err.Error(), nil)
+		return
+	}
+
+	sessionIDs := req.SessionIDs()
+	if len(sessionIDs) == 0 && !authorizeSessionAccess(c, "") {
+		return
+	}
+	for _, sessionID := range sessionIDs {
+		if !authorizeSessionAccess(c, sessionID) {
+			return
+		}
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = req.LastEventID
+	}
+	resumeFrom, err := infraWs.ParseResumeFrom(lastEventID)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_LAST_EVENT_ID", err.Error(), nil)
+		return
+	}
+
+	if h.eventHub == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Event hub not configured", nil)
+		return
+	}
+
+	// Subscribe before replaying so no event falls between the replay and live delivery
+	stream := h.eventHub.OpenStream(infraWs.Subscription{
+		SessionIDs: sessionIDs,
+		EventTypes: req.EventTypes(),
+	}, GetAuthenticatedAPIKey(c), eventStreamBufferSize)
+	defer h.eventHub.CloseStream(stream)
+
+	// The stream outlives the server's write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	// The id of each event carries the last sequence per session so a reconnect resumes every session
+	lastSequences := make(map[string]int64, len(resumeFrom))
+	for sessionID, sequence := range resumeFrom {
+		lastSequences[sessionID] = sequence
+	}
+	writeEvent := func(event *entity.Event) error {
+		if event.Sequence > 0 {
+			lastSequences[event.SessionID] = event.Sequence
+		}
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		if id := infraWs.FormatResumeFrom(lastSequences); id != "" {
+			if _, err := fmt.Fprintf(c.Writer, "id: %s\n", id); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(c.Writer, "data: %s\n\n", data); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	if len(resumeFrom) > 0 {
+		if _, _, err := h.eventHub.ReplayEvents(c.Request.Context(), resumeFrom, stream.Accepts, writeEvent); err != nil {
+			h.logger.WithError(err).Warn("Event stream replay incomplete")
+			if _, err := fmt.Fprintf(c.Writer, ": replay incomplete: %s\n\n", err.Error()); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+
+	heartbeat := time.NewTicker(h.eventStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+
+		case event, ok := <-stream.Events():
+			if !ok {
+				// The stream fell too far behind; the client reconnects with Last-Event-ID
+				return
+			}
+			if event.Sequence > 0 && event.Sequence <= lastSequences[event.SessionID] {
+				continue // Already sent during the replay
+			}
+			if err := writeEvent(event); err != nil {
+				return
+			}
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
 // GetEventByID handles GET /api/events/:id
 // @Summary Get event by ID
 // @Description Retrieve a single event by its unique identifier
@@ -82,6 +237,10 @@ func (h *Handler) GetEventByID(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionRecord(c, event.SessionID, errEventNotFound) {
+		return
+	}
+
 	respondWithSuccess(c, http.StatusOK, event)
 }
 
@@ -114,6 +273,10 @@ func (h *Handler) ReplayEvents(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
 	if h.eventUC == nil {
 		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Event use case not configured", nil)
 		return
@@ -3,6 +3,9 @@ package http
 import (
 	"net/http"
 
+	"whatspire/internal/application/dto"
+	"whatspire/pkg/validator"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -27,3 +30,338 @@ func (h *Handler) SyncGroups(c *gin.Context) {
 
 	respondWithSuccess(c, http.StatusOK, result)
 }
+
+// ListGroups handles GET /api/sessions/:id/groups
+// Serves the locally stored groups; run a sync first to populate them
+func (h *Handler) ListGroups(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Session ID is required", nil)
+		return
+	}
+
+	var req dto.ListGroupsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters", nil)
+		return
+	}
+	req.SessionID = sessionID
+
+	if err := validator.Validate(req); err != nil {
+		details := validator.ValidationErrors(err)
+		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", "Validation failed", details)
+		return
+	}
+
+	if h.groupsUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Groups use case not configured", nil)
+		return
+	}
+
+	result, err := h.groupsUC.ListGroups(c.Request.Context(), req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, result)
+}
+
+// CreateGroup handles POST /api/sessions/:id/groups
+func (h *Handler) CreateGroup(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Session ID is required", nil)
+		return
+	}
+
+	var req dto.CreateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", nil)
+		return
+	}
+
+	req.SessionID = sessionID
+
+	if err := validator.Validate(req); err != nil {
+		details := validator.ValidationErrors(err)
+		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", "Validation failed", details)
+		return
+	}
+
+	if h.groupsUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Groups use case not configured", nil)
+		return
+	}
+
+	group, err := h.groupsUC.CreateGroup(c.Request.Context(), req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusCreated, group)
+}
+
+// GetGroup handles GET /api/sessions/:id/groups/:jid
+func (h *Handler) GetGroup(c *gin.Context) {
+	sessionID, groupJID, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	if h.groupsUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Groups use case not configured", nil)
+		return
+	}
+
+	group, err := h.groupsUC.GetGroup(c.Request.Context(), sessionID, groupJID)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, group)
+}
+
+// UpdateGroup handles PATCH /api/sessions/:id/groups/:jid
+func (h *Handler) UpdateGroup(c *gin.Context) {
+	sessionID, groupJID, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", nil)
+		return
+	}
+
+	req.SessionID = sessionID
+	req.GroupJID = groupJID
+
+	if err := validator.Validate(req); err != nil {
+		details := validator.ValidationErrors(err)
+		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", "Validation failed", details)
+		return
+	}
+
+	if h.groupsUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Groups use case not configured", nil)
+		return
+	}
+
+	group, err := h.groupsUC.UpdateGroup(c.Request.Context(), req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, group)
+}
+
+// SetGroupPicture handles PUT /api/sessions/:id/groups/:jid/picture
+func (h *Handler) SetGroupPicture(c *gin.Context) {
+	sessionID, groupJID, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SetGroupPictureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", nil)
+		return
+	}
+
+	req.SessionID = sessionID
+	req.GroupJID = groupJID
+
+	if err := validator.Validate(req); err != nil {
+		details := validator.ValidationErrors(err)
+		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", "Validation failed", details)
+		return
+	}
+
+	if h.groupsUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Groups use case not configured", nil)
+		return
+	}
+
+	pictureID, err := h.groupsUC.SetGroupPicture(c.Request.Context(), req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, map[string]any{
+		"group_jid":  groupJID,
+		"picture_id": pictureID,
+	})
+}
+
+// UpdateGroupParticipants handles POST /api/sessions/:id/groups/:jid/participants
+func (h *Handler) UpdateGroupParticipants(c *gin.Context) {
+	sessionID, groupJID, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateGroupParticipantsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", nil)
+		return
+	}
+
+	req.SessionID = sessionID
+	req.GroupJID = groupJID
+
+	if err := validator.Validate(req); err != nil {
+		details := validator.ValidationErrors(err)
+		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", "Validation failed", details)
+		return
+	}
+
+	if h.groupsUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Groups use case not configured", nil)
+		return
+	}
+
+	results, err := h.groupsUC.UpdateParticipants(c.Request.Context(), req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, map[string]any{
+		"group_jid":    groupJID,
+		"action":       req.Action,
+		"participants": results,
+	})
+}
+
+// GetGroupInviteLink handles GET /api/sessions/:id/groups/:jid/invite-link
+func (h *Handler) GetGroupInviteLink(c *gin.Context) {
+	sessionID, groupJID, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	if h.groupsUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Groups use case not configured", nil)
+		return
+	}
+
+	link, err := h.groupsUC.GetInviteLink(c.Request.Context(), sessionID, groupJID)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, map[string]any{
+		"group_jid":   groupJID,
+		"invite_link": link,
+	})
+}
+
+// RevokeGroupInviteLink handles DELETE /api/sessions/:id/groups/:jid/invite-link
+func (h *Handler) RevokeGroupInviteLink(c *gin.Context) {
+	sessionID, groupJID, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	if h.groupsUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Groups use case not configured", nil)
+		return
+	}
+
+	link, err := h.groupsUC.RevokeInviteLink(c.Request.Context(), sessionID, groupJID)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	// WhatsApp always issues a replacement link when the current one is revoked
+	respondWithSuccess(c, http.StatusOK, map[string]any{
+		"group_jid":   groupJID,
+		"invite_link": link,
+	})
+}
+
+// JoinGroup handles POST /api/sessions/:id/groups/join
+func (h *Handler) JoinGroup(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Session ID is required", nil)
+		return
+	}
+
+	var req dto.JoinGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", nil)
+		return
+	}
+
+	req.SessionID = sessionID
+
+	if err := validator.Validate(req); err != nil {
+		details := validator.ValidationErrors(err)
+		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", "Validation failed", details)
+		return
+	}
+
+	if h.groupsUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Groups use case not configured", nil)
+		return
+	}
+
+	groupJID, err := h.groupsUC.JoinGroup(c.Request.Context(), req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, map[string]any{
+		"group_jid": groupJID,
+	})
+}
+
+// LeaveGroup handles POST /api/sessions/:id/groups/:jid/leave
+func (h *Handler) LeaveGroup(c *gin.Context) {
+	sessionID, groupJID, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	if h.groupsUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Groups use case not configured", nil)
+		return
+	}
+
+	if err := h.groupsUC.LeaveGroup(c.Request.Context(), sessionID, groupJID); err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, map[string]any{
+		"group_jid": groupJID,
+		"left":      true,
+	})
+}
+
+// groupParams reads the session ID and group JID path parameters, responding with 400 if either is missing
+func groupParams(c *gin.Context) (sessionID, groupJID string, ok bool) {
+	sessionID = c.Param("id")
+	if sessionID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Session ID is required", nil)
+		return "", "", false
+	}
+
+	groupJID = c.Param("jid")
+	if groupJID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Group JID is required", nil)
+		return "", "", false
+	}
+
+	return sessionID, groupJID, true
+}
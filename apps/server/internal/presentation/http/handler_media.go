@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMedia handles GET /api/media/*path
+// Streams a stored media file, supporting Range requests and ETag revalidation
+// Access requires an API key or a signed link (expires and signature query parameters)
+func (h *Handler) GetMedia(c *gin.Context) {
+	if h.mediaUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Media use case not configured", nil)
+		return
+	}
+
+	filePath := mediaRequestPath(c)
+
+	// Media is stored per session, so session-scoped API keys only reach their sessions' media
+	if !authorizeSessionAccess(c, mediaSessionID(filePath)) {
+		return
+	}
+
+	file, err := h.mediaUC.OpenMedia(c.Request.Context(), filePath)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+	defer file.Content.Close()
+
+	if file.ContentType != "" {
+		c.Header("Content-Type", file.ContentType)
+	}
+	if file.ETag != "" {
+		c.Header("ETag", file.ETag)
+	}
+	c.Header("Cache-Control", "private")
+	// Media comes from chat participants, so never let browsers run it as part of this origin
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Security-Policy", "sandbox")
+
+	// ServeContent handles Range, If-Range, If-None-Match and HEAD requests
+	http.ServeContent(c.Writer, c.Request, path.Base(filePath), file.ModTime, file.Content)
+}
+
+// mediaRequestPath returns the requested media path with its dot segments resolved
+// Access checks and storage must see the same path, or "session-1/../session-2/..." reaches another session's media
+func mediaRequestPath(c *gin.Context) string {
+	filePath := strings.ReplaceAll(c.Param("path"), "\\", "/")
+	return strings.TrimPrefix(path.Clean("/"+filePath), "/")
+}
+
+// mediaSessionID returns the ID of the session a media file belongs to
+// Media files are stored under a directory per session
+func mediaSessionID(filePath string) string {
+	sessionID, _, _ := strings.Cut(filePath, "/")
+	return sessionID
+}
+
+// PreviewMediaCleanup handles GET /api/media-cleanup/dry-run
+// Reports the media the cleanup job would delete if it ran now, without deleting anything
+func (h *Handler) PreviewMediaCleanup(c *gin.Context) {
+	// The report covers the media of every session
+	if !authorizeSessionAccess(c, "") {
+		return
+	}
+
+	if h.mediaUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Media use case not configured", nil)
+		return
+	}
+
+	report, err := h.mediaUC.PreviewCleanup(c.Request.Context())
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, report)
+}
@@ -1,20 +1,41 @@
 package http
 
 import (
+	"errors"
+	"mime/multipart"
 	"net/http"
 	"time"
 
 	"whatspire/internal/application/dto"
 	"whatspire/internal/domain/entity"
+	domainErrors "whatspire/internal/domain/errors"
+	"whatspire/internal/domain/valueobject"
 	"whatspire/pkg/validator"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// maxMessageFormSize caps multipart send requests: the largest media WhatsApp accepts plus room for the other fields
+	maxMessageFormSize = valueobject.MaxDocumentSize + 1024*1024
+
+	// messageFormMemory is how much of a multipart send request is held in memory; the rest is spooled to disk
+	messageFormMemory = 1024 * 1024
+)
+
 // SendMessage handles POST /api/messages
+// The body is JSON, or multipart/form-data with the media in a "file" part
 func (h *Handler) SendMessage(c *gin.Context) {
 	var req dto.SendMessageRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if c.ContentType() == gin.MIMEMultipartPOSTForm {
+		file, ok := bindSendMessageForm(c, &req)
+		if !ok {
+			return
+		}
+		if file != nil {
+			defer file.Close()
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
 		respondWithError(c, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", nil)
 		return
 	}
@@ -25,6 +46,10 @@ func (h *Handler) SendMessage(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
 	// Additional validation for message content
 	if err := req.Validate(); err != nil {
 		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", err.Error(), nil)
@@ -62,6 +87,171 @@ func (h *Handler) SendMessage(c *gin.Context) {
 	})
 }
 
+// bindSendMessageForm reads a multipart/form-data send request into req
+// Message fields are form values and the media is the "file" part, which the caller must close
+func bindSendMessageForm(c *gin.Context, req *dto.SendMessageRequest) (multipart.File, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxMessageFormSize)
+	if err := c.Request.ParseMultipartForm(messageFormMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondWithError(c, http.StatusRequestEntityTooLarge, "MEDIA_TOO_LARGE", "Request body is too large", nil)
+			return nil, false
+		}
+		respondWithError(c, http.StatusBadRequest, "INVALID_FORM", "Invalid multipart form", nil)
+		return nil, false
+	}
+
+	req.SessionID = c.PostForm("session_id")
+	req.To = c.PostForm("to")
+	req.Type = c.PostForm("type")
+	if caption, ok := c.GetPostForm("caption"); ok {
+		req.Content.Caption = &caption
+	}
+	if filename, ok := c.GetPostForm("filename"); ok {
+		req.Content.Filename = &filename
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		// Without a file the request fails content validation
+		return nil, true
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_FORM", "Invalid file part", nil)
+		return nil, false
+	}
+
+	req.File = &dto.MediaFileInput{
+		Content:  file,
+		Filename: fileHeader.Filename,
+		MimeType: fileHeader.Header.Get("Content-Type"),
+	}
+	if req.Content.Filename != nil {
+		req.File.Filename = *req.Content.Filename
+	}
+
+	return file, true
+}
+
+// GetMessage handles GET /api/messages/:messageId
+func (h *Handler) GetMessage(c *gin.Context) {
+	messageID := c.Param("messageId")
+	if messageID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Message ID is required", nil)
+		return
+	}
+
+	if h.messageUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Message use case not configured", nil)
+		return
+	}
+
+	status, err := h.messageUC.GetMessageStatus(c.Request.Context(), messageID)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	if !authorizeSessionRecord(c, status.SessionID, domainErrors.ErrMessageNotFound) {
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, status)
+}
+
+// EditMessage handles PATCH /api/messages/:messageId
+func (h *Handler) EditMessage(c *gin.Context) {
+	messageID := c.Param("messageId")
+	if messageID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Message ID is required", nil)
+		return
+	}
+
+	var req dto.EditMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", nil)
+		return
+	}
+
+	// Set message ID from URL parameter
+	req.MessageID = messageID
+
+	if err := validator.Validate(req); err != nil {
+		details := validator.ValidationErrors(err)
+		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", "Validation failed", details)
+		return
+	}
+
+	if h.messageUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Message use case not configured", nil)
+		return
+	}
+
+	if !h.authorizeMessageAccess(c, messageID) {
+		return
+	}
+
+	msg, err := h.messageUC.EditMessage(c.Request.Context(), req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, map[string]any{
+		"message_id": msg.ID,
+		"text":       req.Text,
+		"edited_at":  msg.Content.EditedAt.Format(time.RFC3339),
+	})
+}
+
+// RevokeMessage handles DELETE /api/messages/:messageId?for=everyone
+func (h *Handler) RevokeMessage(c *gin.Context) {
+	messageID := c.Param("messageId")
+	if messageID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Message ID is required", nil)
+		return
+	}
+
+	// Only deleting for everyone is supported; deleting for me is a device-local action
+	if c.Query("for") != "everyone" {
+		respondWithError(c, http.StatusBadRequest, "VALIDATION_FAILED", "for=everyone is required to revoke a message", nil)
+		return
+	}
+
+	if h.messageUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Message use case not configured", nil)
+		return
+	}
+
+	if !h.authorizeMessageAccess(c, messageID) {
+		return
+	}
+
+	msg, err := h.messageUC.RevokeMessage(c.Request.Context(), dto.RevokeMessageRequest{MessageID: messageID})
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, map[string]any{
+		"message_id": msg.ID,
+		"revoked_at": msg.Content.RevokedAt.Format(time.RFC3339),
+	})
+}
+
+// authorizeMessageAccess loads a stored message and checks that the API key may access its session
+func (h *Handler) authorizeMessageAccess(c *gin.Context, messageID string) bool {
+	msg, err := h.messageUC.FindMessage(c.Request.Context(), messageID)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return false
+	}
+
+	return authorizeSessionRecord(c, msg.SessionID, domainErrors.ErrMessageNotFound)
+}
+
 // SendReaction handles POST /api/messages/:messageId/reactions
 func (h *Handler) SendReaction(c *gin.Context) {
 	messageID := c.Param("messageId")
@@ -85,6 +275,10 @@ func (h *Handler) SendReaction(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
 	if h.reactionUC == nil {
 		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Reaction use case not configured", nil)
 		return
@@ -122,6 +316,10 @@ func (h *Handler) RemoveReaction(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
 	if h.reactionUC == nil {
 		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Reaction use case not configured", nil)
 		return
@@ -150,6 +348,10 @@ func (h *Handler) SendReadReceipt(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
 	if h.receiptUC == nil {
 		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Receipt use case not configured", nil)
 		return
@@ -179,6 +381,10 @@ func (h *Handler) SendPresence(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionAccess(c, req.SessionID) {
+		return
+	}
+
 	if h.presenceUC == nil {
 		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Presence use case not configured", nil)
 		return
@@ -197,3 +403,39 @@ func (h *Handler) SendPresence(c *gin.Context) {
 	}
 	respondWithSuccess(c, http.StatusOK, response)
 }
+
+// ListChatMessages handles GET /api/sessions/:id/chats/:jid/messages
+func (h *Handler) ListChatMessages(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Session ID is required", nil)
+		return
+	}
+
+	chatJID := c.Param("jid")
+	if chatJID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_JID", "Chat JID is required", nil)
+		return
+	}
+
+	var req dto.ListMessagesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters", nil)
+		return
+	}
+	req.SessionID = sessionID
+	req.ChatJID = chatJID
+
+	if h.messageUC == nil {
+		respondWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Message use case not configured", nil)
+		return
+	}
+
+	response, err := h.messageUC.ListChatMessages(c.Request.Context(), req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, response)
+}
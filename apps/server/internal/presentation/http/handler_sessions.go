@@ -31,18 +31,8 @@ func (h *Handler) CreateSession(c *gin.Context) {
 	// Generate UUID for session ID
 	sessionID := uuid.New().String()
 
-	// TODO: Implement session config options (req.Config)
-	// - account_protection: Control message sending frequency
-	// - message_logging: Store full message content vs delivery status only
-	// - read_messages: Auto-mark messages as read
-	// - auto_reject_calls: Automatically reject incoming calls
-	// - always_online: Always appear online
-	// - ignore_groups: Skip group messages
-	// - ignore_broadcasts: Skip broadcast messages
-	// - ignore_channels: Skip channel messages
-
 	// Create session in local repository for WhatsApp client tracking
-	session, err := h.sessionUC.CreateSessionWithID(c.Request.Context(), sessionID, req.Name)
+	session, err := h.sessionUC.CreateSessionWithConfig(c.Request.Context(), sessionID, req.Name, req.Config)
 	if err != nil {
 		handleDomainError(c, err, h.logger)
 		return
@@ -63,6 +53,10 @@ func (h *Handler) RegisterSession(c *gin.Context) {
 		return
 	}
 
+	if !authorizeSessionAccess(c, req.ID) {
+		return
+	}
+
 	// Create session in local repository for WhatsApp client tracking
 	session, err := h.sessionUC.CreateSessionWithID(c.Request.Context(), req.ID, req.Name)
 	if err != nil {
@@ -221,9 +215,15 @@ func (h *Handler) ListSessions(c *gin.Context) {
 		return
 	}
 
+	// Session-scoped API keys only see the sessions they are bound to
+	apiKey := GetAuthenticatedAPIKey(c)
+
 	// Convert to response DTOs
 	sessionResponses := make([]map[string]any, 0, len(sessions))
 	for _, s := range sessions {
+		if apiKey != nil && !apiKey.CanAccessSession(s.ID) {
+			continue
+		}
 		sessionResponses = append(sessionResponses, map[string]any{
 			"id":         s.ID,
 			"name":       s.Name,
@@ -304,18 +304,8 @@ func (h *Handler) UpdateSession(c *gin.Context) {
 		return
 	}
 
-	// TODO: Implement session config options (req.Config)
-	// - account_protection: Control message sending frequency
-	// - message_logging: Store full message content vs delivery status only
-	// - read_messages: Auto-mark messages as read
-	// - auto_reject_calls: Automatically reject incoming calls
-	// - always_online: Always appear online
-	// - ignore_groups: Skip group messages
-	// - ignore_broadcasts: Skip broadcast messages
-	// - ignore_channels: Skip channel messages
-
 	// Update session
-	session, err := h.sessionUC.UpdateSession(c.Request.Context(), id, req.Name)
+	session, err := h.sessionUC.UpdateSession(c.Request.Context(), id, req.Name, req.Config)
 	if err != nil {
 		handleDomainError(c, err, h.logger)
 		return
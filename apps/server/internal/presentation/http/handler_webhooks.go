@@ -107,3 +107,190 @@ func (h *Handler) DeleteWebhookConfig(c *gin.Context) {
 
 	respondWithSuccess(c, http.StatusOK, map[string]string{"message": "Webhook configuration deleted successfully"})
 }
+
+// ListWebhookDeliveries handles GET /api/sessions/:id/webhook/deliveries
+// Lists queued, delivered and dead-lettered webhook deliveries for a session
+func (h *Handler) ListWebhookDeliveries(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Session ID is required", nil)
+		return
+	}
+
+	var req dto.ListWebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters", nil)
+		return
+	}
+
+	response, err := h.webhookUC.ListDeliveries(c.Request.Context(), sessionID, req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, response)
+}
+
+// RedeliverWebhookDelivery handles POST /api/sessions/:id/webhook/deliveries/:deliveryId/redeliver
+// Queues a delivery for an immediate attempt with a fresh retry schedule
+func (h *Handler) RedeliverWebhookDelivery(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Session ID is required", nil)
+		return
+	}
+
+	deliveryID := c.Param("deliveryId")
+	if deliveryID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Delivery ID is required", nil)
+		return
+	}
+
+	delivery, err := h.webhookUC.RedeliverDelivery(c.Request.Context(), sessionID, deliveryID)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusAccepted, dto.NewWebhookDeliveryDTO(delivery))
+}
+
+// ListWebhookEndpoints handles GET /api/sessions/:id/webhooks
+// Lists the session's webhook endpoints
+func (h *Handler) ListWebhookEndpoints(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Session ID is required", nil)
+		return
+	}
+
+	endpoints, err := h.webhookUC.ListWebhookEndpoints(c.Request.Context(), sessionID)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	response := dto.ListWebhookEndpointsResponse{
+		Endpoints: make([]dto.WebhookEndpointResponse, len(endpoints)),
+	}
+	for i, endpoint := range endpoints {
+		response.Endpoints[i] = dto.NewWebhookEndpointResponse(endpoint)
+	}
+
+	respondWithSuccess(c, http.StatusOK, response)
+}
+
+// CreateWebhookEndpoint handles POST /api/sessions/:id/webhooks
+// Adds a webhook endpoint to the session
+func (h *Handler) CreateWebhookEndpoint(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Session ID is required", nil)
+		return
+	}
+
+	var req dto.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", nil)
+		return
+	}
+
+	endpoint, err := h.webhookUC.CreateWebhookEndpoint(c.Request.Context(), sessionID, req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusCreated, dto.NewWebhookEndpointResponse(endpoint))
+}
+
+// GetWebhookEndpoint handles GET /api/sessions/:id/webhooks/:endpointId
+// Retrieves one of the session's webhook endpoints
+func (h *Handler) GetWebhookEndpoint(c *gin.Context) {
+	sessionID, endpointID, ok := webhookEndpointParams(c)
+	if !ok {
+		return
+	}
+
+	endpoint, err := h.webhookUC.GetWebhookEndpoint(c.Request.Context(), sessionID, endpointID)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, dto.NewWebhookEndpointResponse(endpoint))
+}
+
+// UpdateWebhookEndpoint handles PUT /api/sessions/:id/webhooks/:endpointId
+// Replaces the settings of one of the session's webhook endpoints
+func (h *Handler) UpdateWebhookEndpoint(c *gin.Context) {
+	sessionID, endpointID, ok := webhookEndpointParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_JSON", "Invalid request body", nil)
+		return
+	}
+
+	endpoint, err := h.webhookUC.UpdateWebhookEndpoint(c.Request.Context(), sessionID, endpointID, req)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, dto.NewWebhookEndpointResponse(endpoint))
+}
+
+// RotateWebhookEndpointSecret handles POST /api/sessions/:id/webhooks/:endpointId/rotate-secret
+// Generates a new secret for one of the session's webhook endpoints
+func (h *Handler) RotateWebhookEndpointSecret(c *gin.Context) {
+	sessionID, endpointID, ok := webhookEndpointParams(c)
+	if !ok {
+		return
+	}
+
+	endpoint, err := h.webhookUC.RotateWebhookEndpointSecret(c.Request.Context(), sessionID, endpointID)
+	if err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, dto.NewWebhookEndpointResponse(endpoint))
+}
+
+// DeleteWebhookEndpoint handles DELETE /api/sessions/:id/webhooks/:endpointId
+// Removes one of the session's webhook endpoints
+func (h *Handler) DeleteWebhookEndpoint(c *gin.Context) {
+	sessionID, endpointID, ok := webhookEndpointParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookUC.DeleteWebhookEndpoint(c.Request.Context(), sessionID, endpointID); err != nil {
+		handleDomainError(c, err, h.logger)
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, map[string]string{"message": "Webhook endpoint deleted successfully"})
+}
+
+// webhookEndpointParams reads the session and endpoint IDs, responding with an error if either is missing
+func webhookEndpointParams(c *gin.Context) (string, string, bool) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Session ID is required", nil)
+		return "", "", false
+	}
+
+	endpointID := c.Param("endpointId")
+	if endpointID == "" {
+		respondWithError(c, http.StatusBadRequest, "INVALID_ID", "Endpoint ID is required", nil)
+		return "", "", false
+	}
+
+	return sessionID, endpointID, true
+}
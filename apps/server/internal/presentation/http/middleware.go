@@ -3,7 +3,6 @@ package http
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +12,9 @@ import (
 	"time"
 
 	"whatspire/internal/application/dto"
+	"whatspire/internal/application/usecase"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
 	"whatspire/internal/domain/repository"
 	"whatspire/internal/infrastructure/config"
 	"whatspire/internal/infrastructure/logger"
@@ -183,7 +185,13 @@ func IsOriginAllowed(origin string, allowedOrigins []string) bool {
 	return getAllowedOrigin(origin, allowedOrigins) != ""
 }
 
+// multipartRoutes are the API routes that also accept multipart/form-data uploads
+var multipartRoutes = map[string]bool{
+	"POST /api/messages": true,
+}
+
 // ContentTypeMiddleware ensures JSON content type for API requests
+// Routes in multipartRoutes may also be sent as multipart/form-data
 func ContentTypeMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Skip for non-API routes
@@ -195,6 +203,10 @@ func ContentTypeMiddleware() gin.HandlerFunc {
 		// For POST/PUT/PATCH requests, validate content type
 		if c.Request.Method == "POST" || c.Request.Method == "PUT" || c.Request.Method == "PATCH" {
 			contentType := c.GetHeader("Content-Type")
+			if c.ContentType() == gin.MIMEMultipartPOSTForm && multipartRoutes[c.Request.Method+" "+c.Request.URL.Path] {
+				c.Next()
+				return
+			}
 			if contentType != "" && contentType != "application/json" {
 				// Check if it starts with application/json (might have charset)
 				if len(contentType) < 16 || contentType[:16] != "application/json" {
@@ -354,15 +366,7 @@ func APIKeyMiddleware(apiKeyConfig config.APIKeyConfig, auditLogger repository.A
 
 		if apiKey == "" {
 			// Log authentication failure
-			if auditLogger != nil {
-				auditLogger.LogAuthFailure(c.Request.Context(), repository.AuthFailureEvent{
-					APIKey:    "",
-					Endpoint:  c.Request.URL.Path,
-					Reason:    "missing_api_key",
-					Timestamp: time.Now(),
-					IPAddress: c.ClientIP(),
-				})
-			}
+			recordAuthFailure(c, auditLogger, "", "missing_api_key")
 
 			c.JSON(http.StatusUnauthorized, dto.NewErrorResponse[interface{}](
 				"MISSING_API_KEY",
@@ -374,22 +378,13 @@ func APIKeyMiddleware(apiKeyConfig config.APIKeyConfig, auditLogger repository.A
 		}
 
 		// Hash the provided key to look it up in the database
-		hash := sha256.Sum256([]byte(apiKey))
-		keyHash := fmt.Sprintf("%x", hash)
+		keyHash := usecase.HashAPIKey(apiKey)
 
 		// Look up the key in the database
 		dbKey, err := apiKeyRepo.FindByKeyHash(c.Request.Context(), keyHash)
 		if err != nil {
 			// Key not found in database, log failure
-			if auditLogger != nil {
-				auditLogger.LogAuthFailure(c.Request.Context(), repository.AuthFailureEvent{
-					APIKey:    apiKey,
-					Endpoint:  c.Request.URL.Path,
-					Reason:    "invalid_api_key",
-					Timestamp: time.Now(),
-					IPAddress: c.ClientIP(),
-				})
-			}
+			recordAuthFailure(c, auditLogger, apiKey, "invalid_api_key")
 
 			c.JSON(http.StatusUnauthorized, dto.NewErrorResponse[interface{}](
 				"INVALID_API_KEY",
@@ -403,15 +398,7 @@ func APIKeyMiddleware(apiKeyConfig config.APIKeyConfig, auditLogger repository.A
 		// Check if the key is active (not revoked)
 		if !dbKey.IsActive {
 			// Log authentication failure for revoked key
-			if auditLogger != nil {
-				auditLogger.LogAuthFailure(c.Request.Context(), repository.AuthFailureEvent{
-					APIKey:    apiKey,
-					Endpoint:  c.Request.URL.Path,
-					Reason:    "revoked_api_key",
-					Timestamp: time.Now(),
-					IPAddress: c.ClientIP(),
-				})
-			}
+			recordAuthFailure(c, auditLogger, apiKey, "revoked_api_key")
 
 			c.JSON(http.StatusUnauthorized, dto.NewErrorResponse[interface{}](
 				"REVOKED_API_KEY",
@@ -422,10 +409,29 @@ func APIKeyMiddleware(apiKeyConfig config.APIKeyConfig, auditLogger repository.A
 			return
 		}
 
+		// Check if the key has expired (including rotated keys past their grace period)
+		if dbKey.IsExpired() {
+			recordAuthFailure(c, auditLogger, apiKey, "expired_api_key")
+
+			c.JSON(http.StatusUnauthorized, dto.NewErrorResponse[interface{}](
+				"EXPIRED_API_KEY",
+				"This API key has expired",
+				nil,
+			))
+			c.Abort()
+			return
+		}
+
+		// Enforce daily quotas so a leaked key cannot flood WhatsApp
+		if dbKey.HasQuota() && !enforceAPIKeyQuota(c, apiKeyRepo, dbKey) {
+			return
+		}
+
 		// Store API key, role, and ID in context for use by other middleware
 		c.Set("api_key", apiKey)
 		c.Set("api_key_role", dbKey.Role)
 		c.Set("api_key_id", dbKey.ID)
+		c.Set(APIKeyEntityContextKey, dbKey)
 
 		// Log API key usage
 		if auditLogger != nil {
@@ -444,12 +450,235 @@ func APIKeyMiddleware(apiKeyConfig config.APIKeyConfig, auditLogger repository.A
 		}()
 
 		c.Next()
+
+		if dbKey.HasQuota() {
+			releaseAPIKeyMessage(c, apiKeyRepo, dbKey)
+		}
+	}
+}
+
+// authFailureReasonContextKey is the Gin context key APIKeyMiddleware reports a rejected API key under
+const authFailureReasonContextKey = "auth_failure_reason"
+
+// recordAuthFailure audits a failed authentication without recording the presented key itself
+// Failures for a presented key are also reported to AuthLockoutMiddleware through the Gin context
+func recordAuthFailure(c *gin.Context, auditLogger repository.AuditLogger, apiKey, reason string) {
+	event := repository.AuthFailureEvent{
+		Endpoint:  c.Request.URL.Path,
+		Reason:    reason,
+		Timestamp: time.Now(),
+		IPAddress: c.ClientIP(),
+	}
+	if apiKey != "" {
+		event.MaskedKey = usecase.MaskAPIKey(apiKey)
+		event.KeyHash = usecase.HashAPIKey(apiKey)
+		c.Set(authFailureReasonContextKey, reason)
+	}
+
+	if auditLogger != nil {
+		auditLogger.LogAuthFailure(c.Request.Context(), event)
+	}
+}
+
+// AuthLockoutMiddleware temporarily blocks client IPs after repeated API key authentication failures.
+// It must run before APIKeyMiddleware, which reports rejected keys through the Gin context.
+// When a lockout starts, a security.auth_lockout event is published and an auth failure is audited.
+// Requests are passed through unchanged when authentication or the lockout threshold is disabled.
+func AuthLockoutMiddleware(apiKeyConfig config.APIKeyConfig, publisher repository.EventPublisher, auditLogger repository.AuditLogger) gin.HandlerFunc {
+	if !apiKeyConfig.Enabled || apiKeyConfig.LockoutThreshold <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	lockout := ratelimit.NewAuthLockout(ratelimit.LockoutConfig{
+		Threshold: apiKeyConfig.LockoutThreshold,
+		Window:    apiKeyConfig.LockoutWindow,
+		Duration:  apiKeyConfig.LockoutDuration,
+	})
+
+	return func(c *gin.Context) {
+		clientIP := c.ClientIP()
+
+		if lockedUntil, locked := lockout.LockedUntil(clientIP); locked {
+			retryAfter := int(time.Until(lockedUntil).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, dto.NewErrorResponse[interface{}](
+				"AUTH_LOCKED_OUT",
+				"Too many failed authentication attempts, try again later",
+				map[string]string{"retry_after": strconv.Itoa(retryAfter)},
+			))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+
+		if _, failed := c.Get(authFailureReasonContextKey); !failed {
+			// A successful authentication clears earlier failures of the client
+			if _, authenticated := c.Get(APIKeyEntityContextKey); authenticated {
+				lockout.Reset(clientIP)
+			}
+			return
+		}
+
+		lockedUntil, lockedNow := lockout.RecordFailure(clientIP)
+		if !lockedNow {
+			return
+		}
+
+		ctx := context.Background()
+		if auditLogger != nil {
+			auditLogger.LogAuthFailure(ctx, repository.AuthFailureEvent{
+				Endpoint:  c.Request.URL.Path,
+				Reason:    "auth_lockout",
+				Timestamp: time.Now(),
+				IPAddress: clientIP,
+			})
+		}
+
+		if publisher != nil {
+			event, err := entity.NewAuthLockoutEvent(uuid.New().String(), entity.AuthLockoutData{
+				IPAddress:   clientIP,
+				Failures:    apiKeyConfig.LockoutThreshold,
+				Endpoint:    c.Request.URL.Path,
+				LockedUntil: lockedUntil.UTC(),
+			})
+			if err == nil {
+				go func() {
+					_ = publisher.Publish(ctx, event)
+				}()
+			}
+		}
 	}
 }
 
+// messageSendRoutes lists the routes whose requests count against the daily message quota
+var messageSendRoutes = map[string]bool{
+	http.MethodPost + " /api/messages":                      true,
+	http.MethodPatch + " /api/messages/:messageId":          true,
+	http.MethodPost + " /api/messages/:messageId/reactions": true,
+}
+
+// isMessageSendRoute reports whether the request sends a message and counts against the daily message quota
+func isMessageSendRoute(c *gin.Context) bool {
+	return messageSendRoutes[c.Request.Method+" "+c.FullPath()]
+}
+
+// enforceAPIKeyQuota counts the request against the key's daily request quota and reserves a slot of the
+// daily message quota for message requests, in a single increment so concurrent requests cannot overrun it
+// Returns false and aborts with 429 if a quota is exceeded; the reserved message slot is then given back
+// Quota storage failures let the request through rather than failing every request of the key
+func enforceAPIKeyQuota(c *gin.Context, apiKeyRepo repository.APIKeyRepository, apiKey *entity.APIKey) bool {
+	now := time.Now()
+	messages := 0
+	if isMessageSendRoute(c) {
+		messages = 1
+	}
+
+	usage, err := apiKeyRepo.IncrementUsage(c.Request.Context(), apiKey.ID, now, 1, messages)
+	if err != nil {
+		return true
+	}
+	if messages > 0 {
+		c.Set(messageSlotContextKey, now)
+	}
+
+	var message string
+	switch {
+	case apiKey.ExceedsRequestQuota(usage):
+		message = fmt.Sprintf("Daily request quota of %d exceeded", apiKey.DailyRequestQuota)
+	case messages > 0 && apiKey.ExceedsMessageQuota(usage):
+		message = fmt.Sprintf("Daily message quota of %d exceeded", apiKey.DailyMessageQuota)
+	default:
+		return true
+	}
+
+	// Quotas reset at midnight UTC
+	utc := now.UTC()
+	resetAt := time.Date(utc.Year(), utc.Month(), utc.Day()+1, 0, 0, 0, 0, time.UTC)
+	c.Header("Retry-After", strconv.Itoa(int(resetAt.Sub(utc).Seconds())+1))
+
+	c.JSON(http.StatusTooManyRequests, dto.NewErrorResponse[interface{}](
+		"QUOTA_EXCEEDED",
+		message,
+		nil,
+	))
+	c.Abort()
+	releaseAPIKeyMessage(c, apiKeyRepo, apiKey)
+	return false
+}
+
+// messageSlotContextKey is the Gin context key enforceAPIKeyQuota records the time of a reserved message slot under
+const messageSlotContextKey = "api_key_message_slot"
+
+// releaseAPIKeyMessage gives back the message slot reserved by enforceAPIKeyQuota when the request did not
+// send a message, so requests that are rejected or fail do not use up the daily message quota
+func releaseAPIKeyMessage(c *gin.Context, apiKeyRepo repository.APIKeyRepository, apiKey *entity.APIKey) {
+	reservedAt, reserved := c.Get(messageSlotContextKey)
+	if !reserved || c.Writer.Status() < http.StatusBadRequest {
+		return
+	}
+	_, _ = apiKeyRepo.IncrementUsage(context.WithoutCancel(c.Request.Context()), apiKey.ID, reservedAt.(time.Time), 0, -1)
+}
+
 // APIKeyContextKey is the context key for the authenticated API key
 const APIKeyContextKey = "api_key"
 
+// mediaTokenContextKey marks requests authorized by a signed media link
+const mediaTokenContextKey = "media_token"
+
+// MediaTokenMiddleware authorizes media downloads that carry a signed link token.
+// Requests without a token are left to API key authentication, which is skipped for
+// requests this middleware has authorized (see skipWithMediaToken).
+// Requests with an invalid or expired token are rejected and audited as auth failures.
+func MediaTokenMiddleware(mediaUC *usecase.MediaUseCase, auditLogger repository.AuditLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		signature := c.Query("signature")
+		if signature == "" {
+			c.Next()
+			return
+		}
+
+		var err error = errors.ErrInvalidMediaToken
+		if mediaUC != nil {
+			err = mediaUC.VerifyMediaToken(mediaRequestPath(c), c.Query("expires"), signature)
+		}
+		if err != nil {
+			recordAuthFailure(c, auditLogger, "", "invalid_media_token")
+
+			message := errors.ErrInvalidMediaToken.Message
+			if domainErr := errors.GetDomainError(err); domainErr != nil {
+				message = domainErr.Message
+			}
+			c.JSON(http.StatusForbidden, dto.NewErrorResponse[interface{}](
+				"INVALID_MEDIA_TOKEN",
+				message,
+				nil,
+			))
+			c.Abort()
+			return
+		}
+
+		c.Set(mediaTokenContextKey, true)
+		c.Next()
+	}
+}
+
+// skipWithMediaToken runs a middleware only for requests that were not authorized by a signed media link
+func skipWithMediaToken(middleware gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool(mediaTokenContextKey) {
+			c.Next()
+			return
+		}
+		middleware(c)
+	}
+}
+
+// APIKeyEntityContextKey is the context key for the stored record of the authenticated API key
+const APIKeyEntityContextKey = "api_key_entity"
+
 // GetAPIKey retrieves the API key from the Gin context
 func GetAPIKey(c *gin.Context) string {
 	if apiKey, exists := c.Get(APIKeyContextKey); exists {
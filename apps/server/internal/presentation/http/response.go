@@ -54,18 +54,21 @@ func handleDomainError(c *gin.Context, err error, log *logger.Logger) {
 func mapErrorToHTTPStatus(code string) int {
 	switch code {
 	// Not Found errors (404)
-	case "SESSION_NOT_FOUND", "MESSAGE_NOT_FOUND", "NOT_FOUND", "CONTACT_NOT_FOUND", "CHAT_NOT_FOUND":
+	case "SESSION_NOT_FOUND", "MESSAGE_NOT_FOUND", "NOT_FOUND", "CONTACT_NOT_FOUND", "CHAT_NOT_FOUND",
+		"GROUP_NOT_FOUND":
 		return http.StatusNotFound
 
 	// Conflict errors (409)
-	case "SESSION_EXISTS", "DUPLICATE":
+	case "SESSION_EXISTS", "DUPLICATE", "DELIVERY_IN_FLIGHT":
 		return http.StatusConflict
 
 	// Bad Request errors (400)
 	case "INVALID_PHONE", "VALIDATION_FAILED", "INVALID_INPUT", "EMPTY_CONTENT", "INVALID_MESSAGE_TYPE",
 		"INVALID_MEDIA_SIZE", "MEDIA_TOO_LARGE", "UNSUPPORTED_MEDIA_TYPE", "INVALID_MIME_TYPE", "UNSUPPORTED_MIME_TYPE",
 		"DISCONNECTED", "SESSION_INVALID", "INVALID_EMOJI", "INVALID_REACTION", "INVALID_RECEIPT_TYPE",
-		"INVALID_PRESENCE_STATE", "INVALID_JID", "INVALID_STATUS", "ALREADY_REVOKED":
+		"INVALID_PRESENCE_STATE", "INVALID_JID", "INVALID_STATUS", "ALREADY_REVOKED",
+		"ALREADY_ROTATED", "API_KEY_EXPIRED",
+		"MESSAGE_NOT_EDITABLE", "MESSAGE_NOT_REVOCABLE", "INVALID_INVITE_LINK":
 		return http.StatusBadRequest
 
 	// Timeout errors (408)
@@ -88,11 +91,11 @@ func mapErrorToHTTPStatus(code string) int {
 		return http.StatusUnauthorized
 
 	// Forbidden errors (403)
-	case "FORBIDDEN", "INSUFFICIENT_PERMISSIONS":
+	case "FORBIDDEN", "INSUFFICIENT_PERMISSIONS", "GROUP_FORBIDDEN", "INVALID_MEDIA_TOKEN":
 		return http.StatusForbidden
 
 	// Rate Limit errors (429)
-	case "RATE_LIMIT_EXCEEDED":
+	case "RATE_LIMIT_EXCEEDED", "QUOTA_EXCEEDED":
 		return http.StatusTooManyRequests
 
 	// Default to Internal Server Error for unknown codes
@@ -1,9 +1,12 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 
 	"whatspire/internal/application/dto"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
 	"whatspire/internal/infrastructure/config"
 
 	"github.com/gin-gonic/gin"
@@ -12,7 +15,8 @@ import (
 // RoleAuthorizationMiddleware creates a middleware that enforces role-based authorization
 // It checks if the authenticated API key has sufficient permissions for the requested operation
 // The role is retrieved from the context (set by APIKeyMiddleware)
-func RoleAuthorizationMiddleware(requiredRole config.Role, apiKeyConfig *config.APIKeyConfig) gin.HandlerFunc {
+// Keys limited to specific scopes must additionally hold every given scope
+func RoleAuthorizationMiddleware(requiredRole config.Role, apiKeyConfig *config.APIKeyConfig, requiredScopes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Skip if API key authentication is disabled
 		if apiKeyConfig == nil || !apiKeyConfig.Enabled {
@@ -55,6 +59,21 @@ func RoleAuthorizationMiddleware(requiredRole config.Role, apiKeyConfig *config.
 			return
 		}
 
+		// Check if the key holds the required scopes
+		if apiKey := GetAuthenticatedAPIKey(c); apiKey != nil {
+			for _, scope := range requiredScopes {
+				if !apiKey.HasScope(scope) {
+					c.JSON(http.StatusForbidden, dto.NewErrorResponse[interface{}](
+						"INSUFFICIENT_SCOPE",
+						fmt.Sprintf("API key is missing the %s scope", scope),
+						nil,
+					))
+					c.Abort()
+					return
+				}
+			}
+		}
+
 		// Store user role in context for potential use by handlers
 		c.Set("user_role", userRole)
 
@@ -62,6 +81,70 @@ func RoleAuthorizationMiddleware(requiredRole config.Role, apiKeyConfig *config.
 	}
 }
 
+// SessionAuthorizationMiddleware creates a middleware that restricts session-scoped API keys
+// to the sessions they are bound to. The session ID is taken from the :id path parameter;
+// routes without it are passed through.
+func SessionAuthorizationMiddleware(apiKeyConfig *config.APIKeyConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiKeyConfig == nil || !apiKeyConfig.Enabled {
+			c.Next()
+			return
+		}
+
+		if sessionID := c.Param("id"); sessionID != "" && !authorizeSessionAccess(c, sessionID) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// authorizeSessionAccess checks that the authenticated API key may access a session
+// taken from the request path, query or body. On failure it responds with 403 and aborts the request.
+func authorizeSessionAccess(c *gin.Context, sessionID string) bool {
+	apiKey := GetAuthenticatedAPIKey(c)
+	if apiKey == nil || apiKey.CanAccessSession(sessionID) {
+		return true
+	}
+
+	message := "API key is not allowed to access this session"
+	if sessionID == "" {
+		message = "A session ID is required for session-scoped API keys"
+	}
+
+	c.JSON(http.StatusForbidden, dto.NewErrorResponse[interface{}](
+		"SESSION_ACCESS_DENIED",
+		message,
+		nil,
+	))
+	c.Abort()
+	return false
+}
+
+// authorizeSessionRecord checks that the authenticated API key may access a record of a session
+// A denied key gets the notFound error, so IDs of other sessions' records cannot be probed
+func authorizeSessionRecord(c *gin.Context, sessionID string, notFound *errors.DomainError) bool {
+	apiKey := GetAuthenticatedAPIKey(c)
+	if apiKey == nil || apiKey.CanAccessSession(sessionID) {
+		return true
+	}
+
+	respondWithError(c, http.StatusNotFound, notFound.Code, notFound.Message, nil)
+	c.Abort()
+	return false
+}
+
+// GetAuthenticatedAPIKey retrieves the stored record of the authenticated API key from the Gin context
+// Returns nil when API key authentication is disabled
+func GetAuthenticatedAPIKey(c *gin.Context) *entity.APIKey {
+	if value, exists := c.Get(APIKeyEntityContextKey); exists {
+		if apiKey, ok := value.(*entity.APIKey); ok {
+			return apiKey
+		}
+	}
+	return nil
+}
+
 // hasPermission checks if a user role has permission for a required role
 // Permission hierarchy: admin > write > read
 func hasPermission(userRole, requiredRole config.Role) bool {
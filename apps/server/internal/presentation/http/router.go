@@ -1,6 +1,7 @@
 package http
 
 import (
+	"whatspire/internal/domain/entity"
 	"whatspire/internal/domain/repository"
 	"whatspire/internal/infrastructure/config"
 	"whatspire/internal/infrastructure/logger"
@@ -31,6 +32,8 @@ type RouterConfig struct {
 	MetricsConfig *config.MetricsConfig
 	// AuditLogger is the audit logger instance (optional)
 	AuditLogger repository.AuditLogger
+	// EventPublisher receives security events such as auth lockouts (optional)
+	EventPublisher repository.EventPublisher
 	// Logger is the logger instance (required)
 	Logger *logger.Logger
 }
@@ -47,6 +50,7 @@ func DefaultRouterConfig() RouterConfig {
 		Metrics:              nil,
 		MetricsConfig:        nil,
 		AuditLogger:          nil,
+		EventPublisher:       nil,
 		Logger:               nil,
 	}
 }
@@ -111,7 +115,11 @@ func registerRoutes(router *gin.Engine, handler *Handler, routerConfig RouterCon
 	api := router.Group("/api")
 
 	// Apply API key authentication to API routes if configured
+	// The API and media route groups share one lockout so failures on either count towards the same threshold
+	var authLockout gin.HandlerFunc
 	if routerConfig.APIKeyConfig != nil && routerConfig.APIKeyConfig.Enabled {
+		authLockout = AuthLockoutMiddleware(*routerConfig.APIKeyConfig, routerConfig.EventPublisher, routerConfig.AuditLogger)
+		api.Use(authLockout)
 		api.Use(APIKeyMiddleware(*routerConfig.APIKeyConfig, routerConfig.AuditLogger, routerConfig.APIKeyRepository))
 	}
 
@@ -119,6 +127,7 @@ func registerRoutes(router *gin.Engine, handler *Handler, routerConfig RouterCon
 	internal := api.Group("/internal")
 	if routerConfig.APIKeyConfig != nil && routerConfig.APIKeyConfig.Enabled {
 		internal.Use(RoleAuthorizationMiddleware(config.RoleAdmin, routerConfig.APIKeyConfig))
+		internal.Use(SessionAuthorizationMiddleware(routerConfig.APIKeyConfig))
 	}
 	internal.POST("/sessions/register", handler.RegisterSession)
 	internal.POST("/sessions/:id/unregister", handler.UnregisterSession)
@@ -128,21 +137,43 @@ func registerRoutes(router *gin.Engine, handler *Handler, routerConfig RouterCon
 	internal.POST("/sessions/:id/history-sync", handler.ConfigureHistorySync)
 
 	// Session routes (groups sync) - require write role for sync, read for list
+	// Session-scoped API keys may only access the sessions they are bound to
 	sessions := api.Group("/sessions")
 	if routerConfig.APIKeyConfig != nil && routerConfig.APIKeyConfig.Enabled {
+		sessions.Use(SessionAuthorizationMiddleware(routerConfig.APIKeyConfig))
 		sessions.POST("", handler.CreateSession) // Public endpoint - no auth required in development
-		sessions.GET("", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig), handler.ListSessions)
-		sessions.GET("/:id", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig), handler.GetSession)
-		sessions.PATCH("/:id", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.UpdateSession)
-		sessions.DELETE("/:id", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.DeleteSession)
-		sessions.POST("/:id/groups/sync", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.SyncGroups)
-		sessions.GET("/:id/contacts", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig), handler.ListContacts)
-		sessions.GET("/:id/chats", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig), handler.ListChats)
+		sessions.GET("", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeSessionsRead), handler.ListSessions)
+		sessions.GET("/:id", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeSessionsRead), handler.GetSession)
+		sessions.PATCH("/:id", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeSessionsWrite), handler.UpdateSession)
+		sessions.DELETE("/:id", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeSessionsWrite), handler.DeleteSession)
+		sessions.POST("/:id/groups/sync", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeGroupsManage), handler.SyncGroups)
+		// Group administration routes - invite links grant access to the group, so reading them requires write role
+		sessions.GET("/:id/groups", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeGroupsRead), handler.ListGroups)
+		sessions.POST("/:id/groups", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeGroupsManage), handler.CreateGroup)
+		sessions.POST("/:id/groups/join", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeGroupsManage), handler.JoinGroup)
+		sessions.GET("/:id/groups/:jid", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeGroupsRead), handler.GetGroup)
+		sessions.PATCH("/:id/groups/:jid", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeGroupsManage), handler.UpdateGroup)
+		sessions.PUT("/:id/groups/:jid/picture", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeGroupsManage), handler.SetGroupPicture)
+		sessions.POST("/:id/groups/:jid/participants", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeGroupsManage), handler.UpdateGroupParticipants)
+		sessions.GET("/:id/groups/:jid/invite-link", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeGroupsManage), handler.GetGroupInviteLink)
+		sessions.DELETE("/:id/groups/:jid/invite-link", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeGroupsManage), handler.RevokeGroupInviteLink)
+		sessions.POST("/:id/groups/:jid/leave", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeGroupsManage), handler.LeaveGroup)
+		sessions.GET("/:id/contacts", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeContactsRead), handler.ListContacts)
+		sessions.GET("/:id/chats", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeContactsRead), handler.ListChats)
+		sessions.GET("/:id/chats/:jid/messages", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeMessagesRead), handler.ListChatMessages)
 		// Webhook routes - require write role
-		sessions.GET("/:id/webhook", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig), handler.GetWebhookConfig)
-		sessions.PUT("/:id/webhook", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.UpdateWebhookConfig)
-		sessions.POST("/:id/webhook/rotate-secret", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.RotateWebhookSecret)
-		sessions.DELETE("/:id/webhook", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.DeleteWebhookConfig)
+		sessions.GET("/:id/webhook", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeWebhooksRead), handler.GetWebhookConfig)
+		sessions.PUT("/:id/webhook", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeWebhooksWrite), handler.UpdateWebhookConfig)
+		sessions.POST("/:id/webhook/rotate-secret", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeWebhooksWrite), handler.RotateWebhookSecret)
+		sessions.DELETE("/:id/webhook", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeWebhooksWrite), handler.DeleteWebhookConfig)
+		sessions.GET("/:id/webhook/deliveries", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeWebhooksRead), handler.ListWebhookDeliveries)
+		sessions.POST("/:id/webhook/deliveries/:deliveryId/redeliver", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeWebhooksWrite), handler.RedeliverWebhookDelivery)
+		sessions.GET("/:id/webhooks", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeWebhooksRead), handler.ListWebhookEndpoints)
+		sessions.POST("/:id/webhooks", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeWebhooksWrite), handler.CreateWebhookEndpoint)
+		sessions.GET("/:id/webhooks/:endpointId", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeWebhooksRead), handler.GetWebhookEndpoint)
+		sessions.PUT("/:id/webhooks/:endpointId", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeWebhooksWrite), handler.UpdateWebhookEndpoint)
+		sessions.DELETE("/:id/webhooks/:endpointId", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeWebhooksWrite), handler.DeleteWebhookEndpoint)
+		sessions.POST("/:id/webhooks/:endpointId/rotate-secret", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeWebhooksWrite), handler.RotateWebhookEndpointSecret)
 	} else {
 		sessions.POST("", handler.CreateSession) // Public endpoint - no auth required in development
 		sessions.GET("", handler.ListSessions)
@@ -150,34 +181,60 @@ func registerRoutes(router *gin.Engine, handler *Handler, routerConfig RouterCon
 		sessions.PATCH("/:id", handler.UpdateSession)
 		sessions.DELETE("/:id", handler.DeleteSession)
 		sessions.POST("/:id/groups/sync", handler.SyncGroups)
+		// Group administration routes
+		sessions.GET("/:id/groups", handler.ListGroups)
+		sessions.POST("/:id/groups", handler.CreateGroup)
+		sessions.POST("/:id/groups/join", handler.JoinGroup)
+		sessions.GET("/:id/groups/:jid", handler.GetGroup)
+		sessions.PATCH("/:id/groups/:jid", handler.UpdateGroup)
+		sessions.PUT("/:id/groups/:jid/picture", handler.SetGroupPicture)
+		sessions.POST("/:id/groups/:jid/participants", handler.UpdateGroupParticipants)
+		sessions.GET("/:id/groups/:jid/invite-link", handler.GetGroupInviteLink)
+		sessions.DELETE("/:id/groups/:jid/invite-link", handler.RevokeGroupInviteLink)
+		sessions.POST("/:id/groups/:jid/leave", handler.LeaveGroup)
 		sessions.GET("/:id/contacts", handler.ListContacts)
 		sessions.GET("/:id/chats", handler.ListChats)
+		sessions.GET("/:id/chats/:jid/messages", handler.ListChatMessages)
 		// Webhook routes
 		sessions.GET("/:id/webhook", handler.GetWebhookConfig)
 		sessions.PUT("/:id/webhook", handler.UpdateWebhookConfig)
 		sessions.POST("/:id/webhook/rotate-secret", handler.RotateWebhookSecret)
 		sessions.DELETE("/:id/webhook", handler.DeleteWebhookConfig)
+		sessions.GET("/:id/webhook/deliveries", handler.ListWebhookDeliveries)
+		sessions.POST("/:id/webhook/deliveries/:deliveryId/redeliver", handler.RedeliverWebhookDelivery)
+		sessions.GET("/:id/webhooks", handler.ListWebhookEndpoints)
+		sessions.POST("/:id/webhooks", handler.CreateWebhookEndpoint)
+		sessions.GET("/:id/webhooks/:endpointId", handler.GetWebhookEndpoint)
+		sessions.PUT("/:id/webhooks/:endpointId", handler.UpdateWebhookEndpoint)
+		sessions.DELETE("/:id/webhooks/:endpointId", handler.DeleteWebhookEndpoint)
+		sessions.POST("/:id/webhooks/:endpointId/rotate-secret", handler.RotateWebhookEndpointSecret)
 	}
 
 	// Contact routes - require read role
 	contacts := api.Group("/contacts")
 	if routerConfig.APIKeyConfig != nil && routerConfig.APIKeyConfig.Enabled {
-		contacts.GET("/check", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig), handler.CheckPhoneNumber)
-		contacts.GET("/:jid/profile", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig), handler.GetUserProfile)
+		contacts.GET("/check", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeContactsRead), handler.CheckPhoneNumber)
+		contacts.GET("/:jid/profile", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeContactsRead), handler.GetUserProfile)
 	} else {
 		contacts.GET("/check", handler.CheckPhoneNumber)
 		contacts.GET("/:jid/profile", handler.GetUserProfile)
 	}
 
-	// Message routes - require write role
+	// Message routes - require write role for sending, read for status
 	messages := api.Group("/messages")
 	if routerConfig.APIKeyConfig != nil && routerConfig.APIKeyConfig.Enabled {
-		messages.POST("", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.SendMessage)
-		messages.POST("/:messageId/reactions", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.SendReaction)
-		messages.DELETE("/:messageId/reactions", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.RemoveReaction)
-		messages.POST("/receipts", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.SendReadReceipt)
+		messages.POST("", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeMessagesSend), handler.SendMessage)
+		messages.GET("/:messageId", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeMessagesRead), handler.GetMessage)
+		messages.PATCH("/:messageId", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeMessagesSend), handler.EditMessage)
+		messages.DELETE("/:messageId", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeMessagesSend), handler.RevokeMessage)
+		messages.POST("/:messageId/reactions", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeMessagesSend), handler.SendReaction)
+		messages.DELETE("/:messageId/reactions", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeMessagesSend), handler.RemoveReaction)
+		messages.POST("/receipts", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopeMessagesSend), handler.SendReadReceipt)
 	} else {
 		messages.POST("", handler.SendMessage)
+		messages.GET("/:messageId", handler.GetMessage)
+		messages.PATCH("/:messageId", handler.EditMessage)
+		messages.DELETE("/:messageId", handler.RevokeMessage)
 		messages.POST("/:messageId/reactions", handler.SendReaction)
 		messages.DELETE("/:messageId/reactions", handler.RemoveReaction)
 		messages.POST("/receipts", handler.SendReadReceipt)
@@ -185,7 +242,7 @@ func registerRoutes(router *gin.Engine, handler *Handler, routerConfig RouterCon
 
 	// Presence routes - require write role
 	if routerConfig.APIKeyConfig != nil && routerConfig.APIKeyConfig.Enabled {
-		api.POST("/presence", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig), handler.SendPresence)
+		api.POST("/presence", RoleAuthorizationMiddleware(config.RoleWrite, routerConfig.APIKeyConfig, entity.ScopePresenceWrite), handler.SendPresence)
 	} else {
 		api.POST("/presence", handler.SendPresence)
 	}
@@ -193,11 +250,13 @@ func registerRoutes(router *gin.Engine, handler *Handler, routerConfig RouterCon
 	// Event routes - require read role for query, admin role for replay
 	events := api.Group("/events")
 	if routerConfig.APIKeyConfig != nil && routerConfig.APIKeyConfig.Enabled {
-		events.GET("", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig), handler.QueryEvents)
-		events.GET("/:id", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig), handler.GetEventByID)
+		events.GET("", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeEventsRead), handler.QueryEvents)
+		events.GET("/stream", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeEventsRead), handler.StreamEvents)
+		events.GET("/:id", RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeEventsRead), handler.GetEventByID)
 		events.POST("/replay", RoleAuthorizationMiddleware(config.RoleAdmin, routerConfig.APIKeyConfig), handler.ReplayEvents)
 	} else {
 		events.GET("", handler.QueryEvents)
+		events.GET("/stream", handler.StreamEvents)
 		events.GET("/:id", handler.GetEventByID)
 		events.POST("/replay", handler.ReplayEvents)
 	}
@@ -209,11 +268,46 @@ func registerRoutes(router *gin.Engine, handler *Handler, routerConfig RouterCon
 		apikeys.GET("", RoleAuthorizationMiddleware(config.RoleAdmin, routerConfig.APIKeyConfig), handler.ListAPIKeys)
 		apikeys.GET("/:id", RoleAuthorizationMiddleware(config.RoleAdmin, routerConfig.APIKeyConfig), handler.GetAPIKeyDetails)
 		apikeys.DELETE("/:id", RoleAuthorizationMiddleware(config.RoleAdmin, routerConfig.APIKeyConfig), handler.RevokeAPIKey)
+		apikeys.POST("/:id/rotate", RoleAuthorizationMiddleware(config.RoleAdmin, routerConfig.APIKeyConfig), handler.RotateAPIKey)
 	} else {
 		apikeys.POST("", handler.CreateAPIKey)
 		apikeys.GET("", handler.ListAPIKeys)
 		apikeys.GET("/:id", handler.GetAPIKeyDetails)
 		apikeys.DELETE("/:id", handler.RevokeAPIKey)
+		apikeys.POST("/:id/rotate", handler.RotateAPIKey)
+	}
+
+	// Audit log routes - require admin role
+	auditLogs := api.Group("/audit-logs")
+	if routerConfig.APIKeyConfig != nil && routerConfig.APIKeyConfig.Enabled {
+		auditLogs.GET("", RoleAuthorizationMiddleware(config.RoleAdmin, routerConfig.APIKeyConfig), handler.ListAuditLogs)
+		auditLogs.GET("/export", RoleAuthorizationMiddleware(config.RoleAdmin, routerConfig.APIKeyConfig), handler.ExportAuditLogs)
+	} else {
+		auditLogs.GET("", handler.ListAuditLogs)
+		auditLogs.GET("/export", handler.ExportAuditLogs)
+	}
+
+	// Media cleanup routes - require admin role
+	mediaCleanup := api.Group("/media-cleanup")
+	if routerConfig.APIKeyConfig != nil && routerConfig.APIKeyConfig.Enabled {
+		mediaCleanup.GET("/dry-run", RoleAuthorizationMiddleware(config.RoleAdmin, routerConfig.APIKeyConfig), handler.PreviewMediaCleanup)
+	} else {
+		mediaCleanup.GET("/dry-run", handler.PreviewMediaCleanup)
+	}
+
+	// Media routes accept a signed link in place of an API key, so they are
+	// registered outside the API group and its authentication
+	media := router.Group("/api/media")
+	if routerConfig.APIKeyConfig != nil && routerConfig.APIKeyConfig.Enabled {
+		media.Use(MediaTokenMiddleware(handler.mediaUC, routerConfig.AuditLogger))
+		media.Use(skipWithMediaToken(authLockout))
+		media.Use(skipWithMediaToken(APIKeyMiddleware(*routerConfig.APIKeyConfig, routerConfig.AuditLogger, routerConfig.APIKeyRepository)))
+		media.Use(skipWithMediaToken(RoleAuthorizationMiddleware(config.RoleRead, routerConfig.APIKeyConfig, entity.ScopeMessagesRead)))
+		media.GET("/*path", handler.GetMedia)
+		media.HEAD("/*path", handler.GetMedia)
+	} else {
+		media.GET("/*path", handler.GetMedia)
+		media.HEAD("/*path", handler.GetMedia)
 	}
 }
 
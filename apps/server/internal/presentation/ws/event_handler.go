@@ -72,8 +72,15 @@ func (h *EventHandler) checkOrigin(r *http.Request) bool {
 }
 
 // HandleEvents handles the WebSocket connection for event streaming
-// WebSocket endpoint: /ws/events
+// WebSocket endpoint: /ws/events?resume_from=session_id:sequence[,session_id:sequence...]
 func (h *EventHandler) HandleEvents(c *gin.Context) {
+	// Parse resume points before upgrading so malformed ones are rejected with a plain HTTP error
+	resumeFrom, err := infraWs.ParseResumeFrom(c.Query("resume_from"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "INVALID_RESUME_FROM", err.Error(), nil)
+		return
+	}
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
@@ -81,8 +88,9 @@ func (h *EventHandler) HandleEvents(c *gin.Context) {
 		return
 	}
 
-	// Create a new client
+	// Create a new client, holding back live events until missed ones are replayed after authentication
 	client := infraWs.NewClient(conn, h.hub)
+	client.SetResumeFrom(resumeFrom)
 
 	// Register the client with the hub
 	h.hub.Register(client)
@@ -15,12 +15,17 @@ var (
 // e164Regex validates E.164 phone number format
 var e164Regex = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
 
+// groupJIDRegex validates WhatsApp group JID format
+var groupJIDRegex = regexp.MustCompile(`^\d{5,32}(-\d{5,16})?@g\.us$`)
+
 // GetValidator returns the singleton validator instance
 func GetValidator() *validator.Validate {
 	once.Do(func() {
 		validate = validator.New()
 		// Register custom e164 validation
 		_ = validate.RegisterValidation("e164", validateE164)
+		// Register custom recipient validation: a phone number or a group
+		_ = validate.RegisterValidation("recipient", validateRecipient)
 	})
 	return validate
 }
@@ -30,6 +35,12 @@ func validateE164(fl validator.FieldLevel) bool {
 	return e164Regex.MatchString(fl.Field().String())
 }
 
+// validateRecipient validates a message recipient: an E.164 phone number or a group JID
+func validateRecipient(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	return e164Regex.MatchString(value) || groupJIDRegex.MatchString(value)
+}
+
 // Validate validates a struct using the singleton validator
 func Validate(s interface{}) error {
 	return GetValidator().Struct(s)
@@ -66,6 +77,8 @@ func formatValidationError(e validator.FieldError) string {
 		return "must be a valid UUID"
 	case "e164":
 		return "must be a valid E.164 phone number (e.g., +1234567890)"
+	case "recipient":
+		return "must be a valid E.164 phone number or group JID (e.g., +1234567890 or 120363025246125486@g.us)"
 	case "url":
 		return "must be a valid URL"
 	case "oneof":
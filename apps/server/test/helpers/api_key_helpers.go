@@ -53,6 +53,18 @@ func CreateTestAPIKey(t *testing.T, repo repository.APIKeyRepository, role strin
 	return testKey
 }
 
+// CreateScopedTestAPIKey creates an API key bound to the given sessions and scopes for testing
+func CreateScopedTestAPIKey(t *testing.T, repo repository.APIKeyRepository, role string, sessionIDs, scopes []string) *TestAPIKey {
+	testKey := GenerateTestAPIKey(role, nil)
+	testKey.Entity.AllowedSessions = sessionIDs
+	testKey.Entity.Scopes = scopes
+
+	err := repo.Save(context.Background(), testKey.Entity)
+	require.NoError(t, err, "Failed to save scoped test API key")
+
+	return testKey
+}
+
 // CreateTestAPIKeyWithPlainText creates an API key with a specific plain-text value
 func CreateTestAPIKeyWithPlainText(t *testing.T, repo repository.APIKeyRepository, plainTextKey, role string, description *string) *TestAPIKey {
 	// Hash the key
@@ -115,13 +127,15 @@ func UpdateTestAPIKeyLastUsed(t *testing.T, repo repository.APIKeyRepository, te
 
 // MockAPIKeyRepository is a simple in-memory implementation for testing
 type MockAPIKeyRepository struct {
-	keys map[string]*entity.APIKey // keyHash -> APIKey
+	keys  map[string]*entity.APIKey      // keyHash -> APIKey
+	usage map[string]*entity.APIKeyUsage // id/day -> usage
 }
 
 // NewMockAPIKeyRepository creates a new mock API key repository
 func NewMockAPIKeyRepository() *MockAPIKeyRepository {
 	return &MockAPIKeyRepository{
-		keys: make(map[string]*entity.APIKey),
+		keys:  make(map[string]*entity.APIKey),
+		usage: make(map[string]*entity.APIKeyUsage),
 	}
 }
 
@@ -216,3 +230,28 @@ func (m *MockAPIKeyRepository) Count(ctx context.Context, role *string, isActive
 	}
 	return count, nil
 }
+
+// IncrementUsage adds to the usage counters of an API key for the day of at
+func (m *MockAPIKeyRepository) IncrementUsage(ctx context.Context, id string, at time.Time, requests, messages int) (*entity.APIKeyUsage, error) {
+	day := entity.APIKeyUsageDay(at)
+	usage, exists := m.usage[id+"/"+day]
+	if !exists {
+		usage = &entity.APIKeyUsage{APIKeyID: id, Day: day}
+		m.usage[id+"/"+day] = usage
+	}
+	usage.Requests += int64(requests)
+	usage.Messages += int64(messages)
+
+	copied := *usage
+	return &copied, nil
+}
+
+// GetUsage retrieves the usage counters of an API key for the day of at
+func (m *MockAPIKeyRepository) GetUsage(ctx context.Context, id string, at time.Time) (*entity.APIKeyUsage, error) {
+	day := entity.APIKeyUsageDay(at)
+	if usage, exists := m.usage[id+"/"+day]; exists {
+		copied := *usage
+		return &copied, nil
+	}
+	return &entity.APIKeyUsage{APIKeyID: id, Day: day}, nil
+}
@@ -0,0 +1,262 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ==================== Fake S3 Server ====================
+
+// FakeS3Object is an object stored by the fake S3 server
+type FakeS3Object struct {
+	Data        []byte
+	ContentType string
+	Metadata    map[string]string // User metadata, keyed by lower case name without the x-amz-meta- prefix
+	Modified    time.Time         // When the object was uploaded
+}
+
+// FakeS3Server is an in-memory, path-style S3-compatible object store for tests
+// Like MinIO it checks credentials, verifies uploaded content against the SHA-256 sent with it,
+// and rejects expired presigned URLs
+type FakeS3Server struct {
+	*httptest.Server
+
+	Bucket      string
+	AccessKeyID string
+	MaxKeys     int // Page size of object listings (default: 1000, as in S3)
+
+	mu      sync.Mutex
+	objects map[string]*FakeS3Object
+	now     func() time.Time
+}
+
+// NewFakeS3Server starts a fake S3 server serving a single bucket; it is closed when the test ends
+func NewFakeS3Server(t *testing.T, bucket, accessKeyID string) *FakeS3Server {
+	t.Helper()
+
+	s := &FakeS3Server{
+		Bucket:      bucket,
+		AccessKeyID: accessKeyID,
+		objects:     make(map[string]*FakeS3Object),
+		now:         time.Now,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// SetNow overrides the clock used to check presigned URL expiry
+func (s *FakeS3Server) SetNow(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// Object returns a stored object, or nil if there is none under the key
+func (s *FakeS3Server) Object(key string) *FakeS3Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.objects[key]
+}
+
+// Len returns the number of stored objects
+func (s *FakeS3Server) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.objects)
+}
+
+func (s *FakeS3Server) handle(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+s.Bucket+"/")
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	if code, message := s.authorize(r); code != "" {
+		writeS3Error(w, http.StatusForbidden, code, message)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key == "" {
+		if r.Method != http.MethodGet || r.URL.Query().Get("list-type") != "2" {
+			writeS3Error(w, http.StatusNotImplemented, "NotImplemented", "Only ListObjectsV2 is supported on the bucket")
+			return
+		}
+		s.listObjects(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+
+		sum := sha256.Sum256(data)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+			writeS3Error(w, http.StatusBadRequest, "XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed")
+			return
+		}
+		if checksum := r.Header.Get("X-Amz-Checksum-Sha256"); checksum != "" && checksum != base64.StdEncoding.EncodeToString(sum[:]) {
+			writeS3Error(w, http.StatusBadRequest, "BadDigest", "The SHA256 you specified did not match the calculated checksum")
+			return
+		}
+
+		object := &FakeS3Object{Data: data, ContentType: r.Header.Get("Content-Type"), Metadata: map[string]string{}, Modified: s.now()}
+		for name := range r.Header {
+			if metaName, ok := strings.CutPrefix(strings.ToLower(name), "x-amz-meta-"); ok {
+				object.Metadata[metaName] = r.Header.Get(name)
+			}
+		}
+		s.objects[key] = object
+		w.WriteHeader(http.StatusOK)
+
+	case http.MethodGet, http.MethodHead:
+		object, ok := s.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist")
+			return
+		}
+
+		w.Header().Set("Content-Type", object.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(object.Data)))
+		for name, value := range object.Metadata {
+			w.Header().Set("X-Amz-Meta-"+name, value)
+		}
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(object.Data)
+		}
+
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed")
+	}
+}
+
+// listObjects writes a page of a ListObjectsV2 response
+// The continuation token is the last key of the previous page
+func (s *FakeS3Server) listObjects(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	prefix := query.Get("prefix")
+	after := query.Get("continuation-token")
+	maxKeys := 1000
+	if s.MaxKeys > 0 {
+		maxKeys = s.MaxKeys
+	}
+	if value := query.Get("max-keys"); value != "" {
+		maxKeys, _ = strconv.Atoi(value)
+	}
+
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	type content struct {
+		Key          string `xml:"Key"`
+		LastModified string `xml:"LastModified"`
+		Size         int    `xml:"Size"`
+	}
+	result := struct {
+		XMLName               xml.Name  `xml:"ListBucketResult"`
+		Name                  string    `xml:"Name"`
+		Prefix                string    `xml:"Prefix"`
+		KeyCount              int       `xml:"KeyCount"`
+		IsTruncated           bool      `xml:"IsTruncated"`
+		NextContinuationToken string    `xml:"NextContinuationToken,omitempty"`
+		Contents              []content `xml:"Contents"`
+	}{Name: s.Bucket, Prefix: prefix}
+
+	if len(keys) > maxKeys {
+		keys = keys[:maxKeys]
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[len(keys)-1]
+	}
+	for _, key := range keys {
+		object := s.objects[key]
+		result.Contents = append(result.Contents, content{
+			Key:          key,
+			LastModified: object.Modified.UTC().Format("2006-01-02T15:04:05.000Z"),
+			Size:         len(object.Data),
+		})
+	}
+	result.KeyCount = len(result.Contents)
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, xml.Header)
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+// authorize checks that a request is signed with the server's access key, either in the
+// Authorization header or as a presigned URL that has not expired
+// It returns an S3 error code and message when the request is rejected
+func (s *FakeS3Server) authorize(r *http.Request) (string, string) {
+	credentialPrefix := "Credential=" + s.AccessKeyID + "/"
+
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") || !strings.Contains(auth, credentialPrefix) || !strings.Contains(auth, "Signature=") {
+			return "InvalidAccessKeyId", "The access key ID you provided does not exist in our records"
+		}
+		return "", ""
+	}
+
+	query := r.URL.Query()
+	if query.Get("X-Amz-Signature") == "" {
+		return "AccessDenied", "Access Denied"
+	}
+	if !strings.HasPrefix("Credential="+query.Get("X-Amz-Credential"), credentialPrefix) {
+		return "InvalidAccessKeyId", "The access key ID you provided does not exist in our records"
+	}
+
+	signedAt, err := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+	if err != nil {
+		return "AuthorizationQueryParametersError", "X-Amz-Date must be in the ISO8601 Long Format"
+	}
+	expires, err := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err != nil {
+		return "AuthorizationQueryParametersError", "X-Amz-Expires should be a number"
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	s.mu.Unlock()
+	if now.After(signedAt.Add(time.Duration(expires) * time.Second)) {
+		return "AccessDenied", "Request has expired"
+	}
+
+	return "", ""
+}
+
+// writeS3Error writes an S3 XML error response
+func writeS3Error(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<Error><Code>%s</Code><Message>%s</Message></Error>", code, message)
+}
@@ -76,7 +76,7 @@ func (m *AuditLoggerMock) LogMessageSent(ctx context.Context, event repository.M
 }
 
 func (m *AuditLoggerMock) LogAuthFailure(ctx context.Context, event repository.AuthFailureEvent) {
-	m.Logs = append(m.Logs, "auth_failure:"+event.APIKey)
+	m.Logs = append(m.Logs, "auth_failure:"+event.MaskedKey)
 }
 
 func (m *AuditLoggerMock) LogWebhookDelivery(ctx context.Context, event repository.WebhookDeliveryEvent) {
@@ -311,6 +311,115 @@ func TestRevokeAPIKey_AlreadyRevoked(t *testing.T) {
 	assert.Equal(t, "ALREADY_REVOKED", response.Error.Code)
 }
 
+// ==================== POST /api/apikeys/:id/rotate Tests ====================
+
+func TestRotateAPIKey_Success(t *testing.T) {
+	router, apiKeyUC, db := setupAPIKeyCRUDTestRouter(t)
+	defer db.Exec("DELETE FROM api_keys WHERE 1=1")
+
+	description := "Integration key"
+	_, apiKey, err := apiKeyUC.CreateAPIKeyWithOptions(context.Background(), "write", &description, usecase.APIKeyOptions{
+		SessionIDs:        []string{"session-1"},
+		DailyMessageQuota: 100,
+	}, "test-user")
+	require.NoError(t, err)
+
+	gracePeriod := 3600
+	body, _ := json.Marshal(dto.RotateAPIKeyRequest{GracePeriodSeconds: &gracePeriod})
+	req := httptest.NewRequest(http.MethodPost, "/api/apikeys/"+apiKey.ID+"/rotate", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response dto.APIResponse[dto.RotateAPIKeyResponse]
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.True(t, response.Success)
+	assert.NotEmpty(t, response.Data.PlainKey)
+	assert.NotEqual(t, apiKey.ID, response.Data.APIKey.ID)
+	assert.Equal(t, "write", response.Data.APIKey.Role)
+	assert.Equal(t, &description, response.Data.APIKey.Description)
+	assert.Equal(t, []string{"session-1"}, response.Data.APIKey.AllowedSessions)
+	assert.Equal(t, 100, response.Data.APIKey.DailyMessageQuota)
+	assert.Nil(t, response.Data.APIKey.ExpiresAt)
+
+	// The previous key stays active until the end of the grace period
+	assert.Equal(t, apiKey.ID, response.Data.PreviousKey.ID)
+	assert.True(t, response.Data.PreviousKey.IsActive)
+	require.NotNil(t, response.Data.PreviousKey.ReplacedBy)
+	assert.Equal(t, response.Data.APIKey.ID, *response.Data.PreviousKey.ReplacedBy)
+	require.NotNil(t, response.Data.PreviousKey.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *response.Data.PreviousKey.ExpiresAt, time.Minute)
+}
+
+func TestRotateAPIKey_WithoutBodyUsesDefaultGracePeriod(t *testing.T) {
+	router, apiKeyUC, db := setupAPIKeyCRUDTestRouter(t)
+	defer db.Exec("DELETE FROM api_keys WHERE 1=1")
+
+	_, apiKey, err := apiKeyUC.CreateAPIKey(context.Background(), "read", nil, "test-user")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/apikeys/"+apiKey.ID+"/rotate", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response dto.APIResponse[dto.RotateAPIKeyResponse]
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	require.NotNil(t, response.Data.PreviousKey.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(usecase.DefaultAPIKeyRotationGracePeriod), *response.Data.PreviousKey.ExpiresAt, time.Minute)
+}
+
+func TestRotateAPIKey_AlreadyRotated(t *testing.T) {
+	router, apiKeyUC, db := setupAPIKeyCRUDTestRouter(t)
+	defer db.Exec("DELETE FROM api_keys WHERE 1=1")
+
+	_, apiKey, err := apiKeyUC.CreateAPIKey(context.Background(), "read", nil, "test-user")
+	require.NoError(t, err)
+
+	_, _, _, err = apiKeyUC.RotateAPIKey(context.Background(), apiKey.ID, time.Hour, nil, "test-user")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/apikeys/"+apiKey.ID+"/rotate", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse[interface{}]
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ALREADY_ROTATED", response.Error.Code)
+}
+
+func TestRotateAPIKey_InvalidGracePeriod(t *testing.T) {
+	router, apiKeyUC, db := setupAPIKeyCRUDTestRouter(t)
+	defer db.Exec("DELETE FROM api_keys WHERE 1=1")
+
+	_, apiKey, err := apiKeyUC.CreateAPIKey(context.Background(), "read", nil, "test-user")
+	require.NoError(t, err)
+
+	gracePeriod := -1
+	body, _ := json.Marshal(dto.RotateAPIKeyRequest{GracePeriodSeconds: &gracePeriod})
+	req := httptest.NewRequest(http.MethodPost, "/api/apikeys/"+apiKey.ID+"/rotate", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 // ==================== GET /api/apikeys Tests (List) ====================
 
 func TestListAPIKeys_Success(t *testing.T) {
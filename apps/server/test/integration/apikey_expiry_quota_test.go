@@ -0,0 +1,254 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/config"
+	"whatspire/internal/infrastructure/persistence"
+	httpHandler "whatspire/internal/presentation/http"
+	"whatspire/test/helpers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ==================== API Key Expiry and Quota Tests ====================
+
+func setupQuotaTestRouter(apiKeyRepo *helpers.MockAPIKeyRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	apiKeyConfig := config.APIKeyConfig{
+		Enabled: true,
+		Header:  "X-API-Key",
+	}
+
+	router := gin.New()
+	api := router.Group("/api")
+	api.Use(httpHandler.APIKeyMiddleware(apiKeyConfig, nil, apiKeyRepo))
+	api.GET("/sessions", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+	api.POST("/messages", func(c *gin.Context) {
+		if c.Query("fail") != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid message"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+	api.PATCH("/messages/:messageId", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	return router
+}
+
+func createQuotaTestAPIKey(t *testing.T, apiKeyRepo *helpers.MockAPIKeyRepository, modify func(apiKey *entity.APIKey)) *helpers.TestAPIKey {
+	testKey := helpers.GenerateTestAPIKey("write", nil)
+	modify(testKey.Entity)
+	require.NoError(t, apiKeyRepo.Save(context.Background(), testKey.Entity))
+	return testKey
+}
+
+func sendQuotaTestRequest(router *gin.Engine, method, path, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("X-API-Key", key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKeyExpiry_ExpiredKeyRejected(t *testing.T) {
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	expired := createQuotaTestAPIKey(t, apiKeyRepo, func(apiKey *entity.APIKey) {
+		expiresAt := time.Now().Add(-time.Minute)
+		apiKey.ExpiresAt = &expiresAt
+	})
+	valid := createQuotaTestAPIKey(t, apiKeyRepo, func(apiKey *entity.APIKey) {
+		expiresAt := time.Now().Add(time.Hour)
+		apiKey.ExpiresAt = &expiresAt
+	})
+
+	router := setupQuotaTestRouter(apiKeyRepo)
+
+	w := sendQuotaTestRequest(router, http.MethodGet, "/api/sessions", expired.PlainText)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var response dto.APIResponse[interface{}]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "EXPIRED_API_KEY", response.Error.Code)
+
+	w = sendQuotaTestRequest(router, http.MethodGet, "/api/sessions", valid.PlainText)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAPIKeyQuota_DailyRequestQuota(t *testing.T) {
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	testKey := createQuotaTestAPIKey(t, apiKeyRepo, func(apiKey *entity.APIKey) {
+		apiKey.DailyRequestQuota = 2
+	})
+
+	router := setupQuotaTestRouter(apiKeyRepo)
+
+	for i := 0; i < 2; i++ {
+		w := sendQuotaTestRequest(router, http.MethodGet, "/api/sessions", testKey.PlainText)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	w := sendQuotaTestRequest(router, http.MethodGet, "/api/sessions", testKey.PlainText)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	var response dto.APIResponse[interface{}]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "QUOTA_EXCEEDED", response.Error.Code)
+	assert.Contains(t, response.Error.Message, "request quota")
+}
+
+func TestAPIKeyQuota_DailyMessageQuota(t *testing.T) {
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	testKey := createQuotaTestAPIKey(t, apiKeyRepo, func(apiKey *entity.APIKey) {
+		apiKey.DailyMessageQuota = 1
+	})
+
+	router := setupQuotaTestRouter(apiKeyRepo)
+
+	w := sendQuotaTestRequest(router, http.MethodPost, "/api/messages", testKey.PlainText)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = sendQuotaTestRequest(router, http.MethodPost, "/api/messages", testKey.PlainText)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	var response dto.APIResponse[interface{}]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "QUOTA_EXCEEDED", response.Error.Code)
+	assert.Contains(t, response.Error.Message, "message quota")
+
+	// Other requests are not counted against the message quota
+	w = sendQuotaTestRequest(router, http.MethodGet, "/api/sessions", testKey.PlainText)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	usage, err := apiKeyRepo.GetUsage(context.Background(), testKey.Entity.ID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), usage.Requests)
+	assert.Equal(t, int64(1), usage.Messages, "rejected requests are not counted as messages")
+}
+
+func TestAPIKeyQuota_MessageQuotaCountsSentMessages(t *testing.T) {
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	testKey := createQuotaTestAPIKey(t, apiKeyRepo, func(apiKey *entity.APIKey) {
+		apiKey.DailyMessageQuota = 2
+	})
+
+	router := setupQuotaTestRouter(apiKeyRepo)
+
+	// Failed sends do not use up the quota
+	for i := 0; i < 3; i++ {
+		w := sendQuotaTestRequest(router, http.MethodPost, "/api/messages?fail=true", testKey.PlainText)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+
+	// Edits send a message like a new message does
+	w := sendQuotaTestRequest(router, http.MethodPost, "/api/messages", testKey.PlainText)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = sendQuotaTestRequest(router, http.MethodPatch, "/api/messages/msg-1", testKey.PlainText)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = sendQuotaTestRequest(router, http.MethodPatch, "/api/messages/msg-1", testKey.PlainText)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	usage, err := apiKeyRepo.GetUsage(context.Background(), testKey.Entity.ID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), usage.Messages)
+}
+
+func TestAPIKeyQuota_ConcurrentSendsCannotExceedMessageQuota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dsn := filepath.Join(t.TempDir(), "quota.db") + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.RunAutoMigration(db, helpers.CreateTestLogger()))
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	apiKeyRepo := persistence.NewAPIKeyRepository(db)
+	testKey := helpers.GenerateTestAPIKey("write", nil)
+	testKey.Entity.DailyMessageQuota = 3
+	require.NoError(t, apiKeyRepo.Save(context.Background(), testKey.Entity))
+
+	// Sends stay in the handler until every request has either reached it or been rejected,
+	// so all of them pass the quota check before any send completes
+	const requests = 10
+	var entered, rejected atomic.Int32
+	release := make(chan struct{})
+
+	router := gin.New()
+	api := router.Group("/api")
+	api.Use(httpHandler.APIKeyMiddleware(config.APIKeyConfig{Enabled: true, Header: "X-API-Key"}, nil, apiKeyRepo))
+	api.POST("/messages", func(c *gin.Context) {
+		entered.Add(1)
+		<-release
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	var wg sync.WaitGroup
+	codes := make([]int, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			codes[i] = sendQuotaTestRequest(router, http.MethodPost, "/api/messages", testKey.PlainText).Code
+			if codes[i] == http.StatusTooManyRequests {
+				rejected.Add(1)
+			}
+		}(i)
+	}
+
+	require.Eventually(t, func() bool {
+		return entered.Load()+rejected.Load() == requests
+	}, 5*time.Second, 5*time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	sent := 0
+	for _, code := range codes {
+		if code == http.StatusOK {
+			sent++
+		}
+	}
+	assert.Equal(t, 3, sent)
+	assert.Equal(t, int32(3), entered.Load())
+
+	usage, err := apiKeyRepo.GetUsage(context.Background(), testKey.Entity.ID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), usage.Messages, "rejected sends give their reserved slot back")
+	assert.Equal(t, int64(requests), usage.Requests)
+}
+
+func TestAPIKeyQuota_UnlimitedKeyNotCounted(t *testing.T) {
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	testKey := helpers.CreateTestAPIKey(t, apiKeyRepo, "write", nil)
+
+	router := setupQuotaTestRouter(apiKeyRepo)
+
+	w := sendQuotaTestRequest(router, http.MethodPost, "/api/messages", testKey.PlainText)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	usage, err := apiKeyRepo.GetUsage(context.Background(), testKey.Entity.ID, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, usage.Requests)
+}
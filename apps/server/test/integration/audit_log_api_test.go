@@ -0,0 +1,206 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/application/usecase"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/config"
+	"whatspire/internal/infrastructure/persistence"
+	httpHandler "whatspire/internal/presentation/http"
+	"whatspire/test/helpers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupAuditLogTestRouter creates a router with API key auth whose audit events are persisted to a real database
+func setupAuditLogTestRouter(t *testing.T) (*gin.Engine, *helpers.MockAPIKeyRepository, *persistence.AuditLogRepository) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // Keep a single in-memory database
+	require.NoError(t, persistence.RunAutoMigration(db, helpers.CreateTestLogger()))
+
+	auditLogRepo := persistence.NewAuditLogRepository(db)
+	auditLogger := persistence.NewPersistentAuditLogger(auditLogRepo, nil, helpers.CreateTestLogger())
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+
+	handler := helpers.NewTestHandlerBuilder().
+		WithAuditLogUseCase(usecase.NewAuditLogUseCase(auditLogRepo)).
+		Build()
+
+	routerConfig := httpHandler.DefaultRouterConfig()
+	routerConfig.APIKeyConfig = &config.APIKeyConfig{Enabled: true, Header: "X-API-Key"}
+	routerConfig.APIKeyRepository = apiKeyRepo
+	routerConfig.AuditLogger = auditLogger
+
+	return helpers.CreateTestRouter(handler, routerConfig), apiKeyRepo, auditLogRepo
+}
+
+func doAuditLogRequest(router *gin.Engine, path, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuditLogAPI_List(t *testing.T) {
+	router, apiKeyRepo, _ := setupAuditLogTestRouter(t)
+	adminKey := helpers.CreateTestAPIKey(t, apiKeyRepo, "admin", nil)
+
+	// Produce an authentication failure and a few usage events
+	assert.Equal(t, http.StatusUnauthorized, doAuditLogRequest(router, "/api/audit-logs", "invalid-key").Code)
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusOK, doAuditLogRequest(router, "/api/audit-logs", adminKey.PlainText).Code)
+	}
+
+	t.Run("filters by event kind and API key", func(t *testing.T) {
+		w := doAuditLogRequest(router, "/api/audit-logs?event_type=api_key_usage&api_key_id="+adminKey.Entity.ID, adminKey.PlainText)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.APIResponse[dto.ListAuditLogsResponse]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		// The request itself is recorded before the handler runs
+		require.Len(t, response.Data.AuditLogs, 4)
+		for _, auditLog := range response.Data.AuditLogs {
+			assert.Equal(t, "api_key_usage", auditLog.EventType)
+			assert.Equal(t, adminKey.Entity.ID, auditLog.APIKeyID)
+			assert.Equal(t, "/api/audit-logs", auditLog.Endpoint)
+		}
+	})
+
+	t.Run("paginates with a cursor", func(t *testing.T) {
+		w := doAuditLogRequest(router, "/api/audit-logs?limit=2", adminKey.PlainText)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var first dto.APIResponse[dto.ListAuditLogsResponse]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+		require.Len(t, first.Data.AuditLogs, 2)
+		require.NotEmpty(t, first.Data.NextCursor)
+
+		w = doAuditLogRequest(router, "/api/audit-logs?limit=100&cursor="+first.Data.NextCursor, adminKey.PlainText)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var second dto.APIResponse[dto.ListAuditLogsResponse]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+		assert.Empty(t, second.Data.NextCursor)
+		require.NotEmpty(t, second.Data.AuditLogs)
+		assert.Equal(t, "auth_failure", second.Data.AuditLogs[len(second.Data.AuditLogs)-1].EventType)
+		for _, auditLog := range second.Data.AuditLogs {
+			assert.NotEqual(t, first.Data.AuditLogs[0].ID, auditLog.ID)
+			assert.NotEqual(t, first.Data.AuditLogs[1].ID, auditLog.ID)
+		}
+	})
+
+	t.Run("rejects invalid filters", func(t *testing.T) {
+		w := doAuditLogRequest(router, "/api/audit-logs?event_type=unknown", adminKey.PlainText)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = doAuditLogRequest(router, "/api/audit-logs?since=yesterday", adminKey.PlainText)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAuditLogAPI_AdminOnly(t *testing.T) {
+	router, apiKeyRepo, _ := setupAuditLogTestRouter(t)
+
+	for _, role := range []string{"read", "write"} {
+		key := helpers.CreateTestAPIKey(t, apiKeyRepo, role, nil)
+
+		assert.Equal(t, http.StatusForbidden, doAuditLogRequest(router, "/api/audit-logs", key.PlainText).Code, role)
+		assert.Equal(t, http.StatusForbidden, doAuditLogRequest(router, "/api/audit-logs/export", key.PlainText).Code, role)
+	}
+}
+
+func TestAuditLogAPI_Export(t *testing.T) {
+	router, apiKeyRepo, _ := setupAuditLogTestRouter(t)
+	adminKey := helpers.CreateTestAPIKey(t, apiKeyRepo, "admin", nil)
+
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, doAuditLogRequest(router, "/api/audit-logs", adminKey.PlainText).Code)
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		w := doAuditLogRequest(router, "/api/audit-logs/export", adminKey.PlainText)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+		assert.Contains(t, w.Header().Get("Content-Disposition"), ".csv")
+
+		records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4) // header + 3 usage events
+		assert.Equal(t, "id", records[0][0])
+		assert.Equal(t, "api_key_usage", records[1][1])
+		assert.Equal(t, adminKey.Entity.ID, records[1][3])
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		w := doAuditLogRequest(router, "/api/audit-logs/export?format=ndjson&event_type=api_key_usage", adminKey.PlainText)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+		var lines int
+		scanner := bufio.NewScanner(strings.NewReader(w.Body.String()))
+		for scanner.Scan() {
+			var auditLog dto.AuditLogDTO
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &auditLog))
+			assert.Equal(t, "api_key_usage", auditLog.EventType)
+			lines++
+		}
+		assert.Equal(t, 4, lines)
+	})
+
+	t.Run("empty export still has a csv header", func(t *testing.T) {
+		w := doAuditLogRequest(router, "/api/audit-logs/export?session_id=none", adminKey.PlainText)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "id,event_type,created_at,api_key_id,session_id,endpoint,action,ip_address,details\n", w.Body.String())
+	})
+
+	t.Run("csv cells are not evaluated as formulas", func(t *testing.T) {
+		router, apiKeyRepo, auditLogRepo := setupAuditLogTestRouter(t)
+		adminKey := helpers.CreateTestAPIKey(t, apiKeyRepo, "admin", nil)
+		require.NoError(t, auditLogRepo.SaveAPIKeyUsage(context.Background(), repository.APIKeyUsageEvent{
+			APIKeyID:  "=HYPERLINK(\"https://example.com\")",
+			Endpoint:  "@SUM(A1:A2)",
+			Method:    "GET",
+			IPAddress: "-1+1",
+			Timestamp: time.Now().Add(-time.Hour),
+		}))
+
+		w := doAuditLogRequest(router, "/api/audit-logs/export?event_type=api_key_usage", adminKey.PlainText)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+		require.NoError(t, err)
+		seeded := records[len(records)-1] // Oldest entry comes last
+		assert.Equal(t, "'=HYPERLINK(\"https://example.com\")", seeded[3])
+		assert.Equal(t, "'@SUM(A1:A2)", seeded[5])
+		assert.Equal(t, "'-1+1", seeded[7])
+		assert.Equal(t, "api_key_usage", seeded[1])
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		w := doAuditLogRequest(router, "/api/audit-logs/export?format=xml", adminKey.PlainText)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	})
+}
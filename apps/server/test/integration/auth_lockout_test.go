@@ -0,0 +1,149 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/application/usecase"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/config"
+	"whatspire/internal/infrastructure/persistence"
+	httpHandler "whatspire/internal/presentation/http"
+	"whatspire/test/helpers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupAuthLockoutTestRouter creates a router that locks clients out after three failed authentications
+func setupAuthLockoutTestRouter(t *testing.T) (*gin.Engine, *helpers.MockAPIKeyRepository, *EventPublisherMock) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // Keep a single in-memory database
+	require.NoError(t, persistence.RunAutoMigration(db, helpers.CreateTestLogger()))
+
+	auditLogRepo := persistence.NewAuditLogRepository(db)
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	publisher := NewEventPublisherMock()
+
+	handler := helpers.NewTestHandlerBuilder().
+		WithAuditLogUseCase(usecase.NewAuditLogUseCase(auditLogRepo)).
+		Build()
+
+	routerConfig := httpHandler.DefaultRouterConfig()
+	routerConfig.APIKeyConfig = &config.APIKeyConfig{
+		Enabled:          true,
+		Header:           "X-API-Key",
+		LockoutThreshold: 3,
+		LockoutWindow:    time.Minute,
+		LockoutDuration:  time.Minute,
+	}
+	routerConfig.APIKeyRepository = apiKeyRepo
+	routerConfig.AuditLogger = persistence.NewPersistentAuditLogger(auditLogRepo, nil, helpers.CreateTestLogger())
+	routerConfig.EventPublisher = publisher
+
+	return helpers.CreateTestRouter(handler, routerConfig), apiKeyRepo, publisher
+}
+
+func TestAuthFailure_AuditRecordsOnlyMaskedKey(t *testing.T) {
+	router, apiKeyRepo, _ := setupAuthLockoutTestRouter(t)
+	adminKey := helpers.CreateTestAPIKey(t, apiKeyRepo, "admin", nil)
+
+	invalidKey := "invalidkey-0123456789-secret"
+	require.Equal(t, http.StatusUnauthorized, doAuditLogRequest(router, "/api/audit-logs", invalidKey).Code)
+
+	w := doAuditLogRequest(router, "/api/audit-logs?event_type=auth_failure", adminKey.PlainText)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), invalidKey)
+
+	var response dto.APIResponse[dto.ListAuditLogsResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data.AuditLogs, 1)
+
+	var details map[string]string
+	require.NoError(t, json.Unmarshal(response.Data.AuditLogs[0].Details, &details))
+	assert.Equal(t, usecase.MaskAPIKey(invalidKey), details["masked_key"])
+	assert.Equal(t, usecase.HashAPIKey(invalidKey), details["key_hash"])
+	assert.Equal(t, "invalid_api_key", details["reason"])
+}
+
+func TestAuthLockout_RepeatedFailures(t *testing.T) {
+	router, apiKeyRepo, publisher := setupAuthLockoutTestRouter(t)
+	adminKey := helpers.CreateTestAPIKey(t, apiKeyRepo, "admin", nil)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusUnauthorized, doAuditLogRequest(router, "/api/audit-logs", "invalid-key").Code)
+	}
+
+	// Even a valid key is rejected while the client is locked out
+	w := doAuditLogRequest(router, "/api/audit-logs", adminKey.PlainText)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	var response dto.APIResponse[any]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Error)
+	assert.Equal(t, "AUTH_LOCKED_OUT", response.Error.Code)
+
+	require.Eventually(t, func() bool {
+		return len(publisher.GetEvents()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	event := publisher.GetEvents()[0]
+	assert.Equal(t, entity.EventTypeSecurityAuthLockout, event.Type)
+
+	var data entity.AuthLockoutData
+	require.NoError(t, json.Unmarshal(event.Data, &data))
+	assert.Equal(t, 3, data.Failures)
+	assert.Equal(t, "/api/audit-logs", data.Endpoint)
+	assert.NotEmpty(t, data.IPAddress)
+}
+
+func TestAuthLockout_SuccessResetsFailures(t *testing.T) {
+	router, apiKeyRepo, publisher := setupAuthLockoutTestRouter(t)
+	adminKey := helpers.CreateTestAPIKey(t, apiKeyRepo, "admin", nil)
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusUnauthorized, doAuditLogRequest(router, "/api/audit-logs", "invalid-key").Code)
+	}
+	require.Equal(t, http.StatusOK, doAuditLogRequest(router, "/api/audit-logs", adminKey.PlainText).Code)
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusUnauthorized, doAuditLogRequest(router, "/api/audit-logs", "invalid-key").Code)
+	}
+	assert.Equal(t, http.StatusOK, doAuditLogRequest(router, "/api/audit-logs", adminKey.PlainText).Code)
+	assert.Empty(t, publisher.GetEvents())
+}
+
+func TestAuthLockout_MissingKeyDoesNotCount(t *testing.T) {
+	router, _, _ := setupAuthLockoutTestRouter(t)
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusUnauthorized, doAuditLogRequest(router, "/api/audit-logs", "").Code)
+	}
+}
+
+func TestAuthLockout_SharedByAPIAndMediaRoutes(t *testing.T) {
+	router, apiKeyRepo, _ := setupAuthLockoutTestRouter(t)
+	adminKey := helpers.CreateTestAPIKey(t, apiKeyRepo, "admin", nil)
+
+	// Failures on either route group count towards the same threshold
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusUnauthorized, doAuditLogRequest(router, "/api/audit-logs", "invalid-key").Code)
+	}
+	assert.Equal(t, http.StatusUnauthorized, doAuditLogRequest(router, "/api/media/session-1/photo.jpg", "invalid-key").Code)
+
+	// A client locked out of the API cannot keep guessing keys on media downloads
+	assert.Equal(t, http.StatusTooManyRequests, doAuditLogRequest(router, "/api/media/session-1/photo.jpg", adminKey.PlainText).Code)
+	assert.Equal(t, http.StatusTooManyRequests, doAuditLogRequest(router, "/api/audit-logs", adminKey.PlainText).Code)
+}
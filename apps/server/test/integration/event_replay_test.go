@@ -64,7 +64,7 @@ func setupEventReplayTest(t *testing.T) (*gorm.DB, repository.EventRepository, *
 	require.NoError(t, err)
 
 	// Run migrations
-	err = db.AutoMigrate(&models.Event{})
+	err = db.AutoMigrate(&models.Event{}, &models.EventSequence{})
 	require.NoError(t, err)
 
 	// Create repositories
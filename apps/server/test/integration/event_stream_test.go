@@ -0,0 +1,249 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/config"
+	"whatspire/internal/infrastructure/persistence"
+	infraWs "whatspire/internal/infrastructure/websocket"
+	httpHandler "whatspire/internal/presentation/http"
+	"whatspire/test/helpers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// eventStreamTestEnv holds a running server streaming events from a hub backed by a real event store
+type eventStreamTestEnv struct {
+	server     *httptest.Server
+	hub        *infraWs.EventHub
+	eventRepo  *persistence.EventRepository
+	apiKeyRepo *helpers.MockAPIKeyRepository
+}
+
+func setupEventStreamTestServer(t *testing.T) *eventStreamTestEnv {
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // Keep a single in-memory database
+	require.NoError(t, persistence.RunAutoMigration(db, helpers.CreateTestLogger()))
+
+	eventRepo := persistence.NewEventRepository(db)
+	hub := infraWs.NewEventHub(infraWs.EventHubConfig{
+		EventRepository: eventRepo,
+		PingInterval:    30 * time.Second,
+		WriteTimeout:    10 * time.Second,
+		AuthTimeout:     5 * time.Second,
+	})
+	go hub.Run()
+	t.Cleanup(hub.Stop)
+
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	handler := helpers.NewTestHandlerBuilder().
+		WithEventHub(hub).
+		WithEventStreamHeartbeat(100 * time.Millisecond).
+		Build()
+
+	routerConfig := httpHandler.DefaultRouterConfig()
+	routerConfig.APIKeyConfig = &config.APIKeyConfig{Enabled: true, Header: "X-API-Key"}
+	routerConfig.APIKeyRepository = apiKeyRepo
+
+	server := httptest.NewServer(helpers.CreateTestRouter(handler, routerConfig))
+	t.Cleanup(server.Close)
+
+	return &eventStreamTestEnv{
+		server:     server,
+		hub:        hub,
+		eventRepo:  eventRepo,
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// newEvent numbers and stores an event like the WhatsApp client wiring does
+func (env *eventStreamTestEnv) newEvent(t *testing.T, eventType entity.EventType, sessionID string) *entity.Event {
+	event := entity.NewEvent(fmt.Sprintf("%s-%d", sessionID, time.Now().UnixNano()), eventType, sessionID, json.RawMessage(`{}`))
+	require.NoError(t, env.eventRepo.Create(context.Background(), event))
+	return event
+}
+
+func (env *eventStreamTestEnv) open(t *testing.T, query, apiKey, lastEventID string) *http.Response {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, env.server.URL+"/api/events/stream"+query, nil)
+	require.NoError(t, err)
+	req.Header.Set("X-API-Key", apiKey)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+// sseMessage is a single message read from an event stream
+type sseMessage struct {
+	ID      string
+	Data    string
+	Comment string
+}
+
+// readSSEMessages reads messages from the stream in the background
+func readSSEMessages(resp *http.Response) <-chan sseMessage {
+	messages := make(chan sseMessage, 64)
+	go func() {
+		defer close(messages)
+
+		var msg sseMessage
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				messages <- msg
+				msg = sseMessage{}
+			case strings.HasPrefix(line, ":"):
+				msg.Comment = strings.TrimSpace(strings.TrimPrefix(line, ":"))
+			case strings.HasPrefix(line, "id: "):
+				msg.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				msg.Data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	return messages
+}
+
+// nextSSEEvent returns the next event message, skipping heartbeats
+func nextSSEEvent(t *testing.T, messages <-chan sseMessage) (sseMessage, *entity.Event) {
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case msg, ok := <-messages:
+			require.True(t, ok, "stream closed")
+			if msg.Data == "" {
+				continue
+			}
+
+			var event entity.Event
+			require.NoError(t, json.Unmarshal([]byte(msg.Data), &event))
+			return msg, &event
+		case <-deadline:
+			t.Fatal("timed out waiting for an event")
+			return sseMessage{}, nil
+		}
+	}
+}
+
+func waitForStream(t *testing.T, hub *infraWs.EventHub) {
+	require.Eventually(t, func() bool { return hub.StreamCount() == 1 }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestEventStream_LiveEventsWithFilters(t *testing.T) {
+	env := setupEventStreamTestServer(t)
+	key := helpers.CreateTestAPIKey(t, env.apiKeyRepo, "read", nil)
+
+	resp := env.open(t, "?session_id=session-a&event_type=message.*", key.PlainText, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	messages := readSSEMessages(resp)
+	waitForStream(t, env.hub)
+
+	env.hub.Broadcast(env.newEvent(t, entity.EventTypePresenceUpdate, "session-a"))
+	env.hub.Broadcast(env.newEvent(t, entity.EventTypeMessageReceived, "session-b"))
+	env.hub.Broadcast(env.newEvent(t, entity.EventTypeMessageReceived, "session-a"))
+
+	msg, event := nextSSEEvent(t, messages)
+	assert.Equal(t, entity.EventTypeMessageReceived, event.Type)
+	assert.Equal(t, "session-a", event.SessionID)
+	assert.Equal(t, int64(2), event.Sequence)
+	assert.Equal(t, "session-a:2", msg.ID)
+}
+
+func TestEventStream_ResumeWithLastEventID(t *testing.T) {
+	env := setupEventStreamTestServer(t)
+	key := helpers.CreateTestAPIKey(t, env.apiKeyRepo, "read", nil)
+
+	for i := 0; i < 3; i++ {
+		env.newEvent(t, entity.EventTypeMessageReceived, "session-a")
+	}
+
+	resp := env.open(t, "", key.PlainText, "session-a:1")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	messages := readSSEMessages(resp)
+
+	msg, event := nextSSEEvent(t, messages)
+	assert.Equal(t, int64(2), event.Sequence)
+	assert.Equal(t, "session-a:2", msg.ID)
+
+	msg, event = nextSSEEvent(t, messages)
+	assert.Equal(t, int64(3), event.Sequence)
+	assert.Equal(t, "session-a:3", msg.ID)
+
+	// Live events follow the replay
+	waitForStream(t, env.hub)
+	env.hub.Broadcast(env.newEvent(t, entity.EventTypeMessageSent, "session-a"))
+
+	_, event = nextSSEEvent(t, messages)
+	assert.Equal(t, int64(4), event.Sequence)
+}
+
+func TestEventStream_Heartbeat(t *testing.T) {
+	env := setupEventStreamTestServer(t)
+	key := helpers.CreateTestAPIKey(t, env.apiKeyRepo, "read", nil)
+
+	resp := env.open(t, "", key.PlainText, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	messages := readSSEMessages(resp)
+
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "heartbeat", msg.Comment)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no heartbeat received")
+	}
+}
+
+func TestEventStream_RequestErrors(t *testing.T) {
+	env := setupEventStreamTestServer(t)
+	readKey := helpers.CreateTestAPIKey(t, env.apiKeyRepo, "read", nil)
+	scopedKey := helpers.CreateScopedTestAPIKey(t, env.apiKeyRepo, "read", []string{"session-a"}, nil)
+
+	tests := []struct {
+		name        string
+		query       string
+		apiKey      string
+		lastEventID string
+		status      int
+	}{
+		{"missing API key", "", "", "", http.StatusUnauthorized},
+		{"unknown event type", "?event_type=unknown.*", readKey.PlainText, "", http.StatusBadRequest},
+		{"malformed Last-Event-ID", "", readKey.PlainText, "bogus", http.StatusBadRequest},
+		{"scoped key without session", "", scopedKey.PlainText, "", http.StatusForbidden},
+		{"scoped key with other session", "?session_id=session-b", scopedKey.PlainText, "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := env.open(t, tt.query, tt.apiKey, tt.lastEventID)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
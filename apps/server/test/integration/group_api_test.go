@@ -0,0 +1,247 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/application/usecase"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/persistence"
+	"whatspire/test/helpers"
+	"whatspire/test/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ==================== Test Setup ====================
+
+const testGroupJID = "120363000000000001@g.us"
+
+func setupGroupTestRouter() (*gin.Engine, *mocks.GroupManagerMock) {
+	manager := mocks.NewGroupManagerMock()
+	manager.AddGroup(entity.NewGroup("group-1", testGroupJID, "Customers", "session-1"))
+
+	handler := helpers.NewTestHandlerBuilder().
+		WithGroupsUseCase(usecase.NewGroupsUseCase(nil, manager, nil)).
+		Build()
+	return helpers.CreateTestRouterWithDefaults(handler), manager
+}
+
+func setupGroupListTestRouter(t *testing.T) (*gin.Engine, *persistence.GroupRepository) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.RunAutoMigration(db, helpers.CreateTestLogger()))
+
+	repo := persistence.NewGroupRepository(db)
+	handler := helpers.NewTestHandlerBuilder().
+		WithGroupsUseCase(usecase.NewGroupsUseCase(nil, mocks.NewGroupManagerMock(), repo)).
+		Build()
+	return helpers.CreateTestRouterWithDefaults(handler), repo
+}
+
+func doGroupRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// ==================== Group Administration Tests ====================
+
+func TestCreateGroup_Success(t *testing.T) {
+	router, manager := setupGroupTestRouter()
+
+	w := doGroupRequest(router, http.MethodPost, "/api/sessions/session-1/groups",
+		`{"name":"Support","participants":["+1111111111","2222222222@s.whatsapp.net"]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var response dto.APIResponse[entity.Group]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Data)
+	assert.Equal(t, "Support", response.Data.Name)
+	assert.Equal(t, 2, response.Data.MemberCount)
+	assert.Contains(t, manager.Groups, response.Data.JID)
+}
+
+func TestCreateGroup_NameTooLong(t *testing.T) {
+	router, _ := setupGroupTestRouter()
+
+	w := doGroupRequest(router, http.MethodPost, "/api/sessions/session-1/groups",
+		`{"name":"`+strings.Repeat("x", 26)+`","participants":["+1111111111"]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_FAILED")
+}
+
+func TestGetGroup_NotFound(t *testing.T) {
+	router, _ := setupGroupTestRouter()
+
+	w := doGroupRequest(router, http.MethodGet, "/api/sessions/session-1/groups/120363999999999999@g.us", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "GROUP_NOT_FOUND")
+}
+
+func TestUpdateGroup_Settings(t *testing.T) {
+	router, _ := setupGroupTestRouter()
+
+	w := doGroupRequest(router, http.MethodPatch, "/api/sessions/session-1/groups/"+testGroupJID,
+		`{"name":"VIP","is_announce":true,"is_locked":true,"ephemeral_seconds":86400}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response dto.APIResponse[entity.Group]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Data)
+	assert.Equal(t, "VIP", response.Data.Name)
+	assert.True(t, response.Data.IsAnnounce)
+	assert.True(t, response.Data.IsLocked)
+	assert.True(t, response.Data.IsEphemeral)
+}
+
+func TestUpdateGroup_InvalidEphemeralTimer(t *testing.T) {
+	router, _ := setupGroupTestRouter()
+
+	w := doGroupRequest(router, http.MethodPatch, "/api/sessions/session-1/groups/"+testGroupJID, `{"ephemeral_seconds":60}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_FAILED")
+}
+
+func TestSetGroupPicture_Success(t *testing.T) {
+	router, manager := setupGroupTestRouter()
+
+	w := doGroupRequest(router, http.MethodPut, "/api/sessions/session-1/groups/"+testGroupJID+"/picture",
+		`{"image_url":"https://example.com/logo.jpg"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "picture_id")
+	assert.Equal(t, "https://example.com/logo.jpg", manager.PictureURLs[testGroupJID])
+}
+
+func TestUpdateGroupParticipants_PartialFailure(t *testing.T) {
+	router, manager := setupGroupTestRouter()
+	manager.ParticipantErrorFn = func(jid string, action entity.ParticipantAction) int {
+		if jid == "+2222222222" {
+			return 409
+		}
+		return 0
+	}
+
+	w := doGroupRequest(router, http.MethodPost, "/api/sessions/session-1/groups/"+testGroupJID+"/participants",
+		`{"action":"promote","participants":["+1111111111","+2222222222"]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response dto.APIResponse[struct {
+		Action       string                           `json:"action"`
+		Participants []entity.ParticipantChangeResult `json:"participants"`
+	}]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Data)
+	assert.Equal(t, "promote", response.Data.Action)
+	require.Len(t, response.Data.Participants, 2)
+	assert.True(t, response.Data.Participants[0].Success)
+	assert.Equal(t, 409, response.Data.Participants[1].ErrorCode)
+}
+
+func TestUpdateGroupParticipants_InvalidAction(t *testing.T) {
+	router, _ := setupGroupTestRouter()
+
+	w := doGroupRequest(router, http.MethodPost, "/api/sessions/session-1/groups/"+testGroupJID+"/participants",
+		`{"action":"ban","participants":["+1111111111"]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGroupInviteLink_RevokeAndJoin(t *testing.T) {
+	router, _ := setupGroupTestRouter()
+
+	type inviteLinkData struct {
+		InviteLink string `json:"invite_link"`
+		GroupJID   string `json:"group_jid"`
+	}
+	readLink := func(w *httptest.ResponseRecorder) string {
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response dto.APIResponse[inviteLinkData]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.Data)
+		return response.Data.InviteLink
+	}
+
+	link := readLink(doGroupRequest(router, http.MethodGet, "/api/sessions/session-1/groups/"+testGroupJID+"/invite-link", ""))
+	assert.True(t, strings.HasPrefix(link, "https://chat.whatsapp.com/"))
+
+	newLink := readLink(doGroupRequest(router, http.MethodDelete, "/api/sessions/session-1/groups/"+testGroupJID+"/invite-link", ""))
+	assert.NotEqual(t, link, newLink)
+
+	// The revoked link can no longer be used
+	w := doGroupRequest(router, http.MethodPost, "/api/sessions/session-2/groups/join", `{"invite_link":"`+link+`"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_INVITE_LINK")
+
+	w = doGroupRequest(router, http.MethodPost, "/api/sessions/session-2/groups/join", `{"invite_link":"`+newLink+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), testGroupJID)
+}
+
+func TestLeaveGroup_Success(t *testing.T) {
+	router, manager := setupGroupTestRouter()
+
+	w := doGroupRequest(router, http.MethodPost, "/api/sessions/session-1/groups/"+testGroupJID+"/leave", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, manager.Groups, testGroupJID)
+}
+
+// ==================== GET /api/sessions/:id/groups Tests ====================
+
+func TestListGroups_FromLocalStore(t *testing.T) {
+	router, repo := setupGroupListTestRouter(t)
+
+	// Groups created through the API are stored locally
+	w := doGroupRequest(router, http.MethodPost, "/api/sessions/session-1/groups", `{"name":"Support","participants":["+1111111111"]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	muted := entity.NewGroup("", "120363000000000009@g.us", "Muted Sales", "session-1")
+	muted.IsMuted = true
+	require.NoError(t, repo.Upsert(context.Background(), muted))
+
+	list := func(query string) dto.ListGroupsResponse {
+		w := doGroupRequest(router, http.MethodGet, "/api/sessions/session-1/groups"+query, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response dto.APIResponse[dto.ListGroupsResponse]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	all := list("")
+	assert.Equal(t, int64(2), all.Pagination.Total)
+	require.Len(t, all.Groups, 2)
+	assert.Equal(t, "Muted Sales", all.Groups[0].Name)
+
+	filtered := list("?muted=false&name=sup")
+	require.Len(t, filtered.Groups, 1)
+	assert.Equal(t, "Support", filtered.Groups[0].Name)
+
+	paged := list("?page=2&limit=1")
+	require.Len(t, paged.Groups, 1)
+	assert.Equal(t, "Support", paged.Groups[0].Name)
+	assert.Equal(t, 2, paged.Pagination.TotalPages)
+
+	assert.Empty(t, list("?admin_only=true").Groups)
+}
+
+func TestListGroups_InvalidQuery(t *testing.T) {
+	router, _ := setupGroupListTestRouter(t)
+
+	w := doGroupRequest(router, http.MethodGet, "/api/sessions/session-1/groups?archived=maybe", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_QUERY")
+
+	w = doGroupRequest(router, http.MethodGet, "/api/sessions/session-1/groups?limit=500", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_FAILED")
+}
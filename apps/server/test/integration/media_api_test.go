@@ -0,0 +1,269 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"whatspire/internal/application/usecase"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/config"
+	"whatspire/internal/infrastructure/jobs"
+	"whatspire/internal/infrastructure/storage"
+	httpHandler "whatspire/internal/presentation/http"
+	"whatspire/test/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mediaTestEnv holds a running server serving media from local storage
+type mediaTestEnv struct {
+	server     *httptest.Server
+	basePath   string
+	storage    *storage.LocalMediaStorage
+	apiKeyRepo *helpers.MockAPIKeyRepository
+}
+
+func setupMediaTestServer(t *testing.T) *mediaTestEnv {
+	server := httptest.NewUnstartedServer(nil)
+	baseURL := "http://" + server.Listener.Addr().String() + "/api/media"
+
+	basePath := t.TempDir()
+	mediaStorage, err := storage.NewLocalMediaStorage(repository.MediaStorageConfig{
+		BasePath:    basePath,
+		BaseURL:     baseURL,
+		MaxFileSize: 1024 * 1024,
+	})
+	require.NoError(t, err)
+	signer := storage.NewHMACMediaURLSigner([]byte("test-signing-key"), time.Hour)
+	mediaStorage.SetURLSigner(signer)
+
+	cleanupJob := jobs.NewMediaCleanupJob(mediaStorage, nil, nil, &config.MediaCleanupConfig{RetentionDays: 30}, nil, helpers.CreateTestLogger())
+
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	handler := helpers.NewTestHandlerBuilder().
+		WithMediaUseCase(usecase.NewMediaUseCase(mediaStorage, signer, cleanupJob)).
+		Build()
+
+	routerConfig := httpHandler.DefaultRouterConfig()
+	routerConfig.APIKeyConfig = &config.APIKeyConfig{Enabled: true, Header: "X-API-Key"}
+	routerConfig.APIKeyRepository = apiKeyRepo
+
+	server.Config.Handler = helpers.CreateTestRouter(handler, routerConfig)
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return &mediaTestEnv{server: server, basePath: basePath, storage: mediaStorage, apiKeyRepo: apiKeyRepo}
+}
+
+// store saves media the way incoming WhatsApp messages do and returns its path and signed URL
+func (env *mediaTestEnv) store(t *testing.T, sessionID, messageID string, data []byte, extension string) (string, string) {
+	filePath, mediaURL, err := env.storage.DownloadAndStore(context.Background(), sessionID, messageID, bytes.NewReader(data), "", extension)
+	require.NoError(t, err)
+	return filePath, mediaURL
+}
+
+func (env *mediaTestEnv) get(t *testing.T, target string, headers map[string]string) (*http.Response, []byte) {
+	if !strings.HasPrefix(target, "http") {
+		target = env.server.URL + target
+	}
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	require.NoError(t, err)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestMediaAPI_SignedLinks(t *testing.T) {
+	env := setupMediaTestServer(t)
+	data := []byte("\x89PNG\r\n\x1a\nimage data")
+	filePath, mediaURL := env.store(t, "session-1", "msg-1", data, "png")
+
+	t.Run("signed link serves the file without an API key", func(t *testing.T) {
+		resp, body := env.get(t, mediaURL, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, data, body)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+		assert.NotEmpty(t, resp.Header.Get("ETag"))
+		assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+	})
+
+	t.Run("signed link is bound to its file", func(t *testing.T) {
+		env.store(t, "session-1", "msg-2", []byte("other"), "png")
+		otherURL := strings.Replace(mediaURL, "msg-1.png", "msg-2.png", 1)
+
+		resp, body := env.get(t, otherURL, nil)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Contains(t, string(body), "INVALID_MEDIA_TOKEN")
+	})
+
+	t.Run("expired link is rejected", func(t *testing.T) {
+		expired, err := url.Parse(mediaURL)
+		require.NoError(t, err)
+		query := expired.Query()
+		query.Set("expires", "1700000000")
+		expired.RawQuery = query.Encode()
+
+		resp, _ := env.get(t, expired.String(), nil)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("unsigned link requires an API key", func(t *testing.T) {
+		resp, body := env.get(t, "/api/media/"+filePath, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Contains(t, string(body), "MISSING_API_KEY")
+	})
+}
+
+func TestMediaAPI_APIKeyAccess(t *testing.T) {
+	env := setupMediaTestServer(t)
+	filePath, _ := env.store(t, "session-1", "msg-1", []byte("voice note"), "ogg")
+
+	readKey := helpers.CreateTestAPIKey(t, env.apiKeyRepo, "read", nil)
+	otherSessionKey := helpers.CreateScopedTestAPIKey(t, env.apiKeyRepo, "read", []string{"session-2"}, nil)
+	noMessagesScopeKey := helpers.CreateScopedTestAPIKey(t, env.apiKeyRepo, "read", nil, []string{entity.ScopeEventsRead})
+
+	t.Run("API key serves the file", func(t *testing.T) {
+		resp, body := env.get(t, "/api/media/"+filePath, map[string]string{"X-API-Key": readKey.PlainText})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "voice note", string(body))
+	})
+
+	t.Run("session-scoped keys only reach their sessions' media", func(t *testing.T) {
+		resp, _ := env.get(t, "/api/media/"+filePath, map[string]string{"X-API-Key": otherSessionKey.PlainText})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("keys need the messages:read scope", func(t *testing.T) {
+		resp, _ := env.get(t, "/api/media/"+filePath, map[string]string{"X-API-Key": noMessagesScopeKey.PlainText})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("dot segments cannot leave the key's sessions", func(t *testing.T) {
+		for _, target := range []string{
+			"/api/media/session-2/../" + filePath,
+			"/api/media/session-2/%2e%2e/" + filePath,
+			"/api/media/session-2/..%2F" + filePath,
+		} {
+			resp, body := env.get(t, target, map[string]string{"X-API-Key": otherSessionKey.PlainText})
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode, target)
+			assert.NotContains(t, string(body), "voice note")
+		}
+	})
+
+	t.Run("missing media is not found", func(t *testing.T) {
+		resp, _ := env.get(t, "/api/media/session-1/missing.ogg", map[string]string{"X-API-Key": readKey.PlainText})
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, _ = env.get(t, "/api/media/session-1/..%2F..%2Fetc%2Fpasswd", map[string]string{"X-API-Key": readKey.PlainText})
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestMediaAPI_RangeAndETag(t *testing.T) {
+	env := setupMediaTestServer(t)
+	_, mediaURL := env.store(t, "session-1", "video-1", []byte("0123456789abcdef"), "mp4")
+
+	resp, _ := env.get(t, mediaURL, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	t.Run("Range returns partial content", func(t *testing.T) {
+		resp, body := env.get(t, mediaURL, map[string]string{"Range": "bytes=4-7"})
+		require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+		assert.Equal(t, "4567", string(body))
+		assert.Equal(t, "bytes 4-7/16", resp.Header.Get("Content-Range"))
+	})
+
+	t.Run("unsatisfiable Range is rejected", func(t *testing.T) {
+		resp, _ := env.get(t, mediaURL, map[string]string{"Range": "bytes=100-"})
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+	})
+
+	t.Run("matching If-None-Match is not modified", func(t *testing.T) {
+		resp, body := env.get(t, mediaURL, map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+		assert.Empty(t, body)
+	})
+
+	t.Run("stale If-Range returns the whole file", func(t *testing.T) {
+		resp, body := env.get(t, mediaURL, map[string]string{"Range": "bytes=0-3", "If-Range": `"stale"`})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "0123456789abcdef", string(body))
+	})
+}
+
+func TestMediaAPI_CleanupDryRun(t *testing.T) {
+	env := setupMediaTestServer(t)
+	expired, _ := env.store(t, "session-1", "msg-1", []byte("old photo"), "jpg")
+	env.store(t, "session-1", "msg-2", []byte("new photo"), "jpg")
+
+	storedAt := time.Now().AddDate(0, 0, -40)
+	require.NoError(t, os.Chtimes(filepath.Join(env.basePath, expired), storedAt, storedAt))
+
+	adminKey := helpers.CreateTestAPIKey(t, env.apiKeyRepo, "admin", nil)
+	readKey := helpers.CreateTestAPIKey(t, env.apiKeyRepo, "read", nil)
+	scopedAdminKey := helpers.CreateScopedTestAPIKey(t, env.apiKeyRepo, "admin", []string{"session-1"}, nil)
+
+	t.Run("admin sees what would be deleted", func(t *testing.T) {
+		resp, body := env.get(t, "/api/media-cleanup/dry-run", map[string]string{"X-API-Key": adminKey.PlainText})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response struct {
+			Data struct {
+				DryRun       bool  `json:"dry_run"`
+				ScannedFiles int   `json:"scanned_files"`
+				DeleteFiles  int   `json:"delete_files"`
+				DeleteBytes  int64 `json:"delete_bytes"`
+				Candidates   []struct {
+					Path   string `json:"path"`
+					Reason string `json:"reason"`
+				} `json:"candidates"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(body, &response))
+
+		assert.True(t, response.Data.DryRun)
+		assert.Equal(t, 2, response.Data.ScannedFiles)
+		assert.Equal(t, 1, response.Data.DeleteFiles)
+		assert.Equal(t, int64(len("old photo")), response.Data.DeleteBytes)
+		require.Len(t, response.Data.Candidates, 1)
+		assert.Equal(t, expired, response.Data.Candidates[0].Path)
+		assert.Equal(t, "retention", response.Data.Candidates[0].Reason)
+
+		_, err := os.Stat(filepath.Join(env.basePath, expired))
+		assert.NoError(t, err, "dry run must not delete media")
+	})
+
+	t.Run("non-admin keys are forbidden", func(t *testing.T) {
+		resp, _ := env.get(t, "/api/media-cleanup/dry-run", map[string]string{"X-API-Key": readKey.PlainText})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("session-scoped keys are forbidden", func(t *testing.T) {
+		resp, body := env.get(t, "/api/media-cleanup/dry-run", map[string]string{"X-API-Key": scopedAdminKey.PlainText})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Contains(t, string(body), "SESSION_ACCESS_DENIED")
+	})
+}
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -9,11 +10,16 @@ import (
 
 	"whatspire/internal/application/dto"
 	"whatspire/internal/application/usecase"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/persistence"
+	"whatspire/internal/infrastructure/whatsapp"
 	"whatspire/test/helpers"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
 )
 
 // ==================== Test Setup ====================
@@ -155,6 +161,24 @@ func TestSendMessage_ValidationFailed_InvalidPhoneNumber(t *testing.T) {
 	assert.False(t, response.Success)
 }
 
+func TestSendMessage_ValidationFailed_NonGroupJID(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	text := "Hello"
+	for _, to := range []string{"1234567890@s.whatsapp.net", "team@g.us"} {
+		w := postSendMessage(router, dto.SendMessageRequest{
+			SessionID: "550e8400-e29b-41d4-a716-446655440000",
+			To:        to,
+			Type:      "text",
+			Content:   dto.SendMessageContentInput{Text: &text},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, to)
+	}
+}
+
 func TestSendMessage_ValidationFailed_EmptyTextContent(t *testing.T) {
 	waClient := NewWhatsAppClientMock()
 	publisher := NewEventPublisherMock()
@@ -335,3 +359,302 @@ func TestSendMessage_DocumentWithoutURL(t *testing.T) {
 
 	assert.False(t, response.Success)
 }
+
+// ==================== Location, Contact, Poll and Sticker Tests ====================
+
+func postSendMessage(router *gin.Engine, reqBody dto.SendMessageRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/messages", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSendMessage_LocationSuccess(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "location",
+		Content: dto.SendMessageContentInput{
+			Location: &dto.LocationInput{Latitude: 30.0444, Longitude: 31.2357, Name: "Cairo Tower"},
+		},
+	})
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
+
+func TestSendMessage_LocationOutOfRange(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "location",
+		Content: dto.SendMessageContentInput{
+			Location: &dto.LocationInput{Latitude: 91, Longitude: 31.2357},
+		},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSendMessage_ContactSuccess(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "contact",
+		Content: dto.SendMessageContentInput{
+			Contacts: []dto.ContactInput{{
+				DisplayName: "Jane Doe",
+				VCard:       "BEGIN:VCARD\nVERSION:3.0\nFN:Jane Doe\nEND:VCARD",
+			}},
+		},
+	})
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
+
+func TestSendMessage_ContactInvalidVCard(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "contact",
+		Content: dto.SendMessageContentInput{
+			Contacts: []dto.ContactInput{{DisplayName: "Jane Doe", VCard: "not a vcard"}},
+		},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSendMessage_PollSuccess(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "poll",
+		Content: dto.SendMessageContentInput{
+			Poll: &dto.PollInput{Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, SelectableCount: 1},
+		},
+	})
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
+
+func TestSendMessage_PollDuplicateOptions(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "poll",
+		Content: dto.SendMessageContentInput{
+			Poll: &dto.PollInput{Question: "Lunch?", Options: []string{"Pizza", "Pizza"}},
+		},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSendMessage_PollTooFewOptions(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "poll",
+		Content: dto.SendMessageContentInput{
+			Poll: &dto.PollInput{Question: "Lunch?", Options: []string{"Pizza"}},
+		},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSendMessage_StickerSuccess(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), NewMediaUploaderMock(), nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	stickerURL := "https://example.com/sticker.webp"
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "sticker",
+		Content:   dto.SendMessageContentInput{StickerURL: &stickerURL},
+	})
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
+
+func TestSendMessage_StickerWithoutUploader(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	stickerURL := "https://example.com/sticker.webp"
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "sticker",
+		Content:   dto.SendMessageContentInput{StickerURL: &stickerURL},
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var response dto.APIResponse[interface{}]
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "MEDIA_UPLOAD_FAILED", response.Error.Code)
+}
+
+func TestSendMessage_ReplyWithMentions(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	text := "Sure @1111111111"
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "text",
+		Content: dto.SendMessageContentInput{
+			Text:     &text,
+			ReplyTo:  &dto.ReplyToInput{MessageID: "3EB0ABCDEF"},
+			Mentions: []string{"+1111111111"},
+		},
+	})
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
+
+func TestSendMessage_GroupReplyWithMentions(t *testing.T) {
+	const (
+		sessionID   = "550e8400-e29b-41d4-a716-446655440000"
+		groupJID    = "120363025246125486@g.us"
+		participant = "1111111111@s.whatsapp.net"
+	)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.RunAutoMigration(db, helpers.CreateTestLogger()))
+	messageRepo := persistence.NewMessageRepository(db)
+
+	// A message a participant posted in the group
+	quoted := entity.NewMessageBuilder("incoming-1", sessionID).
+		WithWhatsAppID("3EB0ABCDEF").
+		From(participant).
+		InChat(groupJID).
+		WithDirection(entity.MessageDirectionInbound).
+		WithContent(entity.NewTextContent("Who can take this?")).
+		WithType(entity.MessageTypeText).
+		Build()
+	require.NoError(t, messageRepo.Save(context.Background(), quoted))
+
+	var sent *entity.Message
+	waClient := NewWhatsAppClientMock()
+	waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+		sent = msg
+		return nil
+	}
+
+	messageUC := helpers.NewTestMessageUseCaseBuilder().
+		WithWhatsAppClient(waClient).
+		WithMessageRepository(messageRepo).
+		Build()
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	text := "On it @1111111111 @2222222222"
+	body, err := json.Marshal(dto.SendMessageRequest{
+		SessionID: sessionID,
+		To:        groupJID,
+		Type:      "text",
+		Content: dto.SendMessageContentInput{
+			Text:     &text,
+			ReplyTo:  &dto.ReplyToInput{MessageID: "3EB0ABCDEF"},
+			Mentions: []string{participant, "+2222222222"},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/messages?sync=true", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	require.NotNil(t, sent)
+	assert.Equal(t, groupJID, sent.To)
+	assert.Equal(t, groupJID, sent.ChatJID)
+
+	// The reply quotes the participant's message in the group itself
+	ctxInfo := whatsapp.BuildContextInfo(sent, "9999999999@s.whatsapp.net")
+	require.NotNil(t, ctxInfo)
+	assert.Equal(t, "3EB0ABCDEF", ctxInfo.GetStanzaID())
+	assert.Equal(t, participant, ctxInfo.GetParticipant())
+	assert.Empty(t, ctxInfo.GetRemoteJID())
+	assert.Equal(t, "Who can take this?", ctxInfo.GetQuotedMessage().GetConversation())
+	assert.Equal(t, []string{participant, "2222222222@s.whatsapp.net"}, ctxInfo.GetMentionedJID())
+
+	// The message is recorded in the group's history
+	stored, err := messageRepo.FindByID(context.Background(), sent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, groupJID, stored.ChatJID)
+}
+
+func TestSendMessage_ReplyWithoutMessageID(t *testing.T) {
+	messageUC := helpers.NewTestMessageUseCase(NewWhatsAppClientMock(), NewEventPublisherMock(), nil, nil)
+	defer messageUC.Close()
+
+	router := setupMessageTestRouter(messageUC)
+
+	text := "Sure"
+	w := postSendMessage(router, dto.SendMessageRequest{
+		SessionID: "550e8400-e29b-41d4-a716-446655440000",
+		To:        "+1234567890",
+		Type:      "text",
+		Content: dto.SendMessageContentInput{
+			Text:    &text,
+			ReplyTo: &dto.ReplyToInput{},
+		},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,333 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/application/usecase"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/config"
+	"whatspire/internal/infrastructure/persistence"
+	httpHandler "whatspire/internal/presentation/http"
+	"whatspire/test/helpers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ==================== Test Setup ====================
+
+func setupMessageHistoryTestRouter(t *testing.T, waClient *WhatsAppClientMock) (*gin.Engine, *usecase.MessageUseCase) {
+	// Create in-memory database
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Run migrations
+	err = persistence.RunAutoMigration(db, helpers.CreateTestLogger())
+	require.NoError(t, err)
+
+	messageUC := helpers.NewTestMessageUseCaseBuilder().
+		WithWhatsAppClient(waClient).
+		WithMessageRepository(persistence.NewMessageRepository(db)).
+		Build()
+	t.Cleanup(messageUC.Close)
+
+	return setupMessageTestRouter(messageUC), messageUC
+}
+
+func sendTestTextMessage(t *testing.T, messageUC *usecase.MessageUseCase, text string) *entity.Message {
+	msg, err := messageUC.SendMessageSync(context.Background(), dto.SendMessageRequest{
+		SessionID: "session-1",
+		To:        "+1234567890",
+		Type:      "text",
+		Content:   dto.SendMessageContentInput{Text: &text},
+	})
+	require.NoError(t, err)
+	return msg
+}
+
+// ==================== GET /api/sessions/:id/chats/:jid/messages Tests ====================
+
+func TestListChatMessages_Pagination(t *testing.T) {
+	router, messageUC := setupMessageHistoryTestRouter(t, NewWhatsAppClientMock())
+
+	for _, text := range []string{"first", "second", "third"} {
+		sendTestTextMessage(t, messageUC, text)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/session-1/chats/1234567890@s.whatsapp.net/messages?limit=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var page dto.APIResponse[dto.ListMessagesResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.True(t, page.Success)
+	require.NotNil(t, page.Data)
+	assert.Len(t, page.Data.Messages, 2)
+	require.NotEmpty(t, page.Data.NextCursor)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/sessions/session-1/chats/1234567890@s.whatsapp.net/messages?limit=2&cursor="+page.Data.NextCursor, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var next dto.APIResponse[dto.ListMessagesResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &next))
+	require.NotNil(t, next.Data)
+	assert.Len(t, next.Data.Messages, 1)
+	assert.Empty(t, next.Data.NextCursor)
+}
+
+func TestListChatMessages_InvalidLimit(t *testing.T) {
+	router, _ := setupMessageHistoryTestRouter(t, NewWhatsAppClientMock())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/session-1/chats/1234567890@s.whatsapp.net/messages?limit=1000", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// ==================== GET /api/messages/:id Tests ====================
+
+func TestGetMessage_StatusTimeline(t *testing.T) {
+	waClient := NewWhatsAppClientMock()
+	waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+		msg.WhatsAppID = "3EB0TEST"
+		return nil
+	}
+	router, messageUC := setupMessageHistoryTestRouter(t, waClient)
+
+	msg := sendTestTextMessage(t, messageUC, "hello")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/messages/"+msg.ID, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.APIResponse[dto.MessageStatusResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Data)
+	assert.Equal(t, msg.ID, response.Data.ID)
+	assert.Equal(t, "3EB0TEST", response.Data.WhatsAppID)
+	assert.Equal(t, "sent", response.Data.Status)
+	require.Len(t, response.Data.Timeline, 2)
+	assert.Equal(t, "pending", response.Data.Timeline[0].Status)
+	assert.Equal(t, "sent", response.Data.Timeline[1].Status)
+}
+
+func TestGetMessage_NotFound(t *testing.T) {
+	router, _ := setupMessageHistoryTestRouter(t, NewWhatsAppClientMock())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/messages/missing", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// ==================== PATCH/DELETE /api/messages/:id Tests ====================
+
+func newSendingWhatsAppClientMock() *WhatsAppClientMock {
+	waClient := NewWhatsAppClientMock()
+	waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+		msg.WhatsAppID = "3EB0TEST"
+		return nil
+	}
+	return waClient
+}
+
+func TestEditMessage_Success(t *testing.T) {
+	router, messageUC := setupMessageHistoryTestRouter(t, newSendingWhatsAppClientMock())
+
+	msg := sendTestTextMessage(t, messageUC, "helo")
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/messages/"+msg.ID, strings.NewReader(`{"text":"hello"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/sessions/session-1/chats/1234567890@s.whatsapp.net/messages", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var page dto.APIResponse[dto.ListMessagesResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	require.NotNil(t, page.Data)
+	require.Len(t, page.Data.Messages, 1)
+	assert.Equal(t, "hello", *page.Data.Messages[0].Content.Text)
+	assert.NotNil(t, page.Data.Messages[0].Content.EditedAt)
+}
+
+func TestEditMessage_EmptyText(t *testing.T) {
+	router, messageUC := setupMessageHistoryTestRouter(t, newSendingWhatsAppClientMock())
+
+	msg := sendTestTextMessage(t, messageUC, "helo")
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/messages/"+msg.ID, strings.NewReader(`{"text":""}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestEditMessage_NotYetSent(t *testing.T) {
+	// Without a WhatsApp ID there is nothing to edit on the network
+	router, messageUC := setupMessageHistoryTestRouter(t, NewWhatsAppClientMock())
+
+	msg := sendTestTextMessage(t, messageUC, "helo")
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/messages/"+msg.ID, strings.NewReader(`{"text":"hello"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "MESSAGE_NOT_EDITABLE")
+}
+
+func TestRevokeMessage_ForEveryone(t *testing.T) {
+	router, messageUC := setupMessageHistoryTestRouter(t, newSendingWhatsAppClientMock())
+
+	msg := sendTestTextMessage(t, messageUC, "oops")
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/messages/"+msg.ID+"?for=everyone", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// A second revoke is rejected
+	req = httptest.NewRequest(http.MethodDelete, "/api/messages/"+msg.ID+"?for=everyone", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "MESSAGE_NOT_REVOCABLE")
+}
+
+func TestRevokeMessage_RequiresForEveryone(t *testing.T) {
+	router, messageUC := setupMessageHistoryTestRouter(t, newSendingWhatsAppClientMock())
+
+	msg := sendTestTextMessage(t, messageUC, "oops")
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/messages/"+msg.ID, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRevokeMessage_NotFound(t *testing.T) {
+	router, _ := setupMessageHistoryTestRouter(t, NewWhatsAppClientMock())
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/messages/missing?for=everyone", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// ==================== Session-Scoped Key Tests ====================
+
+func TestMessageAndEventLookup_SessionScopedKey(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.RunAutoMigration(db, helpers.CreateTestLogger()))
+
+	waClient := newSendingWhatsAppClientMock()
+	changes := 0
+	waClient.EditFn = func(ctx context.Context, sessionID, chatJID, messageID string, messageType entity.MessageType, text string) error {
+		changes++
+		return nil
+	}
+	waClient.RevokeFn = func(ctx context.Context, sessionID, chatJID, messageID string) error {
+		changes++
+		return nil
+	}
+
+	messageUC := helpers.NewTestMessageUseCaseBuilder().
+		WithWhatsAppClient(waClient).
+		WithMessageRepository(persistence.NewMessageRepository(db)).
+		Build()
+	t.Cleanup(messageUC.Close)
+
+	eventRepo := persistence.NewEventRepository(db)
+	event, err := entity.NewEventWithPayload("event-1", entity.EventTypeMessageReceived, "session-1", map[string]string{"text": "hi"})
+	require.NoError(t, err)
+	require.NoError(t, eventRepo.Create(context.Background(), event))
+
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	ownKey := helpers.CreateScopedTestAPIKey(t, apiKeyRepo, "write", []string{"session-1"}, nil)
+	otherKey := helpers.CreateScopedTestAPIKey(t, apiKeyRepo, "write", []string{"session-2"}, nil)
+
+	handler := helpers.NewTestHandlerBuilder().
+		WithMessageUseCase(messageUC).
+		WithEventUseCase(usecase.NewEventUseCase(eventRepo, nil)).
+		Build()
+	routerConfig := httpHandler.DefaultRouterConfig()
+	routerConfig.APIKeyConfig = &config.APIKeyConfig{Enabled: true, Header: "X-API-Key"}
+	routerConfig.APIKeyRepository = apiKeyRepo
+	router := helpers.CreateTestRouter(handler, routerConfig)
+
+	msg := sendTestTextMessage(t, messageUC, "private")
+
+	do := func(key, method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-API-Key", key)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	requests := []struct {
+		name    string
+		method  string
+		target  string
+		body    string
+		missing string
+	}{
+		{"get message", http.MethodGet, "/api/messages/" + msg.ID, "", "/api/messages/missing"},
+		{"edit message", http.MethodPatch, "/api/messages/" + msg.ID, `{"text":"changed"}`, "/api/messages/missing"},
+		{"revoke message", http.MethodDelete, "/api/messages/" + msg.ID + "?for=everyone", "", "/api/messages/missing?for=everyone"},
+		{"get event", http.MethodGet, "/api/events/" + event.ID, "", "/api/events/missing"},
+	}
+
+	for _, tt := range requests {
+		t.Run(tt.name+" of another session is not found", func(t *testing.T) {
+			w := do(otherKey.PlainText, tt.method, tt.target, tt.body)
+			assert.Equal(t, http.StatusNotFound, w.Code)
+
+			// Indistinguishable from an ID that does not exist
+			missing := do(otherKey.PlainText, tt.method, tt.missing, tt.body)
+			assert.JSONEq(t, missing.Body.String(), w.Body.String())
+		})
+	}
+	assert.Zero(t, changes, "nothing is sent to WhatsApp for another session's message")
+
+	for _, tt := range requests {
+		t.Run(tt.name+" of the bound session is allowed", func(t *testing.T) {
+			w := do(ownKey.PlainText, tt.method, tt.target, tt.body)
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		})
+	}
+	assert.Equal(t, 2, changes)
+}
@@ -0,0 +1,293 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/valueobject"
+	"whatspire/internal/infrastructure/persistence"
+	"whatspire/test/helpers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const uploadTestSessionID = "550e8400-e29b-41d4-a716-446655440000"
+
+// uploadTestEnv records what reaches the media uploader and the WhatsApp client
+type uploadTestEnv struct {
+	router   *gin.Engine
+	uploads  []*entity.MediaUploadInfo
+	contents [][]byte
+	sent     []*entity.Message
+}
+
+func setupUploadTestRouter(t *testing.T) *uploadTestEnv {
+	env := &uploadTestEnv{}
+
+	waClient := NewWhatsAppClientMock()
+	waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+		env.sent = append(env.sent, msg)
+		return nil
+	}
+
+	mediaUploader := NewMediaUploaderMock()
+	mediaUploader.UploadContentFn = func(ctx context.Context, sessionID string, info *entity.MediaUploadInfo) (*entity.MediaUploadResult, error) {
+		content, err := io.ReadAll(info.Content)
+		if err != nil {
+			return nil, err
+		}
+		if info.MimeType == "text/html" {
+			return nil, errors.ErrUnsupportedMimeType
+		}
+		env.uploads = append(env.uploads, info)
+		env.contents = append(env.contents, content)
+		return entity.NewMediaUploadResult("https://mmg.whatsapp.net/upload", "/v/upload", []byte("key"), []byte("hash"), []byte("enc"),
+			uint64(len(content)), info.MimeType, info.MediaType), nil
+	}
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.RunAutoMigration(db, helpers.CreateTestLogger()))
+
+	messageUC := helpers.NewTestMessageUseCaseBuilder().
+		WithWhatsAppClient(waClient).
+		WithEventPublisher(NewEventPublisherMock()).
+		WithMediaUploader(mediaUploader).
+		WithMessageRepository(persistence.NewMessageRepository(db)).
+		Build()
+	t.Cleanup(messageUC.Close)
+
+	env.router = setupMessageTestRouter(messageUC)
+	return env
+}
+
+// postForm sends a multipart send request; a nil file leaves out the file part
+func (env *uploadTestEnv) postForm(t *testing.T, fields map[string]string, filename, contentType string, file []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	if file != nil {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		require.NoError(t, err)
+		_, err = part.Write(file)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/messages?sync=true", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	return w
+}
+
+func (env *uploadTestEnv) postJSON(t *testing.T, reqBody dto.SendMessageRequest) *httptest.ResponseRecorder {
+	body, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/messages?sync=true", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	return w
+}
+
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	var response dto.APIResponse[any]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Error)
+	return response.Error.Code
+}
+
+func TestSendMessage_MultipartUpload(t *testing.T) {
+	pdf := []byte("%PDF-1.7 quarterly report")
+
+	t.Run("file part is uploaded and sent", func(t *testing.T) {
+		env := setupUploadTestRouter(t)
+
+		w := env.postForm(t, map[string]string{
+			"session_id": uploadTestSessionID,
+			"to":         "+1234567890",
+			"type":       "document",
+			"caption":    "Q3 numbers",
+		}, "report.pdf", "application/pdf", pdf)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		require.Len(t, env.uploads, 1)
+		assert.Equal(t, valueobject.MediaTypeDocument, env.uploads[0].MediaType)
+		assert.Equal(t, "report.pdf", env.uploads[0].Filename)
+		assert.Equal(t, "application/pdf", env.uploads[0].MimeType)
+		assert.Equal(t, pdf, env.contents[0])
+
+		require.Len(t, env.sent, 1)
+		content := env.sent[0].Content
+		require.NotNil(t, content.Media, "the message carries the uploaded media instead of a URL")
+		assert.Equal(t, uint64(len(pdf)), content.Media.FileLength)
+		assert.Nil(t, content.DocURL)
+		assert.Equal(t, "report.pdf", *content.Filename)
+		assert.Equal(t, "Q3 numbers", *content.Caption)
+
+		// The upload result holds the media keys, which stay out of the message history
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+uploadTestSessionID+"/chats/1234567890@s.whatsapp.net/messages", nil)
+		w = httptest.NewRecorder()
+		env.router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Q3 numbers")
+		assert.NotContains(t, w.Body.String(), "media_key")
+	})
+
+	t.Run("filename field renames the file", func(t *testing.T) {
+		env := setupUploadTestRouter(t)
+
+		w := env.postForm(t, map[string]string{
+			"session_id": uploadTestSessionID,
+			"to":         "+1234567890",
+			"type":       "document",
+			"filename":   "Quarterly report.pdf",
+		}, "tmp-1234.pdf", "application/pdf", pdf)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		require.Len(t, env.uploads, 1)
+		assert.Equal(t, "Quarterly report.pdf", env.uploads[0].Filename)
+	})
+
+	t.Run("media type needs a URL or a file", func(t *testing.T) {
+		env := setupUploadTestRouter(t)
+
+		w := env.postForm(t, map[string]string{
+			"session_id": uploadTestSessionID,
+			"to":         "+1234567890",
+			"type":       "image",
+		}, "", "", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), dto.ErrImageURLRequired.Error())
+	})
+
+	t.Run("files are only accepted for media messages", func(t *testing.T) {
+		env := setupUploadTestRouter(t)
+
+		w := env.postForm(t, map[string]string{
+			"session_id": uploadTestSessionID,
+			"to":         "+1234567890",
+			"type":       "location",
+		}, "report.pdf", "application/pdf", pdf)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, env.uploads)
+	})
+
+	t.Run("constraint violations are reported", func(t *testing.T) {
+		env := setupUploadTestRouter(t)
+
+		w := env.postForm(t, map[string]string{
+			"session_id": uploadTestSessionID,
+			"to":         "+1234567890",
+			"type":       "image",
+		}, "page.html", "text/html", []byte("<html></html>"))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "UNSUPPORTED_MIME_TYPE", errorCode(t, w))
+		assert.Empty(t, env.sent)
+	})
+
+	t.Run("form fields are validated like JSON", func(t *testing.T) {
+		env := setupUploadTestRouter(t)
+
+		w := env.postForm(t, map[string]string{
+			"session_id": "not-a-uuid",
+			"to":         "+1234567890",
+			"type":       "document",
+		}, "report.pdf", "application/pdf", pdf)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "VALIDATION_FAILED", errorCode(t, w))
+		assert.Empty(t, env.uploads)
+	})
+}
+
+func TestSendMessage_InlineMedia(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\nimage data")
+	encoded := base64.StdEncoding.EncodeToString(png)
+
+	t.Run("media_data is decoded and uploaded", func(t *testing.T) {
+		env := setupUploadTestRouter(t)
+		mimeType := "image/png"
+
+		w := env.postJSON(t, dto.SendMessageRequest{
+			SessionID: uploadTestSessionID,
+			To:        "+1234567890",
+			Type:      "image",
+			Content:   dto.SendMessageContentInput{MediaData: &encoded, MimeType: &mimeType},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		require.Len(t, env.uploads, 1)
+		assert.Equal(t, valueobject.MediaTypeImage, env.uploads[0].MediaType)
+		assert.Equal(t, "image/png", env.uploads[0].MimeType)
+		assert.Equal(t, png, env.contents[0])
+
+		require.Len(t, env.sent, 1)
+		assert.NotNil(t, env.sent[0].Content.Media)
+	})
+
+	t.Run("a URL and media_data together are rejected", func(t *testing.T) {
+		env := setupUploadTestRouter(t)
+		imageURL := "https://example.com/image.png"
+
+		w := env.postJSON(t, dto.SendMessageRequest{
+			SessionID: uploadTestSessionID,
+			To:        "+1234567890",
+			Type:      "image",
+			Content:   dto.SendMessageContentInput{ImageURL: &imageURL, MediaData: &encoded},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), dto.ErrMediaSourceConflict.Error())
+	})
+
+	t.Run("media_data must be base64", func(t *testing.T) {
+		env := setupUploadTestRouter(t)
+		invalid := "not base64!"
+
+		w := env.postJSON(t, dto.SendMessageRequest{
+			SessionID: uploadTestSessionID,
+			To:        "+1234567890",
+			Type:      "image",
+			Content:   dto.SendMessageContentInput{MediaData: &invalid},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "VALIDATION_FAILED", errorCode(t, w))
+	})
+
+	t.Run("large files must use multipart", func(t *testing.T) {
+		env := setupUploadTestRouter(t)
+		large := strings.Repeat("A", base64.StdEncoding.EncodedLen(dto.MaxInlineMediaSize)+4)
+
+		w := env.postJSON(t, dto.SendMessageRequest{
+			SessionID: uploadTestSessionID,
+			To:        "+1234567890",
+			Type:      "document",
+			Content:   dto.SendMessageContentInput{MediaData: &large},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), dto.ErrInlineMediaTooLarge.Error())
+		assert.Empty(t, env.uploads)
+	})
+}
@@ -29,7 +29,9 @@ type MediaUploaderMock struct {
 	UploadDocumentFn func(ctx context.Context, sessionID string, url string, filename string) (*entity.MediaUploadResult, error)
 	UploadAudioFn    func(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error)
 	UploadVideoFn    func(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error)
+	UploadStickerFn  func(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error)
 	UploadFn         func(ctx context.Context, sessionID string, info *entity.MediaDownloadInfo) (*entity.MediaUploadResult, error)
+	UploadContentFn  func(ctx context.Context, sessionID string, info *entity.MediaUploadInfo) (*entity.MediaUploadResult, error)
 	Constraints      *valueobject.MediaConstraints
 }
 
@@ -83,6 +85,17 @@ func (m *MediaUploaderMock) UploadVideo(ctx context.Context, sessionID string, u
 	}, nil
 }
 
+func (m *MediaUploaderMock) UploadSticker(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error) {
+	if m.UploadStickerFn != nil {
+		return m.UploadStickerFn(ctx, sessionID, url)
+	}
+	return &entity.MediaUploadResult{
+		URL:        "https://whatsapp.net/media/sticker123",
+		MimeType:   "image/webp",
+		FileLength: 1024,
+	}, nil
+}
+
 func (m *MediaUploaderMock) Upload(ctx context.Context, sessionID string, info *entity.MediaDownloadInfo) (*entity.MediaUploadResult, error) {
 	if m.UploadFn != nil {
 		return m.UploadFn(ctx, sessionID, info)
@@ -94,6 +107,18 @@ func (m *MediaUploaderMock) Upload(ctx context.Context, sessionID string, info *
 	}, nil
 }
 
+func (m *MediaUploaderMock) UploadContent(ctx context.Context, sessionID string, info *entity.MediaUploadInfo) (*entity.MediaUploadResult, error) {
+	if m.UploadContentFn != nil {
+		return m.UploadContentFn(ctx, sessionID, info)
+	}
+	return &entity.MediaUploadResult{
+		URL:        "https://whatsapp.net/media/content123",
+		MimeType:   info.MimeType,
+		MediaType:  info.MediaType,
+		FileLength: 1024,
+	}, nil
+}
+
 func (m *MediaUploaderMock) GetConstraints() *valueobject.MediaConstraints {
 	return m.Constraints
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"whatspire/internal/application/dto"
+	"whatspire/internal/domain/entity"
 	"whatspire/internal/infrastructure/config"
 	httpPresentation "whatspire/internal/presentation/http"
 	"whatspire/test/helpers"
@@ -293,3 +294,103 @@ func TestRoleAuthorization_StoresRoleInContext(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Equal(t, config.RoleAdmin, capturedRole)
 }
+
+// ==================== Scope and Session Authorization Tests ====================
+
+func setupScopedTestRouter(apiKeyConfig *config.APIKeyConfig, apiKeyRepo *helpers.MockAPIKeyRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(httpPresentation.APIKeyMiddleware(*apiKeyConfig, nil, apiKeyRepo))
+
+	sessions := router.Group("/sessions/:id")
+	sessions.Use(httpPresentation.SessionAuthorizationMiddleware(apiKeyConfig))
+	sessions.GET("/groups", httpPresentation.RoleAuthorizationMiddleware(config.RoleRead, apiKeyConfig, entity.ScopeGroupsRead), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+	sessions.POST("/groups", httpPresentation.RoleAuthorizationMiddleware(config.RoleWrite, apiKeyConfig, entity.ScopeGroupsManage), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	return router
+}
+
+func TestScopeAuthorization_MissingScope(t *testing.T) {
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	scopedKey := helpers.CreateScopedTestAPIKey(t, apiKeyRepo, "write", nil, []string{entity.ScopeGroupsRead})
+
+	apiKeyConfig := &config.APIKeyConfig{
+		Enabled: true,
+		Header:  "X-API-Key",
+	}
+
+	router := setupScopedTestRouter(apiKeyConfig, apiKeyRepo)
+
+	tests := []struct {
+		name         string
+		method       string
+		expectStatus int
+	}{
+		{"Scope granted", http.MethodGet, http.StatusOK},
+		{"Scope missing", http.MethodPost, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/sessions/session-1/groups", nil)
+			req.Header.Set("X-API-Key", scopedKey.PlainText)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+			if tt.expectStatus == http.StatusForbidden {
+				var response dto.APIResponse[any]
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "INSUFFICIENT_SCOPE", response.Error.Code)
+				assert.Contains(t, response.Error.Message, entity.ScopeGroupsManage)
+			}
+		})
+	}
+}
+
+func TestSessionAuthorization_SessionScopedKey(t *testing.T) {
+	apiKeyRepo := helpers.NewMockAPIKeyRepository()
+	scopedKey := helpers.CreateScopedTestAPIKey(t, apiKeyRepo, "write", []string{"session-1"}, nil)
+	unscopedKey := helpers.CreateTestAPIKey(t, apiKeyRepo, "write", nil)
+
+	apiKeyConfig := &config.APIKeyConfig{
+		Enabled: true,
+		Header:  "X-API-Key",
+	}
+
+	router := setupScopedTestRouter(apiKeyConfig, apiKeyRepo)
+
+	tests := []struct {
+		name         string
+		key          string
+		sessionID    string
+		expectStatus int
+	}{
+		{"Bound session", scopedKey.PlainText, "session-1", http.StatusOK},
+		{"Other session", scopedKey.PlainText, "session-2", http.StatusForbidden},
+		{"Unscoped key", unscopedKey.PlainText, "session-2", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/sessions/"+tt.sessionID+"/groups", nil)
+			req.Header.Set("X-API-Key", tt.key)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectStatus, w.Code)
+			if tt.expectStatus == http.StatusForbidden {
+				var response dto.APIResponse[any]
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "SESSION_ACCESS_DENIED", response.Error.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package integration
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/application/usecase"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/persistence"
+	"whatspire/internal/infrastructure/webhook"
+	"whatspire/test/helpers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// webhookReceiver is a webhook endpoint whose response status can be changed while deliveries arrive
+type webhookReceiver struct {
+	server      *httptest.Server
+	mu          sync.Mutex
+	statusCode  int
+	deliveryIDs []string
+	signatures  []string
+	bodies      [][]byte
+	headers     []http.Header
+}
+
+func newWebhookReceiver(t *testing.T, statusCode int) *webhookReceiver {
+	r := &webhookReceiver{statusCode: statusCode}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.deliveryIDs = append(r.deliveryIDs, req.Header.Get("X-Webhook-Delivery"))
+		r.signatures = append(r.signatures, req.Header.Get("X-Webhook-Signature"))
+		r.bodies = append(r.bodies, body)
+		r.headers = append(r.headers, req.Header.Clone())
+		w.WriteHeader(r.statusCode)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *webhookReceiver) SetStatusCode(code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statusCode = code
+}
+
+func (r *webhookReceiver) Requests() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.deliveryIDs...)
+}
+
+// EventTypes returns the type of each delivered event, in order of arrival
+func (r *webhookReceiver) EventTypes() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types := make([]string, len(r.bodies))
+	for i, body := range r.bodies {
+		var event struct {
+			Type string `json:"type"`
+		}
+		_ = json.Unmarshal(body, &event)
+		types[i] = event.Type
+	}
+	return types
+}
+
+// Header returns the headers of the request at index i
+func (r *webhookReceiver) Header(i int) http.Header {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.headers[i]
+}
+
+// SignedWith reports whether the request at index i was signed with the secret
+func (r *webhookReceiver) SignedWith(i int, secret string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(r.bodies[i])
+	return r.signatures[i] == hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookDeliveryTestEnv holds a delivery queue and the API serving its deliveries
+type webhookDeliveryTestEnv struct {
+	router    *gin.Engine
+	queue     *webhook.DeliveryQueue
+	receiver  *webhookReceiver
+	webhookUC *usecase.WebhookUseCase
+}
+
+func setupWebhookDeliveryTest(t *testing.T, schedule []time.Duration) *webhookDeliveryTestEnv {
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // Keep a single in-memory database
+	require.NoError(t, persistence.RunAutoMigration(db, helpers.CreateTestLogger()))
+
+	sessionRepo := persistence.NewSessionRepository(db)
+	require.NoError(t, sessionRepo.Create(context.Background(), entity.NewSession("session-1", "Test")))
+
+	// The use case and the queue share the caches, as they do in the application
+	configRepo := persistence.NewCachedWebhookConfigRepository(persistence.NewWebhookConfigRepository(db), time.Hour)
+	endpointRepo := persistence.NewCachedWebhookEndpointRepository(persistence.NewWebhookEndpointRepository(db), time.Hour)
+	deliveryRepo := persistence.NewWebhookDeliveryRepository(db)
+	webhookUC := usecase.NewWebhookUseCase(configRepo, endpointRepo, deliveryRepo, sessionRepo, nil)
+
+	receiver := newWebhookReceiver(t, http.StatusServiceUnavailable)
+	_, err = webhookUC.UpdateWebhookConfig(context.Background(), "session-1", true, receiver.server.URL, nil, false, false, false)
+	require.NoError(t, err)
+
+	publisher := webhook.NewWebhookPublisher(webhook.WebhookConfig{}, helpers.CreateTestLogger(), nil)
+	queue := webhook.NewDeliveryQueue(deliveryRepo, webhook.NewDispatcher(configRepo, endpointRepo), publisher, webhook.DeliveryQueueConfig{
+		RetrySchedule: schedule,
+		PollInterval:  20 * time.Millisecond,
+	}, helpers.CreateTestLogger())
+	queue.Start()
+	t.Cleanup(queue.Stop)
+
+	handler := helpers.NewTestHandlerBuilder().
+		WithWebhookUseCase(webhookUC).
+		Build()
+
+	return &webhookDeliveryTestEnv{
+		router:    helpers.CreateTestRouterWithDefaults(handler),
+		queue:     queue,
+		receiver:  receiver,
+		webhookUC: webhookUC,
+	}
+}
+
+// secret returns the session's current webhook secret
+func (env *webhookDeliveryTestEnv) secret(t *testing.T) string {
+	config, err := env.webhookUC.GetWebhookConfig(context.Background(), "session-1")
+	require.NoError(t, err)
+	return config.Secret
+}
+
+func (env *webhookDeliveryTestEnv) listDeliveries(t *testing.T, query string) dto.ListWebhookDeliveriesResponse {
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/session-1/webhook/deliveries"+query, nil)
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Data dto.ListWebhookDeliveriesResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Data
+}
+
+func (env *webhookDeliveryTestEnv) redeliver(sessionID, deliveryID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/sessions/"+sessionID+"/webhook/deliveries/"+deliveryID+"/redeliver", nil)
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	return w
+}
+
+func (env *webhookDeliveryTestEnv) waitForStatus(t *testing.T, status entity.WebhookDeliveryStatus) dto.WebhookDeliveryDTO {
+	var deliveries []dto.WebhookDeliveryDTO
+	require.Eventually(t, func() bool {
+		deliveries = env.listDeliveries(t, "?status="+status.String()).Deliveries
+		return len(deliveries) == 1
+	}, 3*time.Second, 20*time.Millisecond)
+	return deliveries[0]
+}
+
+func TestWebhookDelivery_DeadLetterAndRedeliver(t *testing.T) {
+	env := setupWebhookDeliveryTest(t, []time.Duration{10 * time.Millisecond, 10 * time.Millisecond})
+
+	event := entity.NewEvent("evt-1", entity.EventTypeMessageReceived, "session-1", json.RawMessage(`{"text":"hi"}`))
+	require.NoError(t, env.queue.Enqueue(context.Background(), event))
+
+	// The receiver is down: the first attempt and both retries fail
+	dead := env.waitForStatus(t, entity.WebhookDeliveryStatusDeadLetter)
+	assert.Equal(t, 3, dead.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, dead.LastStatusCode)
+	assert.Equal(t, "evt-1", dead.EventID)
+	assert.Empty(t, dead.NextAttemptAt)
+	assert.Equal(t, []string{dead.ID, dead.ID, dead.ID}, env.receiver.Requests())
+
+	// Once the receiver recovers the delivery can be sent again
+	env.receiver.SetStatusCode(http.StatusOK)
+	w := env.redeliver("session-1", dead.ID)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+	delivered := env.waitForStatus(t, entity.WebhookDeliveryStatusDelivered)
+	assert.Equal(t, dead.ID, delivered.ID)
+	assert.Equal(t, 1, delivered.Attempts)
+	assert.NotEmpty(t, delivered.DeliveredAt)
+	assert.Len(t, env.receiver.Requests(), 4)
+}
+
+func TestWebhookDelivery_ClientErrorIsNotRetried(t *testing.T) {
+	env := setupWebhookDeliveryTest(t, []time.Duration{10 * time.Millisecond})
+	env.receiver.SetStatusCode(http.StatusBadRequest)
+
+	event := entity.NewEvent("evt-1", entity.EventTypeMessageReceived, "session-1", json.RawMessage(`{}`))
+	require.NoError(t, env.queue.Enqueue(context.Background(), event))
+
+	dead := env.waitForStatus(t, entity.WebhookDeliveryStatusDeadLetter)
+	assert.Equal(t, 1, dead.Attempts)
+	assert.Len(t, env.receiver.Requests(), 1)
+}
+
+func TestWebhookDelivery_RequestErrors(t *testing.T) {
+	env := setupWebhookDeliveryTest(t, nil)
+
+	t.Run("invalid status filter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/session-1/webhook/deliveries?status=lost", nil)
+		w := httptest.NewRecorder()
+		env.router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/missing/webhook/deliveries", nil)
+		w := httptest.NewRecorder()
+		env.router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("unknown delivery", func(t *testing.T) {
+		w := env.redeliver("session-1", "missing")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("delivery of another session", func(t *testing.T) {
+		event := entity.NewEvent("evt-1", entity.EventTypeMessageReceived, "session-1", json.RawMessage(`{}`))
+		require.NoError(t, env.queue.Enqueue(context.Background(), event))
+		dead := env.waitForStatus(t, entity.WebhookDeliveryStatusDeadLetter)
+
+		w := env.redeliver("session-2", dead.ID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestWebhookDelivery_UsesSessionConfig(t *testing.T) {
+	env := setupWebhookDeliveryTest(t, []time.Duration{10 * time.Millisecond})
+	env.receiver.SetStatusCode(http.StatusOK)
+	ctx := context.Background()
+
+	enqueue := func(id string, eventType entity.EventType, data string) {
+		event := entity.NewEvent(id, eventType, "session-1", json.RawMessage(data))
+		require.NoError(t, env.queue.Enqueue(ctx, event))
+	}
+
+	t.Run("signed with the session secret", func(t *testing.T) {
+		enqueue("evt-1", entity.EventTypeMessageReceived, `{"chatJid":"123@s.whatsapp.net"}`)
+		require.Eventually(t, func() bool { return len(env.receiver.Requests()) == 1 }, 3*time.Second, 20*time.Millisecond)
+
+		secret := env.secret(t)
+		require.NotEmpty(t, secret)
+		assert.True(t, env.receiver.SignedWith(0, secret))
+	})
+
+	t.Run("rotated secret applies to the next delivery", func(t *testing.T) {
+		rotated, err := env.webhookUC.RotateWebhookSecret(ctx, "session-1")
+		require.NoError(t, err)
+
+		enqueue("evt-2", entity.EventTypeMessageReceived, `{}`)
+		require.Eventually(t, func() bool { return len(env.receiver.Requests()) == 2 }, 3*time.Second, 20*time.Millisecond)
+		assert.True(t, env.receiver.SignedWith(1, rotated.Secret))
+	})
+
+	t.Run("event and chat filters", func(t *testing.T) {
+		_, err := env.webhookUC.UpdateWebhookConfig(ctx, "session-1", true, env.receiver.server.URL,
+			[]string{string(entity.EventTypeMessageReceived)}, true, true, true)
+		require.NoError(t, err)
+
+		enqueue("evt-3", entity.EventTypePresenceUpdate, `{}`)
+		enqueue("evt-4", entity.EventTypeMessageReceived, `{"chatJid":"123@g.us"}`)
+		enqueue("evt-5", entity.EventTypeMessageReceived, `{"chatJid":"status@broadcast"}`)
+		enqueue("evt-6", entity.EventTypeMessageReceived, `{"chatJid":"123@newsletter"}`)
+		enqueue("evt-7", entity.EventTypeMessageReceived, `{"chatJid":"123@s.whatsapp.net"}`)
+
+		deliveries := env.listDeliveries(t, "").Deliveries
+		require.Len(t, deliveries, 3)
+		assert.Equal(t, "evt-7", deliveries[0].EventID)
+	})
+
+	t.Run("nothing is queued once the webhook is deleted", func(t *testing.T) {
+		require.NoError(t, env.webhookUC.DeleteWebhookConfig(ctx, "session-1"))
+
+		enqueue("evt-8", entity.EventTypeMessageReceived, `{}`)
+		assert.Len(t, env.listDeliveries(t, "").Deliveries, 3)
+	})
+}
+
+func TestWebhookDelivery_DeadLettersWhenWebhookDisabled(t *testing.T) {
+	env := setupWebhookDeliveryTest(t, []time.Duration{time.Hour})
+
+	event := entity.NewEvent("evt-1", entity.EventTypeMessageReceived, "session-1", json.RawMessage(`{}`))
+	require.NoError(t, env.queue.Enqueue(context.Background(), event))
+
+	// The first attempt fails and is retried much later
+	require.Eventually(t, func() bool { return len(env.receiver.Requests()) == 1 }, 3*time.Second, 20*time.Millisecond)
+	var pending dto.WebhookDeliveryDTO
+	require.Eventually(t, func() bool {
+		pending = env.waitForStatus(t, entity.WebhookDeliveryStatusPending)
+		return pending.Attempts == 1
+	}, 3*time.Second, 20*time.Millisecond)
+
+	_, err := env.webhookUC.UpdateWebhookConfig(context.Background(), "session-1", false, "", nil, false, false, false)
+	require.NoError(t, err)
+
+	// Redelivering to a disabled webhook gives up without sending
+	w := env.redeliver("session-1", pending.ID)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+	dead := env.waitForStatus(t, entity.WebhookDeliveryStatusDeadLetter)
+	assert.Contains(t, dead.LastError, "disabled")
+	assert.Len(t, env.receiver.Requests(), 1)
+}
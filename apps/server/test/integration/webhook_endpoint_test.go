@@ -0,0 +1,179 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupWebhookEndpointTest creates a delivery environment without the session's default webhook
+func setupWebhookEndpointTest(t *testing.T) *webhookDeliveryTestEnv {
+	env := setupWebhookDeliveryTest(t, []time.Duration{10 * time.Millisecond})
+	require.NoError(t, env.webhookUC.DeleteWebhookConfig(context.Background(), "session-1"))
+	return env
+}
+
+func (env *webhookDeliveryTestEnv) request(method, path string, body any) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	return w
+}
+
+func (env *webhookDeliveryTestEnv) createEndpoint(t *testing.T, req map[string]any) dto.WebhookEndpointResponse {
+	w := env.request(http.MethodPost, "/api/sessions/session-1/webhooks", req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp struct {
+		Data dto.WebhookEndpointResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Data
+}
+
+func TestWebhookEndpoints_FanOut(t *testing.T) {
+	env := setupWebhookEndpointTest(t)
+	ctx := context.Background()
+
+	crm := newWebhookReceiver(t, http.StatusOK)
+	alerting := newWebhookReceiver(t, http.StatusServiceUnavailable)
+	analytics := newWebhookReceiver(t, http.StatusOK)
+
+	crmEndpoint := env.createEndpoint(t, map[string]any{
+		"name":          "crm",
+		"url":           crm.server.URL,
+		"events":        []string{"message.*"},
+		"headers":       map[string]string{"Authorization": "Bearer crm-token"},
+		"ignore_groups": true,
+	})
+	env.createEndpoint(t, map[string]any{
+		"name":   "alerting",
+		"url":    alerting.server.URL,
+		"events": []string{string(entity.EventTypeConnected), string(entity.EventTypeDisconnected)},
+	})
+	env.createEndpoint(t, map[string]any{
+		"name":   "analytics",
+		"url":    analytics.server.URL,
+		"events": []string{"group.*"},
+	})
+
+	events := []*entity.Event{
+		entity.NewEvent("evt-1", entity.EventTypeMessageReceived, "session-1", json.RawMessage(`{"chatJid":"123@s.whatsapp.net"}`)),
+		entity.NewEvent("evt-2", entity.EventTypeMessageReceived, "session-1", json.RawMessage(`{"chatJid":"123-456@g.us"}`)),
+		entity.NewEvent("evt-3", entity.EventTypeDisconnected, "session-1", nil),
+		entity.NewEvent("evt-4", entity.EventTypeGroupJoined, "session-1", json.RawMessage(`{"group_jid":"123-456@g.us"}`)),
+	}
+	for _, event := range events {
+		require.NoError(t, env.queue.Enqueue(ctx, event))
+	}
+
+	require.Eventually(t, func() bool {
+		return len(crm.Requests()) == 1 && len(analytics.Requests()) == 1 && len(alerting.Requests()) >= 1
+	}, 3*time.Second, 20*time.Millisecond)
+
+	assert.Equal(t, []string{string(entity.EventTypeMessageReceived)}, crm.EventTypes())
+	assert.Equal(t, []string{string(entity.EventTypeGroupJoined)}, analytics.EventTypes())
+	assert.Equal(t, string(entity.EventTypeDisconnected), alerting.EventTypes()[0])
+
+	// Each endpoint signs with its own secret and sends its own headers
+	assert.True(t, crm.SignedWith(0, crmEndpoint.Secret))
+	assert.Equal(t, "Bearer crm-token", crm.Header(0).Get("Authorization"))
+	assert.Empty(t, analytics.Header(0).Get("Authorization"))
+
+	// The failing endpoint is dead-lettered without affecting the others
+	dead := env.waitForStatus(t, entity.WebhookDeliveryStatusDeadLetter)
+	assert.Equal(t, "evt-3", dead.EventID)
+	assert.Len(t, env.listDeliveries(t, "?status=delivered").Deliveries, 2)
+	assert.Len(t, env.listDeliveries(t, "?endpoint_id="+crmEndpoint.ID).Deliveries, 1)
+}
+
+func TestWebhookEndpoints_Management(t *testing.T) {
+	env := setupWebhookEndpointTest(t)
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	endpoint := env.createEndpoint(t, map[string]any{"url": receiver.server.URL})
+	path := "/api/sessions/session-1/webhooks/" + endpoint.ID
+
+	t.Run("created enabled with a secret", func(t *testing.T) {
+		assert.True(t, endpoint.Enabled)
+		assert.Len(t, endpoint.Secret, 64)
+		assert.Empty(t, endpoint.Events)
+
+		w := env.request(http.MethodGet, "/api/sessions/session-1/webhooks", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data dto.ListWebhookEndpointsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data.Endpoints, 1)
+		assert.Equal(t, endpoint.ID, resp.Data.Endpoints[0].ID)
+	})
+
+	t.Run("update keeps the secret and disabled endpoints receive nothing", func(t *testing.T) {
+		w := env.request(http.MethodPut, path, map[string]any{"url": receiver.server.URL, "enabled": false})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Data dto.WebhookEndpointResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.False(t, resp.Data.Enabled)
+		assert.Equal(t, endpoint.Secret, resp.Data.Secret)
+
+		event := entity.NewEvent("evt-1", entity.EventTypeMessageReceived, "session-1", nil)
+		require.NoError(t, env.queue.Enqueue(context.Background(), event))
+		assert.Empty(t, env.listDeliveries(t, "").Deliveries)
+	})
+
+	t.Run("rotate secret", func(t *testing.T) {
+		w := env.request(http.MethodPost, path+"/rotate-secret", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Data dto.WebhookEndpointResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.NotEqual(t, endpoint.Secret, resp.Data.Secret)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for name, body := range map[string]map[string]any{
+			"missing url":     {},
+			"relative url":    {"url": "/hook"},
+			"unknown event":   {"url": receiver.server.URL, "events": []string{"message.unknown"}},
+			"reserved header": {"url": receiver.server.URL, "headers": map[string]string{"x-webhook-signature": "forged"}},
+		} {
+			w := env.request(http.MethodPost, "/api/sessions/session-1/webhooks", body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, name)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		w := env.request(http.MethodDelete, path, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		assert.Equal(t, http.StatusNotFound, env.request(http.MethodGet, path, nil).Code)
+		assert.Equal(t, http.StatusNotFound, env.request(http.MethodDelete, path, nil).Code)
+	})
+
+	t.Run("endpoints of other sessions are not found", func(t *testing.T) {
+		w := env.request(http.MethodGet, "/api/sessions/session-2/webhooks/"+endpoint.ID, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,166 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+)
+
+// GroupManagerMock is a shared in-memory mock implementation of GroupManager
+type GroupManagerMock struct {
+	mu                 sync.RWMutex
+	Groups             map[string]*entity.Group // Keyed by group JID
+	InviteCodes        map[string]string        // Keyed by group JID
+	PictureURLs        map[string]string        // Keyed by group JID
+	UpdateFn           func(ctx context.Context, sessionID, groupJID string, update entity.GroupSettingsUpdate) error
+	ParticipantErrorFn func(jid string, action entity.ParticipantAction) int // Returns a WhatsApp error code for a participant, 0 for success
+	counter            int
+}
+
+// NewGroupManagerMock creates a new GroupManagerMock
+func NewGroupManagerMock() *GroupManagerMock {
+	return &GroupManagerMock{
+		Groups:      make(map[string]*entity.Group),
+		InviteCodes: make(map[string]string),
+		PictureURLs: make(map[string]string),
+	}
+}
+
+// AddGroup registers a group the session is a member of
+func (m *GroupManagerMock) AddGroup(group *entity.Group) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Groups[group.JID] = group
+}
+
+func (m *GroupManagerMock) CreateGroup(ctx context.Context, sessionID, name string, participants []string) (*entity.Group, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.counter++
+	jid := fmt.Sprintf("12036300000%05d@g.us", m.counter)
+	group := entity.NewGroup("", jid, name, sessionID)
+	for _, participant := range participants {
+		group.Participants = append(group.Participants, entity.Participant{JID: participant, Role: entity.ParticipantRoleMember})
+	}
+	group.MemberCount = len(group.Participants)
+	m.Groups[jid] = group
+	return group, nil
+}
+
+func (m *GroupManagerMock) GetGroupInfo(ctx context.Context, sessionID, groupJID string) (*entity.Group, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	group, ok := m.Groups[groupJID]
+	if !ok {
+		return nil, errors.ErrGroupNotFound
+	}
+	return group, nil
+}
+
+func (m *GroupManagerMock) UpdateGroupSettings(ctx context.Context, sessionID, groupJID string, update entity.GroupSettingsUpdate) error {
+	if m.UpdateFn != nil {
+		return m.UpdateFn(ctx, sessionID, groupJID, update)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	group, ok := m.Groups[groupJID]
+	if !ok {
+		return errors.ErrGroupNotFound
+	}
+	if update.Name != nil {
+		group.Name = *update.Name
+	}
+	if update.Description != nil {
+		group.Description = update.Description
+	}
+	if update.IsAnnounce != nil {
+		group.IsAnnounce = *update.IsAnnounce
+	}
+	if update.IsLocked != nil {
+		group.IsLocked = *update.IsLocked
+	}
+	if update.Ephemeral != nil {
+		seconds := int(update.Ephemeral.Seconds())
+		group.IsEphemeral = seconds > 0
+		group.EphemeralTime = &seconds
+	}
+	return nil
+}
+
+func (m *GroupManagerMock) SetGroupPhoto(ctx context.Context, sessionID, groupJID, imageURL string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Groups[groupJID]; !ok {
+		return "", errors.ErrGroupNotFound
+	}
+	m.PictureURLs[groupJID] = imageURL
+	return "picture-1", nil
+}
+
+func (m *GroupManagerMock) UpdateGroupParticipants(ctx context.Context, sessionID, groupJID string, participants []string, action entity.ParticipantAction) ([]entity.ParticipantChangeResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Groups[groupJID]; !ok {
+		return nil, errors.ErrGroupNotFound
+	}
+
+	results := make([]entity.ParticipantChangeResult, 0, len(participants))
+	for _, participant := range participants {
+		code := 0
+		if m.ParticipantErrorFn != nil {
+			code = m.ParticipantErrorFn(participant, action)
+		}
+		results = append(results, entity.ParticipantChangeResult{JID: participant, Success: code == 0, ErrorCode: code})
+	}
+	return results, nil
+}
+
+func (m *GroupManagerMock) GetGroupInviteLink(ctx context.Context, sessionID, groupJID string, reset bool) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Groups[groupJID]; !ok {
+		return "", errors.ErrGroupNotFound
+	}
+	code, ok := m.InviteCodes[groupJID]
+	if !ok || reset {
+		m.counter++
+		code = fmt.Sprintf("INVITE%05d", m.counter)
+		m.InviteCodes[groupJID] = code
+	}
+	return "https://chat.whatsapp.com/" + code, nil
+}
+
+func (m *GroupManagerMock) JoinGroupWithLink(ctx context.Context, sessionID, link string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	code := strings.TrimPrefix(link, "https://chat.whatsapp.com/")
+	for jid, inviteCode := range m.InviteCodes {
+		if inviteCode == code {
+			return jid, nil
+		}
+	}
+	return "", errors.ErrInvalidInviteLink
+}
+
+func (m *GroupManagerMock) LeaveGroup(ctx context.Context, sessionID, groupJID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Groups[groupJID]; !ok {
+		return errors.ErrGroupNotFound
+	}
+	delete(m.Groups, groupJID)
+	return nil
+}
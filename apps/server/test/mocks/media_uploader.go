@@ -13,7 +13,9 @@ type MediaUploaderMock struct {
 	UploadDocumentFn func(ctx context.Context, sessionID string, url string, filename string) (*entity.MediaUploadResult, error)
 	UploadAudioFn    func(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error)
 	UploadVideoFn    func(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error)
+	UploadStickerFn  func(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error)
 	UploadFn         func(ctx context.Context, sessionID string, info *entity.MediaDownloadInfo) (*entity.MediaUploadResult, error)
+	UploadContentFn  func(ctx context.Context, sessionID string, info *entity.MediaUploadInfo) (*entity.MediaUploadResult, error)
 	Constraints      *valueobject.MediaConstraints
 }
 
@@ -72,6 +74,18 @@ func (m *MediaUploaderMock) UploadVideo(ctx context.Context, sessionID string, u
 	}, nil
 }
 
+// UploadSticker mocks sticker upload
+func (m *MediaUploaderMock) UploadSticker(ctx context.Context, sessionID string, url string) (*entity.MediaUploadResult, error) {
+	if m.UploadStickerFn != nil {
+		return m.UploadStickerFn(ctx, sessionID, url)
+	}
+	return &entity.MediaUploadResult{
+		URL:        "https://whatsapp.net/media/sticker123",
+		MimeType:   "image/webp",
+		FileLength: 1024,
+	}, nil
+}
+
 // Upload mocks generic upload
 func (m *MediaUploaderMock) Upload(ctx context.Context, sessionID string, info *entity.MediaDownloadInfo) (*entity.MediaUploadResult, error) {
 	if m.UploadFn != nil {
@@ -84,6 +98,19 @@ func (m *MediaUploaderMock) Upload(ctx context.Context, sessionID string, info *
 	}, nil
 }
 
+// UploadContent mocks upload of media supplied by the caller
+func (m *MediaUploaderMock) UploadContent(ctx context.Context, sessionID string, info *entity.MediaUploadInfo) (*entity.MediaUploadResult, error) {
+	if m.UploadContentFn != nil {
+		return m.UploadContentFn(ctx, sessionID, info)
+	}
+	return &entity.MediaUploadResult{
+		URL:        "https://whatsapp.net/media/content123",
+		MimeType:   info.MimeType,
+		MediaType:  info.MediaType,
+		FileLength: 1024,
+	}, nil
+}
+
 // GetConstraints returns media constraints
 func (m *MediaUploaderMock) GetConstraints() *valueobject.MediaConstraints {
 	return m.Constraints
@@ -23,9 +23,12 @@ type WhatsAppClientMock struct {
 	ConnectFn         func(ctx context.Context, sessionID string) error
 	DisconnectFn      func(ctx context.Context, sessionID string) error
 	SendFn            func(ctx context.Context, msg *entity.Message) error
+	EditFn            func(ctx context.Context, sessionID, chatJID, messageID string, messageType entity.MessageType, text string) error
+	RevokeFn          func(ctx context.Context, sessionID, chatJID, messageID string) error
 	QRChan            chan repository.QREvent
 	JIDMappings       map[string]string
 	SentReadReceipts  []ReadReceiptCall
+	SessionSettings   map[string]entity.SessionSettings
 	historySyncConfig map[string]struct {
 		enabled, fullSync bool
 		since             string
@@ -39,6 +42,7 @@ func NewWhatsAppClientMock() *WhatsAppClientMock {
 		QRChan:           make(chan repository.QREvent, 10),
 		JIDMappings:      make(map[string]string),
 		SentReadReceipts: make([]ReadReceiptCall, 0),
+		SessionSettings:  make(map[string]entity.SessionSettings),
 		historySyncConfig: make(map[string]struct {
 			enabled, fullSync bool
 			since             string
@@ -126,6 +130,22 @@ func (m *WhatsAppClientMock) GetHistorySyncConfig(sessionID string) (enabled, fu
 	return config.enabled, config.fullSync, config.since
 }
 
+func (m *WhatsAppClientMock) SetSessionSettings(sessionID string, settings entity.SessionSettings) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.SessionSettings[sessionID] = settings
+}
+
+func (m *WhatsAppClientMock) GetSessionSettings(sessionID string) entity.SessionSettings {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	settings, exists := m.SessionSettings[sessionID]
+	if !exists {
+		return entity.DefaultSessionSettings()
+	}
+	return settings
+}
+
 func (m *WhatsAppClientMock) SendReaction(ctx context.Context, sessionID, chatJID, messageID, emoji string) error {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -135,6 +155,20 @@ func (m *WhatsAppClientMock) SendReaction(ctx context.Context, sessionID, chatJI
 	return nil
 }
 
+func (m *WhatsAppClientMock) EditMessage(ctx context.Context, sessionID, chatJID, messageID string, messageType entity.MessageType, text string) error {
+	if m.EditFn != nil {
+		return m.EditFn(ctx, sessionID, chatJID, messageID, messageType, text)
+	}
+	return nil
+}
+
+func (m *WhatsAppClientMock) RevokeMessage(ctx context.Context, sessionID, chatJID, messageID string) error {
+	if m.RevokeFn != nil {
+		return m.RevokeFn(ctx, sessionID, chatJID, messageID)
+	}
+	return nil
+}
+
 func (m *WhatsAppClientMock) SendReadReceipt(ctx context.Context, sessionID, chatJID string, messageIDs []string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
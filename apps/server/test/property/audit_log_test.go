@@ -2,9 +2,11 @@ package property
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"whatspire/internal/application/usecase"
 	"whatspire/internal/domain/repository"
 	"whatspire/internal/infrastructure/logger"
 	"whatspire/internal/infrastructure/persistence"
@@ -206,7 +208,8 @@ func TestProperty26_AuditLogCompleteness(t *testing.T) {
 			ipAddress := rapid.StringMatching("[0-9]{1,3}\\.[0-9]{1,3}\\.[0-9]{1,3}\\.[0-9]{1,3}").Draw(t, "ip_address")
 
 			event := repository.AuthFailureEvent{
-				APIKey:    apiKey,
+				MaskedKey: usecase.MaskAPIKey(apiKey),
+				KeyHash:   usecase.HashAPIKey(apiKey),
 				Endpoint:  endpoint,
 				Reason:    reason,
 				Timestamp: time.Now(),
@@ -237,6 +240,9 @@ func TestProperty26_AuditLogCompleteness(t *testing.T) {
 			for _, log := range logs {
 				if log.Endpoint != nil && *log.Endpoint == endpoint && log.IPAddress != nil && *log.IPAddress == ipAddress {
 					found = true
+					if strings.Contains(log.Details, apiKey) {
+						t.Fatalf("Auth failure event recorded the plaintext API key")
+					}
 					break
 				}
 			}
@@ -342,3 +342,127 @@ func TestAPIKey_ActivateAfterRevoke(t *testing.T) {
 	assert.True(t, apiKey.IsRevoked())
 	assert.NotNil(t, apiKey.RevokedAt)
 }
+
+// ==================== Session and Scope Tests ====================
+
+func TestAPIKey_CanAccessSession(t *testing.T) {
+	apiKey := entity.NewAPIKey("key_123", "abc123hash", "write", nil)
+
+	// Keys without allowed sessions may access every session
+	assert.False(t, apiKey.IsSessionScoped())
+	assert.True(t, apiKey.CanAccessSession("session-1"))
+
+	apiKey.AllowedSessions = []string{"session-1", "session-2"}
+	assert.True(t, apiKey.IsSessionScoped())
+	assert.True(t, apiKey.CanAccessSession("session-1"))
+	assert.True(t, apiKey.CanAccessSession("session-2"))
+	assert.False(t, apiKey.CanAccessSession("session-3"))
+	assert.False(t, apiKey.CanAccessSession(""))
+}
+
+func TestAPIKey_HasScope(t *testing.T) {
+	apiKey := entity.NewAPIKey("key_123", "abc123hash", "write", nil)
+
+	// Keys without scopes may perform everything their role allows
+	assert.True(t, apiKey.HasScope(entity.ScopeGroupsManage))
+
+	apiKey.Scopes = []string{entity.ScopeMessagesSend}
+	assert.True(t, apiKey.HasScope(entity.ScopeMessagesSend))
+	assert.False(t, apiKey.HasScope(entity.ScopeGroupsManage))
+}
+
+func TestAPIKey_CanGrant(t *testing.T) {
+	unrestricted := entity.NewAPIKey("key_1", "hash1", "admin", nil)
+	assert.True(t, unrestricted.CanGrant(nil, nil))
+	assert.True(t, unrestricted.CanGrant([]string{"session-1"}, []string{entity.ScopeWebhooksWrite}))
+
+	restricted := entity.NewAPIKey("key_2", "hash2", "admin", nil)
+	restricted.AllowedSessions = []string{"session-1"}
+	restricted.Scopes = []string{entity.ScopeMessagesSend, entity.ScopeMessagesRead}
+
+	assert.True(t, restricted.CanGrant([]string{"session-1"}, []string{entity.ScopeMessagesSend}))
+	assert.False(t, restricted.CanGrant(nil, []string{entity.ScopeMessagesSend}), "all sessions is broader")
+	assert.False(t, restricted.CanGrant([]string{"session-2"}, []string{entity.ScopeMessagesSend}))
+	assert.False(t, restricted.CanGrant([]string{"session-1"}, nil), "all scopes is broader")
+	assert.False(t, restricted.CanGrant([]string{"session-1"}, []string{entity.ScopeGroupsManage}))
+}
+
+func TestIsValidAPIKeyScope(t *testing.T) {
+	for _, scope := range entity.APIKeyScopes {
+		assert.True(t, entity.IsValidAPIKeyScope(scope), scope)
+	}
+	assert.False(t, entity.IsValidAPIKeyScope("messages:delete"))
+	assert.False(t, entity.IsValidAPIKeyScope(""))
+}
+
+// ==================== Expiry, Rotation and Quota Tests ====================
+
+func TestAPIKey_IsExpired(t *testing.T) {
+	apiKey := entity.NewAPIKey("key_123", "abc123hash", "read", nil)
+	assert.False(t, apiKey.IsExpired())
+
+	future := time.Now().Add(time.Hour)
+	apiKey.ExpiresAt = &future
+	assert.False(t, apiKey.IsExpired())
+
+	past := time.Now().Add(-time.Second)
+	apiKey.ExpiresAt = &past
+	assert.True(t, apiKey.IsExpired())
+}
+
+func TestAPIKey_RotateTo(t *testing.T) {
+	t.Run("grace period sets the expiry", func(t *testing.T) {
+		apiKey := entity.NewAPIKey("key_123", "abc123hash", "read", nil)
+
+		apiKey.RotateTo("key_456", time.Hour)
+
+		assert.True(t, apiKey.IsRotated())
+		assert.Equal(t, "key_456", *apiKey.ReplacedBy)
+		require.NotNil(t, apiKey.ExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), *apiKey.ExpiresAt, time.Second)
+		assert.False(t, apiKey.IsExpired())
+	})
+
+	t.Run("earlier expiry is kept", func(t *testing.T) {
+		apiKey := entity.NewAPIKey("key_123", "abc123hash", "read", nil)
+		expiresAt := time.Now().Add(time.Minute)
+		apiKey.ExpiresAt = &expiresAt
+
+		apiKey.RotateTo("key_456", time.Hour)
+
+		assert.Equal(t, expiresAt, *apiKey.ExpiresAt)
+	})
+
+	t.Run("zero grace period expires immediately", func(t *testing.T) {
+		apiKey := entity.NewAPIKey("key_123", "abc123hash", "read", nil)
+
+		apiKey.RotateTo("key_456", 0)
+
+		assert.True(t, apiKey.IsExpired())
+	})
+}
+
+func TestAPIKey_Quotas(t *testing.T) {
+	apiKey := entity.NewAPIKey("key_123", "abc123hash", "write", nil)
+	usage := &entity.APIKeyUsage{Requests: 1000, Messages: 1000}
+
+	// Keys without quotas are unlimited
+	assert.False(t, apiKey.HasQuota())
+	assert.False(t, apiKey.ExceedsRequestQuota(usage))
+	assert.False(t, apiKey.ExceedsMessageQuota(usage))
+
+	apiKey.DailyRequestQuota = 10
+	apiKey.DailyMessageQuota = 5
+	assert.True(t, apiKey.HasQuota())
+
+	assert.False(t, apiKey.ExceedsRequestQuota(&entity.APIKeyUsage{Requests: 10}))
+	assert.True(t, apiKey.ExceedsRequestQuota(&entity.APIKeyUsage{Requests: 11}))
+	assert.False(t, apiKey.ExceedsMessageQuota(&entity.APIKeyUsage{Messages: 5}))
+	assert.True(t, apiKey.ExceedsMessageQuota(&entity.APIKeyUsage{Messages: 6}))
+}
+
+func TestAPIKeyUsageDay(t *testing.T) {
+	local := time.FixedZone("UTC+3", 3*60*60)
+	assert.Equal(t, "2026-01-01", entity.APIKeyUsageDay(time.Date(2026, 1, 2, 1, 0, 0, 0, local)))
+	assert.Equal(t, "2026-01-02", entity.APIKeyUsageDay(time.Date(2026, 1, 2, 3, 0, 0, 0, local)))
+}
@@ -62,6 +62,103 @@ func TestAPIKeyRepository_Save(t *testing.T) {
 		found, err := repo.FindByID(ctx, "key_123")
 		require.NoError(t, err)
 		assert.Nil(t, found.Description)
+		assert.Empty(t, found.AllowedSessions)
+		assert.Empty(t, found.Scopes)
+	})
+
+	t.Run("Save with allowed sessions and scopes", func(t *testing.T) {
+		db.Exec("DELETE FROM api_keys WHERE 1=1")
+
+		apiKey := entity.NewAPIKey("key_123", "hash123", "write", nil)
+		apiKey.AllowedSessions = []string{"session-1", "session-2"}
+		apiKey.Scopes = []string{entity.ScopeMessagesSend}
+		require.NoError(t, repo.Save(ctx, apiKey))
+
+		found, err := repo.FindByKeyHash(ctx, "hash123")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"session-1", "session-2"}, found.AllowedSessions)
+		assert.Equal(t, []string{entity.ScopeMessagesSend}, found.Scopes)
+	})
+
+	t.Run("Save with expiry, successor and quotas", func(t *testing.T) {
+		db.Exec("DELETE FROM api_keys WHERE 1=1")
+
+		apiKey := entity.NewAPIKey("key_123", "hash123", "write", nil)
+		apiKey.DailyRequestQuota = 1000
+		apiKey.DailyMessageQuota = 50
+		apiKey.RotateTo("key_456", time.Hour)
+		require.NoError(t, repo.Save(ctx, apiKey))
+
+		found, err := repo.FindByID(ctx, "key_123")
+		require.NoError(t, err)
+		require.NotNil(t, found.ExpiresAt)
+		assert.WithinDuration(t, *apiKey.ExpiresAt, *found.ExpiresAt, time.Second)
+		require.NotNil(t, found.ReplacedBy)
+		assert.Equal(t, "key_456", *found.ReplacedBy)
+		assert.Equal(t, 1000, found.DailyRequestQuota)
+		assert.Equal(t, 50, found.DailyMessageQuota)
+	})
+}
+
+func TestAPIKeyRepository_Usage(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewAPIKeyRepository(db)
+
+	clean := func() {
+		db.Exec("DELETE FROM api_key_usage WHERE 1=1")
+		db.Exec("DELETE FROM api_keys WHERE 1=1")
+	}
+
+	t.Run("GetUsage without usage returns zero counters", func(t *testing.T) {
+		clean()
+
+		usage, err := repo.GetUsage(ctx, "key_123", time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, "key_123", usage.APIKeyID)
+		assert.Equal(t, entity.APIKeyUsageDay(time.Now()), usage.Day)
+		assert.Zero(t, usage.Requests)
+		assert.Zero(t, usage.Messages)
+	})
+
+	t.Run("IncrementUsage accumulates per day", func(t *testing.T) {
+		clean()
+
+		today := time.Now()
+		yesterday := today.AddDate(0, 0, -1)
+
+		usage, err := repo.IncrementUsage(ctx, "key_123", today, 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), usage.Requests)
+
+		usage, err = repo.IncrementUsage(ctx, "key_123", today, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), usage.Requests)
+		assert.Equal(t, int64(1), usage.Messages)
+
+		_, err = repo.IncrementUsage(ctx, "key_123", yesterday, 5, 5)
+		require.NoError(t, err)
+		_, err = repo.IncrementUsage(ctx, "key_456", today, 7, 0)
+		require.NoError(t, err)
+
+		usage, err = repo.GetUsage(ctx, "key_123", today)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), usage.Requests)
+		assert.Equal(t, int64(1), usage.Messages)
+	})
+
+	t.Run("Delete removes the usage of the key", func(t *testing.T) {
+		clean()
+
+		require.NoError(t, repo.Save(ctx, entity.NewAPIKey("key_123", "hash123", "write", nil)))
+		_, err := repo.IncrementUsage(ctx, "key_123", time.Now(), 1, 0)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, "key_123"))
+
+		usage, err := repo.GetUsage(ctx, "key_123", time.Now())
+		require.NoError(t, err)
+		assert.Zero(t, usage.Requests)
 	})
 }
 
@@ -25,12 +25,16 @@ type APIKeyRepositoryMock struct {
 	ListFn           func(ctx context.Context, limit, offset int, role *string, isActive *bool) ([]*entity.APIKey, error)
 	UpdateFn         func(ctx context.Context, apiKey *entity.APIKey) error
 	CountFn          func(ctx context.Context, role *string, isActive *bool) (int64, error)
+	IncrementUsageFn func(ctx context.Context, id string, at time.Time, requests, messages int) (*entity.APIKeyUsage, error)
+	GetUsageFn       func(ctx context.Context, id string, at time.Time) (*entity.APIKeyUsage, error)
 	APIKeys          map[string]*entity.APIKey // In-memory storage for testing
+	Usage            map[string]*entity.APIKeyUsage
 }
 
 func NewAPIKeyRepositoryMock() *APIKeyRepositoryMock {
 	return &APIKeyRepositoryMock{
 		APIKeys: make(map[string]*entity.APIKey),
+		Usage:   make(map[string]*entity.APIKeyUsage),
 	}
 }
 
@@ -153,6 +157,34 @@ func (m *APIKeyRepositoryMock) Count(ctx context.Context, role *string, isActive
 	return count, nil
 }
 
+func (m *APIKeyRepositoryMock) IncrementUsage(ctx context.Context, id string, at time.Time, requests, messages int) (*entity.APIKeyUsage, error) {
+	if m.IncrementUsageFn != nil {
+		return m.IncrementUsageFn(ctx, id, at, requests, messages)
+	}
+
+	day := entity.APIKeyUsageDay(at)
+	usage, exists := m.Usage[id+"/"+day]
+	if !exists {
+		usage = &entity.APIKeyUsage{APIKeyID: id, Day: day}
+		m.Usage[id+"/"+day] = usage
+	}
+	usage.Requests += int64(requests)
+	usage.Messages += int64(messages)
+	return usage, nil
+}
+
+func (m *APIKeyRepositoryMock) GetUsage(ctx context.Context, id string, at time.Time) (*entity.APIKeyUsage, error) {
+	if m.GetUsageFn != nil {
+		return m.GetUsageFn(ctx, id, at)
+	}
+
+	day := entity.APIKeyUsageDay(at)
+	if usage, exists := m.Usage[id+"/"+day]; exists {
+		return usage, nil
+	}
+	return &entity.APIKeyUsage{APIKeyID: id, Day: day}, nil
+}
+
 // ==================== Mock AuditLogger ====================
 
 type AuditLoggerMock struct {
@@ -370,6 +402,176 @@ func TestAPIKeyUseCase_CreateAPIKey_UniqueKeys(t *testing.T) {
 	}
 }
 
+func TestAPIKeyUseCase_CreateAPIKeyWithOptions_Success(t *testing.T) {
+	repo := NewAPIKeyRepositoryMock()
+	uc := usecase.NewAPIKeyUseCase(repo, NewAuditLoggerMock(), NewAuditLogRepositoryMock())
+
+	plainKey, apiKey, err := uc.CreateAPIKeyWithOptions(context.Background(), "write", nil, usecase.APIKeyOptions{
+		SessionIDs: []string{" session-1 ", "session-2", "session-1"},
+		Scopes:     []string{entity.ScopeMessagesSend},
+	}, "admin@example.com")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, plainKey)
+	assert.Equal(t, []string{"session-1", "session-2"}, apiKey.AllowedSessions)
+	assert.Equal(t, []string{entity.ScopeMessagesSend}, apiKey.Scopes)
+	assert.Len(t, repo.APIKeys, 1)
+}
+
+func TestAPIKeyUseCase_CreateAPIKeyWithOptions_InvalidScope(t *testing.T) {
+	repo := NewAPIKeyRepositoryMock()
+	uc := usecase.NewAPIKeyUseCase(repo, NewAuditLoggerMock(), NewAuditLogRepositoryMock())
+
+	_, apiKey, err := uc.CreateAPIKeyWithOptions(context.Background(), "write", nil, usecase.APIKeyOptions{
+		Scopes: []string{"messages:delete"},
+	}, "admin@example.com")
+
+	assert.Nil(t, apiKey)
+	assert.ErrorIs(t, err, errors.ErrValidationFailed)
+	assert.Len(t, repo.APIKeys, 0)
+}
+
+func TestAPIKeyUseCase_CreateAPIKeyWithOptions_BlankSession(t *testing.T) {
+	repo := NewAPIKeyRepositoryMock()
+	uc := usecase.NewAPIKeyUseCase(repo, NewAuditLoggerMock(), NewAuditLogRepositoryMock())
+
+	_, apiKey, err := uc.CreateAPIKeyWithOptions(context.Background(), "write", nil, usecase.APIKeyOptions{
+		SessionIDs: []string{"session-1", "  "},
+	}, "admin@example.com")
+
+	assert.Nil(t, apiKey)
+	assert.ErrorIs(t, err, errors.ErrValidationFailed)
+	assert.Len(t, repo.APIKeys, 0)
+}
+
+func TestAPIKeyUseCase_CreateAPIKeyWithOptions_ExpiryAndQuotas(t *testing.T) {
+	repo := NewAPIKeyRepositoryMock()
+	uc := usecase.NewAPIKeyUseCase(repo, NewAuditLoggerMock(), NewAuditLogRepositoryMock())
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	_, apiKey, err := uc.CreateAPIKeyWithOptions(context.Background(), "write", nil, usecase.APIKeyOptions{
+		ExpiresAt:         &expiresAt,
+		DailyRequestQuota: 1000,
+		DailyMessageQuota: 50,
+	}, "admin@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, &expiresAt, apiKey.ExpiresAt)
+	assert.Equal(t, 1000, apiKey.DailyRequestQuota)
+	assert.Equal(t, 50, apiKey.DailyMessageQuota)
+}
+
+func TestAPIKeyUseCase_CreateAPIKeyWithOptions_InvalidExpiryAndQuotas(t *testing.T) {
+	repo := NewAPIKeyRepositoryMock()
+	uc := usecase.NewAPIKeyUseCase(repo, NewAuditLoggerMock(), NewAuditLogRepositoryMock())
+
+	past := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name string
+		opts usecase.APIKeyOptions
+	}{
+		{"expiry in the past", usecase.APIKeyOptions{ExpiresAt: &past}},
+		{"negative request quota", usecase.APIKeyOptions{DailyRequestQuota: -1}},
+		{"negative message quota", usecase.APIKeyOptions{DailyMessageQuota: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, apiKey, err := uc.CreateAPIKeyWithOptions(context.Background(), "write", nil, tt.opts, "admin@example.com")
+
+			assert.Nil(t, apiKey)
+			assert.ErrorIs(t, err, errors.ErrValidationFailed)
+		})
+	}
+	assert.Len(t, repo.APIKeys, 0)
+}
+
+// ==================== RotateAPIKey Tests ====================
+
+func TestAPIKeyUseCase_RotateAPIKey_Success(t *testing.T) {
+	repo := NewAPIKeyRepositoryMock()
+	auditLogger := NewAuditLoggerMock()
+	uc := usecase.NewAPIKeyUseCase(repo, auditLogger, NewAuditLogRepositoryMock())
+
+	description := "Integration key"
+	oldPlainKey, apiKey, err := uc.CreateAPIKeyWithOptions(context.Background(), "write", &description, usecase.APIKeyOptions{
+		SessionIDs:        []string{"session-1"},
+		Scopes:            []string{entity.ScopeMessagesSend},
+		DailyRequestQuota: 500,
+	}, "admin@example.com")
+	require.NoError(t, err)
+
+	plainKey, successor, previous, err := uc.RotateAPIKey(context.Background(), apiKey.ID, time.Hour, nil, "admin@example.com")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, plainKey)
+	assert.NotEqual(t, oldPlainKey, plainKey)
+	assert.NotEqual(t, apiKey.ID, successor.ID)
+	assert.Equal(t, "write", successor.Role)
+	assert.Equal(t, &description, successor.Description)
+	assert.Equal(t, []string{"session-1"}, successor.AllowedSessions)
+	assert.Equal(t, []string{entity.ScopeMessagesSend}, successor.Scopes)
+	assert.Equal(t, 500, successor.DailyRequestQuota)
+	assert.Nil(t, successor.ExpiresAt)
+
+	// Both keys work during the grace period
+	assert.True(t, previous.IsActive)
+	assert.False(t, previous.IsExpired())
+	assert.Equal(t, successor.ID, *previous.ReplacedBy)
+	require.NotNil(t, previous.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *previous.ExpiresAt, time.Minute)
+
+	assert.Len(t, repo.APIKeys, 2)
+	assert.Len(t, auditLogger.CreatedEvents, 2)
+}
+
+func TestAPIKeyUseCase_RotateAPIKey_Errors(t *testing.T) {
+	repo := NewAPIKeyRepositoryMock()
+	uc := usecase.NewAPIKeyUseCase(repo, NewAuditLoggerMock(), NewAuditLogRepositoryMock())
+	ctx := context.Background()
+
+	_, revoked, err := uc.CreateAPIKey(ctx, "read", nil, "admin@example.com")
+	require.NoError(t, err)
+	_, err = uc.RevokeAPIKey(ctx, revoked.ID, "admin@example.com", nil)
+	require.NoError(t, err)
+
+	_, rotated, err := uc.CreateAPIKey(ctx, "read", nil, "admin@example.com")
+	require.NoError(t, err)
+	_, _, _, err = uc.RotateAPIKey(ctx, rotated.ID, time.Hour, nil, "admin@example.com")
+	require.NoError(t, err)
+
+	_, expired, err := uc.CreateAPIKey(ctx, "read", nil, "admin@example.com")
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Minute)
+	expired.ExpiresAt = &past
+
+	_, active, err := uc.CreateAPIKey(ctx, "read", nil, "admin@example.com")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		id          string
+		gracePeriod time.Duration
+		expectErr   error
+	}{
+		{"not found", "missing", time.Hour, errors.ErrNotFound},
+		{"revoked", revoked.ID, time.Hour, errors.ErrAlreadyRevoked},
+		{"already rotated", rotated.ID, time.Hour, errors.ErrAlreadyRotated},
+		{"expired", expired.ID, time.Hour, errors.ErrAPIKeyExpired},
+		{"negative grace period", active.ID, -time.Second, errors.ErrValidationFailed},
+		{"grace period too long", active.ID, usecase.MaxAPIKeyRotationGracePeriod + time.Second, errors.ErrValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, successor, _, err := uc.RotateAPIKey(ctx, tt.id, tt.gracePeriod, nil, "admin@example.com")
+
+			assert.Nil(t, successor)
+			assert.ErrorIs(t, err, tt.expectErr)
+		})
+	}
+}
+
 // ==================== RevokeAPIKey Tests ====================
 
 func TestAPIKeyUseCase_RevokeAPIKey_Success(t *testing.T) {
@@ -800,3 +1002,18 @@ func TestAPIKeyUseCase_GetAPIKeyDetails_NilAuditLogRepo(t *testing.T) {
 	assert.Equal(t, 0, totalRequests) // Should return 0 when repo is nil
 	assert.Equal(t, 0, last7Days)
 }
+
+// ==================== Key Masking Tests ====================
+
+func TestMaskAPIKey(t *testing.T) {
+	assert.Equal(t, "abcd1234...wxyz", usecase.MaskAPIKey("abcd1234secretpartwxyz"))
+	assert.Equal(t, "****", usecase.MaskAPIKey("short"))
+}
+
+func TestHashAPIKey(t *testing.T) {
+	hash := usecase.HashAPIKey("abcd1234secretpartwxyz")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, usecase.HashAPIKey("abcd1234secretpartwxyz"))
+	assert.NotEqual(t, hash, usecase.HashAPIKey("abcd1234secretpartwxyZ"))
+}
@@ -0,0 +1,362 @@
+package unit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/application/usecase"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/config"
+	"whatspire/internal/infrastructure/jobs"
+	"whatspire/internal/infrastructure/persistence"
+	"whatspire/test/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ==================== AuditLogRepository Tests ====================
+
+// seedAuditLogs stores one API key usage event per timestamp, oldest first
+func seedAuditLogs(t *testing.T, repo *persistence.AuditLogRepository, apiKeyID, ip string, timestamps ...time.Time) {
+	t.Helper()
+	for _, timestamp := range timestamps {
+		require.NoError(t, repo.SaveAPIKeyUsage(context.Background(), repository.APIKeyUsageEvent{
+			APIKeyID:  apiKeyID,
+			Endpoint:  "/api/sessions",
+			Method:    "GET",
+			IPAddress: ip,
+			Timestamp: timestamp,
+		}))
+	}
+}
+
+func TestAuditLogRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewAuditLogRepository(db)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	clean := func() {
+		db.Exec("DELETE FROM audit_logs WHERE 1=1")
+	}
+
+	t.Run("List returns every event kind newest first", func(t *testing.T) {
+		clean()
+
+		description := "ci key"
+		reason := "leaked"
+		require.NoError(t, repo.SaveAPIKeyCreated(ctx, repository.APIKeyCreatedEvent{
+			APIKeyID: "key-1", Role: "admin", Description: &description, CreatedBy: "admin-key", Timestamp: base,
+		}))
+		require.NoError(t, repo.SaveSessionAction(ctx, repository.SessionActionEvent{
+			SessionID: "session-1", Action: "created", APIKeyID: "key-1", Timestamp: base.Add(time.Minute),
+		}))
+		require.NoError(t, repo.SaveAPIKeyRevoked(ctx, repository.APIKeyRevokedEvent{
+			APIKeyID: "key-1", RevokedBy: "admin-key", RevocationReason: &reason, Timestamp: base.Add(2 * time.Minute),
+		}))
+
+		logs, err := repo.List(ctx, repository.AuditLogFilter{})
+		require.NoError(t, err)
+		require.Len(t, logs, 3)
+		assert.Equal(t, entity.AuditEventAPIKeyRevoked, logs[0].EventType)
+		assert.Equal(t, entity.AuditEventSessionAction, logs[1].EventType)
+		assert.Equal(t, entity.AuditEventAPIKeyCreated, logs[2].EventType)
+		assert.True(t, logs[2].CreatedAt.Equal(base))
+
+		require.NotNil(t, logs[0].Action)
+		assert.Equal(t, "revoked", *logs[0].Action)
+		var details map[string]interface{}
+		require.NoError(t, json.Unmarshal(logs[0].Details, &details))
+		assert.Equal(t, "leaked", details["revocation_reason"])
+	})
+
+	t.Run("List filters by kind, key, session, IP and time range", func(t *testing.T) {
+		clean()
+
+		seedAuditLogs(t, repo, "key-1", "10.0.0.1", base, base.Add(time.Hour))
+		seedAuditLogs(t, repo, "key-2", "10.0.0.2", base.Add(2*time.Hour))
+		require.NoError(t, repo.SaveAuthFailure(ctx, repository.AuthFailureEvent{
+			Endpoint: "/api/sessions", Reason: "invalid_api_key", IPAddress: "10.0.0.1", Timestamp: base.Add(3 * time.Hour),
+		}))
+		require.NoError(t, repo.SaveMessageSent(ctx, repository.MessageSentEvent{
+			SessionID: "session-1", Recipient: "+15550001", MessageType: "text", Timestamp: base.Add(4 * time.Hour),
+		}))
+
+		authFailure := entity.AuditEventAuthFailure
+		logs, err := repo.List(ctx, repository.AuditLogFilter{EventType: &authFailure})
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.Nil(t, logs[0].APIKeyID)
+
+		apiKeyID := "key-1"
+		logs, err = repo.List(ctx, repository.AuditLogFilter{APIKeyID: &apiKeyID})
+		require.NoError(t, err)
+		assert.Len(t, logs, 2)
+
+		sessionID := "session-1"
+		logs, err = repo.List(ctx, repository.AuditLogFilter{SessionID: &sessionID})
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.Equal(t, entity.AuditEventMessageSent, logs[0].EventType)
+
+		ip := "10.0.0.1"
+		logs, err = repo.List(ctx, repository.AuditLogFilter{IPAddress: &ip})
+		require.NoError(t, err)
+		assert.Len(t, logs, 3)
+
+		since := base.Add(time.Hour)
+		until := base.Add(3 * time.Hour)
+		logs, err = repo.List(ctx, repository.AuditLogFilter{Since: &since, Until: &until})
+		require.NoError(t, err)
+		require.Len(t, logs, 3)
+		assert.Equal(t, entity.AuditEventAuthFailure, logs[0].EventType)
+	})
+
+	t.Run("List pages with a keyset cursor", func(t *testing.T) {
+		clean()
+
+		// Two events share a timestamp so the ID tie-breaker is exercised
+		seedAuditLogs(t, repo, "key-1", "10.0.0.1", base, base.Add(time.Minute), base.Add(time.Minute), base.Add(2*time.Minute))
+
+		seen := make(map[string]bool)
+		filter := repository.AuditLogFilter{Limit: 2}
+		for page := 0; page < 3; page++ {
+			logs, err := repo.List(ctx, filter)
+			require.NoError(t, err)
+			if len(logs) == 0 {
+				break
+			}
+			for _, log := range logs {
+				assert.False(t, seen[log.ID], "entry returned twice")
+				seen[log.ID] = true
+			}
+			last := logs[len(logs)-1]
+			filter.Before = &last.CreatedAt
+			filter.BeforeID = last.ID
+		}
+		assert.Len(t, seen, 4)
+	})
+
+	t.Run("DeleteOlderThan removes expired entries only", func(t *testing.T) {
+		clean()
+
+		seedAuditLogs(t, repo, "key-1", "10.0.0.1", base.AddDate(0, 0, -100), base.AddDate(0, 0, -91), base)
+
+		deleted, err := repo.DeleteOlderThan(ctx, base.AddDate(0, 0, -90))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		logs, err := repo.List(ctx, repository.AuditLogFilter{})
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.True(t, logs[0].CreatedAt.Equal(base))
+	})
+}
+
+// ==================== PersistentAuditLogger Tests ====================
+
+type recordingAuditLogger struct {
+	repository.AuditLogger
+	usage []repository.APIKeyUsageEvent
+}
+
+func (r *recordingAuditLogger) LogAPIKeyUsage(ctx context.Context, event repository.APIKeyUsageEvent) {
+	r.usage = append(r.usage, event)
+}
+
+func TestPersistentAuditLogger(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewAuditLogRepository(db)
+	next := &recordingAuditLogger{}
+
+	auditLogger := persistence.NewPersistentAuditLogger(repo, next, helpers.CreateTestLogger())
+	auditLogger.LogAPIKeyUsage(ctx, repository.APIKeyUsageEvent{
+		APIKeyID:  "key-1",
+		Endpoint:  "/api/messages",
+		Method:    "POST",
+		IPAddress: "10.0.0.1",
+		Timestamp: time.Now(),
+	})
+
+	assert.Len(t, next.usage, 1)
+
+	logs, err := repo.List(ctx, repository.AuditLogFilter{})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, entity.AuditEventAPIKeyUsage, logs[0].EventType)
+	require.NotNil(t, logs[0].IPAddress)
+	assert.Equal(t, "10.0.0.1", *logs[0].IPAddress)
+
+	// Write failures are logged and never surface to the audited operation
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	assert.NotPanics(t, func() {
+		auditLogger.LogAPIKeyUsage(ctx, repository.APIKeyUsageEvent{APIKeyID: "key-1"})
+	})
+}
+
+// ==================== AuditLogUseCase Tests ====================
+
+func TestAuditLogUseCase_ListAuditLogs(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewAuditLogRepository(db)
+	uc := usecase.NewAuditLogUseCase(repo)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	seedAuditLogs(t, repo, "key-1", "10.0.0.1", base, base.Add(time.Minute), base.Add(2*time.Minute))
+
+	t.Run("pages with next_cursor", func(t *testing.T) {
+		first, err := uc.ListAuditLogs(ctx, dto.ListAuditLogsRequest{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first.AuditLogs, 2)
+		assert.NotEmpty(t, first.NextCursor)
+		assert.Equal(t, "api_key_usage", first.AuditLogs[0].EventType)
+		assert.Equal(t, "key-1", first.AuditLogs[0].APIKeyID)
+
+		second, err := uc.ListAuditLogs(ctx, dto.ListAuditLogsRequest{Limit: 2, Cursor: first.NextCursor})
+		require.NoError(t, err)
+		require.Len(t, second.AuditLogs, 1)
+		assert.Empty(t, second.NextCursor)
+		assert.Equal(t, base.Format(time.RFC3339Nano), second.AuditLogs[0].CreatedAt)
+	})
+
+	t.Run("applies the default limit", func(t *testing.T) {
+		response, err := uc.ListAuditLogs(ctx, dto.ListAuditLogsRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, 100, response.Limit)
+		assert.Len(t, response.AuditLogs, 3)
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		invalid := []dto.ListAuditLogsRequest{
+			{Limit: 1001},
+			{Cursor: "not-a-cursor"},
+			// Message history cursors do not page audit logs
+			{Cursor: base64.RawURLEncoding.EncodeToString([]byte(base.Format(time.RFC3339Nano) + "|msg-1"))},
+			{AuditLogFilterRequest: dto.AuditLogFilterRequest{EventType: "unknown"}},
+			{AuditLogFilterRequest: dto.AuditLogFilterRequest{Since: "yesterday"}},
+			{AuditLogFilterRequest: dto.AuditLogFilterRequest{Since: "2026-03-02T00:00:00Z", Until: "2026-03-01T00:00:00Z"}},
+		}
+		for _, req := range invalid {
+			_, err := uc.ListAuditLogs(ctx, req)
+			assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		}
+	})
+}
+
+func TestAuditLogUseCase_ExportAuditLogs(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewAuditLogRepository(db)
+	uc := usecase.NewAuditLogUseCase(repo)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// More entries than a single export batch
+	timestamps := make([]time.Time, 0, 520)
+	for i := 0; i < 520; i++ {
+		timestamps = append(timestamps, base.Add(time.Duration(i)*time.Second))
+	}
+	insertAuditLogsInBatch(t, db, timestamps)
+
+	var batches []int
+	seen := make(map[string]bool)
+	err := uc.ExportAuditLogs(ctx, dto.ExportAuditLogsRequest{}, func(auditLogs []dto.AuditLogDTO) error {
+		batches = append(batches, len(auditLogs))
+		for _, auditLog := range auditLogs {
+			seen[auditLog.ID] = true
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{500, 20}, batches)
+	assert.Len(t, seen, 520)
+
+	t.Run("validation errors are returned before any batch", func(t *testing.T) {
+		called := false
+		err := uc.ExportAuditLogs(ctx, dto.ExportAuditLogsRequest{Format: "xml"}, func([]dto.AuditLogDTO) error {
+			called = true
+			return nil
+		})
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.False(t, called)
+	})
+}
+
+// insertAuditLogsInBatch seeds many audit logs in a single transaction
+func insertAuditLogsInBatch(t *testing.T, db *gorm.DB, timestamps []time.Time) {
+	t.Helper()
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		txRepo := persistence.NewAuditLogRepository(tx)
+		for _, timestamp := range timestamps {
+			if err := txRepo.SaveAPIKeyUsage(context.Background(), repository.APIKeyUsageEvent{
+				APIKeyID: "key-1", Endpoint: "/api/sessions", Method: "GET", IPAddress: "10.0.0.1", Timestamp: timestamp,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// ==================== AuditLogCleanupJob Tests ====================
+
+func TestAuditLogCleanupJob_DeletesExpiredEntries(t *testing.T) {
+	db := setupTestDB(t)
+	repo := persistence.NewAuditLogRepository(db)
+	now := time.Now()
+	seedAuditLogs(t, repo, "key-1", "10.0.0.1", now.AddDate(0, 0, -40), now)
+
+	cfg := &config.AuditConfig{
+		RetentionDays:   30,
+		CleanupTime:     "00:00", // Always past, so the job runs right away
+		CleanupInterval: 50 * time.Millisecond,
+	}
+	job := jobs.NewAuditLogCleanupJob(repo, cfg, helpers.CreateTestLogger())
+
+	require.NoError(t, job.Start(context.Background()))
+	require.Eventually(t, func() bool {
+		return job.GetLastRunResult() != nil
+	}, 2*time.Second, 20*time.Millisecond)
+	require.NoError(t, job.Stop())
+
+	result := job.GetLastRunResult()
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(1), result.DeletedCount)
+
+	var count int64
+	db.Table("audit_logs").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestAuditLogCleanupJob_ZeroRetention(t *testing.T) {
+	db := setupTestDB(t)
+	repo := persistence.NewAuditLogRepository(db)
+	seedAuditLogs(t, repo, "key-1", "10.0.0.1", time.Now().AddDate(-5, 0, 0))
+
+	cfg := &config.AuditConfig{
+		RetentionDays:   0, // Keep forever
+		CleanupTime:     "00:00",
+		CleanupInterval: 20 * time.Millisecond,
+	}
+	job := jobs.NewAuditLogCleanupJob(repo, cfg, helpers.CreateTestLogger())
+
+	require.NoError(t, job.Start(context.Background()))
+	time.Sleep(80 * time.Millisecond)
+	require.NoError(t, job.Stop())
+
+	assert.Nil(t, job.GetLastRunResult())
+	var count int64
+	db.Table("audit_logs").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
@@ -2,6 +2,7 @@ package unit
 
 import (
 	"testing"
+	"time"
 
 	"whatspire/internal/infrastructure/config"
 
@@ -56,3 +57,71 @@ func TestConfig_Validate_ValidConfig(t *testing.T) {
 	err = cfg.Validate()
 	assert.NoError(t, err)
 }
+
+func TestConfig_Validate_MediaBackend(t *testing.T) {
+	t.Run("s3 backend requires a bucket and credentials", func(t *testing.T) {
+		v := viper.New()
+		v.Set("media.backend", "s3")
+		_, err := config.LoadWithViper(v)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "media.s3.bucket")
+		assert.Contains(t, err.Error(), "media.s3.access_key_id")
+	})
+
+	t.Run("s3 backend does not need a local path", func(t *testing.T) {
+		v := viper.New()
+		v.Set("media.backend", "s3")
+		v.Set("media.base_path", "")
+		v.Set("media.s3.bucket", "media")
+		v.Set("media.s3.access_key_id", "access-key")
+		v.Set("media.s3.secret_access_key", "secret-key")
+		cfg, err := config.LoadWithViper(v)
+		require.NoError(t, err)
+
+		assert.NoError(t, cfg.Validate())
+		assert.Equal(t, time.Hour, cfg.Media.URLExpiry)
+		assert.Equal(t, "us-east-1", cfg.Media.S3.Region)
+	})
+
+	t.Run("unknown backend is rejected", func(t *testing.T) {
+		v := viper.New()
+		v.Set("media.backend", "ftp")
+		_, err := config.LoadWithViper(v)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "media.backend")
+	})
+}
+
+func TestConfig_Validate_MediaCleanup(t *testing.T) {
+	t.Run("defaults keep media for 30 days once enabled", func(t *testing.T) {
+		v := viper.New()
+		v.Set("media.cleanup.enabled", true)
+		cfg, err := config.LoadWithViper(v)
+		require.NoError(t, err)
+
+		assert.Equal(t, 30, cfg.Media.Cleanup.RetentionDays)
+		assert.Equal(t, int64(0), cfg.Media.Cleanup.MaxTotalSize)
+		assert.Equal(t, 24*time.Hour, cfg.Media.Cleanup.OrphanGracePeriod)
+		assert.Equal(t, time.Hour, cfg.Media.Cleanup.CleanupInterval)
+	})
+
+	t.Run("negative limits are rejected", func(t *testing.T) {
+		v := viper.New()
+		v.Set("media.cleanup.enabled", true)
+		v.Set("media.cleanup.retention_days", -1)
+		v.Set("media.cleanup.max_total_size", -1)
+		v.Set("media.cleanup.cleanup_interval", 0)
+		_, err := config.LoadWithViper(v)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "media.cleanup.retention_days")
+		assert.Contains(t, err.Error(), "media.cleanup.max_total_size")
+		assert.Contains(t, err.Error(), "media.cleanup.cleanup_interval")
+	})
+
+	t.Run("disabled cleanup is not validated", func(t *testing.T) {
+		v := viper.New()
+		v.Set("media.cleanup.retention_days", -1)
+		_, err := config.LoadWithViper(v)
+		assert.NoError(t, err)
+	})
+}
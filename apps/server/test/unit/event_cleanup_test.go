@@ -37,6 +37,14 @@ func (m *mockEventRepository) Count(ctx context.Context, filter repository.Event
 	return 0, nil
 }
 
+func (m *mockEventRepository) LatestSequence(ctx context.Context, sessionID string) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockEventRepository) ListAfterSequence(ctx context.Context, sessionID string, afterSequence int64, limit int) ([]*entity.Event, error) {
+	return nil, nil
+}
+
 func (m *mockEventRepository) DeleteOlderThan(ctx context.Context, timestamp string) (int64, error) {
 	m.deleteOlderThanCalled = true
 	return m.deleteOlderThanCount, m.deleteOlderThanError
@@ -1,7 +1,9 @@
 package unit
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,6 +11,7 @@ import (
 	"time"
 
 	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/persistence"
 	infraWs "whatspire/internal/infrastructure/websocket"
 	"whatspire/internal/presentation/ws"
 	"whatspire/test/helpers"
@@ -321,3 +324,330 @@ func TestEventHandler_GetHub(t *testing.T) {
 	// Verify GetHub returns the correct hub
 	assert.Equal(t, hub, eventHandler.GetHub())
 }
+
+// ==================== Subscription Tests ====================
+
+func createEventHubWithRepository(repo *helpers.MockAPIKeyRepository) *infraWs.EventHub {
+	config := infraWs.EventHubConfig{
+		APIKeyRepository: repo,
+		PingInterval:     30 * time.Second,
+		WriteTimeout:     10 * time.Second,
+		AuthTimeout:      5 * time.Second,
+	}
+	hub := infraWs.NewEventHub(config)
+	go hub.Run()
+	return hub
+}
+
+// authenticateEventClient authenticates a connection and returns the auth response
+func authenticateEventClient(t *testing.T, conn *websocket.Conn, apiKey string) infraWs.AuthResponse {
+	require.NoError(t, conn.WriteJSON(infraWs.AuthMessage{Type: "auth", APIKey: apiKey}))
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var authResp infraWs.AuthResponse
+	require.NoError(t, json.Unmarshal(message, &authResp))
+	return authResp
+}
+
+// subscribeEventClient sends a subscription request and returns the subscription response
+func subscribeEventClient(t *testing.T, conn *websocket.Conn, sessionIDs, eventTypes []string) infraWs.SubscribeResponse {
+	require.NoError(t, conn.WriteJSON(infraWs.SubscribeMessage{
+		Type:       "subscribe",
+		SessionIDs: sessionIDs,
+		EventTypes: eventTypes,
+	}))
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var subscribeResp infraWs.SubscribeResponse
+	require.NoError(t, json.Unmarshal(message, &subscribeResp))
+	return subscribeResp
+}
+
+// readEventTypes reads events until the read times out and returns their session and type
+func readEventTypes(conn *websocket.Conn) []string {
+	var received []string
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(300 * time.Millisecond))
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return received
+		}
+
+		var event entity.Event
+		if json.Unmarshal(message, &event) == nil {
+			received = append(received, event.SessionID+"/"+event.Type.String())
+		}
+	}
+}
+
+func broadcastTestEvents(hub *infraWs.EventHub) {
+	payload := json.RawMessage(`{}`)
+	hub.Broadcast(entity.NewEvent("evt-1", entity.EventTypeMessageReceived, "session-a", payload))
+	hub.Broadcast(entity.NewEvent("evt-2", entity.EventTypePresenceUpdate, "session-a", payload))
+	hub.Broadcast(entity.NewEvent("evt-3", entity.EventTypeMessageSent, "session-b", payload))
+	hub.Broadcast(entity.NewEvent("evt-4", entity.EventTypeConnected, "session-b", payload))
+}
+
+func TestEventHandler_Subscribe_FiltersBySessionAndEventType(t *testing.T) {
+	hub := createEventHubWithConfig("")
+	defer hub.Stop()
+
+	router, _ := setupEventHandlerTestRouter(hub, ws.DefaultEventHandlerConfig())
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	conn := createEventWebSocketConnection(t, server)
+	defer conn.Close()
+
+	require.True(t, authenticateEventClient(t, conn, "").Success)
+
+	subscribeResp := subscribeEventClient(t, conn, []string{"session-a", "session-b"}, []string{"message.*"})
+	assert.Equal(t, "subscribe_response", subscribeResp.Type)
+	require.True(t, subscribeResp.Success)
+	assert.Equal(t, []string{"message.*"}, subscribeResp.EventTypes)
+
+	broadcastTestEvents(hub)
+
+	assert.Equal(t, []string{"session-a/message.received", "session-b/message.sent"}, readEventTypes(conn))
+}
+
+func TestEventHandler_Subscribe_RejectsUnknownEventType(t *testing.T) {
+	hub := createEventHubWithConfig("")
+	defer hub.Stop()
+
+	router, _ := setupEventHandlerTestRouter(hub, ws.DefaultEventHandlerConfig())
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	conn := createEventWebSocketConnection(t, server)
+	defer conn.Close()
+
+	require.True(t, authenticateEventClient(t, conn, "").Success)
+
+	subscribeResp := subscribeEventClient(t, conn, nil, []string{"unknown.*"})
+	assert.False(t, subscribeResp.Success)
+	assert.Contains(t, subscribeResp.Message, "unknown.*")
+}
+
+func TestEventHandler_StoredKeyAuthentication(t *testing.T) {
+	repo := helpers.NewMockAPIKeyRepository()
+	hub := createEventHubWithRepository(repo)
+	defer hub.Stop()
+
+	router, _ := setupEventHandlerTestRouter(hub, ws.DefaultEventHandlerConfig())
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	t.Run("valid key", func(t *testing.T) {
+		key := helpers.CreateTestAPIKey(t, repo, "read", nil)
+
+		conn := createEventWebSocketConnection(t, server)
+		defer conn.Close()
+
+		assert.True(t, authenticateEventClient(t, conn, key.PlainText).Success)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		conn := createEventWebSocketConnection(t, server)
+		defer conn.Close()
+
+		assert.False(t, authenticateEventClient(t, conn, "not-a-stored-key").Success)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		key := helpers.CreateRevokedTestAPIKey(t, repo, "read")
+
+		conn := createEventWebSocketConnection(t, server)
+		defer conn.Close()
+
+		assert.False(t, authenticateEventClient(t, conn, key.PlainText).Success)
+	})
+
+	t.Run("key without events scope", func(t *testing.T) {
+		key := helpers.CreateScopedTestAPIKey(t, repo, "read", nil, []string{entity.ScopeMessagesRead})
+
+		conn := createEventWebSocketConnection(t, server)
+		defer conn.Close()
+
+		assert.False(t, authenticateEventClient(t, conn, key.PlainText).Success)
+	})
+}
+
+func TestEventHandler_SessionScopedKey(t *testing.T) {
+	repo := helpers.NewMockAPIKeyRepository()
+	hub := createEventHubWithRepository(repo)
+	defer hub.Stop()
+
+	router, _ := setupEventHandlerTestRouter(hub, ws.DefaultEventHandlerConfig())
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	key := helpers.CreateScopedTestAPIKey(t, repo, "read", []string{"session-a"}, nil)
+
+	conn := createEventWebSocketConnection(t, server)
+	defer conn.Close()
+
+	require.True(t, authenticateEventClient(t, conn, key.PlainText).Success)
+
+	// Subscribing to another tenant's session is refused
+	subscribeResp := subscribeEventClient(t, conn, []string{"session-b"}, nil)
+	assert.False(t, subscribeResp.Success)
+
+	// Without an explicit subscription only the key's own sessions are delivered
+	time.Sleep(100 * time.Millisecond)
+	broadcastTestEvents(hub)
+	hub.Broadcast(entity.NewEvent("evt-5", entity.EventTypeSyncProgress, "", json.RawMessage(`{}`)))
+
+	assert.Equal(t, []string{"session-a/message.received", "session-a/presence.update"}, readEventTypes(conn))
+}
+
+func TestEventType_Matches(t *testing.T) {
+	assert.True(t, entity.EventTypeMessageReceived.Matches("*"))
+	assert.True(t, entity.EventTypeMessageReceived.Matches("message.*"))
+	assert.True(t, entity.EventTypeMessageReceived.Matches("message.received"))
+	assert.True(t, entity.EventTypeGroupParticipantsAdded.Matches("group.participants.*"))
+	assert.False(t, entity.EventTypeMessageReceived.Matches("message.sent"))
+	assert.False(t, entity.EventTypeMessageReceived.Matches("group.*"))
+	assert.False(t, entity.EventTypeMessageReceived.Matches("mess*"))
+
+	assert.True(t, entity.IsValidEventTypePattern("connection.*"))
+	assert.False(t, entity.IsValidEventTypePattern("unknown.*"))
+	assert.False(t, entity.IsValidEventTypePattern(""))
+}
+
+// ==================== Resume Tests ====================
+
+func TestParseResumeFrom(t *testing.T) {
+	resumeFrom, err := infraWs.ParseResumeFrom("session-a:42, session-b:0")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"session-a": 42, "session-b": 0}, resumeFrom)
+
+	resumeFrom, err = infraWs.ParseResumeFrom("")
+	require.NoError(t, err)
+	assert.Empty(t, resumeFrom)
+
+	for _, invalid := range []string{"42", ":42", "session-a:", "session-a:-1", "session-a:abc"} {
+		_, err := infraWs.ParseResumeFrom(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestEventHandler_Resume_ReplaysMissedEvents(t *testing.T) {
+	db := setupTestDB(t)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // Keep a single in-memory database
+
+	eventRepo := persistence.NewEventRepository(db)
+	ctx := context.Background()
+
+	// Events the client missed while disconnected
+	for i := 1; i <= 3; i++ {
+		event := entity.NewEvent(fmt.Sprintf("stored-%d", i), entity.EventTypeMessageReceived, "session-a", json.RawMessage(`{}`))
+		require.NoError(t, eventRepo.Create(ctx, event))
+	}
+
+	hub := infraWs.NewEventHub(infraWs.EventHubConfig{
+		EventRepository: eventRepo,
+		PingInterval:    30 * time.Second,
+		WriteTimeout:    10 * time.Second,
+		AuthTimeout:     5 * time.Second,
+	})
+	go hub.Run()
+	defer hub.Stop()
+
+	router, _ := setupEventHandlerTestRouter(hub, ws.DefaultEventHandlerConfig())
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/events?resume_from=session-a:1"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.True(t, authenticateEventClient(t, conn, "").Success)
+
+	var sequences []int64
+	for len(sequences) < 2 {
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		_, message, err := conn.ReadMessage()
+		require.NoError(t, err)
+
+		var event entity.Event
+		require.NoError(t, json.Unmarshal(message, &event))
+		sequences = append(sequences, event.Sequence)
+	}
+	assert.Equal(t, []int64{2, 3}, sequences)
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var replayResp infraWs.ReplayResponse
+	require.NoError(t, json.Unmarshal(message, &replayResp))
+	assert.Equal(t, "replay_complete", replayResp.Type)
+	assert.True(t, replayResp.Success)
+	assert.Equal(t, 2, replayResp.Replayed)
+	assert.Equal(t, int64(3), replayResp.LastSequences["session-a"])
+
+	// Live delivery continues after the replay
+	live := entity.NewEvent("live-1", entity.EventTypeMessageSent, "session-a", json.RawMessage(`{}`))
+	require.NoError(t, eventRepo.Create(ctx, live))
+	hub.Broadcast(live)
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, message, err = conn.ReadMessage()
+	require.NoError(t, err)
+
+	var liveEvent entity.Event
+	require.NoError(t, json.Unmarshal(message, &liveEvent))
+	assert.Equal(t, "live-1", liveEvent.ID)
+	assert.Equal(t, int64(4), liveEvent.Sequence)
+}
+
+func TestEventHandler_Resume_InvalidResumeFrom(t *testing.T) {
+	hub := createEventHubWithConfig("")
+	defer hub.Stop()
+
+	router, _ := setupEventHandlerTestRouter(hub, ws.DefaultEventHandlerConfig())
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/events?resume_from=bogus"
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestEventHandler_Resume_WithoutEventRepository(t *testing.T) {
+	hub := createEventHubWithConfig("")
+	defer hub.Stop()
+
+	router, _ := setupEventHandlerTestRouter(hub, ws.DefaultEventHandlerConfig())
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/events?resume_from=session-a:5"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.True(t, authenticateEventClient(t, conn, "").Success)
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var replayResp infraWs.ReplayResponse
+	require.NoError(t, json.Unmarshal(message, &replayResp))
+	assert.Equal(t, "replay_complete", replayResp.Type)
+	assert.False(t, replayResp.Success)
+	assert.Equal(t, int64(5), replayResp.LastSequences["session-a"])
+}
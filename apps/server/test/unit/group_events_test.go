@@ -0,0 +1,222 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/whatsapp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+var (
+	testGroupEventJID = types.NewJID("120363000000000001", types.GroupServer)
+	testOwnJID        = types.NewJID("1000000000", types.DefaultUserServer)
+	testAdminJID      = types.NewJID("2000000000", types.DefaultUserServer)
+	testMemberJID     = types.NewJID("3000000000", types.DefaultUserServer)
+)
+
+func decodeGroupEvent(t *testing.T, event *entity.Event) entity.GroupEvent {
+	t.Helper()
+	var payload entity.GroupEvent
+	require.NoError(t, event.UnmarshalData(&payload))
+	return payload
+}
+
+func TestGroupEventTypes(t *testing.T) {
+	groupTypes := []entity.EventType{
+		entity.EventTypeGroupJoined,
+		entity.EventTypeGroupLeft,
+		entity.EventTypeGroupUpdated,
+		entity.EventTypeGroupParticipantsAdded,
+		entity.EventTypeGroupParticipantsRemoved,
+		entity.EventTypeGroupParticipantsPromoted,
+		entity.EventTypeGroupParticipantsDemoted,
+	}
+
+	for _, eventType := range groupTypes {
+		assert.True(t, eventType.IsValid(), eventType)
+		assert.True(t, eventType.IsGroupEvent(), eventType)
+		assert.False(t, eventType.IsMessageEvent(), eventType)
+	}
+	assert.False(t, entity.EventTypeMessageReceived.IsGroupEvent())
+}
+
+func TestBuildGroupInfoEvents(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	ownJIDs := []types.JID{testOwnJID}
+
+	t.Run("settings changes produce a single updated event", func(t *testing.T) {
+		info := &events.GroupInfo{
+			JID:       testGroupEventJID,
+			Sender:    &testAdminJID,
+			Timestamp: at,
+			Name:      &types.GroupName{Name: "Renamed"},
+			Announce:  &types.GroupAnnounce{IsAnnounce: true},
+			Ephemeral: &types.GroupEphemeral{IsEphemeral: true, DisappearingTimer: 86400},
+		}
+
+		groupEvents, err := whatsapp.BuildGroupInfoEvents("session-1", info, ownJIDs, nil)
+		require.NoError(t, err)
+		require.Len(t, groupEvents, 1)
+		assert.Equal(t, entity.EventTypeGroupUpdated, groupEvents[0].Type)
+		assert.Equal(t, "session-1", groupEvents[0].SessionID)
+
+		payload := decodeGroupEvent(t, groupEvents[0])
+		assert.Equal(t, testGroupEventJID.String(), payload.GroupJID)
+		assert.Equal(t, testAdminJID.String(), payload.ActorJID)
+		require.NotNil(t, payload.Name)
+		assert.Equal(t, "Renamed", *payload.Name)
+		require.NotNil(t, payload.IsAnnounce)
+		assert.True(t, *payload.IsAnnounce)
+		assert.Nil(t, payload.IsLocked)
+		require.NotNil(t, payload.EphemeralTime)
+		assert.Equal(t, 86400, *payload.EphemeralTime)
+		assert.True(t, payload.Timestamp.Equal(at))
+	})
+
+	t.Run("participant changes produce one event per action", func(t *testing.T) {
+		info := &events.GroupInfo{
+			JID:        testGroupEventJID,
+			Sender:     &testAdminJID,
+			Timestamp:  at,
+			Join:       []types.JID{testMemberJID},
+			JoinReason: "invite",
+			Promote:    []types.JID{testMemberJID},
+		}
+
+		groupEvents, err := whatsapp.BuildGroupInfoEvents("session-1", info, ownJIDs, nil)
+		require.NoError(t, err)
+		require.Len(t, groupEvents, 2)
+
+		assert.Equal(t, entity.EventTypeGroupParticipantsAdded, groupEvents[0].Type)
+		added := decodeGroupEvent(t, groupEvents[0])
+		assert.Equal(t, []string{testMemberJID.String()}, added.Participants)
+		assert.Equal(t, "invite", added.Reason)
+		assert.Equal(t, testAdminJID.String(), added.ActorJID)
+
+		assert.Equal(t, entity.EventTypeGroupParticipantsPromoted, groupEvents[1].Type)
+		assert.Empty(t, decodeGroupEvent(t, groupEvents[1]).Reason)
+	})
+
+	t.Run("participants are resolved through the resolver", func(t *testing.T) {
+		lid := types.NewJID("99999", types.HiddenUserServer)
+		info := &events.GroupInfo{JID: testGroupEventJID, Demote: []types.JID{lid}}
+
+		groupEvents, err := whatsapp.BuildGroupInfoEvents("session-1", info, ownJIDs, func(jid types.JID) string {
+			if jid == lid {
+				return testMemberJID.String()
+			}
+			return jid.String()
+		})
+		require.NoError(t, err)
+		require.Len(t, groupEvents, 1)
+		assert.Equal(t, entity.EventTypeGroupParticipantsDemoted, groupEvents[0].Type)
+		assert.Equal(t, []string{testMemberJID.String()}, decodeGroupEvent(t, groupEvents[0]).Participants)
+	})
+
+	t.Run("session removed by an admin also produces a left event", func(t *testing.T) {
+		ownDevice := testOwnJID
+		ownDevice.Device = 12
+		info := &events.GroupInfo{JID: testGroupEventJID, Sender: &testAdminJID, Leave: []types.JID{ownDevice}}
+
+		groupEvents, err := whatsapp.BuildGroupInfoEvents("session-1", info, ownJIDs, nil)
+		require.NoError(t, err)
+		require.Len(t, groupEvents, 2)
+		assert.Equal(t, entity.EventTypeGroupParticipantsRemoved, groupEvents[0].Type)
+		assert.Equal(t, entity.EventTypeGroupLeft, groupEvents[1].Type)
+		assert.Equal(t, whatsapp.GroupLeftReasonRemoved, decodeGroupEvent(t, groupEvents[1]).Reason)
+	})
+
+	t.Run("session leaving by itself", func(t *testing.T) {
+		info := &events.GroupInfo{JID: testGroupEventJID, Sender: &testOwnJID, Leave: []types.JID{testOwnJID}}
+
+		groupEvents, err := whatsapp.BuildGroupInfoEvents("session-1", info, ownJIDs, nil)
+		require.NoError(t, err)
+		require.Len(t, groupEvents, 2)
+		assert.Equal(t, whatsapp.GroupLeftReasonLeft, decodeGroupEvent(t, groupEvents[1]).Reason)
+	})
+
+	t.Run("group deleted", func(t *testing.T) {
+		info := &events.GroupInfo{JID: testGroupEventJID, Sender: &testAdminJID, Delete: &types.GroupDelete{Deleted: true}}
+
+		groupEvents, err := whatsapp.BuildGroupInfoEvents("session-1", info, ownJIDs, nil)
+		require.NoError(t, err)
+		require.Len(t, groupEvents, 1)
+		assert.Equal(t, entity.EventTypeGroupLeft, groupEvents[0].Type)
+		assert.Equal(t, whatsapp.GroupLeftReasonDeleted, decodeGroupEvent(t, groupEvents[0]).Reason)
+	})
+
+	t.Run("unrelated notification produces no events", func(t *testing.T) {
+		info := &events.GroupInfo{JID: testGroupEventJID, NewInviteLink: new(string)}
+
+		groupEvents, err := whatsapp.BuildGroupInfoEvents("session-1", info, ownJIDs, nil)
+		require.NoError(t, err)
+		assert.Empty(t, groupEvents)
+	})
+}
+
+func TestGroupApplyEvent(t *testing.T) {
+	newGroup := func() *entity.Group {
+		group := entity.NewGroup("group-1", testGroupEventJID.String(), "Customers", "session-1")
+		group.Participants = []entity.Participant{
+			*entity.NewParticipant("", testAdminJID.String(), "group-1", entity.ParticipantRoleAdmin),
+			*entity.NewParticipant("", testOwnJID.String(), "group-1", entity.ParticipantRoleMember),
+		}
+		group.MemberCount = 2
+		return group
+	}
+
+	t.Run("settings", func(t *testing.T) {
+		group := newGroup()
+		name, description, locked, timer := "VIP", "", true, 604800
+		group.SetDescription("old")
+
+		group.ApplyEvent(entity.EventTypeGroupUpdated, &entity.GroupEvent{
+			Name: &name, Description: &description, IsLocked: &locked, EphemeralTime: &timer,
+		})
+
+		assert.Equal(t, "VIP", group.Name)
+		assert.Nil(t, group.Description)
+		assert.True(t, group.IsLocked)
+		assert.False(t, group.IsAnnounce)
+		assert.True(t, group.IsEphemeral)
+		assert.Equal(t, 2, group.MemberCount)
+	})
+
+	t.Run("participants added and removed", func(t *testing.T) {
+		group := newGroup()
+
+		group.ApplyEvent(entity.EventTypeGroupParticipantsAdded, &entity.GroupEvent{
+			ActorJID:     testAdminJID.String(),
+			Participants: []string{testMemberJID.String(), testOwnJID.String()},
+		})
+		assert.Equal(t, 3, group.MemberCount)
+		added := group.FindParticipant(testMemberJID.String())
+		require.NotNil(t, added)
+		assert.Equal(t, entity.ParticipantRoleMember, added.Role)
+		require.NotNil(t, added.AddedBy)
+		assert.Equal(t, testAdminJID.String(), *added.AddedBy)
+
+		group.ApplyEvent(entity.EventTypeGroupParticipantsRemoved, &entity.GroupEvent{
+			Participants: []string{testAdminJID.String()},
+		})
+		assert.Equal(t, 2, group.MemberCount)
+		assert.Nil(t, group.FindParticipant(testAdminJID.String()))
+	})
+
+	t.Run("promote and demote", func(t *testing.T) {
+		group := newGroup()
+		assert.False(t, group.HasAdmin(testOwnJID.String()))
+
+		group.ApplyEvent(entity.EventTypeGroupParticipantsPromoted, &entity.GroupEvent{Participants: []string{testOwnJID.String()}})
+		assert.True(t, group.HasAdmin(testOwnJID.String()))
+
+		group.ApplyEvent(entity.EventTypeGroupParticipantsDemoted, &entity.GroupEvent{Participants: []string{testOwnJID.String()}})
+		assert.False(t, group.HasAdmin(testOwnJID.String()))
+	})
+}
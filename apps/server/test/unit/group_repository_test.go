@@ -0,0 +1,185 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== GroupRepository Tests ====================
+
+func newTestStoredGroup(sessionID, jid, name string, participants ...string) *entity.Group {
+	group := entity.NewGroup("", jid, name, sessionID)
+	for _, participant := range participants {
+		group.Participants = append(group.Participants, *entity.NewParticipant("", participant, "", entity.ParticipantRoleMember))
+	}
+	group.MemberCount = len(group.Participants)
+	return group
+}
+
+func TestGroupRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewGroupRepository(db)
+
+	clean := func() {
+		db.Exec("DELETE FROM group_participants WHERE 1=1")
+		db.Exec("DELETE FROM groups WHERE 1=1")
+	}
+
+	t.Run("Upsert and FindByJID", func(t *testing.T) {
+		clean()
+
+		group := newTestStoredGroup("session-1", "1@g.us", "Customers", "111@s.whatsapp.net", "222@s.whatsapp.net")
+		group.Participants[0].Role = entity.ParticipantRoleAdmin
+		require.NoError(t, repo.Upsert(ctx, group))
+		assert.NotEmpty(t, group.ID)
+
+		found, err := repo.FindByJID(ctx, "session-1", "1@g.us")
+		require.NoError(t, err)
+		assert.Equal(t, group.ID, found.ID)
+		assert.Equal(t, "Customers", found.Name)
+		assert.Equal(t, 2, found.MemberCount)
+		require.Len(t, found.Participants, 2)
+		assert.Equal(t, found.ID, found.Participants[0].GroupID)
+		require.NotNil(t, found.FindParticipant("111@s.whatsapp.net"))
+		assert.Equal(t, entity.ParticipantRoleAdmin, found.FindParticipant("111@s.whatsapp.net").Role)
+	})
+
+	t.Run("Upsert keeps the ID and replaces participants", func(t *testing.T) {
+		clean()
+
+		group := newTestStoredGroup("session-1", "1@g.us", "Customers", "111@s.whatsapp.net", "222@s.whatsapp.net")
+		require.NoError(t, repo.Upsert(ctx, group))
+		firstID := group.ID
+
+		updated := newTestStoredGroup("session-1", "1@g.us", "VIP Customers", "333@s.whatsapp.net")
+		require.NoError(t, repo.Upsert(ctx, updated))
+		assert.Equal(t, firstID, updated.ID)
+
+		found, err := repo.FindByJID(ctx, "session-1", "1@g.us")
+		require.NoError(t, err)
+		assert.Equal(t, "VIP Customers", found.Name)
+		require.Len(t, found.Participants, 1)
+		assert.Equal(t, "333@s.whatsapp.net", found.Participants[0].JID)
+	})
+
+	t.Run("Same group JID in different sessions is stored separately", func(t *testing.T) {
+		clean()
+
+		require.NoError(t, repo.Upsert(ctx, newTestStoredGroup("session-1", "1@g.us", "One")))
+		require.NoError(t, repo.Upsert(ctx, newTestStoredGroup("session-2", "1@g.us", "Two")))
+
+		found, err := repo.FindByJID(ctx, "session-2", "1@g.us")
+		require.NoError(t, err)
+		assert.Equal(t, "Two", found.Name)
+	})
+
+	t.Run("FindByJID returns not found", func(t *testing.T) {
+		clean()
+
+		_, err := repo.FindByJID(ctx, "session-1", "missing@g.us")
+		assert.ErrorIs(t, err, errors.ErrGroupNotFound)
+	})
+
+	t.Run("List filters and paginates", func(t *testing.T) {
+		clean()
+
+		archived := newTestStoredGroup("session-1", "1@g.us", "Archived Sales")
+		archived.IsArchived = true
+		muted := newTestStoredGroup("session-1", "2@g.us", "Muted Support")
+		muted.IsMuted = true
+		admin := newTestStoredGroup("session-1", "3@g.us", "sales team")
+		admin.IsAdmin = true
+		other := newTestStoredGroup("session-2", "4@g.us", "Sales elsewhere")
+		for _, group := range []*entity.Group{archived, muted, admin, other} {
+			require.NoError(t, repo.Upsert(ctx, group))
+		}
+
+		name := "SALES"
+		groups, err := repo.List(ctx, repository.GroupFilter{SessionID: "session-1", Name: &name})
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		assert.Equal(t, "Archived Sales", groups[0].Name)
+		assert.Equal(t, "sales team", groups[1].Name)
+		assert.Empty(t, groups[0].Participants)
+
+		notArchived := false
+		count, err := repo.Count(ctx, repository.GroupFilter{SessionID: "session-1", IsArchived: &notArchived})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		isMuted := true
+		groups, err = repo.List(ctx, repository.GroupFilter{SessionID: "session-1", IsMuted: &isMuted})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, "2@g.us", groups[0].JID)
+
+		groups, err = repo.List(ctx, repository.GroupFilter{SessionID: "session-1", AdminOnly: true})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, "3@g.us", groups[0].JID)
+
+		groups, err = repo.List(ctx, repository.GroupFilter{SessionID: "session-1", Limit: 2, Offset: 2})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, "sales team", groups[0].Name)
+	})
+
+	t.Run("Name filter matches wildcards literally", func(t *testing.T) {
+		clean()
+
+		require.NoError(t, repo.Upsert(ctx, newTestStoredGroup("session-1", "1@g.us", "100% Club")))
+		require.NoError(t, repo.Upsert(ctx, newTestStoredGroup("session-1", "2@g.us", "1000 Club")))
+
+		name := "0%"
+		groups, err := repo.List(ctx, repository.GroupFilter{SessionID: "session-1", Name: &name})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, "100% Club", groups[0].Name)
+	})
+
+	t.Run("Delete removes the group", func(t *testing.T) {
+		clean()
+
+		require.NoError(t, repo.Upsert(ctx, newTestStoredGroup("session-1", "1@g.us", "One", "111@s.whatsapp.net")))
+		require.NoError(t, repo.Delete(ctx, "session-1", "1@g.us"))
+
+		_, err := repo.FindByJID(ctx, "session-1", "1@g.us")
+		assert.ErrorIs(t, err, errors.ErrGroupNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, "session-1", "1@g.us"), errors.ErrGroupNotFound)
+
+		var participants int64
+		db.Table("group_participants").Count(&participants)
+		assert.Zero(t, participants)
+	})
+
+	t.Run("DeleteExcept removes stale groups of the session only", func(t *testing.T) {
+		clean()
+
+		require.NoError(t, repo.Upsert(ctx, newTestStoredGroup("session-1", "1@g.us", "Kept")))
+		require.NoError(t, repo.Upsert(ctx, newTestStoredGroup("session-1", "2@g.us", "Stale", "111@s.whatsapp.net")))
+		require.NoError(t, repo.Upsert(ctx, newTestStoredGroup("session-2", "3@g.us", "Other session")))
+
+		deleted, err := repo.DeleteExcept(ctx, "session-1", []string{"1@g.us"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		_, err = repo.FindByJID(ctx, "session-1", "2@g.us")
+		assert.ErrorIs(t, err, errors.ErrGroupNotFound)
+		_, err = repo.FindByJID(ctx, "session-2", "3@g.us")
+		assert.NoError(t, err)
+
+		// An empty keep list removes every group of the session
+		deleted, err = repo.DeleteExcept(ctx, "session-1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+	})
+}
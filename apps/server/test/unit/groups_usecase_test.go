@@ -2,12 +2,16 @@ package unit
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"whatspire/internal/application/dto"
 	"whatspire/internal/application/usecase"
 	"whatspire/internal/domain/entity"
 	"whatspire/internal/domain/errors"
+	"whatspire/internal/infrastructure/persistence"
+	"whatspire/test/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -59,7 +63,7 @@ func TestGroupsUseCase_SyncGroups_Success(t *testing.T) {
 	}
 	fetcher.groups["session-1"] = testGroups
 
-	uc := usecase.NewGroupsUseCase(fetcher)
+	uc := usecase.NewGroupsUseCase(fetcher, nil, nil)
 
 	result, err := uc.SyncGroups(context.Background(), "session-1")
 
@@ -74,7 +78,7 @@ func TestGroupsUseCase_SyncGroups_EmptyGroups(t *testing.T) {
 	fetcher := NewGroupFetcherMock()
 	fetcher.groups["session-1"] = []*entity.Group{}
 
-	uc := usecase.NewGroupsUseCase(fetcher)
+	uc := usecase.NewGroupsUseCase(fetcher, nil, nil)
 
 	result, err := uc.SyncGroups(context.Background(), "session-1")
 
@@ -91,7 +95,7 @@ func TestGroupsUseCase_SyncGroups_SessionNotFound(t *testing.T) {
 		return nil, errors.ErrSessionNotFound
 	}
 
-	uc := usecase.NewGroupsUseCase(fetcher)
+	uc := usecase.NewGroupsUseCase(fetcher, nil, nil)
 
 	result, err := uc.SyncGroups(context.Background(), "non-existent-session")
 
@@ -105,7 +109,7 @@ func TestGroupsUseCase_SyncGroups_SessionDisconnected(t *testing.T) {
 		return nil, errors.ErrDisconnected
 	}
 
-	uc := usecase.NewGroupsUseCase(fetcher)
+	uc := usecase.NewGroupsUseCase(fetcher, nil, nil)
 
 	result, err := uc.SyncGroups(context.Background(), "disconnected-session")
 
@@ -114,7 +118,7 @@ func TestGroupsUseCase_SyncGroups_SessionDisconnected(t *testing.T) {
 }
 
 func TestGroupsUseCase_SyncGroups_NilFetcher(t *testing.T) {
-	uc := usecase.NewGroupsUseCase(nil)
+	uc := usecase.NewGroupsUseCase(nil, nil, nil)
 
 	result, err := uc.SyncGroups(context.Background(), "session-1")
 
@@ -132,7 +136,7 @@ func TestGroupsUseCase_SyncGroups_InternalError(t *testing.T) {
 		return nil, errors.ErrInternal.WithMessage("database connection failed")
 	}
 
-	uc := usecase.NewGroupsUseCase(fetcher)
+	uc := usecase.NewGroupsUseCase(fetcher, nil, nil)
 
 	result, err := uc.SyncGroups(context.Background(), "session-1")
 
@@ -149,7 +153,7 @@ func TestGroupsUseCase_SyncGroups_NonDomainError(t *testing.T) {
 		return nil, context.DeadlineExceeded
 	}
 
-	uc := usecase.NewGroupsUseCase(fetcher)
+	uc := usecase.NewGroupsUseCase(fetcher, nil, nil)
 
 	result, err := uc.SyncGroups(context.Background(), "session-1")
 
@@ -176,7 +180,7 @@ func TestGroupsUseCase_SyncGroups_LargeGroupCount(t *testing.T) {
 	}
 	fetcher.groups["session-1"] = testGroups
 
-	uc := usecase.NewGroupsUseCase(fetcher)
+	uc := usecase.NewGroupsUseCase(fetcher, nil, nil)
 
 	result, err := uc.SyncGroups(context.Background(), "session-1")
 
@@ -192,7 +196,7 @@ func TestGroupsUseCase_SyncGroups_ContextCancelled(t *testing.T) {
 		return nil, ctx.Err()
 	}
 
-	uc := usecase.NewGroupsUseCase(fetcher)
+	uc := usecase.NewGroupsUseCase(fetcher, nil, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
@@ -340,7 +344,7 @@ func TestGroupFetcher_ReturnsGroupsWithFilterFields(t *testing.T) {
 	}
 	fetcher.groups["session-1"] = testGroups
 
-	uc := usecase.NewGroupsUseCase(fetcher)
+	uc := usecase.NewGroupsUseCase(fetcher, nil, nil)
 
 	result, err := uc.SyncGroups(context.Background(), "session-1")
 
@@ -355,3 +359,213 @@ func TestGroupFetcher_ReturnsGroupsWithFilterFields(t *testing.T) {
 	assert.True(t, result.Groups[1].IsArchived)
 	assert.True(t, result.Groups[1].IsMuted)
 }
+
+// ==================== Local Group Store Tests ====================
+
+func TestGroupsUseCase_SyncGroups_PersistsGroups(t *testing.T) {
+	ctx := context.Background()
+	repo := persistence.NewGroupRepository(setupTestDB(t))
+
+	// A group the session has since left
+	require.NoError(t, repo.Upsert(ctx, entity.NewGroup("", "stale@g.us", "Old Group", "session-1")))
+
+	fetcher := NewGroupFetcherMock()
+	fetcher.groups["session-1"] = []*entity.Group{
+		entity.NewGroup("", "1@g.us", "Customers", "session-1"),
+		entity.NewGroup("", "2@g.us", "Suppliers", "session-1"),
+	}
+
+	uc := usecase.NewGroupsUseCase(fetcher, nil, repo)
+
+	result, err := uc.SyncGroups(ctx, "session-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Synced)
+	assert.Empty(t, result.Errors)
+
+	stored, err := repo.FindByJID(ctx, "session-1", "1@g.us")
+	require.NoError(t, err)
+	assert.Equal(t, "Customers", stored.Name)
+	assert.NotNil(t, stored.LastSyncAt)
+
+	_, err = repo.FindByJID(ctx, "session-1", "stale@g.us")
+	assert.ErrorIs(t, err, errors.ErrGroupNotFound)
+}
+
+func TestGroupsUseCase_ListGroups(t *testing.T) {
+	ctx := context.Background()
+	repo := persistence.NewGroupRepository(setupTestDB(t))
+
+	for i, name := range []string{"Alpha", "Bravo", "Charlie"} {
+		group := entity.NewGroup("", fmt.Sprintf("%d@g.us", i), name, "session-1")
+		group.IsAdmin = name != "Bravo"
+		require.NoError(t, repo.Upsert(ctx, group))
+	}
+
+	uc := usecase.NewGroupsUseCase(nil, nil, repo)
+
+	t.Run("paginates with defaults", func(t *testing.T) {
+		result, err := uc.ListGroups(ctx, dto.ListGroupsRequest{SessionID: "session-1", Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, result.Groups, 2)
+		assert.Equal(t, "Alpha", result.Groups[0].Name)
+		assert.Equal(t, 1, result.Pagination.Page)
+		assert.Equal(t, int64(3), result.Pagination.Total)
+		assert.Equal(t, 2, result.Pagination.TotalPages)
+	})
+
+	t.Run("filters admin groups", func(t *testing.T) {
+		result, err := uc.ListGroups(ctx, dto.ListGroupsRequest{SessionID: "session-1", AdminOnly: true})
+		require.NoError(t, err)
+		require.Len(t, result.Groups, 2)
+		assert.Equal(t, "Charlie", result.Groups[1].Name)
+		assert.Equal(t, 50, result.Pagination.Limit)
+	})
+
+	t.Run("requires a repository", func(t *testing.T) {
+		_, err := usecase.NewGroupsUseCase(nil, nil, nil).ListGroups(ctx, dto.ListGroupsRequest{SessionID: "session-1"})
+		assert.ErrorIs(t, err, errors.ErrInternal)
+	})
+}
+
+// ==================== Group Administration Tests ====================
+
+func newAdminTestGroup(manager *mocks.GroupManagerMock) *entity.Group {
+	group := entity.NewGroup("group-1", "120363000000000001@g.us", "Customers", "session-1")
+	manager.AddGroup(group)
+	return group
+}
+
+func TestGroupsUseCase_UpdateGroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("applies settings and returns the refreshed group", func(t *testing.T) {
+		manager := mocks.NewGroupManagerMock()
+		group := newAdminTestGroup(manager)
+		uc := usecase.NewGroupsUseCase(nil, manager, nil)
+
+		name := "VIP Customers"
+		description := "Priority support"
+		announce := true
+		ephemeral := 604800
+
+		updated, err := uc.UpdateGroup(ctx, dto.UpdateGroupRequest{
+			SessionID:        "session-1",
+			GroupJID:         group.JID,
+			Name:             &name,
+			Description:      &description,
+			IsAnnounce:       &announce,
+			EphemeralSeconds: &ephemeral,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, name, updated.Name)
+		require.NotNil(t, updated.Description)
+		assert.Equal(t, description, *updated.Description)
+		assert.True(t, updated.IsAnnounce)
+		assert.False(t, updated.IsLocked, "omitted settings must be left untouched")
+		assert.True(t, updated.IsEphemeral)
+		require.NotNil(t, updated.EphemeralTime)
+		assert.Equal(t, ephemeral, *updated.EphemeralTime)
+	})
+
+	t.Run("passes only provided settings to the manager", func(t *testing.T) {
+		manager := mocks.NewGroupManagerMock()
+		group := newAdminTestGroup(manager)
+		uc := usecase.NewGroupsUseCase(nil, manager, nil)
+
+		var received entity.GroupSettingsUpdate
+		manager.UpdateFn = func(ctx context.Context, sessionID, groupJID string, update entity.GroupSettingsUpdate) error {
+			received = update
+			return nil
+		}
+
+		off := 0
+		_, err := uc.UpdateGroup(ctx, dto.UpdateGroupRequest{SessionID: "session-1", GroupJID: group.JID, EphemeralSeconds: &off})
+		require.NoError(t, err)
+
+		assert.Nil(t, received.Name)
+		assert.Nil(t, received.IsLocked)
+		require.NotNil(t, received.Ephemeral)
+		assert.Equal(t, time.Duration(0), *received.Ephemeral)
+	})
+
+	t.Run("rejects an empty update", func(t *testing.T) {
+		manager := mocks.NewGroupManagerMock()
+		group := newAdminTestGroup(manager)
+		uc := usecase.NewGroupsUseCase(nil, manager, nil)
+
+		_, err := uc.UpdateGroup(ctx, dto.UpdateGroupRequest{SessionID: "session-1", GroupJID: group.JID})
+		assert.ErrorIs(t, err, errors.ErrValidationFailed)
+	})
+}
+
+func TestGroupsUseCase_UpdateParticipants(t *testing.T) {
+	ctx := context.Background()
+	manager := mocks.NewGroupManagerMock()
+	group := newAdminTestGroup(manager)
+	uc := usecase.NewGroupsUseCase(nil, manager, nil)
+
+	// The second participant's privacy settings forbid adding them
+	manager.ParticipantErrorFn = func(jid string, action entity.ParticipantAction) int {
+		if jid == "+2222222222" {
+			return 403
+		}
+		return 0
+	}
+
+	results, err := uc.UpdateParticipants(ctx, dto.UpdateGroupParticipantsRequest{
+		SessionID:    "session-1",
+		GroupJID:     group.JID,
+		Action:       "add",
+		Participants: []string{"+1111111111", "+2222222222"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Success)
+	assert.False(t, results[1].Success)
+	assert.Equal(t, 403, results[1].ErrorCode)
+
+	_, err = uc.UpdateParticipants(ctx, dto.UpdateGroupParticipantsRequest{
+		SessionID:    "session-1",
+		GroupJID:     group.JID,
+		Action:       "kick",
+		Participants: []string{"+1111111111"},
+	})
+	assert.ErrorIs(t, err, errors.ErrValidationFailed)
+}
+
+func TestGroupsUseCase_InviteLinks(t *testing.T) {
+	ctx := context.Background()
+	manager := mocks.NewGroupManagerMock()
+	group := newAdminTestGroup(manager)
+	uc := usecase.NewGroupsUseCase(nil, manager, nil)
+
+	link, err := uc.GetInviteLink(ctx, "session-1", group.JID)
+	require.NoError(t, err)
+
+	again, err := uc.GetInviteLink(ctx, "session-1", group.JID)
+	require.NoError(t, err)
+	assert.Equal(t, link, again, "getting the link must not reset it")
+
+	revoked, err := uc.RevokeInviteLink(ctx, "session-1", group.JID)
+	require.NoError(t, err)
+	assert.NotEqual(t, link, revoked)
+
+	_, err = uc.JoinGroup(ctx, dto.JoinGroupRequest{SessionID: "session-2", InviteLink: link})
+	assert.ErrorIs(t, err, errors.ErrInvalidInviteLink)
+
+	joined, err := uc.JoinGroup(ctx, dto.JoinGroupRequest{SessionID: "session-2", InviteLink: revoked})
+	require.NoError(t, err)
+	assert.Equal(t, group.JID, joined)
+}
+
+func TestGroupsUseCase_NilGroupManager(t *testing.T) {
+	ctx := context.Background()
+	uc := usecase.NewGroupsUseCase(NewGroupFetcherMock(), nil, nil)
+
+	_, err := uc.CreateGroup(ctx, dto.CreateGroupRequest{SessionID: "session-1", Name: "Team", Participants: []string{"+1111111111"}})
+	assert.ErrorIs(t, err, errors.ErrInternal)
+
+	err = uc.LeaveGroup(ctx, "session-1", "120363000000000001@g.us")
+	assert.ErrorIs(t, err, errors.ErrInternal)
+}
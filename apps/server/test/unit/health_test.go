@@ -67,9 +67,20 @@ func (m *MockWhatsAppClient) SetHistorySyncConfig(sessionID string, enabled, ful
 func (m *MockWhatsAppClient) GetHistorySyncConfig(sessionID string) (enabled, fullSync bool, since string) {
 	return false, false, ""
 }
+func (m *MockWhatsAppClient) SetSessionSettings(sessionID string, settings entity.SessionSettings) {
+}
+func (m *MockWhatsAppClient) GetSessionSettings(sessionID string) entity.SessionSettings {
+	return entity.DefaultSessionSettings()
+}
 func (m *MockWhatsAppClient) SendReaction(ctx context.Context, sessionID, chatJID, messageID, emoji string) error {
 	return nil
 }
+func (m *MockWhatsAppClient) EditMessage(ctx context.Context, sessionID, chatJID, messageID string, messageType entity.MessageType, text string) error {
+	return nil
+}
+func (m *MockWhatsAppClient) RevokeMessage(ctx context.Context, sessionID, chatJID, messageID string) error {
+	return nil
+}
 func (m *MockWhatsAppClient) SendReadReceipt(ctx context.Context, sessionID, chatJID string, messageIDs []string) error {
 	return nil
 }
@@ -0,0 +1,241 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/config"
+	"whatspire/internal/infrastructure/jobs"
+	"whatspire/internal/infrastructure/metrics"
+	"whatspire/internal/infrastructure/persistence"
+	"whatspire/internal/infrastructure/storage"
+	"whatspire/test/helpers"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mediaCleanupEnv holds local media storage and the repositories the media cleanup job reads
+type mediaCleanupEnv struct {
+	basePath    string
+	storage     *storage.LocalMediaStorage
+	sessionRepo *persistence.SessionRepository
+	eventRepo   *persistence.EventRepository
+}
+
+func setupMediaCleanupEnv(t *testing.T) *mediaCleanupEnv {
+	basePath := t.TempDir()
+	mediaStorage, err := storage.NewLocalMediaStorage(repository.MediaStorageConfig{
+		BasePath:    basePath,
+		BaseURL:     "http://localhost:8080/api/media",
+		MaxFileSize: 1024 * 1024,
+	})
+	require.NoError(t, err)
+
+	db := setupTestDB(t)
+	return &mediaCleanupEnv{
+		basePath:    basePath,
+		storage:     mediaStorage,
+		sessionRepo: persistence.NewSessionRepository(db),
+		eventRepo:   persistence.NewEventRepository(db),
+	}
+}
+
+// store saves media of the given size, stored age ago and last served accessedAgo ago
+func (env *mediaCleanupEnv) store(t *testing.T, sessionID, messageID string, size int, age, accessedAgo time.Duration) string {
+	filePath, _, err := env.storage.DownloadAndStore(context.Background(), sessionID, messageID, bytes.NewReader(make([]byte, size)), "image/jpeg", "jpg")
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, os.Chtimes(filepath.Join(env.basePath, filePath), now.Add(-accessedAgo), now.Add(-age)))
+	return filePath
+}
+
+func (env *mediaCleanupEnv) exists(filePath string) bool {
+	_, err := os.Stat(filepath.Join(env.basePath, filePath))
+	return err == nil
+}
+
+func (env *mediaCleanupEnv) job(cfg *config.MediaCleanupConfig, eventRepo repository.EventRepository, m *metrics.Metrics) *jobs.MediaCleanupJob {
+	return jobs.NewMediaCleanupJob(env.storage, env.sessionRepo, eventRepo, cfg, m, helpers.CreateTestLogger())
+}
+
+// candidateReasons maps the paths selected for deletion to why they were selected
+func candidateReasons(report *entity.MediaCleanupReport) map[string]entity.MediaCleanupReason {
+	reasons := make(map[string]entity.MediaCleanupReason)
+	for _, candidate := range report.Candidates {
+		reasons[candidate.Path] = candidate.Reason
+	}
+	return reasons
+}
+
+func TestMediaCleanupJob_Retention(t *testing.T) {
+	ctx := context.Background()
+	env := setupMediaCleanupEnv(t)
+	day := 24 * time.Hour
+
+	// session-2 keeps its media for a week instead of the default month
+	session := entity.NewSession("session-2", "Short retention")
+	session.Settings.MediaRetentionDays = 7
+	require.NoError(t, env.sessionRepo.Create(ctx, session))
+
+	expired := env.store(t, "session-1", "msg-1", 10, 40*day, 0)
+	recent := env.store(t, "session-1", "msg-2", 10, 10*day, 0)
+	expiredForSession := env.store(t, "session-2", "msg-3", 10, 10*day, 0)
+	recentForSession := env.store(t, "session-2", "msg-4", 10, 2*day, 0)
+
+	job := env.job(&config.MediaCleanupConfig{RetentionDays: 30}, nil, nil)
+
+	t.Run("dry run reports without deleting", func(t *testing.T) {
+		report, err := job.PlanCleanup(ctx)
+		require.NoError(t, err)
+
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 4, report.ScannedFiles)
+		assert.Equal(t, map[string]entity.MediaCleanupReason{
+			expired:           entity.MediaCleanupReasonRetention,
+			expiredForSession: entity.MediaCleanupReasonRetention,
+		}, candidateReasons(report))
+		assert.Equal(t, int64(20), report.RemainingBytes)
+		assert.Zero(t, report.DeletedFiles)
+		assert.True(t, env.exists(expired))
+		assert.Nil(t, job.GetLastRunResult())
+	})
+
+	t.Run("run deletes expired media", func(t *testing.T) {
+		report, err := job.RunCleanup(ctx)
+		require.NoError(t, err)
+
+		assert.False(t, report.DryRun)
+		assert.Equal(t, 2, report.DeletedFiles)
+		assert.Equal(t, int64(20), report.DeletedBytes)
+		assert.False(t, env.exists(expired))
+		assert.False(t, env.exists(expiredForSession))
+		assert.True(t, env.exists(recent))
+		assert.True(t, env.exists(recentForSession))
+		assert.Equal(t, report, job.GetLastRunResult())
+	})
+
+	t.Run("zero retention keeps media forever", func(t *testing.T) {
+		old := env.store(t, "session-1", "msg-5", 10, 400*day, 0)
+		oldForSession := env.store(t, "session-2", "msg-6", 10, 10*day, 0)
+
+		report, err := env.job(&config.MediaCleanupConfig{}, nil, nil).PlanCleanup(ctx)
+		require.NoError(t, err)
+		assert.NotContains(t, candidateReasons(report), old)
+		assert.Contains(t, candidateReasons(report), oldForSession, "session retention still applies")
+	})
+}
+
+func TestMediaCleanupJob_DiskBudget(t *testing.T) {
+	ctx := context.Background()
+	env := setupMediaCleanupEnv(t)
+
+	// Least recently used first, regardless of when the media was stored
+	leastRecent := env.store(t, "session-1", "msg-1", 100, time.Hour, 3*time.Hour)
+	middle := env.store(t, "session-1", "msg-2", 100, 5*time.Hour, 2*time.Hour)
+	mostRecent := env.store(t, "session-1", "msg-3", 100, 6*time.Hour, time.Minute)
+
+	job := env.job(&config.MediaCleanupConfig{MaxTotalSize: 150}, nil, nil)
+
+	report, err := job.PlanCleanup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]entity.MediaCleanupReason{
+		leastRecent: entity.MediaCleanupReasonDiskBudget,
+		middle:      entity.MediaCleanupReasonDiskBudget,
+	}, candidateReasons(report))
+	assert.Equal(t, int64(100), report.RemainingBytes)
+
+	report, err = job.RunCleanup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.DeletedFiles)
+	assert.True(t, env.exists(mostRecent))
+
+	t.Run("media within the budget is kept", func(t *testing.T) {
+		report, err := job.PlanCleanup(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, report.Candidates)
+	})
+}
+
+func TestMediaCleanupJob_OrphanSweep(t *testing.T) {
+	ctx := context.Background()
+	env := setupMediaCleanupEnv(t)
+
+	event, err := entity.NewEventWithPayload("event-1", entity.EventTypeMessageReceived, "session-1", map[string]string{"messageId": "msg-1"})
+	require.NoError(t, err)
+	require.NoError(t, env.eventRepo.Create(ctx, event))
+
+	referenced := env.store(t, "session-1", "msg-1", 10, 2*time.Hour, 0)
+	orphaned := env.store(t, "session-1", "msg-2", 10, 2*time.Hour, 0)
+	justStored := env.store(t, "session-1", "msg-3", 10, time.Minute, 0)
+	otherSession := env.store(t, "session-2", "msg-1", 10, 2*time.Hour, 0)
+
+	cfg := &config.MediaCleanupConfig{OrphanSweep: true, OrphanGracePeriod: time.Hour}
+
+	t.Run("media without an event is orphaned", func(t *testing.T) {
+		report, err := env.job(cfg, env.eventRepo, nil).PlanCleanup(ctx)
+		require.NoError(t, err)
+
+		assert.True(t, report.OrphanSweep)
+		reasons := candidateReasons(report)
+		assert.Equal(t, entity.MediaCleanupReasonOrphan, reasons[orphaned])
+		assert.Equal(t, entity.MediaCleanupReasonOrphan, reasons[otherSession], "events only reference media of their own session")
+		assert.NotContains(t, reasons, referenced)
+		assert.NotContains(t, reasons, justStored, "media within the grace period is kept")
+	})
+
+	t.Run("sweep is skipped when events are not stored", func(t *testing.T) {
+		report, err := env.job(cfg, nil, nil).PlanCleanup(ctx)
+		require.NoError(t, err)
+
+		assert.False(t, report.OrphanSweep)
+		assert.Empty(t, report.Candidates)
+	})
+}
+
+func TestMediaCleanupJob_Metrics(t *testing.T) {
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+	m := metrics.NewMetrics(metrics.Config{Namespace: "test_media_cleanup"})
+
+	ctx := context.Background()
+	env := setupMediaCleanupEnv(t)
+	env.store(t, "session-1", "msg-1", 100, 40*24*time.Hour, 0)
+	env.store(t, "session-1", "msg-2", 100, time.Hour, 2*time.Hour)
+	env.store(t, "session-1", "msg-3", 100, time.Hour, 0)
+
+	job := env.job(&config.MediaCleanupConfig{RetentionDays: 30, MaxTotalSize: 100}, nil, m)
+
+	_, err := job.PlanCleanup(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, testutil.ToFloat64(m.MediaCleanupRuns.WithLabelValues("success")), "dry runs are not recorded")
+
+	_, err = job.RunCleanup(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.MediaCleanupRuns.WithLabelValues("success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.MediaFilesDeleted.WithLabelValues("retention")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.MediaFilesDeleted.WithLabelValues("disk_budget")))
+	assert.Equal(t, float64(200), testutil.ToFloat64(m.MediaBytesDeleted.WithLabelValues("retention"))+testutil.ToFloat64(m.MediaBytesDeleted.WithLabelValues("disk_budget")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.MediaStoredFiles))
+	assert.Equal(t, float64(100), testutil.ToFloat64(m.MediaStoredBytes))
+}
+
+func TestMediaCleanupJob_StartStop(t *testing.T) {
+	env := setupMediaCleanupEnv(t)
+	job := env.job(&config.MediaCleanupConfig{Enabled: true, RetentionDays: 30, CleanupInterval: time.Hour}, nil, nil)
+
+	require.NoError(t, job.Start(context.Background()))
+	assert.True(t, job.IsRunning())
+
+	require.NoError(t, job.Stop())
+	assert.False(t, job.IsRunning())
+}
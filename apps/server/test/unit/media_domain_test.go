@@ -24,6 +24,7 @@ func TestMediaType_IsValid(t *testing.T) {
 		{"document is valid", valueobject.MediaTypeDocument, true},
 		{"audio is valid", valueobject.MediaTypeAudio, true},
 		{"video is valid", valueobject.MediaTypeVideo, true},
+		{"sticker is valid", valueobject.MediaTypeSticker, true},
 		{"empty is invalid", valueobject.MediaType(""), false},
 		{"unknown is invalid", valueobject.MediaType("unknown"), false},
 	}
@@ -85,6 +86,7 @@ func TestMediaConstraints_GetMaxSize(t *testing.T) {
 		{"document", valueobject.MediaTypeDocument, valueobject.MaxDocumentSize},
 		{"audio", valueobject.MediaTypeAudio, valueobject.MaxAudioSize},
 		{"video", valueobject.MediaTypeVideo, valueobject.MaxVideoSize},
+		{"sticker", valueobject.MediaTypeSticker, valueobject.MaxStickerSize},
 		{"unknown", valueobject.MediaType("unknown"), 0},
 	}
 
@@ -102,6 +104,7 @@ func TestMediaConstraints_GetAllowedTypes(t *testing.T) {
 	assert.Equal(t, valueobject.AllowedDocumentTypes, mc.GetAllowedTypes(valueobject.MediaTypeDocument))
 	assert.Equal(t, valueobject.AllowedAudioTypes, mc.GetAllowedTypes(valueobject.MediaTypeAudio))
 	assert.Equal(t, valueobject.AllowedVideoTypes, mc.GetAllowedTypes(valueobject.MediaTypeVideo))
+	assert.Equal(t, valueobject.AllowedStickerTypes, mc.GetAllowedTypes(valueobject.MediaTypeSticker))
 	assert.Nil(t, mc.GetAllowedTypes(valueobject.MediaType("unknown")))
 }
 
@@ -0,0 +1,172 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/valueobject"
+	"whatspire/internal/infrastructure/whatsapp"
+	"whatspire/test/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is enough of a PNG file for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newTestDownloader(t *testing.T, maxSize int64) (*whatsapp.HTTPMediaDownloader, string) {
+	tempDir := t.TempDir()
+	cfg := whatsapp.DefaultDownloaderConfig()
+	cfg.MaxSize = maxSize
+	cfg.TempDir = tempDir
+	return whatsapp.NewHTTPMediaDownloader(cfg, valueobject.DefaultMediaConstraints()), tempDir
+}
+
+func spooledFiles(t *testing.T, dir string) []os.DirEntry {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	return entries
+}
+
+func TestHTTPMediaDownloader_DownloadToFile(t *testing.T) {
+	content := append(append([]byte{}, pngHeader...), []byte(strings.Repeat("pixel", 20000))...)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/photo.png":
+			// No Content-Type, so the MIME type has to be sniffed
+			w.Header()["Content-Type"] = nil
+			_, _ = w.Write(content)
+		case "/report":
+			w.Header().Set("Content-Type", "application/pdf; charset=binary")
+			w.Header().Set("Content-Disposition", `attachment; filename="report.pdf"`)
+			_, _ = w.Write([]byte("%PDF-1.7"))
+		case "/chunked":
+			// Flushing before writing everything hides the size from Content-Length
+			w.(http.Flusher).Flush()
+			_, _ = w.Write(content)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	ctx := context.Background()
+
+	t.Run("spools, hashes and sniffs the content", func(t *testing.T) {
+		downloader, tempDir := newTestDownloader(t, 1024*1024)
+
+		media, err := downloader.DownloadToFile(ctx, entity.NewMediaDownloadInfo(server.URL+"/photo.png"))
+		require.NoError(t, err)
+
+		hash := sha256.Sum256(content)
+		assert.Equal(t, hash[:], media.SHA256)
+		assert.Equal(t, int64(len(content)), media.Size)
+		assert.Equal(t, "image/png", media.MimeType)
+		assert.Equal(t, "photo.png", media.Filename)
+		assert.Len(t, media.Head(), 512)
+
+		spooled, err := os.ReadFile(media.Path())
+		require.NoError(t, err)
+		assert.Equal(t, content, spooled)
+
+		require.NoError(t, media.Close())
+		assert.Empty(t, spooledFiles(t, tempDir), "closing removes the file")
+	})
+
+	t.Run("declared type and filename win over sniffing", func(t *testing.T) {
+		downloader, _ := newTestDownloader(t, 1024*1024)
+
+		media, err := downloader.DownloadToFile(ctx, entity.NewMediaDownloadInfo(server.URL+"/report"))
+		require.NoError(t, err)
+		defer media.Close()
+
+		assert.Equal(t, "application/pdf", media.MimeType)
+		assert.Equal(t, "report.pdf", media.Filename)
+	})
+
+	t.Run("oversized content is rejected without leaving a file", func(t *testing.T) {
+		downloader, tempDir := newTestDownloader(t, 1024)
+
+		_, err := downloader.DownloadToFile(ctx, entity.NewMediaDownloadInfo(server.URL+"/chunked"))
+		require.Error(t, err)
+		assert.Equal(t, errors.ErrMediaTooLarge.Code, errors.GetDomainError(err).Code)
+		assert.Empty(t, spooledFiles(t, tempDir))
+	})
+
+	t.Run("failed requests leave no file", func(t *testing.T) {
+		downloader, tempDir := newTestDownloader(t, 1024)
+
+		_, err := downloader.DownloadToFile(ctx, entity.NewMediaDownloadInfo(server.URL+"/missing"))
+		require.Error(t, err)
+		assert.Equal(t, errors.ErrMediaDownloadFailed.Code, errors.GetDomainError(err).Code)
+		assert.Empty(t, spooledFiles(t, tempDir))
+	})
+}
+
+func TestHTTPMediaDownloader_ValidateAndDownloadToFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html></html>"))
+	}))
+	t.Cleanup(server.Close)
+
+	downloader, tempDir := newTestDownloader(t, 1024*1024)
+
+	_, err := downloader.ValidateAndDownloadToFile(context.Background(), entity.NewMediaDownloadInfo(server.URL), valueobject.MediaTypeImage)
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrUnsupportedMimeType.Code, errors.GetDomainError(err).Code)
+	assert.Empty(t, spooledFiles(t, tempDir), "rejected media is removed")
+}
+
+func TestWhatsmeowMediaUploader_UploadContent(t *testing.T) {
+	ctx := context.Background()
+	client, err := whatsapp.NewWhatsmeowClient(ctx, whatsapp.ClientConfig{DBPath: filepath.Join(t.TempDir(), "whatsapp.db")}, helpers.CreateTestLogger())
+	require.NoError(t, err)
+
+	downloader, tempDir := newTestDownloader(t, 1024*1024)
+	uploader := whatsapp.NewWhatsmeowMediaUploader(client, downloader, valueobject.DefaultMediaConstraints())
+
+	upload := func(content []byte, mediaType valueobject.MediaType, filename, mimeType string) error {
+		_, err := uploader.UploadContent(ctx, "session-1", &entity.MediaUploadInfo{
+			Content:   bytes.NewReader(content),
+			MediaType: mediaType,
+			Filename:  filename,
+			MimeType:  mimeType,
+		})
+		return err
+	}
+
+	tests := []struct {
+		name      string
+		content   []byte
+		mediaType valueobject.MediaType
+		filename  string
+		mimeType  string
+		wantCode  string
+	}{
+		{"declared type is validated", pngHeader, valueobject.MediaTypeImage, "photo.png", "text/html", errors.ErrUnsupportedMimeType.Code},
+		{"extension is used without a declared type", []byte("plain"), valueobject.MediaTypeImage, "notes.txt", "", errors.ErrUnsupportedMimeType.Code},
+		{"content is sniffed as a last resort", pngHeader, valueobject.MediaTypeSticker, "", "application/octet-stream", errors.ErrUnsupportedMimeType.Code},
+		{"size is validated", make([]byte, valueobject.MaxStickerSize+1), valueobject.MediaTypeSticker, "sticker.webp", "image/webp", errors.ErrMediaTooLarge.Code},
+		// Valid media gets as far as looking up the session
+		{"valid media is uploaded", pngHeader, valueobject.MediaTypeImage, "", "", errors.ErrSessionNotFound.Code},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := upload(tt.content, tt.mediaType, tt.filename, tt.mimeType)
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, errors.GetDomainError(err).Code)
+			assert.Empty(t, spooledFiles(t, tempDir), "spooled content is removed")
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalMediaStorage_GetMediaURL(t *testing.T) {
+	mediaStorage, err := storage.NewLocalMediaStorage(repository.MediaStorageConfig{
+		BasePath:    t.TempDir(),
+		BaseURL:     "https://api.example.com/api/media/",
+		MaxFileSize: 1024,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://api.example.com/api/media/session-1/msg-1.jpg", mediaStorage.GetMediaURL("session-1/msg-1.jpg"))
+
+	signer := storage.NewHMACMediaURLSigner([]byte("test-key"), time.Hour)
+	mediaStorage.SetURLSigner(signer)
+
+	mediaURL, err := url.Parse(mediaStorage.GetMediaURL("session-1/msg-1.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "/api/media/session-1/msg-1.jpg", mediaURL.Path)
+
+	query := mediaURL.Query()
+	assert.NoError(t, signer.Verify("session-1/msg-1.jpg", query.Get("expires"), query.Get("signature")))
+}
+
+func TestLocalMediaStorage_OpenMedia(t *testing.T) {
+	ctx := context.Background()
+	basePath := filepath.Join(t.TempDir(), "media")
+	mediaStorage, err := storage.NewLocalMediaStorage(repository.MediaStorageConfig{
+		BasePath:    basePath,
+		BaseURL:     "http://localhost:8080/api/media",
+		MaxFileSize: 1024,
+	})
+	require.NoError(t, err)
+
+	filePath, _, err := mediaStorage.DownloadAndStore(ctx, "session-1", "msg-1", bytes.NewReader([]byte("image data")), "image/png", "png")
+	require.NoError(t, err)
+
+	// A file outside the base path must not be reachable
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(basePath), "secret.txt"), []byte("secret"), 0600))
+
+	t.Run("opens stored media", func(t *testing.T) {
+		file, err := mediaStorage.OpenMedia(ctx, filePath)
+		require.NoError(t, err)
+		defer file.Content.Close()
+
+		data, err := io.ReadAll(file.Content)
+		require.NoError(t, err)
+		assert.Equal(t, "image data", string(data))
+		assert.Equal(t, "image/png", file.ContentType)
+		assert.Equal(t, int64(10), file.Size)
+		assert.True(t, strings.HasPrefix(file.ETag, `"`) && strings.HasSuffix(file.ETag, `"`))
+		assert.False(t, file.ModTime.IsZero())
+	})
+
+	t.Run("ETag changes with the content", func(t *testing.T) {
+		before, err := mediaStorage.OpenMedia(ctx, filePath)
+		require.NoError(t, err)
+		before.Content.Close()
+
+		_, _, err = mediaStorage.DownloadAndStore(ctx, "session-1", "msg-1", bytes.NewReader([]byte("new image data")), "image/png", "png")
+		require.NoError(t, err)
+
+		after, err := mediaStorage.OpenMedia(ctx, filePath)
+		require.NoError(t, err)
+		after.Content.Close()
+		assert.NotEqual(t, before.ETag, after.ETag)
+	})
+
+	for _, path := range []string{"session-1/missing.png", "session-1", "", "../secret.txt", "session-1/../../secret.txt"} {
+		t.Run("not found: "+path, func(t *testing.T) {
+			_, err := mediaStorage.OpenMedia(ctx, path)
+			assert.True(t, errors.IsNotFound(err), "got %v", err)
+		})
+	}
+}
+
+func TestLocalMediaStorage_ListMedia(t *testing.T) {
+	ctx := context.Background()
+	basePath := t.TempDir()
+	mediaStorage, err := storage.NewLocalMediaStorage(repository.MediaStorageConfig{
+		BasePath:    basePath,
+		BaseURL:     "http://localhost:8080/api/media",
+		MaxFileSize: 1024,
+	})
+	require.NoError(t, err)
+
+	_, _, err = mediaStorage.DownloadAndStore(ctx, "session-1", "msg-1", bytes.NewReader([]byte("image data")), "image/png", "png")
+	require.NoError(t, err)
+	_, _, err = mediaStorage.DownloadAndStore(ctx, "session-2", "msg-2", bytes.NewReader([]byte("audio")), "audio/ogg", "ogg")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(basePath, "stray.bin"), []byte("stray"), 0600))
+
+	media, err := mediaStorage.ListMedia(ctx)
+	require.NoError(t, err)
+	require.Len(t, media, 3)
+
+	byPath := make(map[string]repository.StoredMedia)
+	for _, m := range media {
+		byPath[m.Path] = m
+	}
+
+	image := byPath["session-1/msg-1.png"]
+	assert.Equal(t, "session-1", image.SessionID)
+	assert.Equal(t, "msg-1", image.MessageID)
+	assert.Equal(t, int64(10), image.Size)
+	assert.False(t, image.ModTime.IsZero())
+	assert.Equal(t, "session-2", byPath["session-2/msg-2.ogg"].SessionID)
+	assert.Empty(t, byPath["stray.bin"].SessionID)
+
+	t.Run("serving media records the access", func(t *testing.T) {
+		fullPath := filepath.Join(basePath, "session-1", "msg-1.png")
+		old := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+		require.NoError(t, os.Chtimes(fullPath, old, old))
+
+		file, err := mediaStorage.OpenMedia(ctx, "session-1/msg-1.png")
+		require.NoError(t, err)
+		file.Content.Close()
+
+		media, err := mediaStorage.ListMedia(ctx)
+		require.NoError(t, err)
+		for _, m := range media {
+			if m.Path == "session-1/msg-1.png" {
+				assert.True(t, m.ModTime.Equal(old), "serving media must not change its modification time")
+				assert.True(t, m.LastAccessed.After(old.Add(24*time.Hour)), "last access %s", m.LastAccessed)
+			}
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package unit
+
+import (
+	"testing"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/whatsapp"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== Outbound Message Builder Tests ====================
+
+func newTestOutboundMessage(content entity.MessageContent, msgType entity.MessageType) *entity.Message {
+	return entity.NewMessageBuilder("msg-1", "session-1").
+		To("1234567890").
+		WithContent(content).
+		WithType(msgType).
+		Build()
+}
+
+func TestBuildLocationMessage(t *testing.T) {
+	t.Run("builds location with name and address", func(t *testing.T) {
+		msg := newTestOutboundMessage(entity.MessageContent{
+			Location: &entity.LocationContent{
+				Latitude:  30.0444,
+				Longitude: 31.2357,
+				Name:      "Cairo Tower",
+				Address:   "Zamalek, Cairo",
+			},
+		}, entity.MessageTypeLocation)
+
+		waMsg, err := whatsapp.BuildLocationMessage(msg)
+
+		require.NoError(t, err)
+		loc := waMsg.GetLocationMessage()
+		require.NotNil(t, loc)
+		assert.Equal(t, 30.0444, loc.GetDegreesLatitude())
+		assert.Equal(t, 31.2357, loc.GetDegreesLongitude())
+		assert.Equal(t, "Cairo Tower", loc.GetName())
+		assert.Equal(t, "Zamalek, Cairo", loc.GetAddress())
+	})
+
+	t.Run("fails without location", func(t *testing.T) {
+		msg := newTestOutboundMessage(entity.MessageContent{}, entity.MessageTypeLocation)
+
+		_, err := whatsapp.BuildLocationMessage(msg)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildContactMessage(t *testing.T) {
+	vcard := "BEGIN:VCARD\nVERSION:3.0\nFN:Jane Doe\nTEL:+1234567890\nEND:VCARD"
+
+	t.Run("single card uses contact message", func(t *testing.T) {
+		msg := newTestOutboundMessage(entity.MessageContent{
+			Contacts: []entity.ContactCard{{DisplayName: "Jane Doe", VCard: vcard}},
+		}, entity.MessageTypeContact)
+
+		waMsg, err := whatsapp.BuildContactMessage(msg)
+
+		require.NoError(t, err)
+		require.NotNil(t, waMsg.GetContactMessage())
+		assert.Equal(t, "Jane Doe", waMsg.GetContactMessage().GetDisplayName())
+		assert.Equal(t, vcard, waMsg.GetContactMessage().GetVcard())
+		assert.Nil(t, waMsg.GetContactsArrayMessage())
+	})
+
+	t.Run("several cards use contacts array", func(t *testing.T) {
+		msg := newTestOutboundMessage(entity.MessageContent{
+			Contacts: []entity.ContactCard{
+				{DisplayName: "Jane Doe", VCard: vcard},
+				{DisplayName: "John Doe", VCard: vcard},
+			},
+		}, entity.MessageTypeContact)
+
+		waMsg, err := whatsapp.BuildContactMessage(msg)
+
+		require.NoError(t, err)
+		require.NotNil(t, waMsg.GetContactsArrayMessage())
+		assert.Len(t, waMsg.GetContactsArrayMessage().GetContacts(), 2)
+		assert.Nil(t, waMsg.GetContactMessage())
+	})
+
+	t.Run("fails without contacts", func(t *testing.T) {
+		msg := newTestOutboundMessage(entity.MessageContent{}, entity.MessageTypeContact)
+
+		_, err := whatsapp.BuildContactMessage(msg)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildPollMessage(t *testing.T) {
+	t.Run("builds poll with unique message secret", func(t *testing.T) {
+		msg := newTestOutboundMessage(entity.MessageContent{
+			Poll: &entity.PollContent{
+				Question:        "Lunch?",
+				Options:         []string{"Pizza", "Sushi", "Salad"},
+				SelectableCount: 1,
+			},
+		}, entity.MessageTypePoll)
+
+		first, err := whatsapp.BuildPollMessage(msg)
+		require.NoError(t, err)
+		second, err := whatsapp.BuildPollMessage(msg)
+		require.NoError(t, err)
+
+		poll := first.GetPollCreationMessage()
+		require.NotNil(t, poll)
+		assert.Equal(t, "Lunch?", poll.GetName())
+		assert.Len(t, poll.GetOptions(), 3)
+		assert.Equal(t, "Pizza", poll.GetOptions()[0].GetOptionName())
+		assert.Equal(t, uint32(1), poll.GetSelectableOptionsCount())
+		assert.Len(t, first.GetMessageContextInfo().GetMessageSecret(), 32)
+		assert.NotEqual(t, first.GetMessageContextInfo().GetMessageSecret(), second.GetMessageContextInfo().GetMessageSecret())
+	})
+
+	t.Run("fails with fewer than two options", func(t *testing.T) {
+		msg := newTestOutboundMessage(entity.MessageContent{
+			Poll: &entity.PollContent{Question: "Lunch?", Options: []string{"Pizza"}},
+		}, entity.MessageTypePoll)
+
+		_, err := whatsapp.BuildPollMessage(msg)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildStickerMessage(t *testing.T) {
+	result := &entity.MediaUploadResult{
+		URL:        "https://mmg.whatsapp.net/sticker",
+		DirectPath: "/v/sticker",
+		MimeType:   "image/webp",
+		FileLength: 1024,
+	}
+
+	waMsg := whatsapp.BuildStickerMessage(result)
+
+	require.NotNil(t, waMsg.GetStickerMessage())
+	assert.Equal(t, "image/webp", waMsg.GetStickerMessage().GetMimetype())
+	assert.Equal(t, "/v/sticker", waMsg.GetStickerMessage().GetDirectPath())
+	assert.Equal(t, uint64(1024), waMsg.GetStickerMessage().GetFileLength())
+}
+
+func TestBuildContextInfo(t *testing.T) {
+	t.Run("returns nil without reply or mentions", func(t *testing.T) {
+		msg := newTestOutboundMessage(entity.NewTextContent("hi"), entity.MessageTypeText)
+		assert.Nil(t, whatsapp.BuildContextInfo(msg, "9999999999@s.whatsapp.net"))
+	})
+
+	t.Run("converts mentions to user JIDs", func(t *testing.T) {
+		content := entity.NewTextContent("hi @1111111111")
+		content.Mentions = []string{"+1111111111", "2222222222@s.whatsapp.net"}
+		msg := newTestOutboundMessage(content, entity.MessageTypeText)
+
+		ctxInfo := whatsapp.BuildContextInfo(msg, "")
+		require.NotNil(t, ctxInfo)
+		assert.Equal(t, []string{"1111111111@s.whatsapp.net", "2222222222@s.whatsapp.net"}, ctxInfo.GetMentionedJID())
+		assert.Empty(t, ctxInfo.GetStanzaID())
+	})
+
+	t.Run("quotes a received message", func(t *testing.T) {
+		content := entity.NewTextContent("reply")
+		content.ReplyTo = &entity.ReplyContext{
+			MessageID:  "3EB0ABCDEF",
+			ChatJID:    "1234567890@s.whatsapp.net",
+			Sender:     "1234567890@s.whatsapp.net",
+			QuotedText: "original",
+		}
+		msg := entity.NewMessageBuilder("msg-1", "session-1").
+			InChat("1234567890@s.whatsapp.net").
+			To("1234567890").
+			WithContent(content).
+			WithType(entity.MessageTypeText).
+			Build()
+
+		ctxInfo := whatsapp.BuildContextInfo(msg, "9999999999@s.whatsapp.net")
+		require.NotNil(t, ctxInfo)
+		assert.Equal(t, "3EB0ABCDEF", ctxInfo.GetStanzaID())
+		assert.Equal(t, "1234567890@s.whatsapp.net", ctxInfo.GetParticipant())
+		assert.Empty(t, ctxInfo.GetRemoteJID())
+		assert.Equal(t, "original", ctxInfo.GetQuotedMessage().GetConversation())
+	})
+
+	t.Run("quotes an own message from another chat", func(t *testing.T) {
+		content := entity.NewTextContent("reply")
+		content.ReplyTo = &entity.ReplyContext{
+			MessageID: "3EB0ABCDEF",
+			ChatJID:   "120363000000000000@g.us",
+			FromMe:    true,
+		}
+		msg := newTestOutboundMessage(content, entity.MessageTypeText)
+
+		ctxInfo := whatsapp.BuildContextInfo(msg, "9999999999@s.whatsapp.net")
+		require.NotNil(t, ctxInfo)
+		assert.Equal(t, "9999999999@s.whatsapp.net", ctxInfo.GetParticipant())
+		assert.Equal(t, "120363000000000000@g.us", ctxInfo.GetRemoteJID())
+		assert.Empty(t, ctxInfo.GetQuotedMessage().GetConversation())
+	})
+}
+
+func TestApplyContextInfo(t *testing.T) {
+	ctxInfo := &waE2E.ContextInfo{StanzaID: proto.String("3EB0ABCDEF")}
+
+	t.Run("upgrades plain text to extended text", func(t *testing.T) {
+		waMsg := &waE2E.Message{Conversation: proto.String("hello")}
+		whatsapp.ApplyContextInfo(waMsg, ctxInfo)
+
+		assert.Nil(t, waMsg.Conversation)
+		require.NotNil(t, waMsg.GetExtendedTextMessage())
+		assert.Equal(t, "hello", waMsg.GetExtendedTextMessage().GetText())
+		assert.Equal(t, "3EB0ABCDEF", waMsg.GetExtendedTextMessage().GetContextInfo().GetStanzaID())
+	})
+
+	t.Run("attaches to media messages", func(t *testing.T) {
+		waMsg := &waE2E.Message{ImageMessage: &waE2E.ImageMessage{Caption: proto.String("pic")}}
+		whatsapp.ApplyContextInfo(waMsg, ctxInfo)
+
+		assert.Equal(t, "3EB0ABCDEF", waMsg.GetImageMessage().GetContextInfo().GetStanzaID())
+	})
+
+	t.Run("ignores nil context", func(t *testing.T) {
+		waMsg := &waE2E.Message{Conversation: proto.String("hello")}
+		whatsapp.ApplyContextInfo(waMsg, nil)
+
+		assert.Equal(t, "hello", waMsg.GetConversation())
+		assert.Nil(t, waMsg.GetExtendedTextMessage())
+	})
+}
@@ -0,0 +1,103 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ==================== Message Entity Tests ====================
+
+func TestMessageStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from     entity.MessageStatus
+		to       entity.MessageStatus
+		expected bool
+	}{
+		{entity.MessageStatusPending, entity.MessageStatusSent, true},
+		{entity.MessageStatusPending, entity.MessageStatusFailed, true},
+		{entity.MessageStatusPending, entity.MessageStatusPending, false},
+		{entity.MessageStatusSent, entity.MessageStatusDelivered, true},
+		{entity.MessageStatusSent, entity.MessageStatusRead, true},
+		{entity.MessageStatusSent, entity.MessageStatusPending, false},
+		{entity.MessageStatusDelivered, entity.MessageStatusRead, true},
+		{entity.MessageStatusDelivered, entity.MessageStatusSent, false},
+		{entity.MessageStatusRead, entity.MessageStatusDelivered, false},
+		{entity.MessageStatusFailed, entity.MessageStatusSent, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"->"+tt.to.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestMessage_EditAndRevoke(t *testing.T) {
+	newSentText := func(sentAt time.Time) *entity.Message {
+		return entity.NewMessageBuilder("msg-1", "session-1").
+			WithWhatsAppID("3EB0ABC").
+			WithContent(entity.NewTextContent("hello")).
+			WithType(entity.MessageTypeText).
+			WithTimestamp(sentAt).
+			Build()
+	}
+
+	t.Run("CanEdit within the edit window only", func(t *testing.T) {
+		now := time.Now()
+		assert.True(t, newSentText(now.Add(-time.Minute)).CanEdit(now))
+		assert.False(t, newSentText(now.Add(-entity.MessageEditWindow-time.Second)).CanEdit(now))
+	})
+
+	t.Run("CanEdit requires a sent outbound text or captioned media message", func(t *testing.T) {
+		now := time.Now()
+
+		unsent := newSentText(now)
+		unsent.WhatsAppID = ""
+		assert.False(t, unsent.CanEdit(now))
+
+		inbound := newSentText(now)
+		inbound.Direction = entity.MessageDirectionInbound
+		assert.False(t, inbound.CanEdit(now))
+		assert.False(t, inbound.CanRevoke())
+
+		for _, captioned := range []entity.MessageType{entity.MessageTypeImage, entity.MessageTypeVideo, entity.MessageTypeDocument} {
+			media := newSentText(now)
+			media.Type = captioned
+			assert.True(t, media.CanEdit(now), captioned)
+			assert.True(t, media.CanRevoke(), captioned)
+		}
+
+		for _, uncaptioned := range []entity.MessageType{entity.MessageTypeAudio, entity.MessageTypeSticker, entity.MessageTypeLocation} {
+			other := newSentText(now)
+			other.Type = uncaptioned
+			assert.False(t, other.CanEdit(now), uncaptioned)
+		}
+	})
+
+	t.Run("Edit updates text or caption", func(t *testing.T) {
+		msg := newSentText(time.Now())
+		msg.Edit("hello again", time.Now())
+		assert.Equal(t, "hello again", *msg.Content.Text)
+		assert.NotNil(t, msg.Content.EditedAt)
+
+		image := newSentText(time.Now())
+		image.Type = entity.MessageTypeImage
+		image.Edit("new caption", time.Now())
+		assert.Equal(t, "new caption", *image.Content.Caption)
+		assert.Equal(t, "hello", *image.Content.Text, "the text of a media message is left alone")
+	})
+
+	t.Run("Revoke clears content and blocks further changes", func(t *testing.T) {
+		msg := newSentText(time.Now())
+		msg.Revoke(time.Now())
+
+		assert.True(t, msg.IsRevoked())
+		assert.Nil(t, msg.Content.Text)
+		assert.False(t, msg.CanEdit(time.Now()))
+		assert.False(t, msg.CanRevoke())
+	})
+}
@@ -0,0 +1,106 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/infrastructure/persistence"
+	"whatspire/internal/infrastructure/whatsapp"
+	"whatspire/test/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== MessageHandler Edit/Revoke Tests ====================
+
+func TestMessageHandler_IncomingEditAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	messageRepo := persistence.NewMessageRepository(db)
+
+	handler := whatsapp.NewMessageHandler(whatsapp.NewMessageParser(), nil, nil, helpers.CreateTestLogger())
+	handler.SetMessageRepository(messageRepo)
+
+	// An outbound message we sent earlier, known on the network as 3EB0ORIGINAL
+	original := entity.NewMessageBuilder("our-id", "session-1").
+		InChat("123456789@g.us").
+		WithWhatsAppID("3EB0ORIGINAL").
+		WithContent(entity.NewTextContent("Helo")).
+		WithType(entity.MessageTypeText).
+		WithStatus(entity.MessageStatusSent).
+		Build()
+	require.NoError(t, messageRepo.Save(ctx, original))
+
+	newProtocolEvent := func(protoMsg *waE2E.ProtocolMessage) *waE2E.Message {
+		return &waE2E.Message{ProtocolMessage: protoMsg}
+	}
+
+	t.Run("edit updates the stored message and emits message.edited", func(t *testing.T) {
+		evt := createTextMessageEvent("placeholder")
+		evt.Info.ID = "3EB0EDIT"
+		evt.Message = newProtocolEvent(&waE2E.ProtocolMessage{
+			Type:          waE2E.ProtocolMessage_MESSAGE_EDIT.Enum(),
+			Key:           &waCommon.MessageKey{ID: proto.String("3EB0ORIGINAL")},
+			EditedMessage: &waE2E.Message{Conversation: proto.String("Hello")},
+		})
+
+		event, err := handler.HandleIncomingMessage(ctx, "session-1", nil, evt)
+		require.NoError(t, err)
+		require.NotNil(t, event)
+		assert.Equal(t, entity.EventTypeMessageEdited, event.Type)
+
+		var update entity.MessageUpdate
+		require.NoError(t, event.UnmarshalData(&update))
+		assert.Equal(t, "our-id", update.MessageID)
+		assert.Equal(t, "3EB0ORIGINAL", update.WhatsAppID)
+		require.NotNil(t, update.Text)
+		assert.Equal(t, "Hello", *update.Text)
+
+		stored, err := messageRepo.FindByID(ctx, "our-id")
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", *stored.Content.Text)
+		assert.NotNil(t, stored.Content.EditedAt)
+	})
+
+	t.Run("revoke clears the stored message and emits message.revoked", func(t *testing.T) {
+		evt := createTextMessageEvent("placeholder")
+		evt.Info.ID = "3EB0REVOKE"
+		evt.Info.Timestamp = time.Now()
+		evt.Message = newProtocolEvent(&waE2E.ProtocolMessage{
+			Type: waE2E.ProtocolMessage_REVOKE.Enum(),
+			Key:  &waCommon.MessageKey{ID: proto.String("3EB0ORIGINAL")},
+		})
+
+		event, err := handler.HandleIncomingMessage(ctx, "session-1", nil, evt)
+		require.NoError(t, err)
+		require.NotNil(t, event)
+		assert.Equal(t, entity.EventTypeMessageRevoked, event.Type)
+
+		stored, err := messageRepo.FindByID(ctx, "our-id")
+		require.NoError(t, err)
+		assert.True(t, stored.IsRevoked())
+		assert.Nil(t, stored.Content.Text)
+	})
+
+	t.Run("unknown target still emits the event with the WhatsApp ID", func(t *testing.T) {
+		evt := createTextMessageEvent("placeholder")
+		evt.Message = newProtocolEvent(&waE2E.ProtocolMessage{
+			Type: waE2E.ProtocolMessage_REVOKE.Enum(),
+			Key:  &waCommon.MessageKey{ID: proto.String("3EB0UNKNOWN")},
+		})
+
+		event, err := handler.HandleIncomingMessage(ctx, "session-1", nil, evt)
+		require.NoError(t, err)
+		require.NotNil(t, event)
+
+		var update entity.MessageUpdate
+		require.NoError(t, event.UnmarshalData(&update))
+		assert.Equal(t, "3EB0UNKNOWN", update.MessageID)
+	})
+}
@@ -399,3 +399,73 @@ func createReactionMessageEvent(emoji, targetMsgID string) *events.Message {
 		},
 	}
 }
+
+func TestMessageParser_EditAndRevoke(t *testing.T) {
+	parser := whatsapp.NewMessageParser()
+
+	newProtocolEvent := func(protoMsg *waE2E.ProtocolMessage) *events.Message {
+		evt := createTextMessageEvent("placeholder")
+		evt.Info.ID = "msg-456"
+		evt.Message = &waE2E.Message{ProtocolMessage: protoMsg}
+		return evt
+	}
+
+	t.Run("parses text edit with target message", func(t *testing.T) {
+		evt := newProtocolEvent(&waE2E.ProtocolMessage{
+			Type:          waE2E.ProtocolMessage_MESSAGE_EDIT.Enum(),
+			Key:           &waCommon.MessageKey{ID: proto.String("msg-123")},
+			EditedMessage: &waE2E.Message{Conversation: proto.String("fixed typo")},
+		})
+
+		msg, err := parser.ParseRealtimeMessage("session-123", evt)
+
+		require.NoError(t, err)
+		assert.Equal(t, whatsapp.ParsedMessageTypeEdit, msg.MessageType)
+		require.NotNil(t, msg.TargetMessageID)
+		assert.Equal(t, "msg-123", *msg.TargetMessageID)
+		assert.Equal(t, "fixed typo", msg.GetTextContent())
+	})
+
+	t.Run("parses caption edit", func(t *testing.T) {
+		evt := newProtocolEvent(&waE2E.ProtocolMessage{
+			Type: waE2E.ProtocolMessage_MESSAGE_EDIT.Enum(),
+			Key:  &waCommon.MessageKey{ID: proto.String("msg-123")},
+			EditedMessage: &waE2E.Message{
+				ImageMessage: &waE2E.ImageMessage{Caption: proto.String("new caption")},
+			},
+		})
+
+		msg, err := parser.ParseRealtimeMessage("session-123", evt)
+
+		require.NoError(t, err)
+		assert.Equal(t, whatsapp.ParsedMessageTypeEdit, msg.MessageType)
+		require.NotNil(t, msg.Caption)
+		assert.Equal(t, "new caption", *msg.Caption)
+	})
+
+	t.Run("parses revoke with target message", func(t *testing.T) {
+		evt := newProtocolEvent(&waE2E.ProtocolMessage{
+			Type: waE2E.ProtocolMessage_REVOKE.Enum(),
+			Key:  &waCommon.MessageKey{ID: proto.String("msg-123")},
+		})
+
+		msg, err := parser.ParseRealtimeMessage("session-123", evt)
+
+		require.NoError(t, err)
+		assert.Equal(t, whatsapp.ParsedMessageTypeRevoke, msg.MessageType)
+		require.NotNil(t, msg.TargetMessageID)
+		assert.Equal(t, "msg-123", *msg.TargetMessageID)
+	})
+
+	t.Run("keeps other protocol messages opaque", func(t *testing.T) {
+		evt := newProtocolEvent(&waE2E.ProtocolMessage{
+			Type: waE2E.ProtocolMessage_EPHEMERAL_SETTING.Enum(),
+		})
+
+		msg, err := parser.ParseRealtimeMessage("session-123", evt)
+
+		require.NoError(t, err)
+		assert.Equal(t, whatsapp.ParsedMessageTypeProtocol, msg.MessageType)
+		assert.Nil(t, msg.TargetMessageID)
+	})
+}
@@ -0,0 +1,219 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== MessageRepository Tests ====================
+
+func newTestHistoryMessage(id, sessionID, chatJID string, timestamp time.Time) *entity.Message {
+	text := "message " + id
+	return entity.NewMessageBuilder(id, sessionID).
+		InChat(chatJID).
+		To("1234567890").
+		WithContent(entity.NewTextContent(text)).
+		WithType(entity.MessageTypeText).
+		WithTimestamp(timestamp).
+		Build()
+}
+
+func TestMessageRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewMessageRepository(db)
+
+	t.Run("Save and FindByID", func(t *testing.T) {
+		db.Exec("DELETE FROM messages WHERE 1=1")
+
+		msg := newTestHistoryMessage("msg-1", "session-1", "1234567890@s.whatsapp.net", time.Now())
+		require.NoError(t, repo.Save(ctx, msg))
+
+		found, err := repo.FindByID(ctx, "msg-1")
+		require.NoError(t, err)
+		assert.Equal(t, "session-1", found.SessionID)
+		assert.Equal(t, "1234567890@s.whatsapp.net", found.ChatJID)
+		assert.Equal(t, entity.MessageDirectionOutbound, found.Direction)
+		assert.Equal(t, entity.MessageStatusPending, found.GetStatus())
+		require.NotNil(t, found.Content.Text)
+		assert.Equal(t, "message msg-1", *found.Content.Text)
+	})
+
+	t.Run("Save twice upserts", func(t *testing.T) {
+		db.Exec("DELETE FROM messages WHERE 1=1")
+
+		msg := newTestHistoryMessage("msg-1", "session-1", "chat@s.whatsapp.net", time.Now())
+		require.NoError(t, repo.Save(ctx, msg))
+		require.NoError(t, repo.UpdateStatus(ctx, "msg-1", entity.MessageStatusRead, time.Now()))
+
+		// A re-delivered copy refreshes the message but leaves its status to UpdateStatus
+		msg.Content = entity.NewTextContent("re-delivered")
+		msg.SetStatus(entity.MessageStatusDelivered)
+		require.NoError(t, repo.Save(ctx, msg))
+
+		found, err := repo.FindByID(ctx, "msg-1")
+		require.NoError(t, err)
+		assert.Equal(t, "re-delivered", *found.Content.Text)
+		assert.Equal(t, entity.MessageStatusRead, found.GetStatus())
+	})
+
+	t.Run("FindByID not found", func(t *testing.T) {
+		db.Exec("DELETE FROM messages WHERE 1=1")
+
+		_, err := repo.FindByID(ctx, "missing")
+		assert.ErrorIs(t, err, errors.ErrMessageNotFound)
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		db.Exec("DELETE FROM messages WHERE 1=1")
+
+		msg := newTestHistoryMessage("msg-1", "session-1", "chat@s.whatsapp.net", time.Now())
+		require.NoError(t, repo.Save(ctx, msg))
+
+		require.NoError(t, repo.UpdateStatus(ctx, "msg-1", entity.MessageStatusFailed, time.Now()))
+
+		found, err := repo.FindByID(ctx, "msg-1")
+		require.NoError(t, err)
+		assert.Equal(t, entity.MessageStatusFailed, found.GetStatus())
+
+		err = repo.UpdateStatus(ctx, "missing", entity.MessageStatusSent, time.Now())
+		assert.ErrorIs(t, err, errors.ErrMessageNotFound)
+	})
+
+	t.Run("ListByChat paginates newest first", func(t *testing.T) {
+		db.Exec("DELETE FROM messages WHERE 1=1")
+
+		base := time.Now().Add(-time.Hour)
+		for i := 0; i < 5; i++ {
+			msg := newTestHistoryMessage(fmt.Sprintf("msg-%d", i), "session-1", "chat@s.whatsapp.net", base.Add(time.Duration(i)*time.Minute))
+			require.NoError(t, repo.Save(ctx, msg))
+		}
+		// Other chat and other session must be excluded
+		require.NoError(t, repo.Save(ctx, newTestHistoryMessage("other-chat", "session-1", "other@s.whatsapp.net", base)))
+		require.NoError(t, repo.Save(ctx, newTestHistoryMessage("other-session", "session-2", "chat@s.whatsapp.net", base)))
+
+		page, err := repo.ListByChat(ctx, repository.MessageFilter{
+			SessionID: "session-1",
+			ChatJID:   "chat@s.whatsapp.net",
+			Limit:     2,
+		})
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, "msg-4", page[0].ID)
+		assert.Equal(t, "msg-3", page[1].ID)
+
+		last := page[len(page)-1]
+		page, err = repo.ListByChat(ctx, repository.MessageFilter{
+			SessionID: "session-1",
+			ChatJID:   "chat@s.whatsapp.net",
+			Before:    &last.Timestamp,
+			BeforeID:  last.ID,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, page, 3)
+		assert.Equal(t, "msg-2", page[0].ID)
+		assert.Equal(t, "msg-0", page[2].ID)
+	})
+
+	t.Run("ListByChat breaks timestamp ties by ID", func(t *testing.T) {
+		db.Exec("DELETE FROM messages WHERE 1=1")
+
+		ts := time.Now()
+		for _, id := range []string{"a", "b", "c"} {
+			require.NoError(t, repo.Save(ctx, newTestHistoryMessage(id, "session-1", "chat@s.whatsapp.net", ts)))
+		}
+
+		first, err := repo.ListByChat(ctx, repository.MessageFilter{
+			SessionID: "session-1",
+			ChatJID:   "chat@s.whatsapp.net",
+			Limit:     1,
+		})
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+		assert.Equal(t, "c", first[0].ID)
+
+		rest, err := repo.ListByChat(ctx, repository.MessageFilter{
+			SessionID: "session-1",
+			ChatJID:   "chat@s.whatsapp.net",
+			Before:    &first[0].Timestamp,
+			BeforeID:  first[0].ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, rest, 2)
+		assert.Equal(t, "b", rest[0].ID)
+		assert.Equal(t, "a", rest[1].ID)
+	})
+
+	t.Run("SetWhatsAppID and FindByWhatsAppID", func(t *testing.T) {
+		db.Exec("DELETE FROM messages WHERE 1=1")
+
+		msg := newTestHistoryMessage("msg-1", "session-1", "chat@s.whatsapp.net", time.Now())
+		require.NoError(t, repo.Save(ctx, msg))
+		require.NoError(t, repo.SetWhatsAppID(ctx, "msg-1", "3EB0ABC"))
+
+		found, err := repo.FindByWhatsAppID(ctx, "session-1", "3EB0ABC")
+		require.NoError(t, err)
+		assert.Equal(t, "msg-1", found.ID)
+		assert.Equal(t, "3EB0ABC", found.WhatsAppID)
+
+		_, err = repo.FindByWhatsAppID(ctx, "session-2", "3EB0ABC")
+		assert.ErrorIs(t, err, errors.ErrMessageNotFound)
+	})
+
+	t.Run("UpdateContent", func(t *testing.T) {
+		db.Exec("DELETE FROM messages WHERE 1=1")
+
+		msg := newTestHistoryMessage("msg-1", "session-1", "chat@s.whatsapp.net", time.Now())
+		require.NoError(t, repo.Save(ctx, msg))
+
+		msg.Edit("edited", time.Now())
+		require.NoError(t, repo.UpdateContent(ctx, "msg-1", msg.Content))
+
+		found, err := repo.FindByID(ctx, "msg-1")
+		require.NoError(t, err)
+		require.NotNil(t, found.Content.Text)
+		assert.Equal(t, "edited", *found.Content.Text)
+		assert.NotNil(t, found.Content.EditedAt)
+
+		err = repo.UpdateContent(ctx, "missing", msg.Content)
+		assert.ErrorIs(t, err, errors.ErrMessageNotFound)
+	})
+
+	t.Run("Status history records first occurrence of each status", func(t *testing.T) {
+		db.Exec("DELETE FROM messages WHERE 1=1")
+		db.Exec("DELETE FROM message_status_history WHERE 1=1")
+
+		base := time.Now().Add(-time.Minute)
+		msg := newTestHistoryMessage("msg-1", "session-1", "chat@s.whatsapp.net", base)
+		require.NoError(t, repo.Save(ctx, msg))
+		require.NoError(t, repo.UpdateStatus(ctx, "msg-1", entity.MessageStatusSent, base.Add(time.Second)))
+		require.NoError(t, repo.UpdateStatus(ctx, "msg-1", entity.MessageStatusRead, base.Add(3*time.Second)))
+		require.NoError(t, repo.AddStatusHistory(ctx, "msg-1", entity.MessageStatusDelivered, base.Add(2*time.Second)))
+		// Duplicate receipts are ignored
+		require.NoError(t, repo.AddStatusHistory(ctx, "msg-1", entity.MessageStatusRead, base.Add(10*time.Second)))
+
+		found, err := repo.FindByID(ctx, "msg-1")
+		require.NoError(t, err)
+		assert.Equal(t, entity.MessageStatusRead, found.GetStatus())
+
+		history, err := repo.GetStatusHistory(ctx, "msg-1")
+		require.NoError(t, err)
+		require.Len(t, history, 4)
+		assert.Equal(t, entity.MessageStatusPending, history[0].Status)
+		assert.Equal(t, entity.MessageStatusSent, history[1].Status)
+		assert.Equal(t, entity.MessageStatusDelivered, history[2].Status)
+		assert.Equal(t, entity.MessageStatusRead, history[3].Status)
+		assert.WithinDuration(t, base.Add(3*time.Second), history[3].Timestamp, time.Millisecond)
+	})
+}
@@ -2,17 +2,22 @@ package unit
 
 import (
 	"context"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"whatspire/internal/application/dto"
+	"whatspire/internal/application/usecase"
 	"whatspire/internal/domain/entity"
 	"whatspire/internal/domain/errors"
+	"whatspire/internal/infrastructure/persistence"
 	"whatspire/test/helpers"
 	"whatspire/test/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 // ==================== MessageUseCase Tests ====================
@@ -180,6 +185,59 @@ func TestMessageUseCase_SendMessageSync_InvalidPhoneNumber(t *testing.T) {
 	assert.ErrorIs(t, err, errors.ErrInvalidPhoneNumber)
 }
 
+func TestMessageUseCase_SendMessageSync_DocumentWithoutURL(t *testing.T) {
+	waClient := mocks.NewWhatsAppClientMock()
+	var sent atomic.Int32
+	waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+		sent.Add(1)
+		return nil
+	}
+
+	uc := helpers.NewTestMessageUseCase(waClient, mocks.NewEventPublisherMock(), mocks.NewMediaUploaderMock(), nil)
+	defer uc.Close()
+
+	req := dto.SendMessageRequest{
+		SessionID: "session-1",
+		To:        "+1234567890",
+		Type:      "document",
+		Content:   dto.SendMessageContentInput{},
+	}
+
+	msg, err := uc.SendMessageSync(context.Background(), req)
+
+	assert.Nil(t, msg)
+	assert.ErrorIs(t, err, errors.ErrEmptyContent)
+	assert.Zero(t, sent.Load())
+}
+
+func TestMessageUseCase_SendMessage_ValidatesBeforeUpload(t *testing.T) {
+	var uploads atomic.Int32
+	mediaUploader := mocks.NewMediaUploaderMock()
+	mediaUploader.UploadContentFn = func(ctx context.Context, sessionID string, info *entity.MediaUploadInfo) (*entity.MediaUploadResult, error) {
+		uploads.Add(1)
+		return &entity.MediaUploadResult{}, nil
+	}
+
+	uc := helpers.NewTestMessageUseCase(mocks.NewWhatsAppClientMock(), mocks.NewEventPublisherMock(), mediaUploader, nil)
+	defer uc.Close()
+
+	req := dto.SendMessageRequest{
+		SessionID: "session-1",
+		To:        "+1234567890",
+		Type:      "text",
+		Content:   dto.SendMessageContentInput{},
+		File:      &dto.MediaFileInput{Content: strings.NewReader("data"), Filename: "notes.txt", MimeType: "text/plain"},
+	}
+
+	_, err := uc.SendMessage(context.Background(), req)
+	assert.ErrorIs(t, err, errors.ErrEmptyContent)
+
+	_, err = uc.SendMessageSync(context.Background(), req)
+	assert.ErrorIs(t, err, errors.ErrEmptyContent)
+
+	assert.Zero(t, uploads.Load(), "invalid requests are not uploaded")
+}
+
 func TestMessageUseCase_HandleIncomingMessage(t *testing.T) {
 	publisher := mocks.NewEventPublisherMock()
 
@@ -347,3 +405,454 @@ func TestMessageUseCase_SendMessageSync_NoClient(t *testing.T) {
 	assert.Nil(t, msg)
 	assert.Error(t, err)
 }
+
+func TestMessageUseCase_MessageHistory(t *testing.T) {
+	db := setupTestDB(t)
+	messageRepo := persistence.NewMessageRepository(db)
+
+	waClient := mocks.NewWhatsAppClientMock()
+	uc := helpers.NewTestMessageUseCaseBuilder().
+		WithWhatsAppClient(waClient).
+		WithMessageRepository(messageRepo).
+		Build()
+	defer uc.Close()
+
+	ctx := context.Background()
+	text := "Hello"
+	req := dto.SendMessageRequest{
+		SessionID: "session-1",
+		To:        "+1234567890",
+		Type:      "text",
+		Content: dto.SendMessageContentInput{
+			Text: &text,
+		},
+	}
+
+	t.Run("SendMessageSync records sent message", func(t *testing.T) {
+		msg, err := uc.SendMessageSync(ctx, req)
+		require.NoError(t, err)
+
+		stored, err := messageRepo.FindByID(ctx, msg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "1234567890@s.whatsapp.net", stored.ChatJID)
+		assert.Equal(t, entity.MessageDirectionOutbound, stored.Direction)
+		assert.Equal(t, entity.MessageStatusSent, stored.GetStatus())
+	})
+
+	t.Run("ListChatMessages paginates with cursor", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := uc.SendMessageSync(ctx, req)
+			require.NoError(t, err)
+		}
+
+		page, err := uc.ListChatMessages(ctx, dto.ListMessagesRequest{
+			SessionID: "session-1",
+			ChatJID:   "1234567890@s.whatsapp.net",
+			Limit:     2,
+		})
+		require.NoError(t, err)
+		assert.Len(t, page.Messages, 2)
+		require.NotEmpty(t, page.NextCursor)
+
+		next, err := uc.ListChatMessages(ctx, dto.ListMessagesRequest{
+			SessionID: "session-1",
+			ChatJID:   "1234567890@s.whatsapp.net",
+			Limit:     2,
+			Cursor:    page.NextCursor,
+		})
+		require.NoError(t, err)
+		assert.Len(t, next.Messages, 1)
+		assert.Empty(t, next.NextCursor)
+		assert.NotEqual(t, page.Messages[1].ID, next.Messages[0].ID)
+	})
+
+	t.Run("GetMessageStatus returns timeline and WhatsApp ID", func(t *testing.T) {
+		waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+			msg.WhatsAppID = "3EB0WAID"
+			return nil
+		}
+		defer func() { waClient.SendFn = nil }()
+
+		msg, err := uc.SendMessageSync(ctx, req)
+		require.NoError(t, err)
+
+		status, err := uc.GetMessageStatus(ctx, msg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, msg.ID, status.ID)
+		assert.Equal(t, "3EB0WAID", status.WhatsAppID)
+		assert.Equal(t, "sent", status.Status)
+		require.Len(t, status.Timeline, 2)
+		assert.Equal(t, "pending", status.Timeline[0].Status)
+		assert.Equal(t, "sent", status.Timeline[1].Status)
+	})
+
+	t.Run("GetMessageStatus unknown message", func(t *testing.T) {
+		_, err := uc.GetMessageStatus(ctx, "missing")
+		assert.ErrorIs(t, err, errors.ErrMessageNotFound)
+	})
+
+	t.Run("ListChatMessages rejects invalid cursor", func(t *testing.T) {
+		_, err := uc.ListChatMessages(ctx, dto.ListMessagesRequest{
+			SessionID: "session-1",
+			ChatJID:   "1234567890@s.whatsapp.net",
+			Cursor:    "not-a-cursor",
+		})
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	})
+}
+
+// setupOutboxTestDB returns an in-memory database limited to one connection so that
+// the outbox processor goroutine sees the same database as the test
+func setupOutboxTestDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	return db
+}
+
+func TestMessageUseCase_Outbox(t *testing.T) {
+	t.Run("SendMessage is delivered through the outbox", func(t *testing.T) {
+		db := setupOutboxTestDB(t)
+		outboxRepo := persistence.NewOutboxRepository(db)
+
+		sent := make(chan string, 1)
+		waClient := mocks.NewWhatsAppClientMock()
+		waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+			sent <- msg.ID
+			return nil
+		}
+
+		uc := helpers.NewTestMessageUseCaseBuilder().
+			WithWhatsAppClient(waClient).
+			WithOutboxRepository(outboxRepo).
+			Build()
+		defer uc.Close()
+
+		text := "Hello"
+		msg, err := uc.SendMessage(context.Background(), dto.SendMessageRequest{
+			SessionID: "session-1",
+			To:        "+1234567890",
+			Type:      "text",
+			Content:   dto.SendMessageContentInput{Text: &text},
+		})
+		require.NoError(t, err)
+
+		select {
+		case id := <-sent:
+			assert.Equal(t, msg.ID, id)
+		case <-time.After(3 * time.Second):
+			t.Fatal("message was not delivered from the outbox")
+		}
+
+		assert.Eventually(t, func() bool {
+			return uc.QueueSize() == 0
+		}, 3*time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("Abandoned claims are resumed after the claim timeout", func(t *testing.T) {
+		db := setupOutboxTestDB(t)
+		outboxRepo := persistence.NewOutboxRepository(db)
+		ctx := context.Background()
+
+		// Simulate a message claimed by a process that crashed before sending
+		text := "queued before restart"
+		queued := entity.NewMessageBuilder("msg-restart", "session-1").
+			To("+1234567890").
+			WithContent(entity.NewTextContent(text)).
+			WithType(entity.MessageTypeText).
+			Build()
+		require.NoError(t, outboxRepo.Enqueue(ctx, entity.NewOutboxEntry(queued)))
+		_, err := outboxRepo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+
+		sent := make(chan string, 1)
+		waClient := mocks.NewWhatsAppClientMock()
+		waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+			sent <- msg.ID
+			return nil
+		}
+
+		config := usecase.DefaultMessageUseCaseConfig()
+		config.OutboxPollInterval = 10 * time.Millisecond
+		config.OutboxClaimTimeout = 200 * time.Millisecond
+		uc := usecase.NewMessageUseCaseBuilder(config, helpers.CreateTestLogger()).
+			WithWhatsAppClient(waClient).
+			WithOutboxRepository(outboxRepo).
+			Build()
+		defer uc.Close()
+
+		select {
+		case id := <-sent:
+			assert.Equal(t, "msg-restart", id)
+		case <-time.After(3 * time.Second):
+			t.Fatal("queued message was not resumed")
+		}
+	})
+
+	t.Run("Recent claims of another processor are left alone", func(t *testing.T) {
+		db := setupOutboxTestDB(t)
+		outboxRepo := persistence.NewOutboxRepository(db)
+		ctx := context.Background()
+
+		// Claimed by another replica that is sending it right now
+		text := "being sent elsewhere"
+		inFlight := entity.NewMessageBuilder("msg-in-flight", "session-1").
+			To("+1234567890").
+			WithContent(entity.NewTextContent(text)).
+			WithType(entity.MessageTypeText).
+			Build()
+		require.NoError(t, outboxRepo.Enqueue(ctx, entity.NewOutboxEntry(inFlight)))
+		_, err := outboxRepo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+
+		var sends atomic.Int32
+		waClient := mocks.NewWhatsAppClientMock()
+		waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+			sends.Add(1)
+			return nil
+		}
+
+		config := usecase.DefaultMessageUseCaseConfig()
+		config.OutboxPollInterval = 10 * time.Millisecond
+		uc := usecase.NewMessageUseCaseBuilder(config, helpers.CreateTestLogger()).
+			WithWhatsAppClient(waClient).
+			WithOutboxRepository(outboxRepo).
+			Build()
+		defer uc.Close()
+
+		time.Sleep(200 * time.Millisecond)
+		assert.Zero(t, sends.Load())
+	})
+
+	t.Run("Failed sends are retried then marked failed", func(t *testing.T) {
+		db := setupOutboxTestDB(t)
+		outboxRepo := persistence.NewOutboxRepository(db)
+
+		var attempts atomic.Int32
+		waClient := mocks.NewWhatsAppClientMock()
+		waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+			attempts.Add(1)
+			return errors.ErrConnectionFailed
+		}
+
+		config := usecase.DefaultMessageUseCaseConfig()
+		config.MaxRetries = 1
+		config.OutboxPollInterval = 10 * time.Millisecond
+		uc := usecase.NewMessageUseCaseBuilder(config, helpers.CreateTestLogger()).
+			WithWhatsAppClient(waClient).
+			WithOutboxRepository(outboxRepo).
+			Build()
+		defer uc.Close()
+
+		text := "Hello"
+		_, err := uc.SendMessage(context.Background(), dto.SendMessageRequest{
+			SessionID: "session-1",
+			To:        "+1234567890",
+			Type:      "text",
+			Content:   dto.SendMessageContentInput{Text: &text},
+		})
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			return uc.QueueSize() == 0
+		}, 3*time.Second, 20*time.Millisecond)
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+}
+
+func TestMessageUseCase_ReplyContext(t *testing.T) {
+	db := setupTestDB(t)
+	messageRepo := persistence.NewMessageRepository(db)
+
+	var sent *entity.Message
+	waClient := mocks.NewWhatsAppClientMock()
+	waClient.SendFn = func(ctx context.Context, msg *entity.Message) error {
+		sent = msg
+		return nil
+	}
+
+	uc := helpers.NewTestMessageUseCaseBuilder().
+		WithWhatsAppClient(waClient).
+		WithMessageRepository(messageRepo).
+		Build()
+	defer uc.Close()
+
+	ctx := context.Background()
+	reply := "Got it"
+	newReplyRequest := func(messageID string) dto.SendMessageRequest {
+		return dto.SendMessageRequest{
+			SessionID: "session-1",
+			To:        "+1234567890",
+			Type:      "text",
+			Content: dto.SendMessageContentInput{
+				Text:    &reply,
+				ReplyTo: &dto.ReplyToInput{MessageID: messageID},
+			},
+		}
+	}
+
+	t.Run("resolves a received message by WhatsApp ID", func(t *testing.T) {
+		incoming := entity.NewMessageBuilder("3EB0INCOMING", "session-1").
+			InChat("1234567890@s.whatsapp.net").
+			From("1234567890@s.whatsapp.net").
+			WithWhatsAppID("3EB0INCOMING").
+			WithDirection(entity.MessageDirectionInbound).
+			WithContent(entity.NewTextContent("Are you there?")).
+			WithType(entity.MessageTypeText).
+			Build()
+		require.NoError(t, messageRepo.Save(ctx, incoming))
+
+		_, err := uc.SendMessageSync(ctx, newReplyRequest("3EB0INCOMING"))
+		require.NoError(t, err)
+
+		require.NotNil(t, sent.Content.ReplyTo)
+		assert.Equal(t, "3EB0INCOMING", sent.Content.ReplyTo.MessageID)
+		assert.Equal(t, "1234567890@s.whatsapp.net", sent.Content.ReplyTo.Sender)
+		assert.Equal(t, "Are you there?", sent.Content.ReplyTo.QuotedText)
+		assert.False(t, sent.Content.ReplyTo.FromMe)
+	})
+
+	t.Run("resolves an own message by its API ID", func(t *testing.T) {
+		original := "Original"
+		first, err := uc.SendMessageSync(ctx, dto.SendMessageRequest{
+			SessionID: "session-1",
+			To:        "+1234567890",
+			Type:      "text",
+			Content:   dto.SendMessageContentInput{Text: &original},
+		})
+		require.NoError(t, err)
+		require.NoError(t, messageRepo.SetWhatsAppID(ctx, first.ID, "3EB0OWN"))
+
+		_, err = uc.SendMessageSync(ctx, newReplyRequest(first.ID))
+		require.NoError(t, err)
+
+		require.NotNil(t, sent.Content.ReplyTo)
+		assert.Equal(t, "3EB0OWN", sent.Content.ReplyTo.MessageID)
+		assert.Equal(t, "Original", sent.Content.ReplyTo.QuotedText)
+		assert.True(t, sent.Content.ReplyTo.FromMe)
+	})
+
+	t.Run("passes through unknown message IDs", func(t *testing.T) {
+		_, err := uc.SendMessageSync(ctx, newReplyRequest("3EB0UNKNOWN"))
+		require.NoError(t, err)
+
+		require.NotNil(t, sent.Content.ReplyTo)
+		assert.Equal(t, "3EB0UNKNOWN", sent.Content.ReplyTo.MessageID)
+		assert.Empty(t, sent.Content.ReplyTo.QuotedText)
+	})
+}
+
+func TestMessageUseCase_EditAndRevoke(t *testing.T) {
+	db := setupTestDB(t)
+	messageRepo := persistence.NewMessageRepository(db)
+	publisher := mocks.NewEventPublisherMock()
+
+	var edited, revoked []string
+	waClient := mocks.NewWhatsAppClientMock()
+	waClient.EditFn = func(ctx context.Context, sessionID, chatJID, messageID string, messageType entity.MessageType, text string) error {
+		edited = append(edited, messageID+":"+string(messageType)+":"+text)
+		return nil
+	}
+	waClient.RevokeFn = func(ctx context.Context, sessionID, chatJID, messageID string) error {
+		revoked = append(revoked, messageID)
+		return nil
+	}
+
+	uc := helpers.NewTestMessageUseCaseBuilder().
+		WithWhatsAppClient(waClient).
+		WithEventPublisher(publisher).
+		WithMessageRepository(messageRepo).
+		Build()
+	defer uc.Close()
+
+	ctx := context.Background()
+	saveSentContent := func(id string, sentAt time.Time, msgType entity.MessageType, content entity.MessageContent) {
+		msg := entity.NewMessageBuilder(id, "session-1").
+			InChat("1234567890@s.whatsapp.net").
+			To("+1234567890").
+			WithWhatsAppID("WA-" + id).
+			WithContent(content).
+			WithType(msgType).
+			WithStatus(entity.MessageStatusSent).
+			WithTimestamp(sentAt).
+			Build()
+		require.NoError(t, messageRepo.Save(ctx, msg))
+	}
+	saveSent := func(id string, sentAt time.Time) {
+		saveSentContent(id, sentAt, entity.MessageTypeText, entity.NewTextContent("Helo"))
+	}
+
+	t.Run("EditMessage sends the edit and stores the new text", func(t *testing.T) {
+		saveSent("msg-edit", time.Now())
+
+		msg, err := uc.EditMessage(ctx, dto.EditMessageRequest{MessageID: "msg-edit", Text: "Hello"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"WA-msg-edit:text:Hello"}, edited)
+		assert.NotNil(t, msg.Content.EditedAt)
+
+		stored, err := messageRepo.FindByID(ctx, "msg-edit")
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", *stored.Content.Text)
+
+		events := publisher.GetEvents()
+		require.NotEmpty(t, events)
+		assert.Equal(t, entity.EventTypeMessageEdited, events[len(events)-1].Type)
+	})
+
+	t.Run("EditMessage replaces the caption of a media message", func(t *testing.T) {
+		edited = nil
+		caption := "Sunset"
+		saveSentContent("msg-image", time.Now(), entity.MessageTypeImage, entity.NewImageContent("https://example.com/sunset.jpg", &caption))
+
+		_, err := uc.EditMessage(ctx, dto.EditMessageRequest{MessageID: "msg-image", Text: "Sunset at the beach"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"WA-msg-image:image:Sunset at the beach"}, edited)
+
+		stored, err := messageRepo.FindByID(ctx, "msg-image")
+		require.NoError(t, err)
+		require.NotNil(t, stored.Content.Caption)
+		assert.Equal(t, "Sunset at the beach", *stored.Content.Caption)
+		assert.Nil(t, stored.Content.Text)
+		assert.NotNil(t, stored.Content.EditedAt)
+	})
+
+	t.Run("EditMessage rejects media without a caption", func(t *testing.T) {
+		saveSentContent("msg-audio", time.Now(), entity.MessageTypeAudio, entity.MessageContent{})
+
+		_, err := uc.EditMessage(ctx, dto.EditMessageRequest{MessageID: "msg-audio", Text: "Hello"})
+		assert.ErrorIs(t, err, errors.ErrMessageNotEditable)
+	})
+
+	t.Run("EditMessage rejects messages past the edit window", func(t *testing.T) {
+		saveSent("msg-old", time.Now().Add(-time.Hour))
+
+		_, err := uc.EditMessage(ctx, dto.EditMessageRequest{MessageID: "msg-old", Text: "Hello"})
+		assert.ErrorIs(t, err, errors.ErrMessageNotEditable)
+	})
+
+	t.Run("EditMessage returns not found for unknown messages", func(t *testing.T) {
+		_, err := uc.EditMessage(ctx, dto.EditMessageRequest{MessageID: "missing", Text: "Hello"})
+		assert.ErrorIs(t, err, errors.ErrMessageNotFound)
+	})
+
+	t.Run("RevokeMessage revokes once", func(t *testing.T) {
+		saveSent("msg-revoke", time.Now().Add(-time.Hour))
+
+		_, err := uc.RevokeMessage(ctx, dto.RevokeMessageRequest{MessageID: "msg-revoke"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"WA-msg-revoke"}, revoked)
+
+		stored, err := messageRepo.FindByID(ctx, "msg-revoke")
+		require.NoError(t, err)
+		assert.True(t, stored.IsRevoked())
+		assert.Nil(t, stored.Content.Text)
+
+		events := publisher.GetEvents()
+		require.NotEmpty(t, events)
+		assert.Equal(t, entity.EventTypeMessageRevoked, events[len(events)-1].Type)
+
+		_, err = uc.RevokeMessage(ctx, dto.RevokeMessageRequest{MessageID: "msg-revoke"})
+		assert.ErrorIs(t, err, errors.ErrMessageNotRevocable)
+	})
+}
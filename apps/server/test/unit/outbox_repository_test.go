@@ -0,0 +1,156 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== OutboxRepository Tests ====================
+
+func TestOutboxRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewOutboxRepository(db)
+
+	t.Run("Enqueue and ClaimDue", func(t *testing.T) {
+		db.Exec("DELETE FROM outbox_messages WHERE 1=1")
+
+		msg := newTestHistoryMessage("msg-1", "session-1", "1234567890@s.whatsapp.net", time.Now())
+		require.NoError(t, repo.Enqueue(ctx, entity.NewOutboxEntry(msg)))
+
+		entries, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, entity.OutboxStatusInFlight, entries[0].Status)
+		assert.Equal(t, "msg-1", entries[0].Message.ID)
+		assert.Equal(t, "1234567890", entries[0].Message.To)
+		require.NotNil(t, entries[0].Message.Content.Text)
+		assert.Equal(t, "message msg-1", *entries[0].Message.Content.Text)
+
+		// Claimed entries are not claimed twice
+		entries, err = repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Enqueue duplicate fails", func(t *testing.T) {
+		db.Exec("DELETE FROM outbox_messages WHERE 1=1")
+
+		msg := newTestHistoryMessage("msg-1", "session-1", "chat@s.whatsapp.net", time.Now())
+		require.NoError(t, repo.Enqueue(ctx, entity.NewOutboxEntry(msg)))
+
+		err := repo.Enqueue(ctx, entity.NewOutboxEntry(msg))
+		assert.ErrorIs(t, err, errors.ErrDuplicate)
+	})
+
+	t.Run("ClaimDue skips entries not yet due", func(t *testing.T) {
+		db.Exec("DELETE FROM outbox_messages WHERE 1=1")
+
+		msg := newTestHistoryMessage("msg-1", "session-1", "chat@s.whatsapp.net", time.Now())
+		require.NoError(t, repo.Enqueue(ctx, entity.NewOutboxEntry(msg)))
+		require.NoError(t, repo.Reschedule(ctx, "msg-1", 1, time.Now().Add(time.Hour), "timeout"))
+
+		entries, err := repo.ClaimDue(ctx, time.Now(), 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		entries, err = repo.ClaimDue(ctx, time.Now().Add(2*time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, 1, entries[0].Attempts)
+		assert.Equal(t, "timeout", entries[0].LastError)
+	})
+
+	t.Run("MarkSent and MarkFailed leave the queue", func(t *testing.T) {
+		db.Exec("DELETE FROM outbox_messages WHERE 1=1")
+
+		for _, id := range []string{"msg-1", "msg-2", "msg-3"} {
+			msg := newTestHistoryMessage(id, "session-1", "chat@s.whatsapp.net", time.Now())
+			require.NoError(t, repo.Enqueue(ctx, entity.NewOutboxEntry(msg)))
+		}
+
+		require.NoError(t, repo.MarkSent(ctx, "msg-1"))
+		require.NoError(t, repo.MarkFailed(ctx, "msg-2", 3, "boom"))
+
+		count, err := repo.CountPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		err = repo.MarkSent(ctx, "missing")
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+
+	t.Run("ResetInFlight returns abandoned claims to pending", func(t *testing.T) {
+		db.Exec("DELETE FROM outbox_messages WHERE 1=1")
+
+		msg := newTestHistoryMessage("msg-1", "session-1", "chat@s.whatsapp.net", time.Now())
+		require.NoError(t, repo.Enqueue(ctx, entity.NewOutboxEntry(msg)))
+
+		entries, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		// A claim made since the cutoff may still be sending
+		reset, err := repo.ResetInFlight(ctx, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Zero(t, reset)
+
+		reset, err = repo.ResetInFlight(ctx, time.Now().Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), reset)
+
+		entries, err = repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
+
+func TestOutboxRepository_ConcurrentClaims(t *testing.T) {
+	ctx := context.Background()
+	db := setupSharedTestDB(t)
+	repo := persistence.NewOutboxRepository(db)
+
+	const total = 60
+	for i := 0; i < total; i++ {
+		msg := newTestHistoryMessage(fmt.Sprintf("msg-%d", i), "session-1", "chat@s.whatsapp.net", time.Now())
+		require.NoError(t, repo.Enqueue(ctx, entity.NewOutboxEntry(msg)))
+	}
+
+	// Several processors drain the outbox at once, as replicas sharing a database do
+	var mu sync.Mutex
+	claims := make(map[string]int)
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				entries, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), 5)
+				if !assert.NoError(t, err) || len(entries) == 0 {
+					return
+				}
+				mu.Lock()
+				for _, entry := range entries {
+					claims[entry.Message.ID]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, claims, total)
+	for id, count := range claims {
+		assert.Equal(t, 1, count, "%s was claimed more than once", id)
+	}
+}
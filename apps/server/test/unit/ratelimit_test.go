@@ -272,3 +272,63 @@ func TestLimiter_TokenRefill(t *testing.T) {
 	// Should be allowed again
 	assert.True(t, limiter.Allow(key))
 }
+
+func TestAuthLockout_LocksAfterThreshold(t *testing.T) {
+	lockout := ratelimit.NewAuthLockout(ratelimit.LockoutConfig{
+		Threshold: 3,
+		Window:    time.Minute,
+		Duration:  time.Minute,
+	})
+
+	_, locked := lockout.RecordFailure("10.0.0.1")
+	assert.False(t, locked)
+	_, locked = lockout.RecordFailure("10.0.0.1")
+	assert.False(t, locked)
+
+	_, locked = lockout.LockedUntil("10.0.0.1")
+	assert.False(t, locked, "Client should not be locked out below the threshold")
+
+	lockedUntil, locked := lockout.RecordFailure("10.0.0.1")
+	require.True(t, locked, "Reaching the threshold should start a lockout")
+	assert.WithinDuration(t, time.Now().Add(time.Minute), lockedUntil, time.Second)
+
+	until, locked := lockout.LockedUntil("10.0.0.1")
+	assert.True(t, locked)
+	assert.Equal(t, lockedUntil, until)
+
+	// Other clients are unaffected
+	_, locked = lockout.LockedUntil("10.0.0.2")
+	assert.False(t, locked)
+}
+
+func TestAuthLockout_ResetClearsFailures(t *testing.T) {
+	lockout := ratelimit.NewAuthLockout(ratelimit.LockoutConfig{
+		Threshold: 2,
+		Window:    time.Minute,
+		Duration:  time.Minute,
+	})
+
+	_, locked := lockout.RecordFailure("10.0.0.1")
+	assert.False(t, locked)
+
+	lockout.Reset("10.0.0.1")
+
+	_, locked = lockout.RecordFailure("10.0.0.1")
+	assert.False(t, locked, "Failures before a reset should not count")
+}
+
+func TestAuthLockout_Expires(t *testing.T) {
+	lockout := ratelimit.NewAuthLockout(ratelimit.LockoutConfig{
+		Threshold: 1,
+		Window:    time.Minute,
+		Duration:  50 * time.Millisecond,
+	})
+
+	_, locked := lockout.RecordFailure("10.0.0.1")
+	require.True(t, locked)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, locked = lockout.LockedUntil("10.0.0.1")
+	assert.False(t, locked, "Lockout should expire after its duration")
+}
@@ -2,11 +2,15 @@ package unit
 
 import (
 	"context"
+	"path/filepath"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
 	"whatspire/internal/domain/entity"
 	"whatspire/internal/infrastructure/persistence"
+	"whatspire/internal/infrastructure/persistence/models"
 	"whatspire/test/helpers"
 
 	"github.com/google/uuid"
@@ -28,6 +32,23 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	return db
 }
 
+// setupSharedTestDB creates a migrated file database that several connections can use at once,
+// for tests of concurrent access
+func setupSharedTestDB(t *testing.T) *gorm.DB {
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = persistence.RunAutoMigration(db, helpers.CreateTestLogger())
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	return db
+}
+
 // TestReactionRepository tests the reaction repository operations
 func TestReactionRepository(t *testing.T) {
 	ctx := context.Background()
@@ -310,3 +331,159 @@ func TestPresenceRepository(t *testing.T) {
 		assert.Len(t, presences, 0)
 	})
 }
+
+// TestSessionRepository_Settings tests that session behaviour settings are persisted
+func TestSessionRepository_Settings(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewSessionRepository(db)
+
+	t.Run("Create keeps disabled message logging", func(t *testing.T) {
+		db.Exec("DELETE FROM sessions WHERE 1=1")
+
+		session := entity.NewSession(uuid.New().String(), "Test")
+		session.Settings.MessageLogging = false
+		session.Settings.IgnoreChannels = true
+		require.NoError(t, repo.Create(ctx, session))
+
+		stored, err := repo.GetByID(ctx, session.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.Settings.MessageLogging)
+		assert.True(t, stored.Settings.IgnoreChannels)
+	})
+
+	t.Run("Update replaces settings", func(t *testing.T) {
+		db.Exec("DELETE FROM sessions WHERE 1=1")
+
+		session := entity.NewSession(uuid.New().String(), "Test")
+		require.NoError(t, repo.Create(ctx, session))
+
+		session.SetSettings(entity.SessionSettings{
+			AccountProtection: true,
+			ReadMessages:      true,
+			AutoRejectCalls:   true,
+			AlwaysOnline:      true,
+			IgnoreGroups:      true,
+			IgnoreBroadcasts:  true,
+		})
+		require.NoError(t, repo.Update(ctx, session))
+
+		sessions, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, session.Settings, sessions[0].Settings)
+	})
+}
+
+// TestEventRepository_Sequences tests per-session sequence numbering and replay queries
+func TestEventRepository_Sequences(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewEventRepository(db)
+
+	store := func(sessionID string) *entity.Event {
+		event := entity.NewEvent(uuid.New().String(), entity.EventTypeMessageReceived, sessionID, nil)
+		require.NoError(t, repo.Create(ctx, event))
+		return event
+	}
+
+	t.Run("numbers each session independently", func(t *testing.T) {
+		assert.Equal(t, int64(1), store("session-a").Sequence)
+		assert.Equal(t, int64(2), store("session-a").Sequence)
+		assert.Equal(t, int64(1), store("session-b").Sequence)
+		assert.Equal(t, int64(3), store("session-a").Sequence)
+	})
+
+	t.Run("continues from the stored sequence", func(t *testing.T) {
+		latest, err := repo.LatestSequence(ctx, "session-a")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), latest)
+
+		restarted := persistence.NewEventSequencer(repo)
+		event := entity.NewEvent(uuid.New().String(), entity.EventTypeMessageSent, "session-a", nil)
+		require.NoError(t, restarted.Assign(ctx, event))
+		assert.Equal(t, int64(4), event.Sequence)
+
+		latest, err = repo.LatestSequence(ctx, "unknown")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), latest)
+	})
+
+	t.Run("lists events after a sequence oldest first", func(t *testing.T) {
+		events, err := repo.ListAfterSequence(ctx, "session-a", 1, 0)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, int64(2), events[0].Sequence)
+		assert.Equal(t, int64(3), events[1].Sequence)
+
+		events, err = repo.ListAfterSequence(ctx, "session-a", 0, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, int64(1), events[0].Sequence)
+	})
+
+	t.Run("a failed save leaves no gap", func(t *testing.T) {
+		stored := store("session-c")
+
+		duplicate := entity.NewEvent(stored.ID, entity.EventTypeMessageReceived, "session-c", nil)
+		require.Error(t, repo.Create(ctx, duplicate))
+
+		assert.Equal(t, stored.Sequence+1, store("session-c").Sequence)
+	})
+
+	t.Run("numbering continues after old events are deleted", func(t *testing.T) {
+		last := store("session-d")
+		require.NoError(t, db.Where("session_id = ?", "session-d").Delete(&models.Event{}).Error)
+
+		assert.Equal(t, last.Sequence+1, store("session-d").Sequence)
+	})
+
+	t.Run("a sequence number is stored once per session", func(t *testing.T) {
+		stored := store("session-e")
+
+		err := db.Create(&models.Event{
+			ID:        uuid.New().String(),
+			Type:      string(entity.EventTypeMessageReceived),
+			SessionID: "session-e",
+			Sequence:  stored.Sequence,
+			Timestamp: time.Now(),
+			CreatedAt: time.Now(),
+		}).Error
+		assert.Error(t, err)
+	})
+}
+
+// TestEventRepository_ConcurrentSequences tests that events stored at once, as by replicas sharing
+// a database, get distinct consecutive sequence numbers
+func TestEventRepository_ConcurrentSequences(t *testing.T) {
+	ctx := context.Background()
+	db := setupSharedTestDB(t)
+	repo := persistence.NewEventRepository(db)
+
+	const workers, perWorker = 4, 15
+	var mu sync.Mutex
+	var sequences []int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				event := entity.NewEvent(uuid.New().String(), entity.EventTypeMessageReceived, "session-1", nil)
+				if !assert.NoError(t, repo.Create(ctx, event)) {
+					return
+				}
+				mu.Lock()
+				sequences = append(sequences, event.Sequence)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(sequences, func(i, j int) bool { return sequences[i] < sequences[j] })
+	require.Len(t, sequences, workers*perWorker)
+	for i, sequence := range sequences {
+		assert.Equal(t, int64(i+1), sequence)
+	}
+}
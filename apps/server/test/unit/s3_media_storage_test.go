@@ -0,0 +1,188 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/storage"
+	"whatspire/test/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestS3MediaStorage(t *testing.T, server *helpers.FakeS3Server, prefix string) *storage.S3MediaStorage {
+	t.Helper()
+
+	mediaStorage, err := storage.NewS3MediaStorage(
+		repository.MediaStorageConfig{MaxFileSize: 1024},
+		storage.S3Config{
+			Endpoint:        server.URL,
+			Bucket:          server.Bucket,
+			AccessKeyID:     server.AccessKeyID,
+			SecretAccessKey: "test-secret",
+			Prefix:          prefix,
+			PathStyle:       true,
+			URLExpiry:       15 * time.Minute,
+		},
+	)
+	require.NoError(t, err)
+	return mediaStorage
+}
+
+func TestNewS3MediaStorage_Validation(t *testing.T) {
+	config := repository.MediaStorageConfig{MaxFileSize: 1024}
+
+	_, err := storage.NewS3MediaStorage(config, storage.S3Config{})
+	assert.Error(t, err, "bucket is required")
+
+	_, err = storage.NewS3MediaStorage(config, storage.S3Config{Bucket: "media", Endpoint: "minio:9000"})
+	assert.Error(t, err, "endpoint must be an http(s) URL")
+
+	_, err = storage.NewS3MediaStorage(config, storage.S3Config{Bucket: "media", URLExpiry: 8 * 24 * time.Hour})
+	assert.Error(t, err, "presigned URLs are limited to 7 days")
+}
+
+func TestS3MediaStorage(t *testing.T) {
+	ctx := context.Background()
+	server := helpers.NewFakeS3Server(t, "media", "test-access-key")
+	mediaStorage := newTestS3MediaStorage(t, server, "whatspire")
+
+	var _ repository.MediaStorage = mediaStorage
+
+	t.Run("DownloadAndStore uploads media with its content hash", func(t *testing.T) {
+		data := []byte("fake image data")
+
+		filePath, publicURL, err := mediaStorage.DownloadAndStore(ctx, "session-1", "msg-1", bytes.NewReader(data), "image/jpeg", "jpg")
+		require.NoError(t, err)
+		assert.Equal(t, "session-1/msg-1.jpg", filePath)
+		assert.Equal(t, "s3://media/whatspire/session-1/msg-1.jpg", mediaStorage.GetMediaPath(filePath))
+
+		object := server.Object("whatspire/session-1/msg-1.jpg")
+		require.NotNil(t, object)
+		assert.Equal(t, data, object.Data)
+		assert.Equal(t, "image/jpeg", object.ContentType)
+
+		sum := sha256.Sum256(data)
+		hash, err := mediaStorage.ContentHash(ctx, filePath)
+		require.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(sum[:]), hash)
+
+		// The returned URL is presigned and downloads the media without credentials
+		assert.Contains(t, publicURL, "X-Amz-Signature=")
+		assert.Contains(t, publicURL, "X-Amz-Expires=900")
+		resp, err := http.Get(publicURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, data, body)
+	})
+
+	t.Run("OpenMedia reads the object with its content hash as ETag", func(t *testing.T) {
+		data := []byte("document data")
+		filePath, _, err := mediaStorage.DownloadAndStore(ctx, "session-1", "doc-1", bytes.NewReader(data), "application/pdf", "pdf")
+		require.NoError(t, err)
+
+		file, err := mediaStorage.OpenMedia(ctx, filePath)
+		require.NoError(t, err)
+		defer file.Content.Close()
+
+		body, err := io.ReadAll(file.Content)
+		require.NoError(t, err)
+		assert.Equal(t, data, body)
+		assert.Equal(t, "application/pdf", file.ContentType)
+		assert.Equal(t, int64(len(data)), file.Size)
+
+		sum := sha256.Sum256(data)
+		assert.Equal(t, `"`+hex.EncodeToString(sum[:])+`"`, file.ETag)
+
+		_, err = mediaStorage.OpenMedia(ctx, "session-1/missing.pdf")
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+
+	t.Run("presigned URLs expire", func(t *testing.T) {
+		_, _, err := mediaStorage.DownloadAndStore(ctx, "session-1", "msg-2", bytes.NewReader([]byte("data")), "image/png", ".png")
+		require.NoError(t, err)
+
+		mediaURL := mediaStorage.GetMediaURL("session-1/msg-2.png")
+
+		server.SetNow(func() time.Time { return time.Now().Add(16 * time.Minute) })
+		defer server.SetNow(time.Now)
+
+		resp, err := http.Get(mediaURL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("DownloadAndStore rejects media over the size limit", func(t *testing.T) {
+		objects := server.Len()
+
+		_, _, err := mediaStorage.DownloadAndStore(ctx, "session-1", "too-large", bytes.NewReader(make([]byte, 1025)), "video/mp4", "mp4")
+		assert.ErrorIs(t, err, errors.ErrMediaTooLarge)
+		assert.Equal(t, objects, server.Len(), "nothing is uploaded")
+	})
+
+	t.Run("DownloadAndStore reports object store errors", func(t *testing.T) {
+		wrongCredentials, err := storage.NewS3MediaStorage(
+			repository.MediaStorageConfig{MaxFileSize: 1024},
+			storage.S3Config{Endpoint: server.URL, Bucket: server.Bucket, AccessKeyID: "unknown-access-key", PathStyle: true},
+		)
+		require.NoError(t, err)
+
+		_, _, err = wrongCredentials.DownloadAndStore(ctx, "session-1", "msg-1", bytes.NewReader([]byte("data")), "image/jpeg", "jpg")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "InvalidAccessKeyId")
+	})
+
+	t.Run("DeleteMedia removes the object", func(t *testing.T) {
+		_, _, err := mediaStorage.DownloadAndStore(ctx, "session-2", "msg-1", bytes.NewReader([]byte("data")), "audio/ogg", "ogg")
+		require.NoError(t, err)
+
+		require.NoError(t, mediaStorage.DeleteMedia(ctx, "session-2/msg-1.ogg"))
+		assert.Nil(t, server.Object("whatspire/session-2/msg-1.ogg"))
+
+		err = mediaStorage.DeleteMedia(ctx, "session-2/msg-1.ogg")
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+}
+
+func TestS3MediaStorage_ListMedia(t *testing.T) {
+	ctx := context.Background()
+	server := helpers.NewFakeS3Server(t, "media", "test-access-key")
+	server.MaxKeys = 2 // Force the listing across several pages
+	mediaStorage := newTestS3MediaStorage(t, server, "whatspire")
+
+	for _, messageID := range []string{"msg-1", "msg-2", "msg-3"} {
+		_, _, err := mediaStorage.DownloadAndStore(ctx, "session-1", messageID, bytes.NewReader([]byte(messageID+" data")), "image/jpeg", ".jpg")
+		require.NoError(t, err)
+	}
+	// Objects outside the prefix belong to someone else
+	other := newTestS3MediaStorage(t, server, "other")
+	_, _, err := other.DownloadAndStore(ctx, "session-1", "msg-4", bytes.NewReader([]byte("other")), "image/jpeg", ".jpg")
+	require.NoError(t, err)
+
+	media, err := mediaStorage.ListMedia(ctx)
+	require.NoError(t, err)
+	require.Len(t, media, 3)
+
+	for i, m := range media {
+		messageID := []string{"msg-1", "msg-2", "msg-3"}[i]
+		assert.Equal(t, "session-1/"+messageID+".jpg", m.Path)
+		assert.Equal(t, "session-1", m.SessionID)
+		assert.Equal(t, messageID, m.MessageID)
+		assert.Equal(t, int64(len(messageID+" data")), m.Size)
+		assert.False(t, m.ModTime.IsZero())
+		assert.Equal(t, m.ModTime, m.LastAccessed)
+	}
+}
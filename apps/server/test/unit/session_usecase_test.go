@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"whatspire/internal/application/dto"
 	"whatspire/internal/application/usecase"
 	"whatspire/internal/domain/entity"
 	"whatspire/internal/domain/errors"
@@ -412,3 +413,67 @@ func TestSessionUseCase_DisconnectSession_SessionNotInRepo(t *testing.T) {
 	// Client should be disconnected
 	assert.False(t, waClient.IsConnected("test-id"))
 }
+
+func TestSessionUseCase_CreateSessionWithConfig(t *testing.T) {
+	repo := mocks.NewSessionRepositoryMock()
+	waClient := mocks.NewWhatsAppClientMock()
+
+	uc := usecase.NewSessionUseCase(repo, waClient, nil, nil)
+
+	enabled := true
+	disabled := false
+	session, err := uc.CreateSessionWithConfig(context.Background(), "test-id", "Test Session", &dto.SessionConfig{
+		ReadMessages:   &enabled,
+		IgnoreGroups:   &enabled,
+		MessageLogging: &disabled,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, session.Settings.ReadMessages)
+	assert.True(t, session.Settings.IgnoreGroups)
+	assert.False(t, session.Settings.MessageLogging)
+	assert.False(t, session.Settings.AlwaysOnline)
+
+	// Verify settings were pushed to the client
+	assert.Equal(t, session.Settings, waClient.GetSessionSettings("test-id"))
+}
+
+func TestSessionUseCase_UpdateSession_Config(t *testing.T) {
+	repo := mocks.NewSessionRepositoryMock()
+	waClient := mocks.NewWhatsAppClientMock()
+
+	existingSession := entity.NewSession("test-id", "Test Session")
+	existingSession.Settings.ReadMessages = true
+	repo.Sessions["test-id"] = existingSession
+
+	uc := usecase.NewSessionUseCase(repo, waClient, nil, nil)
+
+	// Only explicitly set options change
+	enabled := true
+	session, err := uc.UpdateSession(context.Background(), "test-id", nil, &dto.SessionConfig{
+		AutoRejectCalls: &enabled,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Test Session", session.Name)
+	assert.True(t, session.Settings.AutoRejectCalls)
+	assert.True(t, session.Settings.ReadMessages)
+	assert.True(t, session.Settings.MessageLogging)
+	assert.Equal(t, session.Settings, waClient.GetSessionSettings("test-id"))
+}
+
+func TestSessionUseCase_ReconnectSession_AppliesStoredSettings(t *testing.T) {
+	repo := mocks.NewSessionRepositoryMock()
+	waClient := mocks.NewWhatsAppClientMock()
+
+	existingSession := entity.NewSession("test-id", "Test Session")
+	existingSession.Settings.AlwaysOnline = true
+	repo.Sessions["test-id"] = existingSession
+
+	uc := usecase.NewSessionUseCase(repo, waClient, nil, nil)
+
+	err := uc.ReconnectSession(context.Background(), "test-id")
+
+	require.NoError(t, err)
+	assert.True(t, waClient.GetSessionSettings("test-id").AlwaysOnline)
+}
@@ -0,0 +1,118 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== WebhookConfig Entity Tests ====================
+
+func TestWebhookConfig_ShouldDeliverChat(t *testing.T) {
+	config := entity.NewWebhookConfig("cfg-1", "session-1")
+	config.Update(true, "https://example.com/hook", nil, true, false, true)
+
+	assert.True(t, config.ShouldDeliverChat(""))
+	assert.True(t, config.ShouldDeliverChat("123@s.whatsapp.net"))
+	assert.False(t, config.ShouldDeliverChat("123-456@g.us"))
+	assert.True(t, config.ShouldDeliverChat("status@broadcast"))
+	assert.False(t, config.ShouldDeliverChat("123@newsletter"))
+}
+
+// ==================== CachedWebhookConfigRepository Tests ====================
+
+func TestCachedWebhookConfigRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewCachedWebhookConfigRepository(persistence.NewWebhookConfigRepository(db), time.Hour)
+
+	newConfig := func() *entity.WebhookConfig {
+		config := entity.NewWebhookConfig("cfg-1", "session-1")
+		config.Update(true, "https://example.com/hook", []string{"message.received"}, false, false, false)
+		return config
+	}
+
+	t.Run("missing configurations are cached until created", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_configs WHERE 1=1")
+		repo.Invalidate("session-1")
+
+		_, err := repo.GetBySessionID(ctx, "session-1")
+		assert.True(t, errors.IsNotFound(err))
+
+		require.NoError(t, repo.Create(ctx, newConfig()))
+
+		config, err := repo.GetBySessionID(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/hook", config.URL)
+	})
+
+	t.Run("updates invalidate the cache", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_configs WHERE 1=1")
+		repo.Invalidate("session-1")
+		require.NoError(t, repo.Create(ctx, newConfig()))
+
+		config, err := repo.GetBySessionID(ctx, "session-1")
+		require.NoError(t, err)
+		require.NoError(t, config.GenerateSecret())
+		require.NoError(t, repo.Update(ctx, config))
+
+		updated, err := repo.GetBySessionID(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Equal(t, config.Secret, updated.Secret)
+	})
+
+	t.Run("deletes invalidate the cache", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_configs WHERE 1=1")
+		repo.Invalidate("session-1")
+		require.NoError(t, repo.Create(ctx, newConfig()))
+
+		_, err := repo.GetBySessionID(ctx, "session-1")
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, "session-1"))
+
+		_, err = repo.GetBySessionID(ctx, "session-1")
+		assert.True(t, errors.IsNotFound(err))
+	})
+
+	t.Run("writes that bypass the repository are not seen until invalidated", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_configs WHERE 1=1")
+		repo.Invalidate("session-1")
+		require.NoError(t, repo.Create(ctx, newConfig()))
+
+		_, err := repo.GetBySessionID(ctx, "session-1")
+		require.NoError(t, err)
+		db.Exec("UPDATE webhook_configs SET url = ? WHERE session_id = ?", "https://example.com/other", "session-1")
+
+		cached, err := repo.GetBySessionID(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/hook", cached.URL)
+
+		repo.Invalidate("session-1")
+		fresh, err := repo.GetBySessionID(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/other", fresh.URL)
+	})
+
+	t.Run("callers get their own copy", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_configs WHERE 1=1")
+		repo.Invalidate("session-1")
+		require.NoError(t, repo.Create(ctx, newConfig()))
+
+		config, err := repo.GetBySessionID(ctx, "session-1")
+		require.NoError(t, err)
+		config.URL = "https://example.com/changed"
+		config.Events[0] = "message.sent"
+
+		again, err := repo.GetBySessionID(ctx, "session-1")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/hook", again.URL)
+		assert.Equal(t, []string{"message.received"}, again.Events)
+	})
+}
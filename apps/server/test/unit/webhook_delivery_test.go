@@ -0,0 +1,253 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/domain/repository"
+	"whatspire/internal/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookDelivery(id, sessionID string) *entity.WebhookDelivery {
+	event := entity.NewEvent("evt-"+id, entity.EventTypeMessageReceived, sessionID, json.RawMessage(`{}`))
+	return entity.NewWebhookDelivery(id, event, "", "https://example.com/hook", []byte(`{"id":"evt-`+id+`"}`))
+}
+
+// ==================== WebhookDelivery Entity Tests ====================
+
+func TestWebhookDelivery_RecordFailure(t *testing.T) {
+	schedule := []time.Duration{time.Minute, time.Hour}
+
+	t.Run("retryable failures follow the schedule", func(t *testing.T) {
+		delivery := newTestWebhookDelivery("d-1", "session-1")
+
+		delivery.RecordFailure(503, "unavailable", "status 503", true, schedule)
+		assert.Equal(t, entity.WebhookDeliveryStatusPending, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), delivery.NextAttemptAt, time.Second)
+
+		delivery.RecordFailure(503, "unavailable", "status 503", true, schedule)
+		assert.Equal(t, entity.WebhookDeliveryStatusPending, delivery.Status)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), delivery.NextAttemptAt, time.Second)
+	})
+
+	t.Run("dead-lettered once the schedule is used up", func(t *testing.T) {
+		delivery := newTestWebhookDelivery("d-1", "session-1")
+
+		for i := 0; i < 3; i++ {
+			delivery.RecordFailure(0, "", "connection refused", true, schedule)
+		}
+		assert.Equal(t, entity.WebhookDeliveryStatusDeadLetter, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.Equal(t, "connection refused", delivery.LastError)
+	})
+
+	t.Run("non-retryable failures are dead-lettered immediately", func(t *testing.T) {
+		delivery := newTestWebhookDelivery("d-1", "session-1")
+
+		delivery.RecordFailure(400, "bad request", "status 400", false, schedule)
+		assert.Equal(t, entity.WebhookDeliveryStatusDeadLetter, delivery.Status)
+		assert.Equal(t, 400, delivery.LastStatusCode)
+	})
+
+	t.Run("requeue starts a fresh schedule", func(t *testing.T) {
+		delivery := newTestWebhookDelivery("d-1", "session-1")
+		delivery.RecordFailure(400, "", "status 400", false, schedule)
+
+		delivery.Requeue()
+		assert.Equal(t, entity.WebhookDeliveryStatusPending, delivery.Status)
+		assert.Equal(t, 0, delivery.Attempts)
+		assert.WithinDuration(t, time.Now(), delivery.NextAttemptAt, time.Second)
+	})
+}
+
+// ==================== WebhookDeliveryRepository Tests ====================
+
+func TestWebhookDeliveryRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewWebhookDeliveryRepository(db)
+
+	t.Run("Enqueue and ClaimDue", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_deliveries WHERE 1=1")
+
+		require.NoError(t, repo.Enqueue(ctx, newTestWebhookDelivery("d-1", "session-1")))
+
+		deliveries, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, entity.WebhookDeliveryStatusInFlight, deliveries[0].Status)
+		assert.Equal(t, entity.EventTypeMessageReceived, deliveries[0].EventType)
+		assert.JSONEq(t, `{"id":"evt-d-1"}`, string(deliveries[0].Payload))
+
+		// Claimed deliveries are not claimed twice
+		deliveries, err = repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("RecordAttempt reschedules and dead-letters", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_deliveries WHERE 1=1")
+
+		require.NoError(t, repo.Enqueue(ctx, newTestWebhookDelivery("d-1", "session-1")))
+		deliveries, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+
+		delivery := deliveries[0]
+		delivery.RecordFailure(502, "bad gateway", "status 502", true, []time.Duration{time.Hour})
+		require.NoError(t, repo.RecordAttempt(ctx, delivery))
+
+		// Not due until the retry time
+		deliveries, err = repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+
+		stored, err := repo.GetByID(ctx, "d-1")
+		require.NoError(t, err)
+		assert.Equal(t, entity.WebhookDeliveryStatusPending, stored.Status)
+		assert.Equal(t, 1, stored.Attempts)
+		assert.Equal(t, 502, stored.LastStatusCode)
+		assert.Equal(t, "bad gateway", stored.LastResponse)
+	})
+
+	t.Run("Requeue rejects in-flight deliveries", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_deliveries WHERE 1=1")
+
+		require.NoError(t, repo.Enqueue(ctx, newTestWebhookDelivery("d-1", "session-1")))
+		deliveries, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+
+		delivery := deliveries[0]
+		delivery.Requeue()
+		assert.ErrorIs(t, repo.Requeue(ctx, delivery), errors.ErrWebhookDeliveryInFlight)
+
+		missing := newTestWebhookDelivery("missing", "session-1")
+		err = repo.Requeue(ctx, missing)
+		assert.True(t, errors.IsNotFound(err))
+	})
+
+	t.Run("ResetInFlight returns abandoned claims to pending", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_deliveries WHERE 1=1")
+
+		require.NoError(t, repo.Enqueue(ctx, newTestWebhookDelivery("d-1", "session-1")))
+		_, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+
+		// A claim made since the cutoff may still be delivering
+		reset, err := repo.ResetInFlight(ctx, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Zero(t, reset)
+
+		reset, err = repo.ResetInFlight(ctx, time.Now().Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), reset)
+
+		deliveries, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+	})
+
+	t.Run("Release returns unattempted claims to pending", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_deliveries WHERE 1=1")
+
+		require.NoError(t, repo.Enqueue(ctx, newTestWebhookDelivery("d-1", "session-1")))
+		require.NoError(t, repo.Enqueue(ctx, newTestWebhookDelivery("d-2", "session-1")))
+		deliveries, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+
+		delivery := deliveries[0]
+		delivery.RecordSuccess(200, "ok")
+		require.NoError(t, repo.RecordAttempt(ctx, delivery))
+
+		// Only deliveries still in flight are released
+		require.NoError(t, repo.Release(ctx, []string{"d-1", "d-2"}))
+
+		deliveries, err = repo.ClaimDue(ctx, time.Now().Add(time.Second), 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "d-2", deliveries[0].ID)
+	})
+
+	t.Run("List and Count filter by session and status", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_deliveries WHERE 1=1")
+
+		for _, d := range []*entity.WebhookDelivery{
+			newTestWebhookDelivery("d-1", "session-1"),
+			newTestWebhookDelivery("d-2", "session-1"),
+			newTestWebhookDelivery("d-3", "session-2"),
+		} {
+			require.NoError(t, repo.Enqueue(ctx, d))
+		}
+
+		dead := newTestWebhookDelivery("d-2", "session-1")
+		dead.RecordFailure(400, "", "status 400", false, nil)
+		require.NoError(t, repo.RecordAttempt(ctx, dead))
+
+		sessionID := "session-1"
+		deliveries, err := repo.List(ctx, repository.WebhookDeliveryFilter{SessionID: &sessionID})
+		require.NoError(t, err)
+		assert.Len(t, deliveries, 2)
+
+		status := entity.WebhookDeliveryStatusDeadLetter
+		filter := repository.WebhookDeliveryFilter{SessionID: &sessionID, Status: &status}
+		deliveries, err = repo.List(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "d-2", deliveries[0].ID)
+
+		count, err := repo.Count(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func TestWebhookDeliveryRepository_ConcurrentClaims(t *testing.T) {
+	ctx := context.Background()
+	db := setupSharedTestDB(t)
+	repo := persistence.NewWebhookDeliveryRepository(db)
+
+	const total = 60
+	for i := 0; i < total; i++ {
+		require.NoError(t, repo.Enqueue(ctx, newTestWebhookDelivery(fmt.Sprintf("d-%d", i), "session-1")))
+	}
+
+	// Several queues drain the deliveries at once, as replicas sharing a database do
+	var mu sync.Mutex
+	claims := make(map[string]int)
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				deliveries, err := repo.ClaimDue(ctx, time.Now().Add(time.Second), 5)
+				if !assert.NoError(t, err) || len(deliveries) == 0 {
+					return
+				}
+				mu.Lock()
+				for _, delivery := range deliveries {
+					claims[delivery.ID]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, claims, total)
+	for id, count := range claims {
+		assert.Equal(t, 1, count, "%s was claimed more than once", id)
+	}
+}
@@ -0,0 +1,168 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"whatspire/internal/application/dto"
+	"whatspire/internal/domain/entity"
+	"whatspire/internal/domain/errors"
+	"whatspire/internal/infrastructure/persistence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookEndpoint(id, sessionID string) *entity.WebhookEndpoint {
+	return entity.NewWebhookEndpoint(id, sessionID, entity.WebhookEndpointSettings{
+		Name:    "crm",
+		Enabled: true,
+		URL:     "https://example.com/hook",
+		Events:  []string{"message.*"},
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+}
+
+// ==================== WebhookEndpoint Entity Tests ====================
+
+func TestWebhookEndpoint_ShouldDeliverEvent(t *testing.T) {
+	endpoint := newTestWebhookEndpoint("ep-1", "session-1")
+
+	assert.True(t, endpoint.ShouldDeliverEvent(entity.EventTypeMessageReceived))
+	assert.True(t, endpoint.ShouldDeliverEvent(entity.EventTypeMessageReaction))
+	assert.False(t, endpoint.ShouldDeliverEvent(entity.EventTypeConnected))
+
+	endpoint.Update(entity.WebhookEndpointSettings{Enabled: true, URL: endpoint.URL})
+	assert.Equal(t, []string{}, endpoint.Events)
+	assert.True(t, endpoint.ShouldDeliverEvent(entity.EventTypeConnected))
+
+	endpoint.Update(entity.WebhookEndpointSettings{Enabled: false, URL: endpoint.URL})
+	assert.False(t, endpoint.ShouldDeliverEvent(entity.EventTypeConnected))
+}
+
+// ==================== WebhookEndpointRequest Tests ====================
+
+func TestWebhookEndpointRequest_Validate(t *testing.T) {
+	valid := func() dto.WebhookEndpointRequest {
+		return dto.WebhookEndpointRequest{
+			URL:     "https://example.com/hook",
+			Events:  []string{"message.*", "connection.connected"},
+			Headers: map[string]string{"Authorization": "Bearer token"},
+		}
+	}
+
+	req := valid()
+	require.NoError(t, req.Validate())
+	assert.True(t, req.Settings().Enabled, "endpoints are enabled by default")
+
+	tests := map[string]func(r *dto.WebhookEndpointRequest){
+		"missing url":          func(r *dto.WebhookEndpointRequest) { r.URL = "" },
+		"unsupported scheme":   func(r *dto.WebhookEndpointRequest) { r.URL = "ftp://example.com/hook" },
+		"unknown event":        func(r *dto.WebhookEndpointRequest) { r.Events = []string{"message.unknown"} },
+		"invalid header name":  func(r *dto.WebhookEndpointRequest) { r.Headers = map[string]string{"Bad Header": "x"} },
+		"reserved header":      func(r *dto.WebhookEndpointRequest) { r.Headers = map[string]string{"content-type": "text/plain"} },
+		"signature header":     func(r *dto.WebhookEndpointRequest) { r.Headers = map[string]string{"X-Webhook-Signature": "x"} },
+		"header value newline": func(r *dto.WebhookEndpointRequest) { r.Headers = map[string]string{"X-Team": "a\r\nb"} },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := valid()
+			mutate(&req)
+			assert.Error(t, req.Validate())
+		})
+	}
+}
+
+// ==================== WebhookEndpointRepository Tests ====================
+
+func TestWebhookEndpointRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewWebhookEndpointRepository(db)
+
+	t.Run("Create and GetByID", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_endpoints WHERE 1=1")
+		require.NoError(t, repo.Create(ctx, newTestWebhookEndpoint("ep-1", "session-1")))
+
+		endpoint, err := repo.GetByID(ctx, "session-1", "ep-1")
+		require.NoError(t, err)
+		assert.Equal(t, "crm", endpoint.Name)
+		assert.Equal(t, []string{"message.*"}, endpoint.Events)
+		assert.Equal(t, map[string]string{"Authorization": "Bearer token"}, endpoint.Headers)
+
+		// Endpoints are scoped to their session
+		_, err = repo.GetByID(ctx, "session-2", "ep-1")
+		assert.True(t, errors.IsNotFound(err))
+	})
+
+	t.Run("ListBySessionID returns the session's endpoints oldest first", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_endpoints WHERE 1=1")
+		first := newTestWebhookEndpoint("ep-2", "session-1")
+		first.CreatedAt = time.Now().Add(-time.Minute)
+		require.NoError(t, repo.Create(ctx, first))
+		require.NoError(t, repo.Create(ctx, newTestWebhookEndpoint("ep-1", "session-1")))
+		require.NoError(t, repo.Create(ctx, newTestWebhookEndpoint("ep-3", "session-2")))
+
+		endpoints, err := repo.ListBySessionID(ctx, "session-1")
+		require.NoError(t, err)
+		require.Len(t, endpoints, 2)
+		assert.Equal(t, "ep-2", endpoints[0].ID)
+		assert.Equal(t, "ep-1", endpoints[1].ID)
+	})
+
+	t.Run("Update and Delete", func(t *testing.T) {
+		db.Exec("DELETE FROM webhook_endpoints WHERE 1=1")
+		endpoint := newTestWebhookEndpoint("ep-1", "session-1")
+		require.NoError(t, repo.Create(ctx, endpoint))
+
+		endpoint.Update(entity.WebhookEndpointSettings{URL: "https://example.com/other"})
+		require.NoError(t, repo.Update(ctx, endpoint))
+
+		stored, err := repo.GetByID(ctx, "session-1", "ep-1")
+		require.NoError(t, err)
+		assert.False(t, stored.Enabled)
+		assert.Equal(t, "https://example.com/other", stored.URL)
+		assert.Nil(t, stored.Headers)
+
+		assert.True(t, errors.IsNotFound(repo.Delete(ctx, "session-2", "ep-1")))
+		require.NoError(t, repo.Delete(ctx, "session-1", "ep-1"))
+		assert.True(t, errors.IsNotFound(repo.Delete(ctx, "session-1", "ep-1")))
+	})
+}
+
+// ==================== CachedWebhookEndpointRepository Tests ====================
+
+func TestCachedWebhookEndpointRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := persistence.NewCachedWebhookEndpointRepository(persistence.NewWebhookEndpointRepository(db), time.Hour)
+
+	endpoints, err := repo.ListBySessionID(ctx, "session-1")
+	require.NoError(t, err)
+	assert.Empty(t, endpoints)
+
+	// Writes invalidate the session's cached list
+	endpoint := newTestWebhookEndpoint("ep-1", "session-1")
+	require.NoError(t, repo.Create(ctx, endpoint))
+	endpoints, err = repo.ListBySessionID(ctx, "session-1")
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	// Callers get their own copies
+	endpoints[0].Headers["Authorization"] = "changed"
+	endpoints, err = repo.ListBySessionID(ctx, "session-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", endpoints[0].Headers["Authorization"])
+
+	require.NoError(t, endpoint.GenerateSecret())
+	require.NoError(t, repo.Update(ctx, endpoint))
+	endpoints, err = repo.ListBySessionID(ctx, "session-1")
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Secret, endpoints[0].Secret)
+
+	require.NoError(t, repo.Delete(ctx, "session-1", "ep-1"))
+	endpoints, err = repo.ListBySessionID(ctx, "session-1")
+	require.NoError(t, err)
+	assert.Empty(t, endpoints)
+}